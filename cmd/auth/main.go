@@ -4,16 +4,40 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"os"
+	"strconv"
+	"strings"
 
 	"github.com/aube/auth/internal/api/rest"
+	appArchive "github.com/aube/auth/internal/application/archive"
+	appBatch "github.com/aube/auth/internal/application/batch"
+	appBundle "github.com/aube/auth/internal/application/bundle"
+	appContentType "github.com/aube/auth/internal/application/contenttype"
+	appFeed "github.com/aube/auth/internal/application/feed"
 	appFile "github.com/aube/auth/internal/application/file"
+	appFolder "github.com/aube/auth/internal/application/folder"
 	appImage "github.com/aube/auth/internal/application/image"
+	appMenu "github.com/aube/auth/internal/application/menu"
+	appNode "github.com/aube/auth/internal/application/node"
 	appPage "github.com/aube/auth/internal/application/page"
+	appRedirect "github.com/aube/auth/internal/application/redirect"
+	appScan "github.com/aube/auth/internal/application/scan"
+	appShare "github.com/aube/auth/internal/application/share"
+	appSitemap "github.com/aube/auth/internal/application/sitemap"
+	appTag "github.com/aube/auth/internal/application/tag"
+	appTranslation "github.com/aube/auth/internal/application/translation"
+	appTrash "github.com/aube/auth/internal/application/trash"
 	appUpload "github.com/aube/auth/internal/application/upload"
 	appUser "github.com/aube/auth/internal/application/user"
+	appVersion "github.com/aube/auth/internal/application/version"
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/aube/auth/internal/infrastructure/clamd"
 	"github.com/aube/auth/internal/infrastructure/fs"
 	"github.com/aube/auth/internal/infrastructure/postgres"
+	"github.com/aube/auth/internal/infrastructure/views"
+	"github.com/aube/auth/internal/utils/locale"
 	"github.com/aube/auth/internal/utils/logger"
+	"github.com/gin-gonic/gin"
 	"github.com/spf13/viper"
 )
 
@@ -24,6 +48,51 @@ func main() {
 	viper.SetDefault("IMAGES_STORAGE_PATH", "./_images")
 	viper.SetDefault("API_PATH", "/api/v1")
 	viper.SetDefault("LOG_LEVEL", "debug")
+	viper.SetDefault("MENU_CACHE_TTL", "5m")
+	viper.SetDefault("REDIRECT_CACHE_TTL", "5m")
+	viper.SetDefault("PAGE_REVISIONS_KEEP_LAST", 0)
+	viper.SetDefault("PAGE_REVISIONS_KEEP_DAYS", 0)
+	// Прежние версии загрузок и изображений; текущая не удаляется
+	viper.SetDefault("FILE_VERSIONS_KEEP_LAST", 10)
+	viper.SetDefault("FILE_VERSIONS_KEEP_DAYS", 0)
+	viper.SetDefault("PAGE_SCHEDULER_INTERVAL", "1m")
+	viper.SetDefault("PAGE_SEARCH_LANGUAGE", "simple")
+	// Блокировка редактирования продлевается клиентом до истечения срока
+	viper.SetDefault("PAGE_LOCK_TTL", "2m")
+	// Каталог темы для серверного рендеринга страниц, пустой - только SPA
+	viper.SetDefault("SITE_TEMPLATES_PATH", "")
+	viper.SetDefault("SITE_TEMPLATES_RELOAD", false)
+	// Публичный адрес сайта для sitemap.xml и canonical, пустой - из запроса
+	viper.SetDefault("SITE_URL", "")
+	viper.SetDefault("SITE_ROBOTS_FILE", "")
+	viper.SetDefault("SITE_NAME", "")
+	viper.SetDefault("SITE_DEFAULT_LOCALE", "ru")
+	viper.SetDefault("SITE_LOCALES", "ru,en")
+	viper.SetDefault("SITE_LOCALE_FALLBACKS", "")
+	viper.SetDefault("FEED_ITEMS_LIMIT", appFeed.DefaultLimit)
+	viper.SetDefault("FEED_FULL_CONTENT", false)
+	// Удалённое хранится в корзине TRASH_RETENTION, 0 - бессрочно
+	viper.SetDefault("TRASH_RETENTION", "720h")
+	viper.SetDefault("TRASH_PURGE_INTERVAL", "1h")
+	// Ограничения ZIP-архивов загрузок: размеры в байтах
+	viper.SetDefault("ARCHIVE_MAX_FILES", appArchive.DefaultLimits.MaxFiles)
+	viper.SetDefault("ARCHIVE_MAX_FILE_SIZE", appArchive.DefaultLimits.MaxFileSize)
+	viper.SetDefault("ARCHIVE_MAX_SIZE", appArchive.DefaultLimits.MaxTotalSize)
+	viper.SetDefault("ARCHIVE_MAX_RATIO", appArchive.DefaultLimits.MaxRatio)
+	// Проверка загрузок clamd: tcp://host:port или unix:///path, пустой - без проверки
+	viper.SetDefault("CLAMD_ADDRESS", "")
+	viper.SetDefault("CLAMD_TIMEOUT", "30s")
+	viper.SetDefault("UPLOAD_SCAN_BLOCK_UNTIL_CLEAN", false)
+	viper.SetDefault("UPLOAD_SCAN_WORKERS", 2)
+	viper.SetDefault("UPLOAD_SCAN_RETRY_INTERVAL", "1m")
+	// Пользователи, которым доступен карантин: ID через запятую
+	viper.SetDefault("ADMIN_USER_IDS", "")
+	// Допустимые типы файлов через запятую, например "image/*,application/pdf";
+	// пустой список разрешает всё, запрет важнее разрешения
+	viper.SetDefault("UPLOAD_ALLOWED_TYPES", "")
+	viper.SetDefault("UPLOAD_DENIED_TYPES", "")
+	viper.SetDefault("IMAGES_ALLOWED_TYPES", "image/*")
+	viper.SetDefault("IMAGES_DENIED_TYPES", "image/svg+xml")
 	viper.ReadInConfig()
 
 	logger.Init(viper.Get("LOG_LEVEL").(string))
@@ -57,18 +126,129 @@ func main() {
 		log.Fatalf("Failed to initialize images repository: %v", err)
 	}
 
-	fileService := appFile.NewFileService(fsRepo)
-	imgFileService := appFile.NewFileService(imgRepo)
+	fileService := appFile.NewFileService(fsRepo, entities.NewTypePolicy(
+		viper.GetString("UPLOAD_ALLOWED_TYPES"),
+		viper.GetString("UPLOAD_DENIED_TYPES"),
+	))
+	imgFileService := appFile.NewFileService(imgRepo, entities.NewTypePolicy(
+		viper.GetString("IMAGES_ALLOWED_TYPES"),
+		viper.GetString("IMAGES_DENIED_TYPES"),
+	))
+
+	// Языки сайта: SITE_LOCALE_FALLBACKS задаёт цепочки вида "uk:ru,en-gb:en"
+	fallbacks, err := locale.ParseFallbacks(viper.GetString("SITE_LOCALE_FALLBACKS"))
+	if err != nil {
+		log.Fatalf("Invalid SITE_LOCALE_FALLBACKS: %v", err)
+	}
+	locales, err := locale.New(
+		viper.GetString("SITE_DEFAULT_LOCALE"),
+		strings.Split(viper.GetString("SITE_LOCALES"), ","),
+		fallbacks,
+	)
+	if err != nil {
+		log.Fatalf("Invalid site locales: %v", err)
+	}
 
 	uploadRepo := postgres.NewUploadRepository(dbPool)
 	imageRepo := postgres.NewImageRepository(dbPool)
 	userRepo := postgres.NewUserRepository(dbPool)
-	pageRepo := postgres.NewPageRepository(dbPool)
+	pageRepo := postgres.NewPageRepository(dbPool, viper.GetString("PAGE_SEARCH_LANGUAGE"))
+	pageRevisionRepo := postgres.NewPageRevisionRepository(dbPool)
+	nodeRepo := postgres.NewNodeRepository(dbPool)
+	menuRepo := postgres.NewMenuRepository(dbPool)
+	redirectRepo := postgres.NewRedirectRepository(dbPool)
+	pageTranslationRepo := postgres.NewPageTranslationRepository(dbPool)
+	sitemapRepo := postgres.NewSitemapRepository(dbPool, locales)
+	feedRepo := postgres.NewFeedRepository(dbPool)
+	trashRepo := postgres.NewTrashRepository(dbPool)
+	tagRepo := postgres.NewTagRepository(dbPool)
+	batchRepo := postgres.NewBatchRepository(dbPool)
+	shareRepo := postgres.NewShareRepository(dbPool)
+	folderRepo := postgres.NewFolderRepository(dbPool)
+	versionRepo := postgres.NewVersionRepository(dbPool)
+	scanRepo := postgres.NewScanRepository(dbPool)
+	bundleRepo := postgres.NewBundleRepository(dbPool, viper.GetString("PAGE_SEARCH_LANGUAGE"))
+	contentTypeRepo := postgres.NewContentTypeRepository(dbPool)
+	pageLockRepo := postgres.NewPageLockRepository(dbPool)
+
+	// Переиндексация страниц после смены языка поиска
+	if _, err := pageRepo.ReindexSearch(ctx); err != nil {
+		log.Fatalf("Failed to reindex pages: %v", err)
+	}
+
+	versionRetention := appVersion.Retention{
+		KeepLast: viper.GetInt("FILE_VERSIONS_KEEP_LAST"),
+		KeepDays: viper.GetInt("FILE_VERSIONS_KEEP_DAYS"),
+	}
+	uploadVersionService := appVersion.NewVersionService(versionRepo, entities.VersionKindUpload, fileService, versionRetention)
+	imageVersionService := appVersion.NewVersionService(versionRepo, entities.VersionKindImage, imgFileService, versionRetention)
+
+	var scanner appScan.Scanner
+	if address := viper.GetString("CLAMD_ADDRESS"); address != "" {
+		client, err := clamd.New(address, viper.GetDuration("CLAMD_TIMEOUT"))
+		if err != nil {
+			log.Fatalf("Failed to configure clamd: %v", err)
+		}
+		scanner = client
+	}
+	scanService := appScan.NewScanService(scanRepo, fileService, scanner, viper.GetBool("UPLOAD_SCAN_BLOCK_UNTIL_CLEAN"))
+
+	adminIDs, err := parseIDs(viper.GetString("ADMIN_USER_IDS"))
+	if err != nil {
+		log.Fatalf("Invalid ADMIN_USER_IDS: %v", err)
+	}
 
 	uploadService := appUpload.NewUploadService(uploadRepo)
+	archiveService := appArchive.NewArchiveService(fileService, uploadService, uploadVersionService, scanService, appArchive.Limits{
+		MaxFiles:     viper.GetInt("ARCHIVE_MAX_FILES"),
+		MaxFileSize:  viper.GetInt64("ARCHIVE_MAX_FILE_SIZE"),
+		MaxTotalSize: viper.GetInt64("ARCHIVE_MAX_SIZE"),
+		MaxRatio:     viper.GetInt64("ARCHIVE_MAX_RATIO"),
+	})
+	shareService := appShare.NewShareService(shareRepo, uploadService, scanService)
+	folderService := appFolder.NewFolderService(folderRepo)
 	imageService := appImage.NewImageService(imageRepo)
 	userService := appUser.NewUserService(userRepo)
-	pageService := appPage.NewPageService(pageRepo)
+	contentTypeService := appContentType.NewContentTypeService(contentTypeRepo)
+	pageService := appPage.NewPageService(pageRepo, pageRevisionRepo, contentTypeService, appPage.RevisionRetention{
+		KeepLast: viper.GetInt("PAGE_REVISIONS_KEEP_LAST"),
+		KeepDays: viper.GetInt("PAGE_REVISIONS_KEEP_DAYS"),
+	})
+	// Рендеринг страниц, сохранённых до появления content_html
+	if _, err := pageService.RenderPending(ctx); err != nil {
+		log.Fatalf("Failed to render pages: %v", err)
+	}
+	lockService := appPage.NewLockService(pageLockRepo, viper.GetDuration("PAGE_LOCK_TTL"))
+	translationService := appTranslation.NewTranslationService(pageTranslationRepo, pageService, locales)
+	nodeService := appNode.NewNodeService(nodeRepo)
+	sitemapService := appSitemap.NewSitemapService(sitemapRepo, appSitemap.MaxURLs)
+	feedService := appFeed.NewFeedService(feedRepo, viper.GetInt("FEED_ITEMS_LIMIT"))
+	menuService := appMenu.NewMenuService(menuRepo, nodeRepo, viper.GetDuration("MENU_CACHE_TTL"))
+	redirectService := appRedirect.NewRedirectService(redirectRepo, viper.GetDuration("REDIRECT_CACHE_TTL"))
+	bundleService := appBundle.NewBundleService(bundleRepo, imgRepo, pageService)
+	trashService := appTrash.NewTrashService(trashRepo, fileService, imgFileService, viper.GetDuration("TRASH_RETENTION"))
+	tagService := appTag.NewTagService(tagRepo)
+	batchService := appBatch.NewBatchService(batchRepo)
+
+	// Кэш меню сбрасывается при любом изменении дерева или страниц
+	nodeService.OnChange(menuService.Invalidate)
+	pageService.OnChange(menuService.Invalidate)
+	bundleService.OnChange(menuService.Invalidate)
+	trashService.OnChange(menuService.Invalidate)
+	batchService.OnChange(menuService.Invalidate)
+
+	// Переименование страниц и перенос узлов создают редиректы со старых адресов
+	pageService.OnPathChange(redirectService.TrackPage)
+	nodeService.OnPathChange(redirectService.TrackNode)
+
+	// Плановая публикация страниц
+	go pageService.RunScheduler(ctx, viper.GetDuration("PAGE_SCHEDULER_INTERVAL"))
+
+	// Окончательное удаление просроченного содержимого корзины
+	go trashService.RunRetention(ctx, viper.GetDuration("TRASH_PURGE_INTERVAL"))
+
+	// Проверка новых загрузок и тех, что остались в очереди
+	go scanService.Run(ctx, viper.GetInt("UPLOAD_SCAN_WORKERS"), viper.GetDuration("UPLOAD_SCAN_RETRY_INTERVAL"))
 
 	// Запуск сервера
 	jwtSecret := viper.Get("JWT_SECRET").(string)
@@ -77,13 +257,53 @@ func main() {
 	}
 	apiPath := viper.Get("API_PATH").(string)
 
+	// Шаблоны проверяются при старте; в режиме отладки перечитываются на каждый запрос
+	site := rest.SiteConfig{
+		URL:             viper.GetString("SITE_URL"),
+		Name:            viper.GetString("SITE_NAME"),
+		FeedFullContent: viper.GetBool("FEED_FULL_CONTENT"),
+	}
+	if templatesPath := viper.GetString("SITE_TEMPLATES_PATH"); templatesPath != "" {
+		site.Views, err = views.New(templatesPath, viper.GetBool("SITE_TEMPLATES_RELOAD") || gin.IsDebugging())
+		if err != nil {
+			log.Fatalf("Failed to load site templates: %v", err)
+		}
+	}
+	if robotsFile := viper.GetString("SITE_ROBOTS_FILE"); robotsFile != "" {
+		robots, err := os.ReadFile(robotsFile)
+		if err != nil {
+			log.Fatalf("Failed to read robots.txt: %v", err)
+		}
+		site.Robots = string(robots)
+	}
+
 	server := rest.NewServer(
 		userService,
 		pageService,
+		lockService,
+		translationService,
+		contentTypeService,
+		nodeService,
+		menuService,
+		redirectService,
 		fileService,
 		imgFileService,
 		uploadService,
+		archiveService,
+		shareService,
+		folderService,
 		imageService,
+		uploadVersionService,
+		imageVersionService,
+		scanService,
+		sitemapService,
+		feedService,
+		bundleService,
+		trashService,
+		tagService,
+		batchService,
+		adminIDs,
+		site,
 		jwtSecret,
 		apiPath,
 	)
@@ -91,3 +311,20 @@ func main() {
 		log.Fatalf("Server failed: %v", err)
 	}
 }
+
+// parseIDs parses a comma-separated list of IDs, empty for none.
+func parseIDs(value string) ([]int64, error) {
+	var ids []int64
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		id, err := strconv.ParseInt(part, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
@@ -0,0 +1,170 @@
+// Command bundle exports pages into a portable archive and imports them.
+//
+// Usage:
+//
+//	bundle export -out pages.zip [-page 1,2] [-node 5]
+//	bundle import [-strategy skip|overwrite|rename] [-dry-run] [-user 1] pages.zip
+//
+// Database and image storage settings are read from .env like the server does.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+
+	appBundle "github.com/aube/auth/internal/application/bundle"
+	appContentType "github.com/aube/auth/internal/application/contenttype"
+	"github.com/aube/auth/internal/application/dto"
+	appPage "github.com/aube/auth/internal/application/page"
+	"github.com/aube/auth/internal/infrastructure/fs"
+	"github.com/aube/auth/internal/infrastructure/postgres"
+	"github.com/aube/auth/internal/utils/logger"
+	"github.com/spf13/viper"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+	}
+
+	viper.SetConfigFile(".env")
+	viper.SetDefault("IMAGES_STORAGE_PATH", "./_images")
+	viper.SetDefault("LOG_LEVEL", "info")
+	viper.SetDefault("PAGE_SEARCH_LANGUAGE", "simple")
+	viper.ReadInConfig()
+
+	logger.Init(viper.GetString("LOG_LEVEL"))
+
+	switch os.Args[1] {
+	case "export":
+		export(os.Args[2:])
+	case "import":
+		importBundle(os.Args[2:])
+	default:
+		usage()
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage:")
+	fmt.Fprintln(os.Stderr, "  bundle export -out pages.zip [-page 1,2] [-node 5]")
+	fmt.Fprintln(os.Stderr, "  bundle import [-strategy skip|overwrite|rename] [-dry-run] [-user 1] pages.zip")
+	os.Exit(2)
+}
+
+func export(args []string) {
+	flags := flag.NewFlagSet("export", flag.ExitOnError)
+	out := flags.String("out", "pages.zip", "archive to write")
+	pages := flags.String("page", "", "comma-separated page ids")
+	node := flags.Int64("node", 0, "export pages of the node subtree")
+	flags.Parse(args)
+
+	req := dto.BundleExportRequest{NodeID: *node}
+	for _, s := range strings.Split(*pages, ",") {
+		if s = strings.TrimSpace(s); s == "" {
+			continue
+		}
+		id, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			log.Fatalf("Invalid page id %q", s)
+		}
+		req.PageIDs = append(req.PageIDs, id)
+	}
+
+	ctx := context.Background()
+	service, closeDB := newService(ctx)
+	defer closeDB()
+
+	f, err := os.Create(*out)
+	if err != nil {
+		log.Fatalf("Failed to create %s: %v", *out, err)
+	}
+	manifest, err := service.Export(ctx, f, req)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(*out)
+		log.Fatalf("Export failed: %v", err)
+	}
+
+	fmt.Printf("Exported %d pages to %s\n", len(manifest.Pages), *out)
+}
+
+func importBundle(args []string) {
+	flags := flag.NewFlagSet("import", flag.ExitOnError)
+	strategy := flags.String("strategy", "skip", "pages with taken names: skip, overwrite or rename")
+	dryRun := flags.Bool("dry-run", false, "only report what would be imported")
+	user := flags.Int64("user", 0, "owner of imported images")
+	flags.Parse(args)
+	if flags.NArg() != 1 {
+		usage()
+	}
+
+	f, err := os.Open(flags.Arg(0))
+	if err != nil {
+		log.Fatalf("Failed to open bundle: %v", err)
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		log.Fatalf("Failed to open bundle: %v", err)
+	}
+
+	ctx := context.Background()
+	service, closeDB := newService(ctx)
+	defer closeDB()
+
+	report, err := service.Import(ctx, f, info.Size(), dto.BundleImportRequest{
+		Strategy: *strategy,
+		DryRun:   *dryRun,
+		UserID:   *user,
+	})
+	if err != nil {
+		log.Fatalf("Import failed: %v", err)
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	enc.Encode(report)
+	if report.Failed > 0 {
+		os.Exit(1)
+	}
+}
+
+func newService(ctx context.Context) (*appBundle.BundleService, func()) {
+	dbPool, err := postgres.NewPool(ctx, postgres.Config{
+		Host:     viper.GetString("DB_HOST"),
+		Port:     viper.GetString("DB_PORT"),
+		User:     viper.GetString("DB_USER"),
+		Password: viper.GetString("DB_PASSWORD"),
+		DBName:   viper.GetString("DB_NAME"),
+		SSLMode:  "disable",
+	})
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+
+	imgRepo, err := fs.NewFileSystemRepository(viper.GetString("IMAGES_STORAGE_PATH"))
+	if err != nil {
+		dbPool.Close()
+		log.Fatalf("Failed to initialize images repository: %v", err)
+	}
+
+	// Поля импортируемых страниц проверяются так же, как при сохранении в редакторе
+	pageService := appPage.NewPageService(
+		postgres.NewPageRepository(dbPool, viper.GetString("PAGE_SEARCH_LANGUAGE")),
+		postgres.NewPageRevisionRepository(dbPool),
+		appContentType.NewContentTypeService(postgres.NewContentTypeRepository(dbPool)),
+		appPage.RevisionRetention{},
+	)
+
+	repo := postgres.NewBundleRepository(dbPool, viper.GetString("PAGE_SEARCH_LANGUAGE"))
+	return appBundle.NewBundleService(repo, imgRepo, pageService), dbPool.Close
+}
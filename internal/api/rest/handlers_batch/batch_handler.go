@@ -0,0 +1,69 @@
+// Package handlers_batch provides the handler of batch operations
+// on pages, uploads and images.
+package handlers_batch
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	appBatch "github.com/aube/auth/internal/application/batch"
+	"github.com/aube/auth/internal/application/dto"
+	appTag "github.com/aube/auth/internal/application/tag"
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/aube/auth/internal/utils/logger"
+	"github.com/rs/zerolog"
+
+	"github.com/gin-gonic/gin"
+)
+
+type BatchService interface {
+	Execute(ctx context.Context, userID int64, req dto.BatchRequest) (entities.BatchResults, error)
+}
+
+type BatchHandler interface {
+	Execute(c *gin.Context)
+}
+
+type Handler struct {
+	batchService BatchService
+	log          zerolog.Logger
+}
+
+func NewBatchHandler(batchService BatchService) BatchHandler {
+	return &Handler{
+		batchService: batchService,
+		log:          logger.Get().With().Str("handlers", "batch_handler").Logger(),
+	}
+}
+
+// Execute applies one action to many items and returns the result of each.
+func (h *Handler) Execute(c *gin.Context) {
+	var req dto.BatchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.Debug().Err(err).Msg("Execute1")
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	results, err := h.batchService.Execute(c.Request.Context(), int64(c.GetInt("userID")), req)
+	if err != nil {
+		h.log.Debug().Err(err).Msg("Execute2")
+		h.abortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewBatchResponse(results))
+}
+
+func (h *Handler) abortWithError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, appBatch.ErrBatchTooLarge):
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": err.Error()})
+	case errors.Is(err, appBatch.ErrEmptyBatch), errors.Is(err, entities.ErrInvalidBatchAction),
+		errors.Is(err, entities.ErrInvalidTag), errors.Is(err, appTag.ErrTooManyTags):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to process batch"})
+	}
+}
@@ -0,0 +1,122 @@
+// Package handlers_bundle provides handlers for page import and export.
+package handlers_bundle
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"time"
+
+	appBundle "github.com/aube/auth/internal/application/bundle"
+	"github.com/aube/auth/internal/application/dto"
+	appPage "github.com/aube/auth/internal/application/page"
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/aube/auth/internal/utils/logger"
+	"github.com/rs/zerolog"
+
+	"github.com/gin-gonic/gin"
+)
+
+type BundleService interface {
+	Export(ctx context.Context, w io.Writer, req dto.BundleExportRequest) (*entities.BundleManifest, error)
+	Import(ctx context.Context, r io.ReaderAt, size int64, req dto.BundleImportRequest) (*entities.ImportReport, error)
+}
+
+type BundleHandler interface {
+	Export(c *gin.Context)
+	Import(c *gin.Context)
+}
+
+type Handler struct {
+	bundleService BundleService
+	log           zerolog.Logger
+}
+
+func NewBundleHandler(bundleService BundleService) BundleHandler {
+	return &Handler{
+		bundleService: bundleService,
+		log:           logger.Get().With().Str("handlers", "bundle_handler").Logger(),
+	}
+}
+
+// Export downloads a bundle with pages ?id=1&id=2 and/or the pages of
+// the node subtree ?node_id=.
+// The archive is built in a temporary file so that errors can still be
+// answered with JSON.
+func (h *Handler) Export(c *gin.Context) {
+	var req dto.BundleExportRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.log.Debug().Err(err).Msg("Export1")
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	tmp, err := os.CreateTemp("", "bundle-*.zip")
+	if err != nil {
+		h.log.Error().Err(err).Msg("Export2")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to export pages"})
+		return
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	if _, err := h.bundleService.Export(c.Request.Context(), tmp, req); err != nil {
+		h.log.Debug().Err(err).Msg("Export3")
+		switch {
+		case errors.Is(err, appBundle.ErrNothingToExport):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, appPage.ErrPageNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to export pages"})
+		}
+		return
+	}
+
+	c.FileAttachment(tmp.Name(), "pages-"+time.Now().UTC().Format("20060102-150405")+".zip")
+}
+
+// Import stores pages from an uploaded bundle (form field "file").
+// ?strategy=skip|overwrite|rename sets what happens to pages whose name is
+// taken, ?dry_run=true only reports it. Answers with entities.ImportReport.
+func (h *Handler) Import(c *gin.Context) {
+	var req dto.BundleImportRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.log.Debug().Err(err).Msg("Import1")
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	req.UserID = int64(c.GetInt("userID"))
+
+	header, err := c.FormFile("file")
+	if err != nil {
+		h.log.Debug().Err(err).Msg("Import2")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Bundle file is required"})
+		return
+	}
+	file, err := header.Open()
+	if err != nil {
+		h.log.Debug().Err(err).Msg("Import3")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid file"})
+		return
+	}
+	defer file.Close()
+
+	report, err := h.bundleService.Import(c.Request.Context(), file, header.Size, req)
+	if err != nil {
+		h.log.Debug().Err(err).Msg("Import4")
+		switch {
+		case errors.Is(err, appBundle.ErrInvalidBundle),
+			errors.Is(err, appBundle.ErrUnsupportedBundle),
+			errors.Is(err, appBundle.ErrInvalidStrategy):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to import pages"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
@@ -0,0 +1,161 @@
+// Package handlers_content_type provides handlers for managing page content types.
+package handlers_content_type
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	appContentType "github.com/aube/auth/internal/application/contenttype"
+	"github.com/aube/auth/internal/application/dto"
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/aube/auth/internal/utils/logger"
+	"github.com/rs/zerolog"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ContentTypeService interface {
+	List(ctx context.Context) (*entities.ContentTypes, error)
+	GetByID(ctx context.Context, id int64) (*entities.ContentType, error)
+	FindByName(ctx context.Context, name string) (*entities.ContentType, error)
+	Create(ctx context.Context, typeDTO dto.ContentTypeRequest) (*entities.ContentType, error)
+	Update(ctx context.Context, typeDTO dto.ContentTypeRequest) (*entities.ContentType, error)
+	Delete(ctx context.Context, id int64) error
+}
+
+type ContentTypeHandler interface {
+	List(c *gin.Context)
+	Get(c *gin.Context)
+	Create(c *gin.Context)
+	Update(c *gin.Context)
+	Delete(c *gin.Context)
+}
+
+type Handler struct {
+	contentTypeService ContentTypeService
+	log                zerolog.Logger
+}
+
+func NewContentTypeHandler(contentTypeService ContentTypeService) ContentTypeHandler {
+	return &Handler{
+		contentTypeService: contentTypeService,
+		log:                logger.Get().With().Str("handlers", "content_type_handler").Logger(),
+	}
+}
+
+func (h *Handler) List(c *gin.Context) {
+	contentTypes, err := h.contentTypeService.List(c.Request.Context())
+	if err != nil {
+		h.log.Debug().Err(err).Msg("List")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list content types"})
+		return
+	}
+
+	rows := make([]dto.ContentTypeResponse, len(*contentTypes))
+	for i, contentType := range *contentTypes {
+		rows[i] = *dto.NewContentTypeResponse(&contentType)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"rows": rows})
+}
+
+// Get returns a content type by ?id= or ?name=.
+func (h *Handler) Get(c *gin.Context) {
+	var (
+		contentType *entities.ContentType
+		err         error
+	)
+	if name := c.Query("name"); name != "" {
+		contentType, err = h.contentTypeService.FindByName(c.Request.Context(), name)
+	} else {
+		id, ok := h.queryID(c)
+		if !ok {
+			return
+		}
+		contentType, err = h.contentTypeService.GetByID(c.Request.Context(), id)
+	}
+	if err != nil {
+		h.log.Debug().Err(err).Msg("Get")
+		h.abortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewContentTypeResponse(contentType))
+}
+
+func (h *Handler) Create(c *gin.Context) {
+	var req dto.ContentTypeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.Debug().Err(err).Msg("Create1")
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	contentType, err := h.contentTypeService.Create(c.Request.Context(), req)
+	if err != nil {
+		h.log.Debug().Err(err).Msg("Create2")
+		h.abortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.NewContentTypeResponse(contentType))
+}
+
+func (h *Handler) Update(c *gin.Context) {
+	var req dto.ContentTypeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.Debug().Err(err).Msg("Update1")
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if req.ID <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "id is required"})
+		return
+	}
+
+	contentType, err := h.contentTypeService.Update(c.Request.Context(), req)
+	if err != nil {
+		h.log.Debug().Err(err).Msg("Update2")
+		h.abortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewContentTypeResponse(contentType))
+}
+
+func (h *Handler) Delete(c *gin.Context) {
+	id, ok := h.queryID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.contentTypeService.Delete(c.Request.Context(), id); err != nil {
+		h.log.Debug().Err(err).Msg("Delete")
+		h.abortWithError(c, err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+func (h *Handler) queryID(c *gin.Context) (int64, bool) {
+	id, err := strconv.ParseInt(c.Query("id"), 10, 64)
+	if err != nil || id <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "id is required"})
+		return 0, false
+	}
+	return id, true
+}
+
+func (h *Handler) abortWithError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, appContentType.ErrContentTypeNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, appContentType.ErrContentTypeExists), errors.Is(err, appContentType.ErrContentTypeInUse):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	}
+}
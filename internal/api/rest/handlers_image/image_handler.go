@@ -7,11 +7,15 @@ import (
 	"net/http"
 	"strconv"
 
+	"github.com/aube/auth/internal/api/rest/middlewares"
 	"github.com/aube/auth/internal/application/dto"
 	appFile "github.com/aube/auth/internal/application/file"
-	appImage "github.com/aube/auth/internal/application/upload"
+	appImage "github.com/aube/auth/internal/application/image"
+	appUpload "github.com/aube/auth/internal/application/upload"
 	"github.com/aube/auth/internal/domain/entities"
+	"github.com/aube/auth/internal/utils/disposition"
 	"github.com/aube/auth/internal/utils/logger"
+	"github.com/aube/auth/internal/utils/query"
 	"github.com/rs/zerolog"
 
 	"github.com/gin-gonic/gin"
@@ -20,55 +24,70 @@ import (
 type FileService interface {
 	Delete(ctx context.Context, id string) error
 	Download(ctx context.Context, uuid string) (io.ReadCloser, error)
-	Upload(ctx context.Context, size int64, data io.Reader) (*entities.File, error)
+	Upload(ctx context.Context, size int64, declaredType string, data io.Reader) (*entities.File, error)
 }
 
 type ImageService interface {
 	Delete(ctx context.Context, uuid string, userID int64) error
-	DeleteForce(ctx context.Context, uuid string, userID int64) error
 	GetByName(ctx context.Context, name string, userID int64) (*entities.Image, error)
 	GetByUUID(ctx context.Context, uuid string, userID int64) (*entities.Image, error)
-	ListByUserID(ctx context.Context, userID int64, offset int, limit int, params map[string]any) (*entities.Images, *dto.Pagination, error)
+	ListByUserID(ctx context.Context, userID int64, offset int, limit int, filter *query.Query) (*entities.Images, *dto.Pagination, error)
 	RegisterUploadedImage(ctx context.Context, userID int64, file *entities.File, name string, category string, contentType string, description string) (*entities.Image, error)
 }
 
+// VersionService defines the interface for versions of images.
+type VersionService interface {
+	AddImageVersion(ctx context.Context, existing *entities.Image, userID int64, file *entities.File, contentType string) (*entities.Image, error)
+	Get(ctx context.Context, fileID int64, version int) (*entities.FileVersion, error)
+	List(ctx context.Context, fileID int64) (*entities.FileVersions, error)
+	Promote(ctx context.Context, fileID int64, userID int64, version int) error
+}
+
 type ImageHandler interface {
 	DeleteFile(c *gin.Context)
 	DownloadFile(c *gin.Context)
 	ListFiles(c *gin.Context)
+	ListVersions(c *gin.Context)
+	PromoteVersion(c *gin.Context)
 	ImageFile(c *gin.Context)
 }
 
 // SavedFile implements structure for saved file results.
 // File: FileService.Upload operation result.
 // Filename: Data from fileHeader.Filename.
-// ContentType: Content-Type uploaded file.
+// ContentType: Type of the uploaded file detected from its content.
+// Existing: Image the file becomes a new version of, nil for a new image.
 type SavedFile struct {
 	File        *entities.File
 	Filename    string
 	ContentType string
+	Existing    *entities.Image
 }
 
 // Handler implements UploadHandler for handling file-related HTTP requests.
 // FileService: Service for file storage operations.
 // ImageService: Service for upload metadata operations.
+// VersionService: Service for versions of images.
 // log: Logger instance for the handler.
 type Handler struct {
-	FileService  FileService
-	ImageService ImageService
-	log          zerolog.Logger
+	FileService    FileService
+	ImageService   ImageService
+	VersionService VersionService
+	log            zerolog.Logger
 }
 
 // NewHandler создает новый экземпляр Handler
-func NewImageHandler(FileService FileService, ImageService ImageService) *Handler {
+func NewImageHandler(FileService FileService, ImageService ImageService, VersionService VersionService) *Handler {
 	return &Handler{
-		FileService:  FileService,
-		ImageService: ImageService,
-		log:          logger.Get().With().Str("handlers", "file_handler").Logger(),
+		FileService:    FileService,
+		ImageService:   ImageService,
+		VersionService: VersionService,
+		log:            logger.Get().With().Str("handlers", "file_handler").Logger(),
 	}
 }
 
-// UploadImage обрабатывает загрузку файла
+// UploadImage обрабатывает загрузку файла; файл с именем существующего
+// изображения становится его новой версией
 func (h *Handler) UploadImage(c *gin.Context) {
 
 	userID := c.GetInt("userID")
@@ -77,21 +96,37 @@ func (h *Handler) UploadImage(c *gin.Context) {
 
 	savedFile, err := h.saveFile(c, "file", userID)
 	if err != nil {
+		if errors.Is(err, appFile.ErrTypeNotAllowed) {
+			c.JSON(http.StatusUnsupportedMediaType, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid file"})
 		return
 	}
 
-	upload, err := h.ImageService.RegisterUploadedImage(
-		c.Request.Context(),
-		int64(userID),
-		savedFile.File,
-		savedFile.Filename,
-		category,
-		savedFile.ContentType,
-		description,
-	)
+	var upload *entities.Image
+	if savedFile.Existing != nil {
+		upload, err = h.VersionService.AddImageVersion(c.Request.Context(), savedFile.Existing, int64(userID), savedFile.File, savedFile.ContentType)
+	} else {
+		upload, err = h.ImageService.RegisterUploadedImage(
+			c.Request.Context(),
+			int64(userID),
+			savedFile.File,
+			savedFile.Filename,
+			category,
+			savedFile.ContentType,
+			description,
+		)
+	}
 	if err != nil {
 		h.log.Debug().Err(err).Msg("ImageFile5")
+		if err := h.FileService.Delete(c.Request.Context(), savedFile.File.Name); err != nil {
+			h.log.Debug().Err(err).Msg("ImageFile6")
+		}
+		if errors.Is(err, appUpload.ErrFileNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to write upload file into DB"})
 		return
 	}
@@ -100,7 +135,8 @@ func (h *Handler) UploadImage(c *gin.Context) {
 }
 
 // DownloadFile handles file download requests.
-// Supports lookup by UUID or filename and enforces user ownership.
+// Supports lookup by UUID or filename and enforces user ownership;
+// ?version= downloads an older version of the image.
 func (h *Handler) DownloadFile(c *gin.Context) {
 
 	upload, err := h.getUpload(c)
@@ -108,6 +144,11 @@ func (h *Handler) DownloadFile(c *gin.Context) {
 		return
 	}
 
+	upload, err = h.withVersion(c, upload)
+	if err != nil {
+		return
+	}
+
 	content, err := h.FileService.Download(c.Request.Context(), upload.UUID)
 	if err != nil {
 		if errors.Is(err, appFile.ErrFileNotFound) {
@@ -120,8 +161,9 @@ func (h *Handler) DownloadFile(c *gin.Context) {
 	}
 	defer content.Close()
 
-	c.Header("Content-Disposition", "attachment; filename="+upload.Name)
+	c.Header("Content-Disposition", disposition.Header(disposition.Attachment, upload.Name))
 	c.Header("Content-Type", upload.ContentType)
+	c.Header("X-Content-Type-Options", "nosniff")
 	c.Header("Content-Length", strconv.FormatInt(upload.Size, 10))
 
 	c.Stream(func(w io.Writer) bool {
@@ -133,22 +175,39 @@ func (h *Handler) DownloadFile(c *gin.Context) {
 }
 
 // ListFiles retrieves a paginated list of files uploaded by the user.
-// Uses PaginationMiddleware for offset/limit handling.
+// Uses PaginationMiddleware and CursorMiddleware.
 func (h *Handler) ListFiles(c *gin.Context) {
 
 	userID := c.GetInt("userID")
 	offset := c.GetInt("offset")
 	limit := c.GetInt("limit")
 
-	params := make(map[string]any)
-	if c.Query("updated_at") != "" {
-		params["updated_at >="] = c.Query("updated_at")
+	// ?updated_at= остаётся сокращением для filter[updated_at][gte]
+	values := c.Request.URL.Query()
+	if updatedAt := values.Get("updated_at"); updatedAt != "" {
+		values.Add("filter[updated_at][gte]", updatedAt)
 	}
-	params["deleted"] = "false"
+	filter, err := query.Parse(values, appImage.ListSchema)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	filter.Add("deleted", query.OpEq, false)
+	middlewares.WithCursor(c, filter)
 
-	uploads, pagination, err := h.ImageService.ListByUserID(c.Request.Context(), int64(userID), offset, limit, params)
+	uploads, pagination, err := h.ImageService.ListByUserID(c.Request.Context(), int64(userID), offset, limit, filter)
 	if err != nil {
 		h.log.Debug().Err(err).Msg("ListFiles")
+		if errors.Is(err, query.ErrInvalidQuery) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list files"})
+		return
+	}
+
+	if err := middlewares.SignCursors(c, pagination); err != nil {
+		h.log.Debug().Err(err).Msg("SignCursors")
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list files"})
 		return
 	}
@@ -164,6 +223,7 @@ func (h *Handler) ListFiles(c *gin.Context) {
 	})
 }
 
+// DeleteFile moves the image to the trash; the stored file is removed on purge.
 func (h *Handler) DeleteFile(c *gin.Context) {
 
 	userID := c.GetInt("userID")
@@ -175,39 +235,26 @@ func (h *Handler) DeleteFile(c *gin.Context) {
 	}
 	if err := h.ImageService.Delete(c.Request.Context(), UUID, int64(userID)); err != nil {
 		h.log.Debug().Err(err).Msg("DeleteFile")
-		c.JSON(http.StatusBadRequest, gin.H{"error": "File UUID is can't be deleted"})
-		return
-	}
-
-	if err := h.FileService.Delete(c.Request.Context(), UUID); err != nil {
-		h.log.Debug().Err(err).Msg("DeleteFile")
-		if errors.Is(err, appFile.ErrFileNotFound) {
-			c.JSON(http.StatusNotFound, gin.H{"error": "File not found"})
+		if errors.Is(err, appUpload.ErrFileNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "File not found in DB"})
 			return
 		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete file"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": "File UUID is can't be deleted"})
 		return
 	}
+
+	// Файл остаётся в хранилище до окончательного удаления из корзины
 	c.Status(http.StatusNoContent)
 }
 
-func (h *Handler) cleanupBeforeCreate(ctx context.Context, name string, userID int64) error {
-
-	upload, err := h.ImageService.GetByName(ctx, name, userID)
-
-	if err != nil {
-		return nil // file not found
-	}
-
-	if err := h.ImageService.DeleteForce(ctx, upload.UUID, userID); err != nil {
-		return err
-	}
+// existingImage returns the owner's image with the name, nil when there is none.
+func (h *Handler) existingImage(ctx context.Context, name string, userID int64) (*entities.Image, error) {
 
-	if err := h.FileService.Delete(ctx, upload.UUID); err != nil {
-		return err
+	image, err := h.ImageService.GetByName(ctx, name, userID)
+	if errors.Is(err, appUpload.ErrFileNotFound) {
+		return nil, nil
 	}
-
-	return nil
+	return image, err
 }
 
 // saveFile write file to FS via FileService.Upload.
@@ -219,7 +266,7 @@ func (h *Handler) saveFile(c *gin.Context, fieldName string, userID int) (*Saved
 		return nil, err
 	}
 
-	err = h.cleanupBeforeCreate(c.Request.Context(), fileHeader.Filename, int64(userID))
+	existing, err := h.existingImage(c.Request.Context(), fileHeader.Filename, int64(userID))
 	if err != nil {
 		h.log.Debug().Err(err).Msg("saveFile2")
 		return nil, err
@@ -235,6 +282,7 @@ func (h *Handler) saveFile(c *gin.Context, fieldName string, userID int) (*Saved
 	file, err := h.FileService.Upload(
 		c.Request.Context(),
 		fileHeader.Size,
+		fileHeader.Header.Get("Content-Type"),
 		uploadingFile,
 	)
 	if err != nil {
@@ -245,7 +293,8 @@ func (h *Handler) saveFile(c *gin.Context, fieldName string, userID int) (*Saved
 	return &SavedFile{
 		file,
 		fileHeader.Filename,
-		fileHeader.Header.Get("Content-Type"),
+		file.ContentType,
+		existing,
 	}, nil
 }
 
@@ -271,7 +320,7 @@ func (h *Handler) getUpload(c *gin.Context) (*entities.Image, error) {
 	}
 
 	if err != nil {
-		if errors.Is(err, appImage.ErrFileNotFound) {
+		if errors.Is(err, appUpload.ErrFileNotFound) {
 			c.JSON(http.StatusNotFound, gin.H{"error": "File not found in DB"})
 			return nil, err
 		}
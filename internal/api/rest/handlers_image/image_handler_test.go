@@ -3,7 +3,6 @@ package handlers_image
 import (
 	"bytes"
 	"context"
-	"errors"
 	"io"
 	"mime/multipart"
 	"net/http"
@@ -11,7 +10,9 @@ import (
 	"testing"
 
 	"github.com/aube/auth/internal/application/dto"
+	appUpload "github.com/aube/auth/internal/application/upload"
 	"github.com/aube/auth/internal/domain/entities"
+	"github.com/aube/auth/internal/utils/query"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -37,8 +38,8 @@ type MockFileService struct {
 	mock.Mock
 }
 
-func (m *MockFileService) Upload(ctx context.Context, size int64, data io.Reader) (*entities.File, error) {
-	args := m.Called(ctx, size, data)
+func (m *MockFileService) Upload(ctx context.Context, size int64, declaredType string, data io.Reader) (*entities.File, error) {
+	args := m.Called(ctx, size, declaredType, data)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -86,7 +87,7 @@ func (m *MockImageService) GetByName(ctx context.Context, name string, userID in
 	return args.Get(0).(*entities.Image), args.Error(1)
 }
 
-func (m *MockImageService) ListByUserID(ctx context.Context, userID int64, offset, limit int, params map[string]any) (*entities.Images, *dto.Pagination, error) {
+func (m *MockImageService) ListByUserID(ctx context.Context, userID int64, offset, limit int, filter *query.Query) (*entities.Images, *dto.Pagination, error) {
 	args := m.Called(ctx, userID, offset, limit)
 	return args.Get(0).(*entities.Images), args.Get(1).(*dto.Pagination), args.Error(2)
 }
@@ -102,13 +103,14 @@ func TestImageHandler_UploadImage_Success(t *testing.T) {
 	// Setup
 	mockFileService := new(MockFileService)
 	mockImageService := new(MockImageService)
-	handler := NewImageHandler(mockFileService, mockImageService)
+	handler := NewImageHandler(mockFileService, mockImageService, nil)
 
 	// Test data
 	testContent := []byte("test content")
 	expectedFile := &entities.File{
-		Name: "test-uuid",
-		Size: int64(len(testContent)),
+		Name:        "test-uuid",
+		Size:        int64(len(testContent)),
+		ContentType: "text/plain",
 	}
 	expectedImage := &entities.Image{
 		UUID:        "test-uuid",
@@ -119,27 +121,26 @@ func TestImageHandler_UploadImage_Success(t *testing.T) {
 
 	// Mock expectations
 	mockImageService.On("GetByName", mock.Anything, "test.txt", int64(1)).
-		Return(nil, errors.New("not found"))
+		Return(nil, appUpload.ErrFileNotFound)
 
 	// Важное изменение: используем mock.MatchedBy для проверки reader
-	mockFileService.On("Image",
-		mock.Anything,           // context
-		int64(len(testContent)), // size
+	mockFileService.On("Upload",
+		mock.Anything,              // context
+		int64(len(testContent)),    // size
+		"application/octet-stream", // declared type
 		mock.MatchedBy(func(r io.Reader) bool {
 			data, err := io.ReadAll(r)
 			return err == nil && string(data) == string(testContent)
 		}),
 	).Return(expectedFile, nil)
 
-	mockImageService.On("RegisterImageedFile",
+	mockImageService.On("RegisterUploadedImage",
 		mock.Anything,
 		int64(1),
 		expectedFile,
 		"test.txt",
 		"docs",
-		mock.MatchedBy(func(contentType string) bool {
-			return contentType == "text/plain" || contentType == "application/octet-stream"
-		}),
+		"text/plain",
 		"test file",
 	).Return(expectedImage, nil)
 
@@ -161,7 +162,7 @@ func TestImageHandler_UploadImage_Success(t *testing.T) {
 	// Create gin context with custom writer
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
-	c.Set("userID", int64(1))
+	c.Set("userID", 1)
 	c.Request = httptest.NewRequest("POST", "/upload", body)
 	c.Request.Header.Set("Content-Type", writer.FormDataContentType())
 
@@ -180,7 +181,7 @@ func TestImageHandler_DownloadFile_Success(t *testing.T) {
 	// Setup
 	mockFileService := new(MockFileService)
 	mockImageService := new(MockImageService)
-	handler := NewImageHandler(mockFileService, mockImageService)
+	handler := NewImageHandler(mockFileService, mockImageService, nil)
 
 	// Mock data
 	uuid := "test-uuid"
@@ -203,7 +204,7 @@ func TestImageHandler_DownloadFile_Success(t *testing.T) {
 
 	// Create gin context with our custom writer
 	c, _ := gin.CreateTestContext(w)
-	c.Set("userID", int64(1))
+	c.Set("userID", 1)
 	c.Request = httptest.NewRequest("GET", "/download?uuid="+uuid, nil)
 
 	// Execute
@@ -222,7 +223,7 @@ func TestImageHandler_ListFiles_Success(t *testing.T) {
 	// Setup
 	mockFileService := new(MockFileService)
 	mockImageService := new(MockImageService)
-	handler := NewImageHandler(mockFileService, mockImageService)
+	handler := NewImageHandler(mockFileService, mockImageService, nil)
 
 	// Mock expectations
 	uploads := &entities.Images{
@@ -236,7 +237,7 @@ func TestImageHandler_ListFiles_Success(t *testing.T) {
 	// Create test context
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
-	c.Set("userID", int64(1))
+	c.Set("userID", 1)
 	c.Set("offset", 0)
 	c.Set("limit", 10)
 	c.Request = httptest.NewRequest("GET", "/uploads", nil)
@@ -255,17 +256,16 @@ func TestImageHandler_DeleteFile_Success(t *testing.T) {
 	// Setup
 	mockFileService := new(MockFileService)
 	mockImageService := new(MockImageService)
-	handler := NewImageHandler(mockFileService, mockImageService)
+	handler := NewImageHandler(mockFileService, mockImageService, nil)
 
 	// Mock expectations
 	uuid := "aaaaaaaa-aaaa-bbbb-cccc-aaaabbbbcccc"
 	mockImageService.On("Delete", mock.Anything, uuid, int64(1)).Return(nil)
-	mockFileService.On("Delete", mock.Anything, uuid).Return(nil)
 
 	// Create proper router for the test
 	router := gin.Default()
 	router.DELETE("/file", func(c *gin.Context) {
-		c.Set("userID", int64(1))
+		c.Set("userID", 1)
 		handler.DeleteFile(c)
 	})
 
@@ -279,6 +279,7 @@ func TestImageHandler_DeleteFile_Success(t *testing.T) {
 	// Assert
 	assert.Equal(t, http.StatusNoContent, w.Code)
 	assert.Empty(t, w.Body.String()) // Проверяем что тело ответа пустое
-	mockFileService.AssertExpectations(t)
+	// Файл удаляется только при очистке корзины
+	mockFileService.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
 	mockImageService.AssertExpectations(t)
 }
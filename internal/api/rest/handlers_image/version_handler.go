@@ -0,0 +1,131 @@
+package handlers_image
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/aube/auth/internal/application/dto"
+	appUpload "github.com/aube/auth/internal/application/upload"
+	appVersion "github.com/aube/auth/internal/application/version"
+	"github.com/aube/auth/internal/domain/entities"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ListVersions returns versions of an image (?uuid= or ?name=),
+// newest first; the current one is marked.
+func (h *Handler) ListVersions(c *gin.Context) {
+
+	image, err := h.getUpload(c)
+	if err != nil {
+		return
+	}
+
+	versions, err := h.VersionService.List(c.Request.Context(), image.ID)
+	if err != nil {
+		h.log.Debug().Err(err).Msg("ListVersions")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list versions"})
+		return
+	}
+
+	rows := make([]dto.FileVersionResponse, len(*versions))
+	for i, version := range *versions {
+		rows[i] = dto.NewFileVersionResponse(&version)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"image": dto.NewImageResponse(image),
+		"rows":  rows,
+	})
+}
+
+// PromoteVersion makes an older version of an image current.
+func (h *Handler) PromoteVersion(c *gin.Context) {
+
+	userID := int64(c.GetInt("userID"))
+
+	var req dto.VersionPromoteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.Debug().Err(err).Msg("PromoteVersion1")
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if req.UUID == "" && req.Name == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "File UUID or Name is required"})
+		return
+	}
+	if req.Version <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Version is required"})
+		return
+	}
+
+	var (
+		image *entities.Image
+		err   error
+	)
+	if req.UUID != "" {
+		image, err = h.ImageService.GetByUUID(c.Request.Context(), req.UUID, userID)
+	} else {
+		image, err = h.ImageService.GetByName(c.Request.Context(), req.Name, userID)
+	}
+	if err != nil {
+		h.log.Debug().Err(err).Msg("PromoteVersion2")
+		h.versionError(c, err)
+		return
+	}
+
+	if err := h.VersionService.Promote(c.Request.Context(), image.ID, userID, req.Version); err != nil {
+		h.log.Debug().Err(err).Msg("PromoteVersion3")
+		h.versionError(c, err)
+		return
+	}
+
+	// UUID текущей версии сменился, имя — нет
+	image, err = h.ImageService.GetByName(c.Request.Context(), image.Name, userID)
+	if err != nil {
+		h.log.Debug().Err(err).Msg("PromoteVersion4")
+		h.versionError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewImageResponse(image))
+}
+
+// withVersion returns the image with the content of the version requested
+// by ?version=; without it the current content is served.
+func (h *Handler) withVersion(c *gin.Context, image *entities.Image) (*entities.Image, error) {
+
+	value := c.Query("version")
+	if value == "" {
+		return image, nil
+	}
+
+	number, err := strconv.Atoi(value)
+	if err != nil || number <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid version"})
+		return nil, errors.New("bad request")
+	}
+
+	version, err := h.VersionService.Get(c.Request.Context(), image.ID, number)
+	if err != nil {
+		h.log.Debug().Err(err).Msg("withVersion")
+		h.versionError(c, err)
+		return nil, err
+	}
+
+	versioned := *image
+	versioned.UUID = version.UUID
+	versioned.Size = version.Size
+	versioned.ContentType = version.ContentType
+	versioned.Version = version.Version
+	return &versioned, nil
+}
+
+func (h *Handler) versionError(c *gin.Context, err error) {
+	if errors.Is(err, appVersion.ErrVersionNotFound) || errors.Is(err, appUpload.ErrFileNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to change version"})
+}
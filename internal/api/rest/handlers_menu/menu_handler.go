@@ -0,0 +1,115 @@
+// Package handlers_menu provides handlers for navigation menus.
+package handlers_menu
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/aube/auth/internal/application/dto"
+	appMenu "github.com/aube/auth/internal/application/menu"
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/aube/auth/internal/utils/logger"
+	"github.com/rs/zerolog"
+
+	"github.com/gin-gonic/gin"
+)
+
+type MenuService interface {
+	GetMenu(ctx context.Context, name string, currentPath string) ([]*entities.MenuItem, error)
+	List(ctx context.Context) (*entities.Menus, error)
+	Save(ctx context.Context, menuDTO dto.MenuRequest) (*entities.Menu, error)
+	Delete(ctx context.Context, name string) error
+}
+
+type MenuHandler interface {
+	GetMenu(c *gin.Context)
+	List(c *gin.Context)
+	Save(c *gin.Context)
+	Delete(c *gin.Context)
+}
+
+type Handler struct {
+	menuService MenuService
+	log         zerolog.Logger
+}
+
+func NewMenuHandler(menuService MenuService) MenuHandler {
+	return &Handler{
+		menuService: menuService,
+		log:         logger.Get().With().Str("handlers", "menu_handler").Logger(),
+	}
+}
+
+// GetMenu returns the nested menu; ?current= marks the active trail.
+func (h *Handler) GetMenu(c *gin.Context) {
+	name := c.Param("name")
+
+	items, err := h.menuService.GetMenu(c.Request.Context(), name, c.Query("current"))
+	if err != nil {
+		h.log.Debug().Err(err).Msg("GetMenu")
+		if errors.Is(err, appMenu.ErrMenuNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "menu not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to build menu"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"name":  name,
+		"items": dto.NewMenuItemsResponse(items),
+	})
+}
+
+func (h *Handler) List(c *gin.Context) {
+	menus, err := h.menuService.List(c.Request.Context())
+	if err != nil {
+		h.log.Debug().Err(err).Msg("List")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list menus"})
+		return
+	}
+
+	rows := make([]dto.MenuResponse, len(*menus))
+	for i, menu := range *menus {
+		rows[i] = *dto.NewMenuResponse(&menu)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"rows": rows,
+	})
+}
+
+func (h *Handler) Save(c *gin.Context) {
+	var req dto.MenuRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.Debug().Err(err).Msg("Save1")
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	menu, err := h.menuService.Save(c.Request.Context(), req)
+	if err != nil {
+		h.log.Debug().Err(err).Msg("Save2")
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewMenuResponse(menu))
+}
+
+func (h *Handler) Delete(c *gin.Context) {
+	name := c.Query("name")
+	if name == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Menu name is required"})
+		return
+	}
+
+	if err := h.menuService.Delete(c.Request.Context(), name); err != nil {
+		h.log.Debug().Err(err).Msg("Delete")
+		c.JSON(http.StatusNotFound, gin.H{"error": "menu not found"})
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
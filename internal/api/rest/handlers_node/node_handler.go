@@ -0,0 +1,309 @@
+// Package handlers_node provides handlers for the site tree.
+package handlers_node
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/aube/auth/internal/application/dto"
+	appNode "github.com/aube/auth/internal/application/node"
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/aube/auth/internal/utils/logger"
+	"github.com/rs/zerolog"
+
+	"github.com/gin-gonic/gin"
+)
+
+type NodeService interface {
+	Create(ctx context.Context, nodeDTO dto.CreateNodeRequest) (*entities.Node, error)
+	Update(ctx context.Context, nodeDTO dto.UpdateNodeRequest) (*entities.Node, error)
+	Move(ctx context.Context, moveDTO dto.MoveNodeRequest) (*entities.Node, error)
+	Delete(ctx context.Context, id int64) error
+
+	GetByID(ctx context.Context, id int64) (*entities.Node, error)
+	Resolve(ctx context.Context, path string) (*entities.Node, error)
+	GetTree(ctx context.Context, rootID int64, depth int) ([]*entities.NodeTree, error)
+	GetBreadcrumbs(ctx context.Context, pageID int64) (*entities.Nodes, error)
+
+	ListNodePages(ctx context.Context, nodeID int64) (*entities.NodePages, error)
+	AttachPage(ctx context.Context, linkDTO dto.NodePageRequest) error
+	DetachPage(ctx context.Context, nodeID int64, pageID int64) error
+}
+
+// PageService is used to resolve the page a node points to.
+type PageService interface {
+	GetByID(ctx context.Context, id int64) (*entities.PageWithTime, error)
+}
+
+type NodeHandler interface {
+	Create(c *gin.Context)
+	Update(c *gin.Context)
+	Move(c *gin.Context)
+	Delete(c *gin.Context)
+	GetByParam(c *gin.Context)
+	GetTree(c *gin.Context)
+	GetBreadcrumbs(c *gin.Context)
+	Resolve(c *gin.Context)
+	ListNodePages(c *gin.Context)
+	AttachPage(c *gin.Context)
+	DetachPage(c *gin.Context)
+}
+
+type Handler struct {
+	nodeService NodeService
+	pageService PageService
+	log         zerolog.Logger
+}
+
+func NewNodeHandler(nodeService NodeService, pageService PageService) NodeHandler {
+	return &Handler{
+		nodeService: nodeService,
+		pageService: pageService,
+		log:         logger.Get().With().Str("handlers", "node_handler").Logger(),
+	}
+}
+
+func (h *Handler) Create(c *gin.Context) {
+	var req dto.CreateNodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.Debug().Err(err).Msg("Create1")
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	node, err := h.nodeService.Create(c.Request.Context(), req)
+	if err != nil {
+		h.log.Debug().Err(err).Msg("Create2")
+		h.abortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.NewNodeResponse(node))
+}
+
+func (h *Handler) Update(c *gin.Context) {
+	var req dto.UpdateNodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.Debug().Err(err).Msg("Update1")
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	node, err := h.nodeService.Update(c.Request.Context(), req)
+	if err != nil {
+		h.log.Debug().Err(err).Msg("Update2")
+		h.abortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewNodeResponse(node))
+}
+
+func (h *Handler) Move(c *gin.Context) {
+	var req dto.MoveNodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.Debug().Err(err).Msg("Move1")
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	node, err := h.nodeService.Move(c.Request.Context(), req)
+	if err != nil {
+		h.log.Debug().Err(err).Msg("Move2")
+		h.abortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewNodeResponse(node))
+}
+
+func (h *Handler) Delete(c *gin.Context) {
+	nodeID, ok := h.queryID(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.nodeService.Delete(c.Request.Context(), nodeID); err != nil {
+		h.log.Debug().Err(err).Msg("Delete")
+		h.abortWithError(c, err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+// GetByParam returns a single node by ?id= or ?path=.
+func (h *Handler) GetByParam(c *gin.Context) {
+	var (
+		node *entities.Node
+		err  error
+	)
+
+	if path := c.Query("path"); path != "" && c.Query("id") == "" {
+		node, err = h.nodeService.Resolve(c.Request.Context(), path)
+	} else {
+		nodeID, ok := h.queryID(c, "id")
+		if !ok {
+			return
+		}
+		node, err = h.nodeService.GetByID(c.Request.Context(), nodeID)
+	}
+	if err != nil {
+		h.log.Debug().Err(err).Msg("GetByParam")
+		h.abortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewNodeResponse(node))
+}
+
+// GetTree returns nested nodes; ?root= selects a subtree, ?depth= limits levels.
+func (h *Handler) GetTree(c *gin.Context) {
+	rootID, _ := strconv.ParseInt(c.DefaultQuery("root", "0"), 10, 64)
+	depth, _ := strconv.Atoi(c.DefaultQuery("depth", "0"))
+	if rootID < 0 || depth < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "root and depth must be positive"})
+		return
+	}
+
+	tree, err := h.nodeService.GetTree(c.Request.Context(), rootID, depth)
+	if err != nil {
+		h.log.Debug().Err(err).Msg("GetTree")
+		h.abortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"items": dto.NewNodeTreeResponse(tree),
+	})
+}
+
+func (h *Handler) GetBreadcrumbs(c *gin.Context) {
+	pageID, ok := h.queryID(c, "page_id")
+	if !ok {
+		return
+	}
+
+	nodes, err := h.nodeService.GetBreadcrumbs(c.Request.Context(), pageID)
+	if err != nil {
+		h.log.Debug().Err(err).Msg("GetBreadcrumbs")
+		h.abortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"items": dto.NewNodesResponse(nodes),
+	})
+}
+
+// Resolve maps a URL path to its node and page.
+func (h *Handler) Resolve(c *gin.Context) {
+	path := c.Query("path")
+	if path == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Path is required"})
+		return
+	}
+
+	ctx := c.Request.Context()
+	node, err := h.nodeService.Resolve(ctx, path)
+	if err != nil {
+		h.log.Debug().Err(err).Msg("Resolve1")
+		h.abortWithError(c, err)
+		return
+	}
+
+	// Неопубликованные страницы видны только авторизованным пользователям
+	page, err := h.pageService.GetByID(ctx, node.PageID)
+	if err == nil && !page.IsPublished() && c.GetInt("userID") == 0 {
+		err = appNode.ErrNodeNotFound
+	}
+	if err != nil {
+		h.log.Debug().Err(err).Msg("Resolve2")
+		c.JSON(http.StatusNotFound, gin.H{"error": "page not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"node": dto.NewNodeResponse(node),
+		"page": dto.NewPageResponse(page),
+	})
+}
+
+func (h *Handler) ListNodePages(c *gin.Context) {
+	nodeID, ok := h.queryID(c, "id")
+	if !ok {
+		return
+	}
+
+	links, err := h.nodeService.ListNodePages(c.Request.Context(), nodeID)
+	if err != nil {
+		h.log.Debug().Err(err).Msg("ListNodePages")
+		h.abortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"rows": dto.NewNodePagesResponse(links),
+	})
+}
+
+func (h *Handler) AttachPage(c *gin.Context) {
+	var req dto.NodePageRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.Debug().Err(err).Msg("AttachPage1")
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.nodeService.AttachPage(c.Request.Context(), req); err != nil {
+		h.log.Debug().Err(err).Msg("AttachPage2")
+		h.abortWithError(c, err)
+		return
+	}
+
+	c.Status(http.StatusCreated)
+}
+
+func (h *Handler) DetachPage(c *gin.Context) {
+	nodeID, ok := h.queryID(c, "node_id")
+	if !ok {
+		return
+	}
+	pageID, ok := h.queryID(c, "page_id")
+	if !ok {
+		return
+	}
+
+	if err := h.nodeService.DetachPage(c.Request.Context(), nodeID, pageID); err != nil {
+		h.log.Debug().Err(err).Msg("DetachPage")
+		h.abortWithError(c, err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+// queryID reads a positive numeric query parameter, answering 400 when it is missing.
+func (h *Handler) queryID(c *gin.Context, name string) (int64, bool) {
+	id, err := strconv.ParseInt(c.Query(name), 10, 64)
+	if err != nil || id <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": name + " is required"})
+		return 0, false
+	}
+	return id, true
+}
+
+func (h *Handler) abortWithError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, appNode.ErrNodeNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, appNode.ErrNodeExists), errors.Is(err, appNode.ErrNodeCycle):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, entities.ErrInvalidNode):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to process node"})
+	}
+}
@@ -2,12 +2,19 @@ package handlers_page
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"strconv"
+	"strings"
 
+	"github.com/aube/auth/internal/api/rest/middlewares"
+	appContentType "github.com/aube/auth/internal/application/contenttype"
 	"github.com/aube/auth/internal/application/dto"
+	appPage "github.com/aube/auth/internal/application/page"
 	"github.com/aube/auth/internal/domain/entities"
+	"github.com/aube/auth/internal/utils/locale"
 	"github.com/aube/auth/internal/utils/logger"
+	"github.com/aube/auth/internal/utils/query"
 	"github.com/rs/zerolog"
 
 	"github.com/gin-gonic/gin"
@@ -21,7 +28,29 @@ type PageService interface {
 	Update(ctx context.Context, pageDTO dto.UpdatePageRequest) (*entities.PageWithTime, error)
 	GetByName(ctx context.Context, name string) (*entities.PageWithTime, error)
 	GetByID(ctx context.Context, id int64) (*entities.PageWithTime, error)
-	ListPages(ctx context.Context, offset int, limit int, params map[string]any) (*entities.PagesWithTimes, *dto.Pagination, error)
+	ListPages(ctx context.Context, offset int, limit int, filter *query.Query) (*entities.PagesWithTimes, *dto.Pagination, error)
+	Search(ctx context.Context, searchDTO dto.PageSearchRequest, offset, limit int, filter *query.Query) (*entities.PageSearchResults, *dto.Pagination, error)
+	SetStatus(ctx context.Context, statusDTO dto.PageStatusRequest) (*entities.PageWithTime, error)
+
+	ListRevisions(ctx context.Context, pageID int64, offset, limit int, filter *query.Query) (*entities.PageRevisions, *dto.Pagination, error)
+	GetRevision(ctx context.Context, pageID int64, revision int) (*entities.PageRevision, error)
+	DiffRevisions(ctx context.Context, pageID int64, from, to int) (*dto.PageRevisionDiff, error)
+	RestoreRevision(ctx context.Context, pageID int64, revision int, authorID int64) (*entities.PageWithTime, error)
+}
+
+type TranslationService interface {
+	Locales() *locale.Locales
+	List(ctx context.Context, pageID int64) (*entities.PageTranslations, error)
+	Save(ctx context.Context, translationDTO dto.PageTranslationRequest) (*entities.PageTranslation, error)
+	Delete(ctx context.Context, pageID int64, loc string) error
+	GetByName(ctx context.Context, name string, loc string) (*entities.PageWithTime, error)
+	Localize(ctx context.Context, page *entities.PageWithTime, loc string) (*entities.PageWithTime, error)
+}
+
+type LockService interface {
+	Lock(ctx context.Context, pageID int64, userID int64) (*entities.PageLock, error)
+	Unlock(ctx context.Context, pageID int64, userID int64) error
+	GetLock(ctx context.Context, pageID int64) (*entities.PageLock, error)
 }
 
 type PageHandler interface {
@@ -30,19 +59,37 @@ type PageHandler interface {
 	Delete(c *gin.Context)
 	GetByParam(c *gin.Context)
 	ListPages(c *gin.Context)
+	SearchPages(c *gin.Context)
+	SetStatus(c *gin.Context)
+	CreatePreview(c *gin.Context)
+	GetPreview(c *gin.Context)
+	ListRevisions(c *gin.Context)
+	GetRevision(c *gin.Context)
+	DiffRevisions(c *gin.Context)
+	RestoreRevision(c *gin.Context)
+	ListTranslations(c *gin.Context)
+	SaveTranslation(c *gin.Context)
+	DeleteTranslation(c *gin.Context)
+	LockPage(c *gin.Context)
+	UnlockPage(c *gin.Context)
+	GetLock(c *gin.Context)
 }
 
 type Handler struct {
-	pageService PageService
-	jwtSecret   []byte
-	log         zerolog.Logger
+	pageService        PageService
+	translationService TranslationService
+	lockService        LockService
+	jwtSecret          []byte
+	log                zerolog.Logger
 }
 
-func NewPageHandler(pageService PageService, jwtSecret string) PageHandler {
+func NewPageHandler(pageService PageService, translationService TranslationService, lockService LockService, jwtSecret string) PageHandler {
 	return &Handler{
-		pageService: pageService,
-		jwtSecret:   []byte(jwtSecret),
-		log:         logger.Get().With().Str("handlers", "page_handler").Logger(),
+		pageService:        pageService,
+		translationService: translationService,
+		lockService:        lockService,
+		jwtSecret:          []byte(jwtSecret),
+		log:                logger.Get().With().Str("handlers", "page_handler").Logger(),
 	}
 }
 
@@ -56,6 +103,7 @@ func (h *Handler) Create(c *gin.Context) {
 
 	ctx := c.Request.Context()
 	pageDTO := dto.CreatePageRequest(req)
+	pageDTO.AuthorID = int64(c.GetInt("userID"))
 
 	page, err := h.pageService.Create(ctx, pageDTO)
 	if err != nil {
@@ -64,6 +112,7 @@ func (h *Handler) Create(c *gin.Context) {
 		return
 	}
 
+	setETag(c, page)
 	c.JSON(http.StatusCreated, dto.NewPageResponse(page))
 }
 
@@ -77,14 +126,26 @@ func (h *Handler) Update(c *gin.Context) {
 
 	ctx := c.Request.Context()
 	pageDTO := dto.UpdatePageRequest(req)
+	pageDTO.AuthorID = int64(c.GetInt("userID"))
+	version, ok := h.ifMatch(c)
+	if !ok {
+		return
+	}
+	if version > 0 {
+		pageDTO.Version = version
+	}
 
 	page, err := h.pageService.Update(ctx, pageDTO)
 	if err != nil {
 		h.log.Debug().Err(err).Msg("Update2")
+		if h.versionConflict(c, err) {
+			return
+		}
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
+	setETag(c, page)
 	c.JSON(http.StatusOK, dto.NewPageResponse(page))
 }
 
@@ -124,9 +185,13 @@ func (h *Handler) GetByID(c *gin.Context) {
 		c.JSON(http.StatusNotFound, gin.H{"error": "page not found"})
 		return
 	}
+	if !h.canView(c, page) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "page not found"})
+		return
+	}
 	h.log.Debug().Msg(page.Name)
 
-	c.JSON(http.StatusOK, dto.NewPageResponse(page))
+	h.localized(c, page, h.locale(c))
 }
 
 func (h *Handler) GetByName(c *gin.Context) {
@@ -136,31 +201,66 @@ func (h *Handler) GetByName(c *gin.Context) {
 		return
 	}
 	ctx := c.Request.Context()
-	page, err := h.pageService.GetByName(ctx, name)
+	loc := h.locale(c)
+	page, err := h.translationService.GetByName(ctx, name, loc)
 	if err != nil {
 		h.log.Debug().Err(err).Msg("GetProfile")
 		c.JSON(http.StatusNotFound, gin.H{"error": "page not found"})
 		return
 	}
+	if !h.canView(c, page) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "page not found"})
+		return
+	}
 	h.log.Debug().Msg(page.Name)
 
-	c.JSON(http.StatusOK, dto.NewPageResponse(page))
+	h.localized(c, page, loc)
 }
 
+// ListPages returns pages matching filter[...] parameters.
+// Uses PaginationMiddleware and CursorMiddleware.
 func (h *Handler) ListPages(c *gin.Context) {
 
 	offset := c.GetInt("offset")
 	limit := c.GetInt("limit")
 
-	params := make(map[string]any)
-	if c.Query("updated_at") != "" {
-		params["updated_at >="] = c.Query("updated_at")
+	// ?updated_at= и ?type= остаются сокращениями для filter[...]
+	values := c.Request.URL.Query()
+	if updatedAt := values.Get("updated_at"); updatedAt != "" {
+		values.Add("filter[updated_at][gte]", updatedAt)
+	}
+	if contentType := values.Get("type"); contentType != "" {
+		values.Add("filter[content_type]", contentType)
 	}
-	params["deleted"] = "false"
+	filter, err := query.Parse(values, appPage.ListSchema)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	// ?type=product&field.color=red: страницы типа с заданными значениями полей
+	for key, fieldValues := range values {
+		if name, ok := strings.CutPrefix(key, "field."); ok && len(fieldValues) > 0 {
+			filter.Add(appPage.FieldFilterPrefix+name, query.OpEq, fieldValues[0])
+		}
+	}
+	if !h.visibilityFilter(c, filter) {
+		return
+	}
+	middlewares.WithCursor(c, filter)
 
-	pages, pagination, err := h.pageService.ListPages(c.Request.Context(), offset, limit, params)
+	pages, pagination, err := h.pageService.ListPages(c.Request.Context(), offset, limit, filter)
 	if err != nil {
 		h.log.Debug().Err(err).Msg("ListFiles")
+		if errors.Is(err, appPage.ErrInvalidFieldFilter) || errors.Is(err, appContentType.ErrContentTypeNotFound) ||
+			errors.Is(err, query.ErrInvalidQuery) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list files"})
+		return
+	}
+	if err := middlewares.SignCursors(c, pagination); err != nil {
+		h.log.Debug().Err(err).Msg("SignCursors")
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list files"})
 		return
 	}
@@ -176,6 +276,77 @@ func (h *Handler) ListPages(c *gin.Context) {
 	})
 }
 
+// SearchPages runs a full-text search (?q=&lang=).
+// Uses PaginationMiddleware for offset/limit handling.
+func (h *Handler) SearchPages(c *gin.Context) {
+	var req dto.PageSearchRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.log.Debug().Err(err).Msg("SearchPages1")
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	filter := query.New()
+	if !h.visibilityFilter(c, filter) {
+		return
+	}
+
+	results, pagination, err := h.pageService.Search(c.Request.Context(), req, c.GetInt("offset"), c.GetInt("limit"), filter)
+	if err != nil {
+		h.log.Debug().Err(err).Msg("SearchPages2")
+		if errors.Is(err, appPage.ErrEmptySearchQuery) || errors.Is(err, appPage.ErrInvalidSearchLanguage) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to search pages"})
+		return
+	}
+
+	rows := make([]dto.PageSearchResponse, len(*results))
+	for i, result := range *results {
+		rows[i] = *dto.NewPageSearchResponse(&result)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"rows":       rows,
+		"pagination": pagination,
+	})
+}
+
+func (h *Handler) SetStatus(c *gin.Context) {
+	var req dto.PageStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.Debug().Err(err).Msg("SetStatus1")
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	version, ok := h.ifMatch(c)
+	if !ok {
+		return
+	}
+	if version > 0 {
+		req.Version = version
+	}
+
+	page, err := h.pageService.SetStatus(c.Request.Context(), req)
+	if err != nil {
+		h.log.Debug().Err(err).Msg("SetStatus2")
+		if h.versionConflict(c, err) {
+			return
+		}
+		if errors.Is(err, appPage.ErrPageNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	setETag(c, page)
+	c.JSON(http.StatusOK, dto.NewPageResponse(page))
+}
+
 func (h *Handler) Delete(c *gin.Context) {
 	ID := c.Query("id")
 	if ID == "" {
@@ -206,3 +377,29 @@ func (h *Handler) Delete(c *gin.Context) {
 
 	c.Status(http.StatusOK)
 }
+
+// canView hides unpublished pages from anonymous visitors.
+func (h *Handler) canView(c *gin.Context, page *entities.PageWithTime) bool {
+	return page.IsPublished() || c.GetInt("userID") > 0
+}
+
+// visibilityFilter adds list filters by status: visitors see only published
+// pages, editors may filter by ?status=. Answers 400 and returns false
+// on an unknown status.
+func (h *Handler) visibilityFilter(c *gin.Context, filter *query.Query) bool {
+	filter.Add("deleted", query.OpEq, false)
+
+	// Посетителям доступны только опубликованные страницы
+	status := c.Query("status")
+	if c.GetInt("userID") == 0 {
+		status = entities.PageStatusPublished
+	}
+	if status != "" {
+		if !entities.IsValidPageStatus(status) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid page status"})
+			return false
+		}
+		filter.Add("status", query.OpEq, status)
+	}
+	return true
+}
@@ -0,0 +1,126 @@
+package handlers_page
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/aube/auth/internal/application/dto"
+	appPage "github.com/aube/auth/internal/application/page"
+	"github.com/aube/auth/internal/domain/entities"
+
+	"github.com/gin-gonic/gin"
+)
+
+// LockPage takes or renews (heartbeat) the edit lock of the current user.
+// Answers 409 with the lock when another user is editing the page.
+func (h *Handler) LockPage(c *gin.Context) {
+	var req dto.PageLockRequest
+	if err := c.ShouldBindJSON(&req); err != nil || req.ID <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Page ID is required"})
+		return
+	}
+
+	lock, err := h.lockService.Lock(c.Request.Context(), req.ID, int64(c.GetInt("userID")))
+	if err != nil {
+		h.log.Debug().Err(err).Msg("LockPage")
+		var locked *appPage.LockedError
+		switch {
+		case errors.As(err, &locked):
+			c.JSON(http.StatusConflict, gin.H{
+				"error": err.Error(),
+				"lock":  dto.NewPageLockResponse(locked.Lock),
+			})
+		case errors.Is(err, appPage.ErrPageNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to lock page"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewPageLockResponse(lock))
+}
+
+// UnlockPage releases the lock of the current user (?id=).
+func (h *Handler) UnlockPage(c *gin.Context) {
+	pageID, err := strconv.ParseInt(c.Query("id"), 10, 64)
+	if err != nil || pageID <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Page ID is required"})
+		return
+	}
+
+	if err := h.lockService.Unlock(c.Request.Context(), pageID, int64(c.GetInt("userID"))); err != nil {
+		h.log.Debug().Err(err).Msg("UnlockPage")
+		if errors.Is(err, appPage.ErrLockNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to unlock page"})
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+// GetLock shows who is editing the page (?id=).
+func (h *Handler) GetLock(c *gin.Context) {
+	pageID, err := strconv.ParseInt(c.Query("id"), 10, 64)
+	if err != nil || pageID <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Page ID is required"})
+		return
+	}
+
+	lock, err := h.lockService.GetLock(c.Request.Context(), pageID)
+	if err != nil {
+		h.log.Debug().Err(err).Msg("GetLock")
+		if errors.Is(err, appPage.ErrLockNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get page lock"})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewPageLockResponse(lock))
+}
+
+// ifMatch reads the expected page version from If-Match ("3", W/"3");
+// 0 when the header is absent or "*". Answers 400 and returns false
+// on a malformed header.
+func (h *Handler) ifMatch(c *gin.Context) (int, bool) {
+	value := strings.TrimSpace(c.GetHeader("If-Match"))
+	if value == "" || value == "*" {
+		return 0, true
+	}
+
+	version, err := strconv.Atoi(strings.Trim(strings.TrimPrefix(value, "W/"), `"`))
+	if err != nil || version <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid If-Match header"})
+		return 0, false
+	}
+	return version, true
+}
+
+// setETag exposes the page version for later If-Match requests.
+func setETag(c *gin.Context, page *entities.PageWithTime) {
+	c.Header("ETag", `"`+strconv.Itoa(page.Version)+`"`)
+}
+
+// versionConflict answers 409 with the current version of the page.
+// Returns false when err is not a version conflict.
+func (h *Handler) versionConflict(c *gin.Context, err error) bool {
+	var conflict *appPage.VersionConflictError
+	if !errors.As(err, &conflict) {
+		return false
+	}
+
+	setETag(c, conflict.Current)
+	c.JSON(http.StatusConflict, gin.H{
+		"error":   err.Error(),
+		"version": conflict.Current.Version,
+		"page":    dto.NewPageResponse(conflict.Current),
+	})
+	return true
+}
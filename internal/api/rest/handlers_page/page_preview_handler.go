@@ -0,0 +1,86 @@
+package handlers_page
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/aube/auth/internal/application/dto"
+	"github.com/aube/auth/internal/utils/signer"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	previewPurpose    = "page-preview"
+	previewDefaultTTL = time.Hour
+	previewMaxTTL     = 7 * 24 * time.Hour
+)
+
+// CreatePreview issues a signed, expiring link that shows a page
+// regardless of its status.
+func (h *Handler) CreatePreview(c *gin.Context) {
+	var req dto.PagePreviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.Debug().Err(err).Msg("CreatePreview1")
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if req.ID <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Page ID is required"})
+		return
+	}
+
+	ttl := time.Duration(req.TTL) * time.Second
+	if ttl <= 0 {
+		ttl = previewDefaultTTL
+	}
+	if ttl > previewMaxTTL {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ttl is too long"})
+		return
+	}
+
+	if _, err := h.pageService.GetByID(c.Request.Context(), req.ID); err != nil {
+		h.log.Debug().Err(err).Msg("CreatePreview2")
+		c.JSON(http.StatusNotFound, gin.H{"error": "page not found"})
+		return
+	}
+
+	expiresAt := time.Now().Add(ttl)
+	token := h.previewSigner().Sign(strconv.FormatInt(req.ID, 10), expiresAt)
+
+	c.JSON(http.StatusCreated, dto.PagePreviewResponse{
+		Token:     token,
+		URL:       c.Request.URL.Path + "?token=" + token,
+		ExpiresAt: expiresAt,
+	})
+}
+
+// GetPreview returns the page a preview token (?token=) was issued for.
+func (h *Handler) GetPreview(c *gin.Context) {
+	payload, err := h.previewSigner().Verify(c.Query("token"), time.Now())
+	if err != nil {
+		h.log.Debug().Err(err).Msg("GetPreview1")
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+
+	pageID, err := strconv.ParseInt(payload, 10, 64)
+	if err != nil {
+		c.JSON(http.StatusForbidden, gin.H{"error": signer.ErrInvalidToken.Error()})
+		return
+	}
+
+	page, err := h.pageService.GetByID(c.Request.Context(), pageID)
+	if err != nil {
+		h.log.Debug().Err(err).Msg("GetPreview2")
+		c.JSON(http.StatusNotFound, gin.H{"error": "page not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewPageResponse(page))
+}
+
+func (h *Handler) previewSigner() *signer.Signer {
+	return signer.New(string(h.jwtSecret), previewPurpose)
+}
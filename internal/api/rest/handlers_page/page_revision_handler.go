@@ -0,0 +1,125 @@
+package handlers_page
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/aube/auth/internal/api/rest/middlewares"
+	"github.com/aube/auth/internal/application/dto"
+	appPage "github.com/aube/auth/internal/application/page"
+	"github.com/aube/auth/internal/utils/query"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ListRevisions returns revisions of a page (?id=), newest first.
+// Uses PaginationMiddleware and CursorMiddleware.
+func (h *Handler) ListRevisions(c *gin.Context) {
+	pageID, err := strconv.ParseInt(c.Query("id"), 10, 64)
+	if err != nil || pageID <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Page ID is required"})
+		return
+	}
+
+	filter := middlewares.WithCursor(c, nil)
+	revisions, pagination, err := h.pageService.ListRevisions(c.Request.Context(), pageID, c.GetInt("offset"), c.GetInt("limit"), filter)
+	if err != nil {
+		h.log.Debug().Err(err).Msg("ListRevisions")
+		if errors.Is(err, query.ErrInvalidQuery) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list revisions"})
+		return
+	}
+	if err := middlewares.SignCursors(c, pagination); err != nil {
+		h.log.Debug().Err(err).Msg("SignCursors")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list revisions"})
+		return
+	}
+
+	rows := make([]dto.PageRevisionResponse, len(*revisions))
+	for i, rev := range *revisions {
+		rows[i] = *dto.NewPageRevisionResponse(&rev)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"rows":       rows,
+		"pagination": pagination,
+	})
+}
+
+// GetRevision returns a full snapshot (?id=&revision=).
+func (h *Handler) GetRevision(c *gin.Context) {
+	pageID, err := strconv.ParseInt(c.Query("id"), 10, 64)
+	if err != nil || pageID <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Page ID is required"})
+		return
+	}
+	revision, err := strconv.Atoi(c.Query("revision"))
+	if err != nil || revision <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Revision is required"})
+		return
+	}
+
+	rev, err := h.pageService.GetRevision(c.Request.Context(), pageID, revision)
+	if err != nil {
+		h.log.Debug().Err(err).Msg("GetRevision")
+		h.revisionError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewPageRevisionResponse(rev))
+}
+
+// DiffRevisions compares two revisions (?id=&from=&to=).
+func (h *Handler) DiffRevisions(c *gin.Context) {
+	pageID, err := strconv.ParseInt(c.Query("id"), 10, 64)
+	if err != nil || pageID <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Page ID is required"})
+		return
+	}
+	from, errFrom := strconv.Atoi(c.Query("from"))
+	to, errTo := strconv.Atoi(c.Query("to"))
+	if errFrom != nil || errTo != nil || from <= 0 || to <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "from and to revisions are required"})
+		return
+	}
+
+	res, err := h.pageService.DiffRevisions(c.Request.Context(), pageID, from, to)
+	if err != nil {
+		h.log.Debug().Err(err).Msg("DiffRevisions")
+		h.revisionError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// RestoreRevision writes an old revision back as a new one.
+func (h *Handler) RestoreRevision(c *gin.Context) {
+	var req dto.RestoreRevisionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.Debug().Err(err).Msg("RestoreRevision1")
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	page, err := h.pageService.RestoreRevision(c.Request.Context(), req.ID, req.Revision, int64(c.GetInt("userID")))
+	if err != nil {
+		h.log.Debug().Err(err).Msg("RestoreRevision2")
+		h.revisionError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewPageResponse(page))
+}
+
+func (h *Handler) revisionError(c *gin.Context, err error) {
+	if errors.Is(err, appPage.ErrRevisionNotFound) || errors.Is(err, appPage.ErrPageNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+}
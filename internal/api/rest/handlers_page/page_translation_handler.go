@@ -0,0 +1,118 @@
+package handlers_page
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/aube/auth/internal/application/dto"
+	appPage "github.com/aube/auth/internal/application/page"
+	appTranslation "github.com/aube/auth/internal/application/translation"
+	"github.com/aube/auth/internal/domain/entities"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ListTranslations returns translations of a page (?id=) and the locales of the site.
+func (h *Handler) ListTranslations(c *gin.Context) {
+	pageID, err := strconv.ParseInt(c.Query("id"), 10, 64)
+	if err != nil || pageID <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Page ID is required"})
+		return
+	}
+
+	ctx := c.Request.Context()
+	page, err := h.pageService.GetByID(ctx, pageID)
+	if err != nil || !h.canView(c, page) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "page not found"})
+		return
+	}
+
+	translations, err := h.translationService.List(ctx, pageID)
+	if err != nil {
+		h.log.Debug().Err(err).Msg("ListTranslations")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list translations"})
+		return
+	}
+
+	rows := make([]dto.PageTranslationResponse, len(*translations))
+	for i, translation := range *translations {
+		rows[i] = *dto.NewPageTranslationResponse(&translation)
+	}
+
+	locales := h.translationService.Locales()
+	c.JSON(http.StatusOK, gin.H{
+		"rows":           rows,
+		"default_locale": locales.Default(),
+		"locales":        locales.Supported(),
+	})
+}
+
+// SaveTranslation creates or replaces the translation of a page in a locale.
+func (h *Handler) SaveTranslation(c *gin.Context) {
+	var req dto.PageTranslationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.Debug().Err(err).Msg("SaveTranslation1")
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	translation, err := h.translationService.Save(c.Request.Context(), req)
+	if err != nil {
+		h.log.Debug().Err(err).Msg("SaveTranslation2")
+		switch {
+		case errors.Is(err, appPage.ErrPageNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, appTranslation.ErrTranslationExists):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewPageTranslationResponse(translation))
+}
+
+// DeleteTranslation removes the translation of a page (?id=&locale=).
+func (h *Handler) DeleteTranslation(c *gin.Context) {
+	pageID, err := strconv.ParseInt(c.Query("id"), 10, 64)
+	if err != nil || pageID <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Page ID is required"})
+		return
+	}
+	loc := c.Query("locale")
+	if loc == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Locale is required"})
+		return
+	}
+
+	if err := h.translationService.Delete(c.Request.Context(), pageID, loc); err != nil {
+		h.log.Debug().Err(err).Msg("DeleteTranslation")
+		c.JSON(http.StatusNotFound, gin.H{"error": "translation not found"})
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+// locale picks the response locale from ?lang= or Accept-Language.
+func (h *Handler) locale(c *gin.Context) string {
+	return h.translationService.Locales().Negotiate(c.Query("lang"), c.GetHeader("Accept-Language"))
+}
+
+// localized answers with the page in the locale, falling back along the
+// locale chain, and reports the locale used in Content-Language.
+func (h *Handler) localized(c *gin.Context, page *entities.PageWithTime, loc string) {
+	page, err := h.translationService.Localize(c.Request.Context(), page, loc)
+	if err != nil {
+		h.log.Debug().Err(err).Msg("localized")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get page"})
+		return
+	}
+
+	c.Header("Content-Language", page.Locale)
+	c.Header("Vary", "Accept-Language")
+	setETag(c, page)
+	c.JSON(http.StatusOK, dto.NewPageResponse(page))
+}
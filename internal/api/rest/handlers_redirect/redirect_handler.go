@@ -0,0 +1,190 @@
+// Package handlers_redirect provides handlers for managing URL redirects.
+package handlers_redirect
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/aube/auth/internal/api/rest/middlewares"
+	"github.com/aube/auth/internal/application/dto"
+	appRedirect "github.com/aube/auth/internal/application/redirect"
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/aube/auth/internal/utils/logger"
+	"github.com/aube/auth/internal/utils/query"
+	"github.com/rs/zerolog"
+
+	"github.com/gin-gonic/gin"
+)
+
+type RedirectService interface {
+	List(ctx context.Context, offset, limit int, filter *query.Query) (*entities.Redirects, *dto.Pagination, error)
+	GetByID(ctx context.Context, id int64) (*entities.Redirect, error)
+	Create(ctx context.Context, redirectDTO dto.RedirectRequest) (*entities.Redirect, error)
+	Update(ctx context.Context, redirectDTO dto.RedirectRequest) (*entities.Redirect, error)
+	Delete(ctx context.Context, id int64) error
+	Resolve(ctx context.Context, path string) (string, int, error)
+}
+
+type RedirectHandler interface {
+	List(c *gin.Context)
+	GetByID(c *gin.Context)
+	Create(c *gin.Context)
+	Update(c *gin.Context)
+	Delete(c *gin.Context)
+	Resolve(c *gin.Context)
+}
+
+type Handler struct {
+	redirectService RedirectService
+	log             zerolog.Logger
+}
+
+func NewRedirectHandler(redirectService RedirectService) RedirectHandler {
+	return &Handler{
+		redirectService: redirectService,
+		log:             logger.Get().With().Str("handlers", "redirect_handler").Logger(),
+	}
+}
+
+func (h *Handler) List(c *gin.Context) {
+	filter := middlewares.WithCursor(c, nil)
+	redirects, pagination, err := h.redirectService.List(c.Request.Context(), c.GetInt("offset"), c.GetInt("limit"), filter)
+	if err != nil {
+		h.log.Debug().Err(err).Msg("List")
+		if errors.Is(err, query.ErrInvalidQuery) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list redirects"})
+		return
+	}
+	if err := middlewares.SignCursors(c, pagination); err != nil {
+		h.log.Debug().Err(err).Msg("SignCursors")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list redirects"})
+		return
+	}
+
+	rows := make([]dto.RedirectResponse, len(*redirects))
+	for i, redirect := range *redirects {
+		rows[i] = *dto.NewRedirectResponse(&redirect)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"rows":       rows,
+		"pagination": pagination,
+	})
+}
+
+func (h *Handler) GetByID(c *gin.Context) {
+	id, ok := h.queryID(c)
+	if !ok {
+		return
+	}
+
+	redirect, err := h.redirectService.GetByID(c.Request.Context(), id)
+	if err != nil {
+		h.log.Debug().Err(err).Msg("GetByID")
+		h.abortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewRedirectResponse(redirect))
+}
+
+func (h *Handler) Create(c *gin.Context) {
+	var req dto.RedirectRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.Debug().Err(err).Msg("Create1")
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	redirect, err := h.redirectService.Create(c.Request.Context(), req)
+	if err != nil {
+		h.log.Debug().Err(err).Msg("Create2")
+		h.abortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.NewRedirectResponse(redirect))
+}
+
+func (h *Handler) Update(c *gin.Context) {
+	var req dto.RedirectRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.Debug().Err(err).Msg("Update1")
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if req.ID <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "id is required"})
+		return
+	}
+
+	redirect, err := h.redirectService.Update(c.Request.Context(), req)
+	if err != nil {
+		h.log.Debug().Err(err).Msg("Update2")
+		h.abortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewRedirectResponse(redirect))
+}
+
+func (h *Handler) Delete(c *gin.Context) {
+	id, ok := h.queryID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.redirectService.Delete(c.Request.Context(), id); err != nil {
+		h.log.Debug().Err(err).Msg("Delete")
+		h.abortWithError(c, err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+// Resolve returns where ?path= redirects to, for client-side routing.
+func (h *Handler) Resolve(c *gin.Context) {
+	path := c.Query("path")
+	if path == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "path is required"})
+		return
+	}
+
+	target, code, err := h.redirectService.Resolve(c.Request.Context(), path)
+	if err != nil {
+		h.log.Debug().Err(err).Msg("Resolve")
+		h.abortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"target": target,
+		"code":   code,
+	})
+}
+
+func (h *Handler) queryID(c *gin.Context) (int64, bool) {
+	id, err := strconv.ParseInt(c.Query("id"), 10, 64)
+	if err != nil || id <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "id is required"})
+		return 0, false
+	}
+	return id, true
+}
+
+func (h *Handler) abortWithError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, appRedirect.ErrRedirectNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, appRedirect.ErrRedirectExists), errors.Is(err, appRedirect.ErrRedirectLoop):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,162 @@
+package handlers_site
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aube/auth/internal/application/dto"
+	appFeed "github.com/aube/auth/internal/application/feed"
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/aube/auth/internal/utils/logger"
+	"github.com/rs/zerolog"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	feedFormatRSS  = "rss"
+	feedFormatAtom = "atom"
+)
+
+type FeedService interface {
+	Feed(ctx context.Context, category string) (*entities.Feed, error)
+}
+
+type FeedHandler interface {
+	Feed(c *gin.Context)
+	AllFeed(c *gin.Context)
+}
+
+type feedHandler struct {
+	feedService FeedService
+	siteURL     string
+	siteName    string
+	fullContent bool
+	log         zerolog.Logger
+}
+
+// NewFeedHandler creates RSS/Atom feed handlers.
+// siteURL: Public base URL, empty to take it from the request
+// siteName: Feed title, empty to use the host name
+// fullContent: Include page content by default (?full=true|false overrides it)
+func NewFeedHandler(feedService FeedService, siteURL string, siteName string, fullContent bool) FeedHandler {
+	return &feedHandler{
+		feedService: feedService,
+		siteURL:     siteURL,
+		siteName:    siteName,
+		fullContent: fullContent,
+		log:         logger.Get().With().Str("handlers", "feed_handler").Logger(),
+	}
+}
+
+// Feed serves /feeds/{category}.rss and /feeds/{category}.atom.
+func (h *feedHandler) Feed(c *gin.Context) {
+	file := c.Param("file")
+	format := strings.TrimPrefix(path.Ext(file), ".")
+	category := strings.TrimSuffix(file, path.Ext(file))
+	if category == "" {
+		c.String(http.StatusNotFound, "Not found")
+		return
+	}
+	h.serve(c, category, format)
+}
+
+// AllFeed serves /feeds.rss and /feeds.atom with pages of all categories.
+func (h *feedHandler) AllFeed(c *gin.Context) {
+	h.serve(c, "", strings.TrimPrefix(path.Ext(c.Request.URL.Path), "."))
+}
+
+func (h *feedHandler) serve(c *gin.Context, category string, format string) {
+	if format != feedFormatRSS && format != feedFormatAtom {
+		c.String(http.StatusNotFound, "Not found")
+		return
+	}
+
+	full := h.fullContent
+	if v := c.Query("full"); v != "" {
+		full, _ = strconv.ParseBool(v)
+	}
+
+	feed, err := h.feedService.Feed(c.Request.Context(), category)
+	if err != nil {
+		h.log.Debug().Err(err).Msg("serve")
+		if errors.Is(err, appFeed.ErrFeedNotFound) {
+			c.String(http.StatusNotFound, "Not found")
+			return
+		}
+		c.String(http.StatusInternalServerError, "Failed to build feed")
+		return
+	}
+
+	base := baseURL(c, h.siteURL)
+	title := dto.FeedTitle(h.title(base), category)
+
+	var doc any
+	contentType := "application/rss+xml; charset=utf-8"
+	if format == feedFormatAtom {
+		doc = dto.NewAtomFeed(base, base+c.Request.URL.Path, title, feed, full)
+		contentType = "application/atom+xml; charset=utf-8"
+	} else {
+		doc = dto.NewRSSFeed(base, title, feed, full)
+	}
+
+	data, err := xml.Marshal(doc)
+	if err != nil {
+		h.log.Error().Err(err).Msg("serve")
+		c.String(http.StatusInternalServerError, "Failed to build feed")
+		return
+	}
+	data = append([]byte(xml.Header), data...)
+
+	sum := sha256.Sum256(data)
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+	c.Header("ETag", etag)
+	if !feed.Updated.IsZero() {
+		c.Header("Last-Modified", feed.Updated.UTC().Format(http.TimeFormat))
+	}
+
+	if notModified(c, etag, feed.Updated) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
+	c.Data(http.StatusOK, contentType, data)
+}
+
+func (h *feedHandler) title(base string) string {
+	if h.siteName != "" {
+		return h.siteName
+	}
+	if u, err := url.Parse(base); err == nil && u.Host != "" {
+		return u.Host
+	}
+	return base
+}
+
+// notModified evaluates If-None-Match, and If-Modified-Since when no ETag was sent.
+func notModified(c *gin.Context, etag string, lastModified time.Time) bool {
+	if match := c.GetHeader("If-None-Match"); match != "" {
+		for _, tag := range strings.Split(match, ",") {
+			tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+			if tag == etag || tag == "*" {
+				return true
+			}
+		}
+		return false
+	}
+
+	if since := c.GetHeader("If-Modified-Since"); since != "" && !lastModified.IsZero() {
+		t, err := http.ParseTime(since)
+		return err == nil && !lastModified.Truncate(time.Second).After(t)
+	}
+	return false
+}
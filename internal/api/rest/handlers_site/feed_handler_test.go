@@ -0,0 +1,149 @@
+package handlers_site
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	appFeed "github.com/aube/auth/internal/application/feed"
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockFeedService реализует FeedService интерфейс
+type MockFeedService struct {
+	mock.Mock
+}
+
+func (m *MockFeedService) Feed(ctx context.Context, category string) (*entities.Feed, error) {
+	args := m.Called(ctx, category)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Feed), args.Error(1)
+}
+
+var testFeedUpdated = time.Date(2025, 5, 21, 10, 0, 0, 0, time.UTC)
+
+func setupFeedRouter(feeds *MockFeedService, full bool) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	h := NewFeedHandler(feeds, "https://example.com", "Example", full)
+	r.GET("/feeds.rss", h.AllFeed)
+	r.GET("/feeds.atom", h.AllFeed)
+	r.GET("/feeds/:file", h.Feed)
+	return r
+}
+
+func newsFeed() *entities.Feed {
+	return &entities.Feed{
+		Category: "news",
+		Updated:  testFeedUpdated,
+		Items: entities.FeedItems{{
+			PageID:      1,
+			Title:       "Release",
+			Category:    "news",
+			Path:        "/news/release",
+			Summary:     "We shipped",
+			ContentHTML: "<p>Full story</p>",
+			PublishedAt: testFeedUpdated,
+			UpdatedAt:   testFeedUpdated,
+		}},
+	}
+}
+
+func TestFeedHandler_RSS(t *testing.T) {
+	feeds := new(MockFeedService)
+	r := setupFeedRouter(feeds, false)
+	feeds.On("Feed", mock.Anything, "news").Return(newsFeed(), nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/feeds/news.rss", nil)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Header().Get("Content-Type"), "application/rss+xml")
+	assert.Equal(t, "Wed, 21 May 2025 10:00:00 GMT", w.Header().Get("Last-Modified"))
+	assert.NotEmpty(t, w.Header().Get("ETag"))
+	assert.Contains(t, w.Body.String(), "<title>Example: news</title>")
+	assert.Contains(t, w.Body.String(), "<link>https://example.com/news/release</link>")
+	assert.Contains(t, w.Body.String(), "<description>We shipped</description>")
+	assert.NotContains(t, w.Body.String(), "content:encoded")
+}
+
+func TestFeedHandler_AtomFullContent(t *testing.T) {
+	feeds := new(MockFeedService)
+	r := setupFeedRouter(feeds, false)
+	feeds.On("Feed", mock.Anything, "news").Return(newsFeed(), nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/feeds/news.atom?full=true", nil)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Header().Get("Content-Type"), "application/atom+xml")
+	assert.Contains(t, w.Body.String(), `<link href="https://example.com/feeds/news.atom" rel="self"></link>`)
+	assert.Contains(t, w.Body.String(), "<updated>2025-05-21T10:00:00Z</updated>")
+	assert.Contains(t, w.Body.String(), `<content type="html">&lt;p&gt;Full story&lt;/p&gt;</content>`)
+}
+
+func TestFeedHandler_AllCategories(t *testing.T) {
+	feeds := new(MockFeedService)
+	r := setupFeedRouter(feeds, true)
+	feeds.On("Feed", mock.Anything, "").Return(newsFeed(), nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/feeds.rss", nil)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "<title>Example</title>")
+	assert.Contains(t, w.Body.String(), "<content:encoded>&lt;p&gt;Full story&lt;/p&gt;</content:encoded>")
+}
+
+func TestFeedHandler_NotModified(t *testing.T) {
+	feeds := new(MockFeedService)
+	r := setupFeedRouter(feeds, false)
+	feeds.On("Feed", mock.Anything, "news").Return(newsFeed(), nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/feeds/news.rss", nil)
+	r.ServeHTTP(w, req)
+	etag := w.Header().Get("ETag")
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/feeds/news.rss", nil)
+	req.Header.Set("If-None-Match", etag)
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotModified, w.Code)
+	assert.Empty(t, w.Body.String())
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/feeds/news.rss", nil)
+	req.Header.Set("If-Modified-Since", testFeedUpdated.Format(http.TimeFormat))
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotModified, w.Code)
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/feeds/news.rss", nil)
+	req.Header.Set("If-Modified-Since", testFeedUpdated.Add(-time.Hour).Format(http.TimeFormat))
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestFeedHandler_NotFound(t *testing.T) {
+	feeds := new(MockFeedService)
+	r := setupFeedRouter(feeds, false)
+	feeds.On("Feed", mock.Anything, "missing").Return(nil, appFeed.ErrFeedNotFound)
+
+	for _, path := range []string{"/feeds/missing.rss", "/feeds/news.json", "/feeds/.rss"} {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", path, nil)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNotFound, w.Code, path)
+	}
+}
@@ -0,0 +1,58 @@
+package handlers_site
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	appRedirect "github.com/aube/auth/internal/application/redirect"
+	"github.com/aube/auth/internal/utils/logger"
+	"github.com/rs/zerolog"
+
+	"github.com/gin-gonic/gin"
+)
+
+type RedirectService interface {
+	Resolve(ctx context.Context, path string) (string, int, error)
+}
+
+type RedirectHandler interface {
+	Redirect(c *gin.Context)
+}
+
+type redirectHandler struct {
+	redirectService RedirectService
+	log             zerolog.Logger
+}
+
+// NewRedirectHandler creates the middleware applying redirect rules to public paths.
+func NewRedirectHandler(redirectService RedirectService) RedirectHandler {
+	return &redirectHandler{
+		redirectService: redirectService,
+		log:             logger.Get().With().Str("handlers", "redirect_handler").Logger(),
+	}
+}
+
+// Redirect answers GET/HEAD requests matching a redirect rule and aborts
+// the chain; other requests pass through. The query string is kept when
+// the target has none. Loops are logged and treated as no redirect.
+func (h *redirectHandler) Redirect(c *gin.Context) {
+	if c.Request.Method != http.MethodGet && c.Request.Method != http.MethodHead {
+		return
+	}
+
+	target, code, err := h.redirectService.Resolve(c.Request.Context(), c.Request.URL.Path)
+	if err != nil {
+		if !errors.Is(err, appRedirect.ErrRedirectNotFound) {
+			h.log.Debug().Err(err).Str("path", c.Request.URL.Path).Msg("Redirect")
+		}
+		return
+	}
+
+	if c.Request.URL.RawQuery != "" && !strings.Contains(target, "?") {
+		target += "?" + c.Request.URL.RawQuery
+	}
+	c.Redirect(code, target)
+	c.Abort()
+}
@@ -0,0 +1,74 @@
+package handlers_site
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	appRedirect "github.com/aube/auth/internal/application/redirect"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockRedirectService реализует RedirectService интерфейс
+type MockRedirectService struct {
+	mock.Mock
+}
+
+func (m *MockRedirectService) Resolve(ctx context.Context, path string) (string, int, error) {
+	args := m.Called(ctx, path)
+	return args.String(0), args.Int(1), args.Error(2)
+}
+
+func setupRedirectRouter(redirects *MockRedirectService) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.NoRoute(NewRedirectHandler(redirects).Redirect, func(c *gin.Context) {
+		c.String(http.StatusOK, "page")
+	})
+	return r
+}
+
+func TestRedirectHandler_Redirect(t *testing.T) {
+	redirects := new(MockRedirectService)
+	r := setupRedirectRouter(redirects)
+
+	redirects.On("Resolve", mock.Anything, "/old").Return("/new", http.StatusMovedPermanently, nil)
+	redirects.On("Resolve", mock.Anything, "/p").Return("/page?id=1", http.StatusFound, nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/old?utm=x", nil)
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusMovedPermanently, w.Code)
+	assert.Equal(t, "/new?utm=x", w.Header().Get("Location"))
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/p?utm=x", nil)
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusFound, w.Code)
+	assert.Equal(t, "/page?id=1", w.Header().Get("Location"))
+}
+
+func TestRedirectHandler_PassThrough(t *testing.T) {
+	redirects := new(MockRedirectService)
+	r := setupRedirectRouter(redirects)
+
+	redirects.On("Resolve", mock.Anything, "/about").Return("", 0, appRedirect.ErrRedirectNotFound)
+	redirects.On("Resolve", mock.Anything, "/ping").Return("", 0, appRedirect.ErrRedirectLoop)
+
+	for _, path := range []string{"/about", "/ping"} {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", path, nil)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code, path)
+		assert.Equal(t, "page", w.Body.String(), path)
+	}
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/old", nil)
+	r.ServeHTTP(w, req)
+	assert.Equal(t, "page", w.Body.String())
+	redirects.AssertNotCalled(t, "Resolve", mock.Anything, "/old")
+}
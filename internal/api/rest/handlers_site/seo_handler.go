@@ -0,0 +1,117 @@
+package handlers_site
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/aube/auth/internal/application/dto"
+	appSitemap "github.com/aube/auth/internal/application/sitemap"
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/aube/auth/internal/utils/logger"
+	"github.com/rs/zerolog"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SitemapService interface {
+	Files(ctx context.Context) (int, error)
+	Entries(ctx context.Context, file int) (*entities.SitemapEntries, error)
+}
+
+type SeoHandler interface {
+	Robots(c *gin.Context)
+	Sitemap(c *gin.Context)
+	SitemapFile(c *gin.Context)
+}
+
+type seoHandler struct {
+	sitemapService SitemapService
+	siteURL        string
+	robots         string
+	disallow       string
+	log            zerolog.Logger
+}
+
+// NewSeoHandler creates handlers for crawlers.
+// siteURL: Public base URL, empty to take it from the request
+// robots: robots.txt content, empty for the default (everything except disallow is allowed)
+// disallow: Path prefix hidden from crawlers by the default robots.txt (the API path)
+func NewSeoHandler(sitemapService SitemapService, siteURL string, robots string, disallow string) SeoHandler {
+	return &seoHandler{
+		sitemapService: sitemapService,
+		siteURL:        siteURL,
+		robots:         robots,
+		disallow:       disallow,
+		log:            logger.Get().With().Str("handlers", "seo_handler").Logger(),
+	}
+}
+
+// Robots serves robots.txt with a Sitemap line pointing to sitemap.xml.
+func (h *seoHandler) Robots(c *gin.Context) {
+	robots := h.robots
+	if robots == "" {
+		robots = "User-agent: *\nDisallow: " + strings.TrimSuffix(h.disallow, "/") + "/\n"
+	}
+	if !strings.Contains(strings.ToLower(robots), "sitemap:") {
+		robots = strings.TrimRight(robots, "\n") + "\n\nSitemap: " + baseURL(c, h.siteURL) + "/sitemap.xml\n"
+	}
+
+	c.String(http.StatusOK, robots)
+}
+
+// Sitemap serves sitemap.xml: a URL set, or a sitemap index when
+// the URLs do not fit into one file.
+func (h *seoHandler) Sitemap(c *gin.Context) {
+	files, err := h.sitemapService.Files(c.Request.Context())
+	if err != nil {
+		h.log.Debug().Err(err).Msg("Sitemap")
+		c.String(http.StatusInternalServerError, "Failed to build sitemap")
+		return
+	}
+
+	if files > 1 {
+		h.writeXML(c, dto.NewSitemapIndex(baseURL(c, h.siteURL), files))
+		return
+	}
+	h.serveFile(c, 1)
+}
+
+// SitemapFile serves one file of a split sitemap: /sitemap/{n}.xml.
+func (h *seoHandler) SitemapFile(c *gin.Context) {
+	file, err := strconv.Atoi(strings.TrimSuffix(c.Param("file"), ".xml"))
+	if err != nil {
+		c.String(http.StatusNotFound, "Not found")
+		return
+	}
+	h.serveFile(c, file)
+}
+
+func (h *seoHandler) serveFile(c *gin.Context, file int) {
+	entries, err := h.sitemapService.Entries(c.Request.Context(), file)
+	if err != nil {
+		h.log.Debug().Err(err).Msg("serveFile")
+		if errors.Is(err, appSitemap.ErrSitemapNotFound) {
+			c.String(http.StatusNotFound, "Not found")
+			return
+		}
+		c.String(http.StatusInternalServerError, "Failed to build sitemap")
+		return
+	}
+
+	h.writeXML(c, dto.NewSitemapURLSet(baseURL(c, h.siteURL), entries))
+}
+
+func (h *seoHandler) writeXML(c *gin.Context, v any) {
+	data, err := xml.Marshal(v)
+	if err != nil {
+		h.log.Error().Err(err).Msg("writeXML")
+		c.String(http.StatusInternalServerError, "Failed to build sitemap")
+		return
+	}
+
+	c.Data(http.StatusOK, "application/xml; charset=utf-8", append([]byte(xml.Header), data...))
+}
@@ -0,0 +1,131 @@
+package handlers_site
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	appSitemap "github.com/aube/auth/internal/application/sitemap"
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockSitemapService реализует SitemapService интерфейс
+type MockSitemapService struct {
+	mock.Mock
+}
+
+func (m *MockSitemapService) Files(ctx context.Context) (int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockSitemapService) Entries(ctx context.Context, file int) (*entities.SitemapEntries, error) {
+	args := m.Called(ctx, file)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.SitemapEntries), args.Error(1)
+}
+
+func setupSeoRouter(sitemaps *MockSitemapService, robots string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	h := NewSeoHandler(sitemaps, "https://example.com", robots, "/api/v1")
+	r.GET("/robots.txt", h.Robots)
+	r.GET("/sitemap.xml", h.Sitemap)
+	r.GET("/sitemap/:file", h.SitemapFile)
+	return r
+}
+
+func TestSeoHandler_Robots(t *testing.T) {
+	r := setupSeoRouter(new(MockSitemapService), "")
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/robots.txt", nil)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "Disallow: /api/v1/")
+	assert.Contains(t, w.Body.String(), "Sitemap: https://example.com/sitemap.xml")
+}
+
+func TestSeoHandler_RobotsCustom(t *testing.T) {
+	robots := "User-agent: *\nDisallow: /private/\nSitemap: https://cdn.example.com/sitemap.xml\n"
+	r := setupSeoRouter(new(MockSitemapService), robots)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/robots.txt", nil)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, robots, w.Body.String())
+}
+
+func TestSeoHandler_SitemapURLSet(t *testing.T) {
+	sitemaps := new(MockSitemapService)
+	r := setupSeoRouter(sitemaps, "")
+
+	sitemaps.On("Files", mock.Anything).Return(1, nil)
+	sitemaps.On("Entries", mock.Anything, 1).Return(&entities.SitemapEntries{
+		{Path: "/", LastMod: time.Date(2025, 5, 20, 10, 0, 0, 0, time.UTC)},
+		{Path: "/about"},
+	}, nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/sitemap.xml", nil)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Header().Get("Content-Type"), "application/xml")
+	assert.Contains(t, w.Body.String(), "<urlset")
+	assert.Contains(t, w.Body.String(), "<loc>https://example.com/</loc><lastmod>2025-05-20</lastmod>")
+	assert.Contains(t, w.Body.String(), "<loc>https://example.com/about</loc>")
+	assert.NotContains(t, w.Body.String(), "xmlns:xhtml")
+}
+
+func TestSeoHandler_SitemapAlternates(t *testing.T) {
+	sitemaps := new(MockSitemapService)
+	r := setupSeoRouter(sitemaps, "")
+
+	alternates := []entities.PageAlternate{{Locale: "en", Path: "/en/about"}, {Locale: "ru", Path: "/about"}}
+	sitemaps.On("Files", mock.Anything).Return(1, nil)
+	sitemaps.On("Entries", mock.Anything, 1).Return(&entities.SitemapEntries{
+		{Path: "/en/about", Alternates: alternates},
+		{Path: "/about", Alternates: alternates},
+	}, nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/sitemap.xml", nil)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `xmlns:xhtml="http://www.w3.org/1999/xhtml"`)
+	assert.Contains(t, w.Body.String(), `<xhtml:link rel="alternate" hreflang="en" href="https://example.com/en/about"></xhtml:link>`)
+	assert.Equal(t, 4, strings.Count(w.Body.String(), "<xhtml:link"))
+}
+
+func TestSeoHandler_SitemapIndex(t *testing.T) {
+	sitemaps := new(MockSitemapService)
+	r := setupSeoRouter(sitemaps, "")
+
+	sitemaps.On("Files", mock.Anything).Return(2, nil)
+	sitemaps.On("Entries", mock.Anything, 3).Return(nil, appSitemap.ErrSitemapNotFound)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/sitemap.xml", nil)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "<sitemapindex")
+	assert.Contains(t, w.Body.String(), "<loc>https://example.com/sitemap/2.xml</loc>")
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/sitemap/3.xml", nil)
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
@@ -0,0 +1,226 @@
+// Package handlers_site renders published pages with the site theme.
+package handlers_site
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"html/template"
+	"net/http"
+	"strings"
+
+	appNode "github.com/aube/auth/internal/application/node"
+	appPage "github.com/aube/auth/internal/application/page"
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/aube/auth/internal/infrastructure/views"
+	"github.com/aube/auth/internal/utils/locale"
+	"github.com/aube/auth/internal/utils/logger"
+	"github.com/rs/zerolog"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PageService interface {
+	GetByName(ctx context.Context, name string) (*entities.PageWithTime, error)
+	GetByID(ctx context.Context, id int64) (*entities.PageWithTime, error)
+}
+
+type NodeService interface {
+	Resolve(ctx context.Context, path string) (*entities.Node, error)
+}
+
+type TranslationService interface {
+	Locales() *locale.Locales
+	GetByName(ctx context.Context, name string, loc string) (*entities.PageWithTime, error)
+	Localize(ctx context.Context, page *entities.PageWithTime, loc string) (*entities.PageWithTime, error)
+	Alternates(ctx context.Context, page *entities.PageWithTime, nodePath string) ([]entities.PageAlternate, error)
+}
+
+type SiteHandler interface {
+	Render(c *gin.Context)
+}
+
+type Handler struct {
+	pageService        PageService
+	nodeService        NodeService
+	translationService TranslationService
+	views              *views.Views
+	siteURL            string
+	log                zerolog.Logger
+}
+
+// NewSiteHandler creates the page rendering handler.
+// translationService: Locale variants of pages, nil for a single-locale site
+// siteURL: Public base URL for canonical links, empty to take it from the request
+func NewSiteHandler(
+	pageService PageService,
+	nodeService NodeService,
+	translationService TranslationService,
+	views *views.Views,
+	siteURL string,
+) SiteHandler {
+	return &Handler{
+		pageService:        pageService,
+		nodeService:        nodeService,
+		translationService: translationService,
+		views:              views,
+		siteURL:            siteURL,
+		log:                logger.Get().With().Str("handlers", "site_handler").Logger(),
+	}
+}
+
+// Render resolves the request path to a published page (by node path or
+// page name) and renders it with the template named by page.Template.
+// A leading supported locale ("/en/about") selects the translation.
+func (h *Handler) Render(c *gin.Context) {
+	path := entities.NormalizeNodePath(c.Request.URL.Path)
+	if c.Request.Method != http.MethodGet && c.Request.Method != http.MethodHead {
+		h.renderError(c, http.StatusNotFound, path)
+		return
+	}
+
+	ctx := c.Request.Context()
+	loc, pagePath := h.splitLocale(path)
+	page, nodePath, err := h.resolve(ctx, pagePath, loc)
+	if err == nil && h.translationService != nil {
+		page, err = h.translationService.Localize(ctx, page, loc)
+	}
+	if err != nil {
+		h.log.Debug().Err(err).Str("path", path).Msg("Render1")
+		if errors.Is(err, appPage.ErrPageNotFound) {
+			h.renderError(c, http.StatusNotFound, path)
+			return
+		}
+		h.renderError(c, http.StatusInternalServerError, path)
+		return
+	}
+
+	name := page.Template
+	if name == "" {
+		name = views.DefaultTemplate
+	}
+
+	base := baseURL(c, h.siteURL)
+	data := views.PageData{
+		Title:        page.Title,
+		Meta:         page.Meta,
+		Canonical:    page.Meta.CanonicalURL(base, path),
+		H1:           page.H1,
+		Content:      template.HTML(page.ContentHTML), // санитизирован при сохранении
+		ContentShort: page.ContentShort,
+		Path:         path,
+		Status:       http.StatusOK,
+		Lang:         page.Locale,
+		ContentType:  page.ContentType,
+		Fields:       page.Fields,
+	}
+	if h.translationService != nil {
+		h.alternates(ctx, &data, page, nodePath, base)
+	}
+
+	var buf bytes.Buffer
+	err = h.views.RenderPage(&buf, name, data)
+	if err != nil {
+		h.log.Error().Err(err).Str("template", name).Msg("Render2")
+		h.renderError(c, http.StatusInternalServerError, path)
+		return
+	}
+
+	c.Data(http.StatusOK, "text/html; charset=utf-8", buf.Bytes())
+}
+
+// resolve finds the published page for path; pages outside the tree are
+// looked up by their name in loc. nodePath is empty for those.
+// Returns appPage.ErrPageNotFound when there is none.
+func (h *Handler) resolve(ctx context.Context, path string, loc string) (page *entities.PageWithTime, nodePath string, err error) {
+	node, err := h.nodeService.Resolve(ctx, path)
+	switch {
+	case err == nil && node.Published && node.PageID > 0:
+		nodePath = path
+		page, err = h.pageService.GetByID(ctx, node.PageID)
+	case err == nil || errors.Is(err, appNode.ErrNodeNotFound):
+		// Страницы вне дерева доступны по имени: /about
+		name := strings.TrimPrefix(path, "/")
+		if name == "" {
+			name = entities.HomePageName
+		}
+		if strings.Contains(name, "/") {
+			return nil, "", appPage.ErrPageNotFound
+		}
+		if h.translationService != nil {
+			page, err = h.translationService.GetByName(ctx, name, loc)
+		} else {
+			page, err = h.pageService.GetByName(ctx, name)
+		}
+	}
+	if err != nil {
+		return nil, "", err
+	}
+
+	if !page.IsPublished() {
+		return nil, "", appPage.ErrPageNotFound
+	}
+	return page, nodePath, nil
+}
+
+// splitLocale separates a leading non-default locale from path:
+// "/en/about" gives ("en", "/about"). Paths without one are in the default locale.
+func (h *Handler) splitLocale(path string) (string, string) {
+	if h.translationService == nil {
+		return "", path
+	}
+
+	locales := h.translationService.Locales()
+	first, rest, _ := strings.Cut(strings.TrimPrefix(path, "/"), "/")
+	if first == locales.Default() || !locales.IsSupported(first) {
+		return locales.Default(), path
+	}
+	return first, "/" + rest
+}
+
+// alternates fills hreflang links of a page with translations.
+// Errors are logged: the page is rendered without the links.
+func (h *Handler) alternates(ctx context.Context, data *views.PageData, page *entities.PageWithTime, nodePath, base string) {
+	alternates, err := h.translationService.Alternates(ctx, page, nodePath)
+	if err != nil {
+		h.log.Error().Err(err).Int64("page", page.ID).Msg("alternates")
+		return
+	}
+
+	def := h.translationService.Locales().Default()
+	for _, alt := range alternates {
+		data.Alternates = append(data.Alternates, views.Alternate{Lang: alt.Locale, URL: base + alt.Path})
+		if alt.Locale == def {
+			data.XDefault = base + alt.Path
+		}
+	}
+}
+
+func (h *Handler) renderError(c *gin.Context, status int, path string) {
+	data := views.PageData{Path: path}
+	if h.translationService != nil {
+		data.Lang, _ = h.splitLocale(path)
+	}
+
+	var buf bytes.Buffer
+	if err := h.views.RenderError(&buf, status, data); err != nil {
+		h.log.Error().Err(err).Int("status", status).Msg("renderError")
+		c.String(status, http.StatusText(status))
+		return
+	}
+
+	c.Data(status, "text/html; charset=utf-8", buf.Bytes())
+}
+
+// baseURL returns the configured site URL or builds one from the request.
+func baseURL(c *gin.Context, siteURL string) string {
+	if siteURL != "" {
+		return strings.TrimSuffix(siteURL, "/")
+	}
+
+	scheme := "http"
+	if c.Request.TLS != nil || c.GetHeader("X-Forwarded-Proto") == "https" {
+		scheme = "https"
+	}
+	return scheme + "://" + c.Request.Host
+}
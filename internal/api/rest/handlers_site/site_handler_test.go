@@ -0,0 +1,227 @@
+package handlers_site
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	appNode "github.com/aube/auth/internal/application/node"
+	appPage "github.com/aube/auth/internal/application/page"
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/aube/auth/internal/infrastructure/views"
+	"github.com/aube/auth/internal/utils/locale"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockPageService реализует PageService интерфейс
+type MockPageService struct {
+	mock.Mock
+}
+
+func (m *MockPageService) GetByName(ctx context.Context, name string) (*entities.PageWithTime, error) {
+	args := m.Called(ctx, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.PageWithTime), args.Error(1)
+}
+
+func (m *MockPageService) GetByID(ctx context.Context, id int64) (*entities.PageWithTime, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.PageWithTime), args.Error(1)
+}
+
+// MockNodeService реализует NodeService интерфейс
+type MockNodeService struct {
+	mock.Mock
+}
+
+func (m *MockNodeService) Resolve(ctx context.Context, path string) (*entities.Node, error) {
+	args := m.Called(ctx, path)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Node), args.Error(1)
+}
+
+func setupRouter(t *testing.T, pages *MockPageService, nodes *MockNodeService) *gin.Engine {
+	siteViews, err := views.New("../site", false)
+	require.NoError(t, err)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.NoRoute(NewSiteHandler(pages, nodes, nil, siteViews, "https://example.com").Render)
+	return r
+}
+
+func TestSiteHandler_RenderByNodePath(t *testing.T) {
+	pages := new(MockPageService)
+	nodes := new(MockNodeService)
+	r := setupRouter(t, pages, nodes)
+
+	nodes.On("Resolve", mock.Anything, "/about/team").Return(&entities.Node{ID: 2, PageID: 7, Published: true}, nil)
+	pages.On("GetByID", mock.Anything, int64(7)).Return(&entities.PageWithTime{
+		ID:          7,
+		Name:        "team",
+		Title:       "Our team",
+		H1:          "Team",
+		ContentHTML: "<p>People</p>",
+		Meta:        entities.PageMeta{Description: "Who we are", OpenGraph: entities.OpenGraphMeta{Image: "/img/team.jpg"}},
+		Status:      entities.PageStatusPublished,
+	}, nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/about/team/", nil)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Header().Get("Content-Type"), "text/html")
+	assert.Contains(t, w.Body.String(), "<title>Our team</title>")
+	assert.Contains(t, w.Body.String(), "<p>People</p>")
+	assert.Contains(t, w.Body.String(), `<link rel="canonical" href="https://example.com/about/team">`)
+	assert.Contains(t, w.Body.String(), `<meta name="description" content="Who we are">`)
+	assert.Contains(t, w.Body.String(), `<meta property="og:image" content="/img/team.jpg">`)
+}
+
+func TestSiteHandler_RenderByName(t *testing.T) {
+	pages := new(MockPageService)
+	nodes := new(MockNodeService)
+	r := setupRouter(t, pages, nodes)
+
+	nodes.On("Resolve", mock.Anything, "/").Return(nil, appNode.ErrNodeNotFound)
+	pages.On("GetByName", mock.Anything, "index").Return(&entities.PageWithTime{
+		ID:     1,
+		Name:   "index",
+		H1:     "Home",
+		Status: entities.PageStatusPublished,
+	}, nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/", nil)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "<h1>Home</h1>")
+}
+
+func TestSiteHandler_NotFound(t *testing.T) {
+	pages := new(MockPageService)
+	nodes := new(MockNodeService)
+	r := setupRouter(t, pages, nodes)
+
+	nodes.On("Resolve", mock.Anything, "/draft").Return(nil, appNode.ErrNodeNotFound)
+	nodes.On("Resolve", mock.Anything, "/missing").Return(nil, appNode.ErrNodeNotFound)
+	pages.On("GetByName", mock.Anything, "draft").Return(&entities.PageWithTime{
+		ID:     3,
+		Name:   "draft",
+		Status: entities.PageStatusDraft,
+	}, nil)
+	pages.On("GetByName", mock.Anything, "missing").Return(nil, appPage.ErrPageNotFound)
+
+	for _, path := range []string{"/draft", "/missing"} {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", path, nil)
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code, path)
+		assert.Contains(t, w.Body.String(), "Страница не найдена", path)
+	}
+}
+
+func TestSiteHandler_ServerError(t *testing.T) {
+	pages := new(MockPageService)
+	nodes := new(MockNodeService)
+	r := setupRouter(t, pages, nodes)
+
+	nodes.On("Resolve", mock.Anything, "/about").Return(nil, assert.AnError)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/about", nil)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Contains(t, w.Body.String(), "Ошибка сервера")
+}
+
+// MockTranslationService реализует TranslationService интерфейс
+type MockTranslationService struct {
+	mock.Mock
+	locales *locale.Locales
+}
+
+func (m *MockTranslationService) Locales() *locale.Locales {
+	return m.locales
+}
+
+func (m *MockTranslationService) GetByName(ctx context.Context, name string, loc string) (*entities.PageWithTime, error) {
+	args := m.Called(ctx, name, loc)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.PageWithTime), args.Error(1)
+}
+
+func (m *MockTranslationService) Localize(ctx context.Context, page *entities.PageWithTime, loc string) (*entities.PageWithTime, error) {
+	args := m.Called(ctx, page, loc)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.PageWithTime), args.Error(1)
+}
+
+func (m *MockTranslationService) Alternates(ctx context.Context, page *entities.PageWithTime, nodePath string) ([]entities.PageAlternate, error) {
+	args := m.Called(ctx, page, nodePath)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.PageAlternate), args.Error(1)
+}
+
+func TestSiteHandler_RenderTranslation(t *testing.T) {
+	siteViews, err := views.New("../site", false)
+	require.NoError(t, err)
+	locales, err := locale.New("ru", []string{"ru", "en"}, nil)
+	require.NoError(t, err)
+
+	pages := new(MockPageService)
+	nodes := new(MockNodeService)
+	translations := &MockTranslationService{locales: locales}
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.NoRoute(NewSiteHandler(pages, nodes, translations, siteViews, "https://example.com").Render)
+
+	page := &entities.PageWithTime{ID: 7, Name: "team", Status: entities.PageStatusPublished}
+	nodes.On("Resolve", mock.Anything, "/about/team").Return(&entities.Node{ID: 2, PageID: 7, Published: true}, nil)
+	pages.On("GetByID", mock.Anything, int64(7)).Return(page, nil)
+	translations.On("Localize", mock.Anything, page, "en").Return(&entities.PageWithTime{
+		ID:     7,
+		Name:   "team",
+		H1:     "Our team",
+		Status: entities.PageStatusPublished,
+		Locale: "en",
+	}, nil)
+	translations.On("Alternates", mock.Anything, mock.Anything, "/about/team").Return([]entities.PageAlternate{
+		{Locale: "ru", Path: "/about/team"},
+		{Locale: "en", Path: "/en/about/team"},
+	}, nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/en/about/team", nil)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `<html lang="en">`)
+	assert.Contains(t, w.Body.String(), "<h1>Our team</h1>")
+	assert.Contains(t, w.Body.String(), `<link rel="canonical" href="https://example.com/en/about/team">`)
+	assert.Contains(t, w.Body.String(), `<link rel="alternate" hreflang="ru" href="https://example.com/about/team">`)
+	assert.Contains(t, w.Body.String(), `<link rel="alternate" hreflang="en" href="https://example.com/en/about/team">`)
+	assert.Contains(t, w.Body.String(), `<link rel="alternate" hreflang="x-default" href="https://example.com/about/team">`)
+}
@@ -0,0 +1,198 @@
+// Package handlers_tag provides handlers for tags of pages, uploads
+// and images and the facets of their lists.
+package handlers_tag
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/aube/auth/internal/application/dto"
+	appImage "github.com/aube/auth/internal/application/image"
+	appPage "github.com/aube/auth/internal/application/page"
+	appTag "github.com/aube/auth/internal/application/tag"
+	appUpload "github.com/aube/auth/internal/application/upload"
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/aube/auth/internal/utils/logger"
+	"github.com/aube/auth/internal/utils/query"
+	"github.com/rs/zerolog"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TagService interface {
+	List(ctx context.Context, kind string, userID int64) (*entities.Tags, error)
+	ItemTags(ctx context.Context, item *entities.TaggedItem, userID int64) ([]string, error)
+	SetItemTags(ctx context.Context, item *entities.TaggedItem, userID int64, names []string) ([]string, error)
+	Rename(ctx context.Context, kind string, userID int64, from, to string) error
+	Merge(ctx context.Context, kind string, userID int64, from []string, to string) error
+	Facets(ctx context.Context, kind string, userID int64, filter *query.Query) (*entities.Facets, error)
+}
+
+type TagHandler interface {
+	List(c *gin.Context)
+	GetItemTags(c *gin.Context)
+	SetItemTags(c *gin.Context)
+	Rename(c *gin.Context)
+	Merge(c *gin.Context)
+	Facets(c *gin.Context)
+}
+
+type Handler struct {
+	tagService TagService
+	log        zerolog.Logger
+}
+
+// listSchemas are the filters of the list endpoints facets are counted for.
+var listSchemas = map[string]query.Schema{
+	entities.TagKindPage:   appPage.ListSchema,
+	entities.TagKindUpload: appUpload.ListSchema,
+	entities.TagKindImage:  appImage.ListSchema,
+}
+
+func NewTagHandler(tagService TagService) TagHandler {
+	return &Handler{
+		tagService: tagService,
+		log:        logger.Get().With().Str("handlers", "tag_handler").Logger(),
+	}
+}
+
+// List returns tags of a kind (?type=): shared page tags or the user's file tags.
+func (h *Handler) List(c *gin.Context) {
+	tags, err := h.tagService.List(c.Request.Context(), c.Query("type"), int64(c.GetInt("userID")))
+	if err != nil {
+		h.log.Debug().Err(err).Msg("List")
+		h.abortWithError(c, err)
+		return
+	}
+
+	rows := make([]dto.TagResponse, len(*tags))
+	for i, tag := range *tags {
+		rows[i] = dto.NewTagResponse(&tag)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"rows": rows})
+}
+
+// GetItemTags returns tags of an item (?type=&id= or ?type=&uuid=).
+func (h *Handler) GetItemTags(c *gin.Context) {
+	var req dto.TagItemRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.log.Debug().Err(err).Msg("GetItemTags1")
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	item, err := entities.NewTaggedItemRef(req.Type, req.ID, req.UUID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	tags, err := h.tagService.ItemTags(c.Request.Context(), item, int64(c.GetInt("userID")))
+	if err != nil {
+		h.log.Debug().Err(err).Msg("GetItemTags2")
+		h.abortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"tags": tags})
+}
+
+// SetItemTags replaces tags of an item and returns them normalized.
+func (h *Handler) SetItemTags(c *gin.Context) {
+	var req dto.TagItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.Debug().Err(err).Msg("SetItemTags1")
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	item, err := entities.NewTaggedItemRef(req.Type, req.ID, req.UUID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	tags, err := h.tagService.SetItemTags(c.Request.Context(), item, int64(c.GetInt("userID")), req.Tags)
+	if err != nil {
+		h.log.Debug().Err(err).Msg("SetItemTags2")
+		h.abortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"tags": tags})
+}
+
+func (h *Handler) Rename(c *gin.Context) {
+	var req dto.TagRenameRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.Debug().Err(err).Msg("Rename1")
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.tagService.Rename(c.Request.Context(), req.Type, int64(c.GetInt("userID")), req.From, req.To); err != nil {
+		h.log.Debug().Err(err).Msg("Rename2")
+		h.abortWithError(c, err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+func (h *Handler) Merge(c *gin.Context) {
+	var req dto.TagMergeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.Debug().Err(err).Msg("Merge1")
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.tagService.Merge(c.Request.Context(), req.Type, int64(c.GetInt("userID")), req.From, req.To); err != nil {
+		h.log.Debug().Err(err).Msg("Merge2")
+		h.abortWithError(c, err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+// Facets counts items of a kind (?type=) per tag and per category
+// for the filter[...] parameters of its list endpoint.
+func (h *Handler) Facets(c *gin.Context) {
+	kind := c.Query("type")
+	schema, ok := listSchemas[kind]
+	if !ok {
+		h.abortWithError(c, appTag.ErrInvalidKind)
+		return
+	}
+
+	filter, err := query.Parse(c.Request.URL.Query(), schema)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	filter.Add("deleted", query.OpEq, false)
+
+	facets, err := h.tagService.Facets(c.Request.Context(), kind, int64(c.GetInt("userID")), filter)
+	if err != nil {
+		h.log.Debug().Err(err).Msg("Facets")
+		h.abortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewFacetsResponse(facets))
+}
+
+func (h *Handler) abortWithError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, appTag.ErrItemNotFound), errors.Is(err, appTag.ErrTagNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, appTag.ErrInvalidKind), errors.Is(err, appTag.ErrTooManyTags),
+		errors.Is(err, entities.ErrInvalidTag), errors.Is(err, query.ErrInvalidQuery):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to process tags"})
+	}
+}
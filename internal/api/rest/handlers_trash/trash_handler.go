@@ -0,0 +1,138 @@
+// Package handlers_trash provides handlers for the trash of deleted
+// pages, uploads and images.
+package handlers_trash
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/aube/auth/internal/api/rest/middlewares"
+	"github.com/aube/auth/internal/application/dto"
+	appTrash "github.com/aube/auth/internal/application/trash"
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/aube/auth/internal/utils/logger"
+	"github.com/aube/auth/internal/utils/query"
+	"github.com/rs/zerolog"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TrashService interface {
+	List(ctx context.Context, itemType string, userID int64, offset, limit int, filter *query.Query) (*entities.TrashItems, *dto.Pagination, error)
+	Restore(ctx context.Context, item *entities.TrashItem, userID int64) error
+	Purge(ctx context.Context, item *entities.TrashItem, userID int64) error
+	Retention() time.Duration
+}
+
+type TrashHandler interface {
+	List(c *gin.Context)
+	Restore(c *gin.Context)
+	Purge(c *gin.Context)
+}
+
+type Handler struct {
+	trashService TrashService
+	log          zerolog.Logger
+}
+
+func NewTrashHandler(trashService TrashService) TrashHandler {
+	return &Handler{
+		trashService: trashService,
+		log:          logger.Get().With().Str("handlers", "trash_handler").Logger(),
+	}
+}
+
+// List returns deleted items of the user, ?type= limits them to one kind.
+func (h *Handler) List(c *gin.Context) {
+	items, pagination, err := h.trashService.List(
+		c.Request.Context(),
+		c.Query("type"),
+		int64(c.GetInt("userID")),
+		c.GetInt("offset"),
+		c.GetInt("limit"),
+		middlewares.WithCursor(c, nil),
+	)
+	if err != nil {
+		h.log.Debug().Err(err).Msg("List")
+		h.abortWithError(c, err)
+		return
+	}
+	if err := middlewares.SignCursors(c, pagination); err != nil {
+		h.log.Debug().Err(err).Msg("SignCursors")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list trash"})
+		return
+	}
+
+	retention := h.trashService.Retention()
+	rows := make([]dto.TrashItemResponse, len(*items))
+	for i, item := range *items {
+		rows[i] = dto.NewTrashItemResponse(&item, retention)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"rows":       rows,
+		"pagination": pagination,
+	})
+}
+
+func (h *Handler) Restore(c *gin.Context) {
+	var req dto.TrashRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.Debug().Err(err).Msg("Restore1")
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	item, err := entities.NewTrashItemRef(req.Type, req.ID, req.UUID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.trashService.Restore(c.Request.Context(), item, int64(c.GetInt("userID"))); err != nil {
+		h.log.Debug().Err(err).Msg("Restore2")
+		h.abortWithError(c, err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+// Purge permanently deletes the item (?type=&id= or ?type=&uuid=).
+func (h *Handler) Purge(c *gin.Context) {
+	var req dto.TrashRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.log.Debug().Err(err).Msg("Purge1")
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	item, err := entities.NewTrashItemRef(req.Type, req.ID, req.UUID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.trashService.Purge(c.Request.Context(), item, int64(c.GetInt("userID"))); err != nil {
+		h.log.Debug().Err(err).Msg("Purge2")
+		h.abortWithError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *Handler) abortWithError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, appTrash.ErrItemNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, appTrash.ErrNameTaken):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, appTrash.ErrInvalidType), errors.Is(err, query.ErrInvalidQuery):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to process trash item"})
+	}
+}
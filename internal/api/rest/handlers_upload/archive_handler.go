@@ -0,0 +1,120 @@
+package handlers_upload
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+
+	appArchive "github.com/aube/auth/internal/application/archive"
+	"github.com/aube/auth/internal/application/dto"
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/aube/auth/internal/utils/disposition"
+	"github.com/aube/auth/internal/utils/logger"
+	"github.com/rs/zerolog"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ArchiveService defines the interface for ZIP download and import of uploads.
+type ArchiveService interface {
+	Uploads(ctx context.Context, userID int64, category string, uuids []string) (*entities.Uploads, error)
+	Write(ctx context.Context, w io.Writer, uploads *entities.Uploads) error
+	Import(ctx context.Context, userID int64, r io.ReaderAt, size int64, category, description string) (entities.ArchiveEntries, error)
+}
+
+type ArchiveHandler interface {
+	DownloadArchive(c *gin.Context)
+	UploadArchive(c *gin.Context)
+}
+
+type archiveHandler struct {
+	archiveService ArchiveService
+	log            zerolog.Logger
+}
+
+func NewArchiveHandler(archiveService ArchiveService) ArchiveHandler {
+	return &archiveHandler{
+		archiveService: archiveService,
+		log:            logger.Get().With().Str("handlers", "archive_handler").Logger(),
+	}
+}
+
+// DownloadArchive streams a ZIP of the user's uploads with the UUIDs
+// (?uuid=...&uuid=...) or in the category (?category=).
+func (h *archiveHandler) DownloadArchive(c *gin.Context) {
+	userID := c.GetInt("userID")
+	category, hasCategory := c.GetQuery("category")
+	uuids := c.QueryArray("uuid")
+
+	if !hasCategory && len(uuids) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Category or file UUIDs are required"})
+		return
+	}
+
+	uploads, err := h.archiveService.Uploads(c.Request.Context(), int64(userID), category, uuids)
+	if err != nil {
+		h.log.Debug().Err(err).Msg("DownloadArchive1")
+		h.abortWithError(c, err)
+		return
+	}
+
+	c.Header("Content-Disposition", disposition.Header(disposition.Attachment, appArchive.FileName(category, len(*uploads))))
+	c.Header("Content-Type", "application/zip")
+	c.Status(http.StatusOK)
+
+	// Архив пишется по мере чтения файлов, статус ответа уже отправлен
+	if err := h.archiveService.Write(c.Request.Context(), c.Writer, uploads); err != nil {
+		h.log.Error().Err(err).Msg("DownloadArchive2")
+	}
+}
+
+// UploadArchive registers files of a ZIP archive (form field "file")
+// as uploads and returns the result of each file.
+func (h *archiveHandler) UploadArchive(c *gin.Context) {
+	userID := c.GetInt("userID")
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		h.log.Debug().Err(err).Msg("UploadArchive1")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid file"})
+		return
+	}
+
+	archive, err := fileHeader.Open()
+	if err != nil {
+		h.log.Debug().Err(err).Msg("UploadArchive2")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid file"})
+		return
+	}
+	defer archive.Close()
+
+	entries, err := h.archiveService.Import(
+		c.Request.Context(),
+		int64(userID),
+		archive,
+		fileHeader.Size,
+		c.PostForm("category"),
+		c.PostForm("description"),
+	)
+	if err != nil {
+		h.log.Debug().Err(err).Msg("UploadArchive3")
+		h.abortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewArchiveImportResponse(entries))
+}
+
+func (h *archiveHandler) abortWithError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, appArchive.ErrNoFiles):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, appArchive.ErrTooManyFiles), errors.Is(err, appArchive.ErrArchiveTooLarge):
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": err.Error()})
+	case errors.Is(err, appArchive.ErrInvalidArchive):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to process archive"})
+	}
+}
@@ -0,0 +1,228 @@
+package handlers_upload
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/aube/auth/internal/api/rest/middlewares"
+	"github.com/aube/auth/internal/application/dto"
+	appFolder "github.com/aube/auth/internal/application/folder"
+	appUpload "github.com/aube/auth/internal/application/upload"
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/aube/auth/internal/utils/logger"
+	"github.com/aube/auth/internal/utils/query"
+	"github.com/rs/zerolog"
+
+	"github.com/gin-gonic/gin"
+)
+
+// FolderService defines the interface for folders of uploads.
+type FolderService interface {
+	Create(ctx context.Context, userID int64, req dto.FolderRequest) (*entities.UploadFolder, error)
+	Get(ctx context.Context, id int64, userID int64) (*entities.UploadFolders, error)
+	Rename(ctx context.Context, id int64, userID int64, name string, policy string) (*entities.UploadFolder, error)
+	Move(ctx context.Context, id int64, userID int64, parentID int64, policy string) (*entities.UploadFolder, error)
+	Delete(ctx context.Context, id int64, userID int64) (int64, error)
+	List(ctx context.Context, userID int64, folderID int64, offset, limit int, filter *query.Query) (*entities.FolderEntries, *dto.Pagination, error)
+}
+
+type FolderHandler interface {
+	CreateFolder(c *gin.Context)
+	GetFolder(c *gin.Context)
+	RenameFolder(c *gin.Context)
+	MoveFolder(c *gin.Context)
+	DeleteFolder(c *gin.Context)
+	ListFolder(c *gin.Context)
+	MoveFile(c *gin.Context)
+}
+
+type folderHandler struct {
+	folderService FolderService
+	uploadService UploadService
+	log           zerolog.Logger
+}
+
+func NewFolderHandler(folderService FolderService, uploadService UploadService) FolderHandler {
+	return &folderHandler{
+		folderService: folderService,
+		uploadService: uploadService,
+		log:           logger.Get().With().Str("handlers", "folder_handler").Logger(),
+	}
+}
+
+func (h *folderHandler) CreateFolder(c *gin.Context) {
+	var req dto.FolderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.Debug().Err(err).Msg("CreateFolder1")
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	folder, err := h.folderService.Create(c.Request.Context(), int64(c.GetInt("userID")), req)
+	if err != nil {
+		h.log.Debug().Err(err).Msg("CreateFolder2")
+		h.abortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.NewFolderResponse(folder))
+}
+
+// GetFolder returns a folder (?id=) with its path from the root.
+func (h *folderHandler) GetFolder(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Query("id"), 10, 64)
+	if err != nil || id <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Folder ID is required"})
+		return
+	}
+
+	folders, err := h.folderService.Get(c.Request.Context(), id, int64(c.GetInt("userID")))
+	if err != nil {
+		h.log.Debug().Err(err).Msg("GetFolder")
+		h.abortWithError(c, err)
+		return
+	}
+
+	path := make([]dto.FolderResponse, len(*folders))
+	for i, folder := range *folders {
+		path[i] = dto.NewFolderResponse(&folder)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"folder": path[len(path)-1],
+		"path":   path,
+	})
+}
+
+func (h *folderHandler) RenameFolder(c *gin.Context) {
+	var req dto.FolderRenameRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.Debug().Err(err).Msg("RenameFolder1")
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	folder, err := h.folderService.Rename(c.Request.Context(), req.ID, int64(c.GetInt("userID")), req.Name, req.Conflict)
+	if err != nil {
+		h.log.Debug().Err(err).Msg("RenameFolder2")
+		h.abortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewFolderResponse(folder))
+}
+
+func (h *folderHandler) MoveFolder(c *gin.Context) {
+	var req dto.FolderMoveRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.Debug().Err(err).Msg("MoveFolder1")
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	folder, err := h.folderService.Move(c.Request.Context(), req.ID, int64(c.GetInt("userID")), req.ParentID, req.Conflict)
+	if err != nil {
+		h.log.Debug().Err(err).Msg("MoveFolder2")
+		h.abortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewFolderResponse(folder))
+}
+
+// DeleteFolder deletes a folder (?id=) with its subfolders; their files go to the trash.
+func (h *folderHandler) DeleteFolder(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Query("id"), 10, 64)
+	if err != nil || id <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Folder ID is required"})
+		return
+	}
+
+	trashed, err := h.folderService.Delete(c.Request.Context(), id, int64(c.GetInt("userID")))
+	if err != nil {
+		h.log.Debug().Err(err).Msg("DeleteFolder")
+		h.abortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"trashed": trashed})
+}
+
+// ListFolder returns subfolders and files of a folder (?id=, the root by default).
+// Uses PaginationMiddleware and CursorMiddleware.
+func (h *folderHandler) ListFolder(c *gin.Context) {
+	folderID, err := folderParam(c.Query("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	filter, err := query.Parse(c.Request.URL.Query(), appFolder.ListSchema)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	middlewares.WithCursor(c, filter)
+
+	entries, pagination, err := h.folderService.List(c.Request.Context(), int64(c.GetInt("userID")), folderID, c.GetInt("offset"), c.GetInt("limit"), filter)
+	if err != nil {
+		h.log.Debug().Err(err).Msg("ListFolder")
+		h.abortWithError(c, err)
+		return
+	}
+
+	if err := middlewares.SignCursors(c, pagination); err != nil {
+		h.log.Debug().Err(err).Msg("SignCursors")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list folder"})
+		return
+	}
+
+	rows := make([]dto.FolderEntryResponse, len(*entries))
+	for i, entry := range *entries {
+		rows[i] = dto.NewFolderEntryResponse(&entry)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"rows":       rows,
+		"pagination": pagination,
+	})
+}
+
+// MoveFile moves an upload to another folder.
+func (h *folderHandler) MoveFile(c *gin.Context) {
+	var req dto.UploadMoveRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.Debug().Err(err).Msg("MoveFile1")
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if req.Conflict == "" {
+		req.Conflict = entities.ConflictReject
+	}
+
+	upload, err := h.uploadService.Move(c.Request.Context(), req.UUID, int64(c.GetInt("userID")), req.FolderID, req.Conflict)
+	if err != nil {
+		h.log.Debug().Err(err).Msg("MoveFile2")
+		h.abortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewUploadResponse(upload))
+}
+
+func (h *folderHandler) abortWithError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, appFolder.ErrFolderNotFound), errors.Is(err, appUpload.ErrFileNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, appFolder.ErrFolderExists), errors.Is(err, appUpload.ErrNameTaken):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, appFolder.ErrFolderCycle), errors.Is(err, entities.ErrInvalidConflictPolicy),
+		errors.Is(err, entities.ErrInvalidFolderName),
+		errors.Is(err, query.ErrInvalidQuery):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to process folder"})
+	}
+}
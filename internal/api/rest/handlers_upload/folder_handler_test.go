@@ -0,0 +1,168 @@
+package handlers_upload
+
+import (
+	"bytes"
+	"context"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aube/auth/internal/application/dto"
+	appFolder "github.com/aube/auth/internal/application/folder"
+	appUpload "github.com/aube/auth/internal/application/upload"
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/aube/auth/internal/utils/query"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockFolderService реализует FolderService интерфейс
+type MockFolderService struct {
+	mock.Mock
+}
+
+func (m *MockFolderService) Create(ctx context.Context, userID int64, req dto.FolderRequest) (*entities.UploadFolder, error) {
+	args := m.Called(ctx, userID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.UploadFolder), args.Error(1)
+}
+
+func (m *MockFolderService) Get(ctx context.Context, id int64, userID int64) (*entities.UploadFolders, error) {
+	args := m.Called(ctx, id, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.UploadFolders), args.Error(1)
+}
+
+func (m *MockFolderService) Rename(ctx context.Context, id int64, userID int64, name string, policy string) (*entities.UploadFolder, error) {
+	args := m.Called(ctx, id, userID, name, policy)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.UploadFolder), args.Error(1)
+}
+
+func (m *MockFolderService) Move(ctx context.Context, id int64, userID int64, parentID int64, policy string) (*entities.UploadFolder, error) {
+	args := m.Called(ctx, id, userID, parentID, policy)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.UploadFolder), args.Error(1)
+}
+
+func (m *MockFolderService) Delete(ctx context.Context, id int64, userID int64) (int64, error) {
+	args := m.Called(ctx, id, userID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockFolderService) List(ctx context.Context, userID int64, folderID int64, offset, limit int, filter *query.Query) (*entities.FolderEntries, *dto.Pagination, error) {
+	args := m.Called(ctx, userID, folderID)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+	return args.Get(0).(*entities.FolderEntries), args.Get(1).(*dto.Pagination), args.Error(2)
+}
+
+func folderContext(method, target, body string) (*gin.Context, *httptest.ResponseRecorder) {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set("userID", 1)
+	c.Request = httptest.NewRequest(method, target, bytes.NewBufferString(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+	return c, w
+}
+
+func TestFolderHandler_CreateFolder(t *testing.T) {
+	folders := new(MockFolderService)
+	handler := NewFolderHandler(folders, new(MockUploadService))
+
+	folders.On("Create", mock.Anything, int64(1), dto.FolderRequest{ParentID: 3, Name: "docs"}).
+		Return(&entities.UploadFolder{ID: 5, ParentID: 3, Name: "docs"}, nil)
+	folders.On("Create", mock.Anything, int64(1), dto.FolderRequest{Name: "docs"}).
+		Return(nil, appFolder.ErrFolderExists)
+	folders.On("Create", mock.Anything, int64(1), dto.FolderRequest{ParentID: 9, Name: "docs"}).
+		Return(nil, appFolder.ErrFolderNotFound)
+
+	c, w := folderContext("POST", "/folder", `{"parent_id":3,"name":"docs"}`)
+	handler.CreateFolder(c)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Contains(t, w.Body.String(), `"id":5`)
+
+	c, w = folderContext("POST", "/folder", `{"name":"docs"}`)
+	handler.CreateFolder(c)
+	assert.Equal(t, http.StatusConflict, w.Code)
+
+	c, w = folderContext("POST", "/folder", `{"parent_id":9,"name":"docs"}`)
+	handler.CreateFolder(c)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestFolderHandler_GetFolder(t *testing.T) {
+	folders := new(MockFolderService)
+	handler := NewFolderHandler(folders, new(MockUploadService))
+
+	folders.On("Get", mock.Anything, int64(5), int64(1)).Return(&entities.UploadFolders{
+		{ID: 3, Name: "projects"},
+		{ID: 5, ParentID: 3, Name: "docs"},
+	}, nil)
+
+	c, w := folderContext("GET", "/folder?id=5", "")
+	handler.GetFolder(c)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"folder":{"id":5,"parent_id":3,"name":"docs"`)
+}
+
+func TestFolderHandler_MoveFile(t *testing.T) {
+	uploads := new(MockUploadService)
+	handler := NewFolderHandler(new(MockFolderService), uploads)
+
+	uploads.On("Move", mock.Anything, "u1", int64(1), int64(5), entities.ConflictReject).
+		Return(nil, appUpload.ErrNameTaken)
+	uploads.On("Move", mock.Anything, "u1", int64(1), int64(5), entities.ConflictRename).
+		Return(&entities.Upload{UUID: "u1", Name: "a (1).pdf", FolderID: 5}, nil)
+
+	c, w := folderContext("POST", "/upload/move", `{"uuid":"u1","folder_id":5}`)
+	handler.MoveFile(c)
+	assert.Equal(t, http.StatusConflict, w.Code)
+
+	c, w = folderContext("POST", "/upload/move", `{"uuid":"u1","folder_id":5,"conflict":"rename"}`)
+	handler.MoveFile(c)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"name":"a (1).pdf","folder_id":5`)
+}
+
+func TestUploadHandler_UploadFile_NameTaken(t *testing.T) {
+	mockFileService := new(MockFileService)
+	mockUploadService := new(MockUploadService)
+	handler := NewUploadHandler(mockFileService, mockUploadService, nil, nil)
+
+	mockUploadService.On("ResolveName", mock.Anything, int64(1), int64(5), "test.txt", entities.ConflictReject).
+		Return("", nil, appUpload.ErrNameTaken)
+
+	body := new(bytes.Buffer)
+	writer := multipart.NewWriter(body)
+	part, err := writer.CreateFormFile("file", "test.txt")
+	require.NoError(t, err)
+	_, err = part.Write([]byte("test content"))
+	require.NoError(t, err)
+	require.NoError(t, writer.WriteField("folder_id", "5"))
+	require.NoError(t, writer.WriteField("conflict", "reject"))
+	writer.Close()
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set("userID", 1)
+	c.Request = httptest.NewRequest("POST", "/upload", body)
+	c.Request.Header.Set("Content-Type", writer.FormDataContentType())
+
+	handler.UploadFile(c)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	mockFileService.AssertNotCalled(t, "Upload", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
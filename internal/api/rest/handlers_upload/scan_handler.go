@@ -0,0 +1,126 @@
+package handlers_upload
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/aube/auth/internal/api/rest/middlewares"
+	"github.com/aube/auth/internal/application/dto"
+	appScan "github.com/aube/auth/internal/application/scan"
+	appUpload "github.com/aube/auth/internal/application/upload"
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/aube/auth/internal/utils/logger"
+	"github.com/aube/auth/internal/utils/query"
+	"github.com/rs/zerolog"
+
+	"github.com/gin-gonic/gin"
+)
+
+// QuarantineService defines the interface for admin review of scanned files.
+type QuarantineService interface {
+	List(ctx context.Context, offset, limit int, filter *query.Query) (*entities.Uploads, *dto.Pagination, error)
+	Rescan(ctx context.Context, uuid string) error
+	Release(ctx context.Context, uuid string, adminID int64) error
+}
+
+type QuarantineHandler interface {
+	ListQuarantine(c *gin.Context)
+	Rescan(c *gin.Context)
+	Release(c *gin.Context)
+}
+
+type quarantineHandler struct {
+	quarantineService QuarantineService
+	log               zerolog.Logger
+}
+
+func NewQuarantineHandler(quarantineService QuarantineService) QuarantineHandler {
+	return &quarantineHandler{
+		quarantineService: quarantineService,
+		log:               logger.Get().With().Str("handlers", "quarantine_handler").Logger(),
+	}
+}
+
+// ListQuarantine returns uploads of all users not found clean;
+// filter[scan_status]= picks other statuses.
+// Uses PaginationMiddleware and CursorMiddleware.
+func (h *quarantineHandler) ListQuarantine(c *gin.Context) {
+	filter, err := query.Parse(c.Request.URL.Query(), appScan.QuarantineSchema)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	middlewares.WithCursor(c, filter)
+
+	uploads, pagination, err := h.quarantineService.List(c.Request.Context(), c.GetInt("offset"), c.GetInt("limit"), filter)
+	if err != nil {
+		h.log.Debug().Err(err).Msg("ListQuarantine")
+		if errors.Is(err, query.ErrInvalidQuery) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list files"})
+		return
+	}
+	if err := middlewares.SignCursors(c, pagination); err != nil {
+		h.log.Debug().Err(err).Msg("SignCursors")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list files"})
+		return
+	}
+
+	rows := make([]dto.QuarantineResponse, len(*uploads))
+	for i, upload := range *uploads {
+		rows[i] = dto.NewQuarantineResponse(&upload)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"rows":       rows,
+		"pagination": pagination,
+	})
+}
+
+// Rescan queues a stored file for another scan.
+func (h *quarantineHandler) Rescan(c *gin.Context) {
+	var req dto.ScanRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.quarantineService.Rescan(c.Request.Context(), req.UUID); err != nil {
+		h.log.Debug().Err(err).Msg("Rescan")
+		h.quarantineError(c, err)
+		return
+	}
+
+	c.Status(http.StatusAccepted)
+}
+
+// Release marks a stored file as clean by decision of the admin.
+func (h *quarantineHandler) Release(c *gin.Context) {
+	var req dto.ScanRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.quarantineService.Release(c.Request.Context(), req.UUID, int64(c.GetInt("userID"))); err != nil {
+		h.log.Debug().Err(err).Msg("Release")
+		h.quarantineError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *quarantineHandler) quarantineError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, appUpload.ErrFileNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, appScan.ErrScanningDisabled):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to change scan status"})
+	}
+}
@@ -0,0 +1,188 @@
+package handlers_upload
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aube/auth/internal/application/dto"
+	appScan "github.com/aube/auth/internal/application/scan"
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/aube/auth/internal/utils/query"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockScanService реализует ScanService и QuarantineService интерфейсы
+type MockScanService struct {
+	mock.Mock
+}
+
+func (m *MockScanService) CheckDownload(status string) error {
+	return m.Called(status).Error(0)
+}
+
+func (m *MockScanService) InitialStatus() string {
+	return m.Called().String(0)
+}
+
+func (m *MockScanService) Submit(ctx context.Context, uuid string) error {
+	return m.Called(ctx, uuid).Error(0)
+}
+
+func (m *MockScanService) List(ctx context.Context, offset, limit int, filter *query.Query) (*entities.Uploads, *dto.Pagination, error) {
+	args := m.Called(ctx, offset, limit, filter)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+	return args.Get(0).(*entities.Uploads), args.Get(1).(*dto.Pagination), args.Error(2)
+}
+
+func (m *MockScanService) Rescan(ctx context.Context, uuid string) error {
+	return m.Called(ctx, uuid).Error(0)
+}
+
+func (m *MockScanService) Release(ctx context.Context, uuid string, adminID int64) error {
+	return m.Called(ctx, uuid, adminID).Error(0)
+}
+
+func TestUploadHandler_UploadFile_SubmitsScan(t *testing.T) {
+	tests := []struct {
+		name      string
+		submitErr error
+	}{
+		{"queued", nil},
+		// Файл уже записан в ожидании и попадёт в очередь позже
+		{"submit failed", errors.New("db down")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockFileService := new(MockFileService)
+			mockUploadService := new(MockUploadService)
+			mockScanService := new(MockScanService)
+			handler := NewUploadHandler(mockFileService, mockUploadService, nil, mockScanService)
+
+			file := &entities.File{Name: "new-uuid", Size: 12}
+			mockUploadService.On("ResolveName", mock.Anything, int64(1), int64(0), "test.txt", entities.ConflictOverwrite).
+				Return("test.txt", nil, nil)
+			mockFileService.On("Upload", mock.Anything, int64(12), mock.Anything, mock.Anything).Return(file, nil)
+			mockScanService.On("InitialStatus").Return(entities.ScanStatusPending)
+			pending := mock.MatchedBy(func(f *entities.File) bool { return f.ScanStatus == entities.ScanStatusPending })
+			mockUploadService.On("RegisterUploadedFile", mock.Anything, int64(1), int64(0), pending, "test.txt", "", mock.Anything, "").
+				Return(&entities.Upload{UUID: "new-uuid", Name: "test.txt", ScanStatus: entities.ScanStatusPending}, nil)
+			mockScanService.On("Submit", mock.Anything, "new-uuid").Return(tt.submitErr)
+
+			body := new(bytes.Buffer)
+			writer := multipart.NewWriter(body)
+			part, err := writer.CreateFormFile("file", "test.txt")
+			require.NoError(t, err)
+			_, err = part.Write([]byte("test content"))
+			require.NoError(t, err)
+			writer.Close()
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Set("userID", 1)
+			c.Request = httptest.NewRequest("POST", "/upload", body)
+			c.Request.Header.Set("Content-Type", writer.FormDataContentType())
+
+			handler.UploadFile(c)
+
+			assert.Equal(t, http.StatusCreated, w.Code)
+			assert.Contains(t, w.Body.String(), `"scan_status":"pending"`)
+			mockUploadService.AssertExpectations(t)
+			mockScanService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUploadHandler_DownloadFile_Blocked(t *testing.T) {
+	tests := []struct {
+		name   string
+		status string
+		err    error
+		code   int
+	}{
+		{"infected", entities.ScanStatusInfected, appScan.ErrQuarantined, http.StatusForbidden},
+		{"pending", entities.ScanStatusPending, appScan.ErrNotScanned, http.StatusLocked},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockFileService := new(MockFileService)
+			mockUploadService := new(MockUploadService)
+			mockScanService := new(MockScanService)
+			handler := NewUploadHandler(mockFileService, mockUploadService, nil, mockScanService)
+
+			upload := &entities.Upload{ID: 7, UUID: "u1", Name: "test.txt", ScanStatus: tt.status}
+			mockUploadService.On("GetByUUID", mock.Anything, "u1", int64(1)).Return(upload, nil)
+			mockScanService.On("CheckDownload", tt.status).Return(tt.err)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Set("userID", 1)
+			c.Request = httptest.NewRequest("GET", "/upload?uuid=u1", nil)
+
+			handler.DownloadFile(c)
+
+			assert.Equal(t, tt.code, w.Code)
+			mockFileService.AssertNotCalled(t, "Download", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestQuarantineHandler_ListQuarantine(t *testing.T) {
+	mockScanService := new(MockScanService)
+	handler := NewQuarantineHandler(mockScanService)
+
+	uploads := &entities.Uploads{{UUID: "u1", Name: "virus.exe", UserID: 3, ScanStatus: entities.ScanStatusInfected, ScanDetail: "Eicar"}}
+	mockScanService.On("List", mock.Anything, 0, 0, mock.MatchedBy(func(filter *query.Query) bool {
+		status, _ := filter.Value("scan_status")
+		return status == entities.ScanStatusInfected
+	})).Return(uploads, &dto.Pagination{}, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("GET", "/admin/quarantine?filter[scan_status]=infected", nil)
+
+	handler.ListQuarantine(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"scan_detail":"Eicar"`)
+	assert.Contains(t, w.Body.String(), `"user_id":3`)
+}
+
+func TestQuarantineHandler_Release(t *testing.T) {
+	mockScanService := new(MockScanService)
+	handler := NewQuarantineHandler(mockScanService)
+
+	mockScanService.On("Release", mock.Anything, "u1", int64(5)).Return(nil)
+
+	c, w := folderContext("POST", "/admin/quarantine/release", `{"uuid":"u1"}`)
+	c.Set("userID", 5)
+
+	handler.Release(c)
+
+	assert.Equal(t, http.StatusNoContent, c.Writer.Status())
+	assert.Empty(t, w.Body.String())
+	mockScanService.AssertExpectations(t)
+}
+
+func TestQuarantineHandler_Rescan_Disabled(t *testing.T) {
+	mockScanService := new(MockScanService)
+	handler := NewQuarantineHandler(mockScanService)
+
+	mockScanService.On("Rescan", mock.Anything, "u1").Return(appScan.ErrScanningDisabled)
+
+	c, w := folderContext("POST", "/admin/quarantine/rescan", `{"uuid":"u1"}`)
+
+	handler.Rescan(c)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
@@ -0,0 +1,264 @@
+package handlers_upload
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aube/auth/internal/api/rest/middlewares"
+	"github.com/aube/auth/internal/application/dto"
+	appShare "github.com/aube/auth/internal/application/share"
+	appUpload "github.com/aube/auth/internal/application/upload"
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/aube/auth/internal/utils/logger"
+	"github.com/aube/auth/internal/utils/query"
+	"github.com/aube/auth/internal/utils/signer"
+	"github.com/rs/zerolog"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	presignPurpose    = "upload-download"
+	presignDefaultTTL = 15 * time.Minute
+	presignMaxTTL     = 24 * time.Hour
+
+	// SharePath is the public path of share and presigned links.
+	SharePath = "/s/"
+)
+
+// ShareService defines the interface for public links to uploads.
+type ShareService interface {
+	Create(ctx context.Context, userID int64, req dto.UploadShareRequest) (*entities.UploadShare, error)
+	List(ctx context.Context, userID int64, offset, limit int, filter *query.Query) (*entities.UploadShares, *dto.Pagination, error)
+	Revoke(ctx context.Context, id int64, userID int64) error
+	Open(ctx context.Context, token string, password string) (*entities.UploadShare, error)
+}
+
+type ShareHandler interface {
+	CreateShare(c *gin.Context)
+	ListShares(c *gin.Context)
+	RevokeShare(c *gin.Context)
+	CreatePresigned(c *gin.Context)
+	DownloadShared(c *gin.Context)
+}
+
+type shareHandler struct {
+	fileService   FileService
+	uploadService UploadService
+	shareService  ShareService
+	scanService   ScanService
+	jwtSecret     string
+	log           zerolog.Logger
+}
+
+func NewShareHandler(fileService FileService, uploadService UploadService, shareService ShareService, scanService ScanService, jwtSecret string) ShareHandler {
+	return &shareHandler{
+		fileService:   fileService,
+		uploadService: uploadService,
+		shareService:  shareService,
+		scanService:   scanService,
+		jwtSecret:     jwtSecret,
+		log:           logger.Get().With().Str("handlers", "share_handler").Logger(),
+	}
+}
+
+// CreateShare creates a public link to an upload of the user.
+func (h *shareHandler) CreateShare(c *gin.Context) {
+	var req dto.UploadShareRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.Debug().Err(err).Msg("CreateShare1")
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if req.TTL < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ttl cannot be negative"})
+		return
+	}
+
+	share, err := h.shareService.Create(c.Request.Context(), int64(c.GetInt("userID")), req)
+	if err != nil {
+		h.log.Debug().Err(err).Msg("CreateShare2")
+		if errors.Is(err, appUpload.ErrFileNotFound) || errors.Is(err, appShare.ErrShareNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "File not found in DB"})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.NewUploadShareResponse(share, SharePath+share.Token))
+}
+
+// ListShares returns links of the user. Uses PaginationMiddleware and CursorMiddleware.
+func (h *shareHandler) ListShares(c *gin.Context) {
+	shares, pagination, err := h.shareService.List(
+		c.Request.Context(),
+		int64(c.GetInt("userID")),
+		c.GetInt("offset"),
+		c.GetInt("limit"),
+		middlewares.WithCursor(c, nil),
+	)
+	if err != nil {
+		h.log.Debug().Err(err).Msg("ListShares")
+		if errors.Is(err, query.ErrInvalidQuery) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list share links"})
+		return
+	}
+	if err := middlewares.SignCursors(c, pagination); err != nil {
+		h.log.Debug().Err(err).Msg("SignCursors")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list share links"})
+		return
+	}
+
+	rows := make([]dto.UploadShareResponse, len(*shares))
+	for i, share := range *shares {
+		rows[i] = dto.NewUploadShareResponse(&share, SharePath+share.Token)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"rows":       rows,
+		"pagination": pagination,
+	})
+}
+
+// RevokeShare deletes a link (?id=) of the user.
+func (h *shareHandler) RevokeShare(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Query("id"), 10, 64)
+	if err != nil || id <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Link ID is required"})
+		return
+	}
+
+	if err := h.shareService.Revoke(c.Request.Context(), id, int64(c.GetInt("userID"))); err != nil {
+		h.log.Debug().Err(err).Msg("RevokeShare")
+		if errors.Is(err, appShare.ErrShareNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to revoke share link"})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// CreatePresigned issues a signed, short-lived link to an upload.
+// Such links are not stored and cannot be revoked.
+func (h *shareHandler) CreatePresigned(c *gin.Context) {
+	var req dto.UploadPresignRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.Debug().Err(err).Msg("CreatePresigned1")
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ttl := time.Duration(req.TTL) * time.Second
+	if ttl <= 0 {
+		ttl = presignDefaultTTL
+	}
+	if ttl > presignMaxTTL {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ttl is too long"})
+		return
+	}
+	if req.Disposition == "" {
+		req.Disposition = entities.ShareDispositionAttachment
+	}
+	if !entities.IsValidShareDisposition(req.Disposition) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid disposition"})
+		return
+	}
+
+	userID := int64(c.GetInt("userID"))
+	upload, err := h.uploadService.GetByUUID(c.Request.Context(), req.UUID, userID)
+	if err != nil {
+		h.log.Debug().Err(err).Msg("CreatePresigned2")
+		c.JSON(http.StatusNotFound, gin.H{"error": "File not found in DB"})
+		return
+	}
+
+	expiresAt := time.Now().Add(ttl)
+	payload := strings.Join([]string{strconv.FormatInt(userID, 10), upload.UUID, req.Disposition}, ":")
+	token := h.presignSigner().Sign(payload, expiresAt)
+
+	c.JSON(http.StatusCreated, dto.UploadPresignResponse{
+		Token:     token,
+		URL:       SharePath + token,
+		ExpiresAt: expiresAt,
+	})
+}
+
+// DownloadShared serves the file of a share link or a presigned link.
+// The password of a protected link is passed in ?password= or the
+// X-Share-Password header.
+func (h *shareHandler) DownloadShared(c *gin.Context) {
+	token := c.Param("token")
+
+	// Подписанные ссылки содержат точки, токены сохранённых ссылок - нет
+	if strings.Contains(token, ".") {
+		h.downloadPresigned(c, token)
+		return
+	}
+
+	password := c.GetHeader("X-Share-Password")
+	if password == "" {
+		password = c.Query("password")
+	}
+
+	share, err := h.shareService.Open(c.Request.Context(), token, password)
+	if err != nil {
+		h.log.Debug().Err(err).Msg("DownloadShared")
+		switch {
+		case errors.Is(err, appShare.ErrShareNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, appShare.ErrShareExpired), errors.Is(err, appShare.ErrShareExhausted):
+			c.JSON(http.StatusGone, gin.H{"error": err.Error()})
+		case errors.Is(err, appShare.ErrPasswordRequired):
+			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		default:
+			scanError(c, err)
+		}
+		return
+	}
+
+	serveUpload(c, h.fileService, h.scanService, share.Upload, share.Disposition, h.log)
+}
+
+func (h *shareHandler) downloadPresigned(c *gin.Context, token string) {
+	payload, err := h.presignSigner().Verify(token, time.Now())
+	if err != nil {
+		h.log.Debug().Err(err).Msg("downloadPresigned1")
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+
+	parts := strings.Split(payload, ":")
+	if len(parts) != 3 {
+		c.JSON(http.StatusForbidden, gin.H{"error": signer.ErrInvalidToken.Error()})
+		return
+	}
+	userID, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		c.JSON(http.StatusForbidden, gin.H{"error": signer.ErrInvalidToken.Error()})
+		return
+	}
+
+	upload, err := h.uploadService.GetByUUID(c.Request.Context(), parts[1], userID)
+	if err != nil {
+		h.log.Debug().Err(err).Msg("downloadPresigned2")
+		c.JSON(http.StatusNotFound, gin.H{"error": "File not found in DB"})
+		return
+	}
+
+	serveUpload(c, h.fileService, h.scanService, upload, parts[2], h.log)
+}
+
+func (h *shareHandler) presignSigner() *signer.Signer {
+	return signer.New(h.jwtSecret, presignPurpose)
+}
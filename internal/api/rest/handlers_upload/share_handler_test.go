@@ -0,0 +1,124 @@
+package handlers_upload
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aube/auth/internal/application/dto"
+	appShare "github.com/aube/auth/internal/application/share"
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/aube/auth/internal/utils/query"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockShareService реализует ShareService интерфейс
+type MockShareService struct {
+	mock.Mock
+}
+
+func (m *MockShareService) Create(ctx context.Context, userID int64, req dto.UploadShareRequest) (*entities.UploadShare, error) {
+	args := m.Called(ctx, userID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.UploadShare), args.Error(1)
+}
+
+func (m *MockShareService) List(ctx context.Context, userID int64, offset, limit int, filter *query.Query) (*entities.UploadShares, *dto.Pagination, error) {
+	args := m.Called(ctx, userID, offset, limit)
+	return args.Get(0).(*entities.UploadShares), args.Get(1).(*dto.Pagination), args.Error(2)
+}
+
+func (m *MockShareService) Revoke(ctx context.Context, id int64, userID int64) error {
+	return m.Called(ctx, id, userID).Error(0)
+}
+
+func (m *MockShareService) Open(ctx context.Context, token string, password string) (*entities.UploadShare, error) {
+	args := m.Called(ctx, token, password)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.UploadShare), args.Error(1)
+}
+
+func shareRouter(handler ShareHandler) *gin.Engine {
+	r := gin.New()
+	r.GET(SharePath+":token", handler.DownloadShared)
+	r.POST("/presign", func(c *gin.Context) {
+		c.Set("userID", 7)
+		handler.CreatePresigned(c)
+	})
+	return r
+}
+
+func newStreamRecorder() *responseWriterCloseNotifier {
+	return &responseWriterCloseNotifier{ResponseRecorder: httptest.NewRecorder()}
+}
+
+func TestShareHandler_Presigned_RoundTrip(t *testing.T) {
+	mockFileService := new(MockFileService)
+	mockUploadService := new(MockUploadService)
+	r := shareRouter(NewShareHandler(mockFileService, mockUploadService, new(MockShareService), nil, "secret"))
+
+	upload := &entities.Upload{UUID: "u1", Name: "photo.png", ContentType: "image/png", Size: 3}
+	mockUploadService.On("GetByUUID", mock.Anything, "u1", int64(7)).Return(upload, nil)
+	mockFileService.On("Download", mock.Anything, "u1").Return(io.NopCloser(strings.NewReader("png")), nil)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest("POST", "/presign", bytes.NewBufferString(`{"uuid":"u1","disposition":"inline"}`)))
+	require.Equal(t, http.StatusCreated, w.Code)
+
+	var res dto.UploadPresignResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+
+	sw := newStreamRecorder()
+	r.ServeHTTP(sw, httptest.NewRequest("GET", res.URL, nil))
+	assert.Equal(t, http.StatusOK, sw.Code)
+	assert.Equal(t, "inline; filename=photo.png", sw.Header().Get("Content-Disposition"))
+	assert.Equal(t, "png", sw.Body.String())
+
+	// Подпись другого секрета не принимается
+	other := shareRouter(NewShareHandler(mockFileService, mockUploadService, new(MockShareService), nil, "other"))
+	w = httptest.NewRecorder()
+	other.ServeHTTP(w, httptest.NewRequest("GET", res.URL, nil))
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func TestShareHandler_DownloadShared(t *testing.T) {
+	mockFileService := new(MockFileService)
+	mockShareService := new(MockShareService)
+	r := shareRouter(NewShareHandler(mockFileService, new(MockUploadService), mockShareService, nil, "secret"))
+
+	share := &entities.UploadShare{
+		Upload:      &entities.Upload{UUID: "u1", Name: "report.pdf", ContentType: "application/pdf", Size: 3},
+		Disposition: entities.ShareDispositionAttachment,
+	}
+	mockShareService.On("Open", mock.Anything, "tok", "secret-pass").Return(share, nil)
+	mockShareService.On("Open", mock.Anything, "tok", "").Return(nil, appShare.ErrPasswordRequired)
+	mockShareService.On("Open", mock.Anything, "old", "").Return(nil, appShare.ErrShareExpired)
+	mockFileService.On("Download", mock.Anything, "u1").Return(io.NopCloser(strings.NewReader("pdf")), nil)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest("GET", SharePath+"tok", nil))
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	req := httptest.NewRequest("GET", SharePath+"tok", nil)
+	req.Header.Set("X-Share-Password", "secret-pass")
+	sw := newStreamRecorder()
+	r.ServeHTTP(sw, req)
+	assert.Equal(t, http.StatusOK, sw.Code)
+	assert.Equal(t, "attachment; filename=report.pdf", sw.Header().Get("Content-Disposition"))
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest("GET", SharePath+"old", nil))
+	assert.Equal(t, http.StatusGone, w.Code)
+}
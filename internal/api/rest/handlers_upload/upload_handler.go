@@ -8,11 +8,16 @@ import (
 	"net/http"
 	"strconv"
 
+	"github.com/aube/auth/internal/api/rest/middlewares"
 	"github.com/aube/auth/internal/application/dto"
 	appFile "github.com/aube/auth/internal/application/file"
+	appFolder "github.com/aube/auth/internal/application/folder"
+	appScan "github.com/aube/auth/internal/application/scan"
 	appUpload "github.com/aube/auth/internal/application/upload"
 	"github.com/aube/auth/internal/domain/entities"
+	"github.com/aube/auth/internal/utils/disposition"
 	"github.com/aube/auth/internal/utils/logger"
+	"github.com/aube/auth/internal/utils/query"
 	"github.com/rs/zerolog"
 
 	"github.com/gin-gonic/gin"
@@ -22,89 +27,163 @@ import (
 type FileService interface {
 	Delete(ctx context.Context, id string) error
 	Download(ctx context.Context, uuid string) (io.ReadCloser, error)
-	Upload(ctx context.Context, size int64, data io.Reader) (*entities.File, error)
+	Upload(ctx context.Context, size int64, declaredType string, data io.Reader) (*entities.File, error)
 }
 
 // UploadService defines the interface for upload metadata operations (CRUD and listing).
 type UploadService interface {
 	Delete(ctx context.Context, uuid string, userID int64) error
-	DeleteForce(ctx context.Context, uuid string, userID int64) error
-	GetByName(ctx context.Context, name string, userID int64) (*entities.Upload, error)
+	GetByName(ctx context.Context, name string, folderID int64, userID int64) (*entities.Upload, error)
 	GetByUUID(ctx context.Context, uuid string, userID int64) (*entities.Upload, error)
-	ListByUserID(ctx context.Context, userID int64, offset int, limit int, params map[string]any) (*entities.Uploads, *dto.Pagination, error)
-	RegisterUploadedFile(ctx context.Context, userID int64, file *entities.File, name string, category string, contentType string, description string) (*entities.Upload, error)
+	ListByUserID(ctx context.Context, userID int64, offset int, limit int, filter *query.Query) (*entities.Uploads, *dto.Pagination, error)
+	Move(ctx context.Context, uuid string, userID int64, folderID int64, policy string) (*entities.Upload, error)
+	RegisterUploadedFile(ctx context.Context, userID int64, folderID int64, file *entities.File, name string, category string, contentType string, description string) (*entities.Upload, error)
+	ResolveName(ctx context.Context, userID int64, folderID int64, name string, policy string) (string, *entities.Upload, error)
+}
+
+// VersionService defines the interface for versions of uploads.
+type VersionService interface {
+	AddUploadVersion(ctx context.Context, existing *entities.Upload, userID int64, file *entities.File, contentType string) (*entities.Upload, error)
+	Get(ctx context.Context, fileID int64, version int) (*entities.FileVersion, error)
+	List(ctx context.Context, fileID int64) (*entities.FileVersions, error)
+	Promote(ctx context.Context, fileID int64, userID int64, version int) error
+}
+
+// ScanService defines the interface for malware scans of stored files.
+type ScanService interface {
+	CheckDownload(status string) error
+	InitialStatus() string
+	Submit(ctx context.Context, uuid string) error
 }
 
 type UploadHandler interface {
 	DeleteFile(c *gin.Context)
 	DownloadFile(c *gin.Context)
 	ListFiles(c *gin.Context)
+	ListVersions(c *gin.Context)
+	PromoteVersion(c *gin.Context)
 	UploadFile(c *gin.Context)
 }
 
 // SavedFile implements structure for saved file results.
 // File: FileService.Upload operation result.
 // Filename: Data from fileHeader.Filename.
-// ContentType: Content-Type uploaded file.
+// ContentType: Type of the uploaded file detected from its content.
+// Existing: Upload the file becomes a new version of, nil for a new upload.
 type SavedFile struct {
 	File        *entities.File
 	Filename    string
 	ContentType string
+	Existing    *entities.Upload
 }
 
 // Handler implements UploadHandler for handling file-related HTTP requests.
 // FileService: Service for file storage operations.
 // UploadService: Service for upload metadata operations.
+// VersionService: Service for versions of uploads.
+// ScanService: Service for malware scans, nil when not used.
 // log: Logger instance for the handler.
 type Handler struct {
-	FileService   FileService
-	UploadService UploadService
-	log           zerolog.Logger
+	FileService    FileService
+	UploadService  UploadService
+	VersionService VersionService
+	ScanService    ScanService
+	log            zerolog.Logger
 }
 
 // NewHandler создает новый экземпляр Handler
-func NewUploadHandler(FileService FileService, UploadService UploadService) *Handler {
+func NewUploadHandler(FileService FileService, UploadService UploadService, VersionService VersionService, ScanService ScanService) *Handler {
 	return &Handler{
-		FileService:   FileService,
-		UploadService: UploadService,
-		log:           logger.Get().With().Str("handlers", "file_handler").Logger(),
+		FileService:    FileService,
+		UploadService:  UploadService,
+		VersionService: VersionService,
+		ScanService:    ScanService,
+		log:            logger.Get().With().Str("handlers", "file_handler").Logger(),
 	}
 }
 
 // UploadFile handles file upload requests.
 // Validates user authentication, processes the file, and stores metadata.
+// The file goes to folder_id (root by default); a taken name is handled
+// by the conflict field: overwrite (default) stores the file as a new
+// version of the existing upload, reject or rename.
 func (h *Handler) UploadFile(c *gin.Context) {
 
 	userID := c.GetInt("userID")
 	description := c.PostForm("description")
 	category := c.PostForm("category")
+	policy := c.DefaultPostForm("conflict", entities.ConflictOverwrite)
 
-	savedFile, err := h.saveFile(c, "file", userID)
+	folderID, err := folderParam(c.PostForm("folder_id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid file"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	upload, err := h.UploadService.RegisterUploadedFile(
-		c.Request.Context(),
-		int64(userID),
-		savedFile.File,
-		savedFile.Filename,
-		category,
-		savedFile.ContentType,
-		description,
-	)
+	savedFile, err := h.saveFile(c, "file", userID, folderID, policy)
+	if err != nil {
+		switch {
+		case errors.Is(err, appUpload.ErrNameTaken):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		case errors.Is(err, entities.ErrInvalidConflictPolicy):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, appFile.ErrTypeNotAllowed):
+			c.JSON(http.StatusUnsupportedMediaType, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid file"})
+		}
+		return
+	}
+
+	if h.ScanService != nil {
+		// Статус записывается вместе с файлом, чтобы файл не сочли сохранённым без проверки
+		savedFile.File.ScanStatus = h.ScanService.InitialStatus()
+	}
+
+	var upload *entities.Upload
+	if savedFile.Existing != nil {
+		upload, err = h.VersionService.AddUploadVersion(c.Request.Context(), savedFile.Existing, int64(userID), savedFile.File, savedFile.ContentType)
+	} else {
+		upload, err = h.UploadService.RegisterUploadedFile(
+			c.Request.Context(),
+			int64(userID),
+			folderID,
+			savedFile.File,
+			savedFile.Filename,
+			category,
+			savedFile.ContentType,
+			description,
+		)
+	}
 	if err != nil {
 		h.log.Debug().Err(err).Msg("UploadFile5")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to write upload file into DB"})
+		if err := h.FileService.Delete(c.Request.Context(), savedFile.File.Name); err != nil {
+			h.log.Debug().Err(err).Msg("UploadFile6")
+		}
+		switch {
+		case errors.Is(err, appFolder.ErrFolderNotFound), errors.Is(err, appUpload.ErrFileNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, appUpload.ErrNameTaken):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to write upload file into DB"})
+		}
 		return
 	}
 
+	if h.ScanService != nil {
+		if err := h.ScanService.Submit(c.Request.Context(), upload.UUID); err != nil {
+			// Файл остаётся в ожидании и попадёт в очередь при следующем проходе
+			h.log.Error().Err(err).Msg("UploadFile7")
+		}
+	}
+
 	c.JSON(http.StatusCreated, dto.NewUploadResponse(upload))
 }
 
 // DownloadFile handles file download requests.
-// Supports lookup by UUID or filename and enforces user ownership.
+// Supports lookup by UUID or filename and enforces user ownership;
+// ?version= downloads an older version of the file.
 func (h *Handler) DownloadFile(c *gin.Context) {
 
 	upload, err := h.getUpload(c)
@@ -112,20 +191,44 @@ func (h *Handler) DownloadFile(c *gin.Context) {
 		return
 	}
 
-	content, err := h.FileService.Download(c.Request.Context(), upload.UUID)
+	upload, err = h.withVersion(c, upload)
+	if err != nil {
+		return
+	}
+
+	serveUpload(c, h.FileService, h.ScanService, upload, disposition.Attachment, h.log)
+}
+
+// serveUpload streams the stored file of an upload with its name and type
+// unless the malware scan blocks it. Types browsers could run scripts from
+// are always served as attachments.
+// mode: "attachment" or "inline"
+func serveUpload(c *gin.Context, fileService FileService, scans ScanService, upload *entities.Upload, mode string, log zerolog.Logger) {
+	if scans != nil {
+		if err := scans.CheckDownload(upload.ScanStatus); err != nil {
+			scanError(c, err)
+			return
+		}
+	}
+
+	content, err := fileService.Download(c.Request.Context(), upload.UUID)
 	if err != nil {
 		if errors.Is(err, appFile.ErrFileNotFound) {
 			c.JSON(http.StatusNotFound, gin.H{"error": "File not found on FS"})
 			return
 		}
-		h.log.Debug().Err(err).Msg("DownloadFile2")
+		log.Debug().Err(err).Msg("DownloadFile2")
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to read file"})
 		return
 	}
 	defer content.Close()
 
-	c.Header("Content-Disposition", "attachment; filename="+upload.Name)
+	if entities.IsRiskyType(upload.ContentType) {
+		mode = disposition.Attachment
+	}
+	c.Header("Content-Disposition", disposition.Header(mode, upload.Name))
 	c.Header("Content-Type", upload.ContentType)
+	c.Header("X-Content-Type-Options", "nosniff")
 	c.Header("Content-Length", strconv.FormatInt(upload.Size, 10))
 
 	c.Stream(func(w io.Writer) bool {
@@ -136,23 +239,52 @@ func (h *Handler) DownloadFile(c *gin.Context) {
 	})
 }
 
+// scanError answers a download blocked by the malware scan.
+func scanError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, appScan.ErrQuarantined):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, appScan.ErrNotScanned):
+		c.JSON(http.StatusLocked, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get file"})
+	}
+}
+
 // ListFiles retrieves a paginated list of files uploaded by the user.
-// Uses PaginationMiddleware for offset/limit handling.
+// Uses PaginationMiddleware and CursorMiddleware.
 func (h *Handler) ListFiles(c *gin.Context) {
 
 	userID := c.GetInt("userID")
 	offset := c.GetInt("offset")
 	limit := c.GetInt("limit")
 
-	params := make(map[string]any)
-	if c.Query("updated_at") != "" {
-		params["updated_at >="] = c.Query("updated_at")
+	// ?updated_at= остаётся сокращением для filter[updated_at][gte]
+	values := c.Request.URL.Query()
+	if updatedAt := values.Get("updated_at"); updatedAt != "" {
+		values.Add("filter[updated_at][gte]", updatedAt)
+	}
+	filter, err := query.Parse(values, appUpload.ListSchema)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
 	}
-	params["deleted"] = "false"
+	filter.Add("deleted", query.OpEq, false)
+	middlewares.WithCursor(c, filter)
 
-	uploads, pagination, err := h.UploadService.ListByUserID(c.Request.Context(), int64(userID), offset, limit, params)
+	uploads, pagination, err := h.UploadService.ListByUserID(c.Request.Context(), int64(userID), offset, limit, filter)
 	if err != nil {
 		h.log.Debug().Err(err).Msg("ListFiles")
+		if errors.Is(err, query.ErrInvalidQuery) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list files"})
+		return
+	}
+
+	if err := middlewares.SignCursors(c, pagination); err != nil {
+		h.log.Debug().Err(err).Msg("SignCursors")
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list files"})
 		return
 	}
@@ -169,7 +301,7 @@ func (h *Handler) ListFiles(c *gin.Context) {
 }
 
 // DeleteFile handles file deletion requests.
-// Validates user ownership before deleting both file and metadata.
+// Moves the upload to the trash; the stored file is removed on purge.
 func (h *Handler) DeleteFile(c *gin.Context) {
 
 	userID := c.GetInt("userID")
@@ -181,44 +313,20 @@ func (h *Handler) DeleteFile(c *gin.Context) {
 	}
 	if err := h.UploadService.Delete(c.Request.Context(), UUID, int64(userID)); err != nil {
 		h.log.Debug().Err(err).Msg("DeleteFile")
-		c.JSON(http.StatusBadRequest, gin.H{"error": "File UUID is can't be deleted"})
-		return
-	}
-
-	if err := h.FileService.Delete(c.Request.Context(), UUID); err != nil {
-		h.log.Debug().Err(err).Msg("DeleteFile")
-		if errors.Is(err, appFile.ErrFileNotFound) {
-			c.JSON(http.StatusNotFound, gin.H{"error": "File not found"})
+		if errors.Is(err, appUpload.ErrFileNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "File not found in DB"})
 			return
 		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete file"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": "File UUID is can't be deleted"})
 		return
 	}
-	c.Status(http.StatusNoContent)
-}
-
-// cleanupBeforeCreate ensures no duplicate filenames exist for the user by cleaning up existing entries.
-func (h *Handler) cleanupBeforeCreate(ctx context.Context, name string, userID int64) error {
-
-	upload, err := h.UploadService.GetByName(ctx, name, userID)
-
-	if err != nil {
-		return nil // file not found
-	}
-
-	if err := h.UploadService.DeleteForce(ctx, upload.UUID, userID); err != nil {
-		return err
-	}
-
-	if err := h.FileService.Delete(ctx, upload.UUID); err != nil {
-		return err
-	}
 
-	return nil
+	// Файл остаётся в хранилище до окончательного удаления из корзины
+	c.Status(http.StatusNoContent)
 }
 
 // saveFile write file to FS via FileService.Upload.
-func (h *Handler) saveFile(c *gin.Context, fieldName string, userID int) (*SavedFile, error) {
+func (h *Handler) saveFile(c *gin.Context, fieldName string, userID int, folderID int64, policy string) (*SavedFile, error) {
 
 	fileHeader, err := c.FormFile(fieldName)
 	if err != nil {
@@ -226,7 +334,7 @@ func (h *Handler) saveFile(c *gin.Context, fieldName string, userID int) (*Saved
 		return nil, err
 	}
 
-	err = h.cleanupBeforeCreate(c.Request.Context(), fileHeader.Filename, int64(userID))
+	name, existing, err := h.UploadService.ResolveName(c.Request.Context(), int64(userID), folderID, fileHeader.Filename, policy)
 	if err != nil {
 		h.log.Debug().Err(err).Msg("saveFile2")
 		return nil, err
@@ -242,6 +350,7 @@ func (h *Handler) saveFile(c *gin.Context, fieldName string, userID int) (*Saved
 	file, err := h.FileService.Upload(
 		c.Request.Context(),
 		fileHeader.Size,
+		fileHeader.Header.Get("Content-Type"),
 		uploadingFile,
 	)
 	if err != nil {
@@ -251,12 +360,26 @@ func (h *Handler) saveFile(c *gin.Context, fieldName string, userID int) (*Saved
 
 	return &SavedFile{
 		file,
-		fileHeader.Filename,
-		fileHeader.Header.Get("Content-Type"),
+		name,
+		file.ContentType,
+		existing,
 	}, nil
 }
 
-// getUpload return data from uploads table DB by uuid or filename.
+// folderParam parses a folder ID parameter; empty means the root.
+func folderParam(value string) (int64, error) {
+	if value == "" {
+		return 0, nil
+	}
+	id, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || id < 0 {
+		return 0, errors.New("invalid folder_id")
+	}
+	return id, nil
+}
+
+// getUpload return data from uploads table DB by uuid or filename
+// (in folder_id, the root by default).
 func (h *Handler) getUpload(c *gin.Context) (*entities.Upload, error) {
 
 	userID := c.GetInt("userID")
@@ -268,13 +391,18 @@ func (h *Handler) getUpload(c *gin.Context) (*entities.Upload, error) {
 		return nil, errors.New("bad request")
 	}
 
+	folderID, err := folderParam(c.Query("folder_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return nil, err
+	}
+
 	var upload *entities.Upload
-	var err error
 
 	if UUID != "" {
 		upload, err = h.UploadService.GetByUUID(c.Request.Context(), UUID, int64(userID))
 	} else {
-		upload, err = h.UploadService.GetByName(c.Request.Context(), name, int64(userID))
+		upload, err = h.UploadService.GetByName(c.Request.Context(), name, folderID, int64(userID))
 	}
 
 	if err != nil {
@@ -3,15 +3,20 @@ package handlers_upload
 import (
 	"bytes"
 	"context"
-	"errors"
+	"fmt"
 	"io"
 	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
+	"net/textproto"
+	"strings"
 	"testing"
 
 	"github.com/aube/auth/internal/application/dto"
+	appFile "github.com/aube/auth/internal/application/file"
+	appUpload "github.com/aube/auth/internal/application/upload"
 	"github.com/aube/auth/internal/domain/entities"
+	"github.com/aube/auth/internal/utils/query"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -37,8 +42,8 @@ type MockFileService struct {
 	mock.Mock
 }
 
-func (m *MockFileService) Upload(ctx context.Context, size int64, data io.Reader) (*entities.File, error) {
-	args := m.Called(ctx, size, data)
+func (m *MockFileService) Upload(ctx context.Context, size int64, declaredType string, data io.Reader) (*entities.File, error) {
+	args := m.Called(ctx, size, declaredType, data)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -62,8 +67,8 @@ type MockUploadService struct {
 	mock.Mock
 }
 
-func (m *MockUploadService) RegisterUploadedFile(ctx context.Context, userID int64, file *entities.File, name, category, contentType, description string) (*entities.Upload, error) {
-	args := m.Called(ctx, userID, file, name, category, contentType, description)
+func (m *MockUploadService) RegisterUploadedFile(ctx context.Context, userID int64, folderID int64, file *entities.File, name, category, contentType, description string) (*entities.Upload, error) {
+	args := m.Called(ctx, userID, folderID, file, name, category, contentType, description)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -78,15 +83,31 @@ func (m *MockUploadService) GetByUUID(ctx context.Context, uuid string, userID i
 	return args.Get(0).(*entities.Upload), args.Error(1)
 }
 
-func (m *MockUploadService) GetByName(ctx context.Context, name string, userID int64) (*entities.Upload, error) {
-	args := m.Called(ctx, name, userID)
+func (m *MockUploadService) GetByName(ctx context.Context, name string, folderID int64, userID int64) (*entities.Upload, error) {
+	args := m.Called(ctx, name, folderID, userID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*entities.Upload), args.Error(1)
 }
 
-func (m *MockUploadService) ListByUserID(ctx context.Context, userID int64, offset, limit int, params map[string]any) (*entities.Uploads, *dto.Pagination, error) {
+func (m *MockUploadService) ResolveName(ctx context.Context, userID int64, folderID int64, name string, policy string) (string, *entities.Upload, error) {
+	args := m.Called(ctx, userID, folderID, name, policy)
+	if args.Get(1) == nil {
+		return args.String(0), nil, args.Error(2)
+	}
+	return args.String(0), args.Get(1).(*entities.Upload), args.Error(2)
+}
+
+func (m *MockUploadService) Move(ctx context.Context, uuid string, userID int64, folderID int64, policy string) (*entities.Upload, error) {
+	args := m.Called(ctx, uuid, userID, folderID, policy)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Upload), args.Error(1)
+}
+
+func (m *MockUploadService) ListByUserID(ctx context.Context, userID int64, offset, limit int, filter *query.Query) (*entities.Uploads, *dto.Pagination, error) {
 	args := m.Called(ctx, userID, offset, limit)
 	return args.Get(0).(*entities.Uploads), args.Get(1).(*dto.Pagination), args.Error(2)
 }
@@ -102,13 +123,14 @@ func TestUploadHandler_UploadFile_Success(t *testing.T) {
 	// Setup
 	mockFileService := new(MockFileService)
 	mockUploadService := new(MockUploadService)
-	handler := NewUploadHandler(mockFileService, mockUploadService)
+	handler := NewUploadHandler(mockFileService, mockUploadService, nil, nil)
 
 	// Test data
 	testContent := []byte("test content")
 	expectedFile := &entities.File{
-		Name: "test-uuid",
-		Size: int64(len(testContent)),
+		Name:        "test-uuid",
+		Size:        int64(len(testContent)),
+		ContentType: "text/plain",
 	}
 	expectedUpload := &entities.Upload{
 		UUID:        "test-uuid",
@@ -118,13 +140,14 @@ func TestUploadHandler_UploadFile_Success(t *testing.T) {
 	}
 
 	// Mock expectations
-	mockUploadService.On("GetByName", mock.Anything, "test.txt", int64(1)).
-		Return(nil, errors.New("not found"))
+	mockUploadService.On("ResolveName", mock.Anything, int64(1), int64(0), "test.txt", entities.ConflictOverwrite).
+		Return("test.txt", nil, nil)
 
 	// Важное изменение: используем mock.MatchedBy для проверки reader
 	mockFileService.On("Upload",
 		mock.Anything,           // context
 		int64(len(testContent)), // size
+		mock.Anything,           // declared type
 		mock.MatchedBy(func(r io.Reader) bool {
 			data, err := io.ReadAll(r)
 			return err == nil && string(data) == string(testContent)
@@ -134,6 +157,7 @@ func TestUploadHandler_UploadFile_Success(t *testing.T) {
 	mockUploadService.On("RegisterUploadedFile",
 		mock.Anything,
 		int64(1),
+		int64(0),
 		expectedFile,
 		"test.txt",
 		"docs",
@@ -180,7 +204,7 @@ func TestUploadHandler_DownloadFile_Success(t *testing.T) {
 	// Setup
 	mockFileService := new(MockFileService)
 	mockUploadService := new(MockUploadService)
-	handler := NewUploadHandler(mockFileService, mockUploadService)
+	handler := NewUploadHandler(mockFileService, mockUploadService, nil, nil)
 
 	// Mock data
 	uuid := "test-uuid"
@@ -218,11 +242,65 @@ func TestUploadHandler_DownloadFile_Success(t *testing.T) {
 	mockUploadService.AssertExpectations(t)
 }
 
+func TestUploadHandler_UploadFile_TypeNotAllowed(t *testing.T) {
+	mockFileService := new(MockFileService)
+	mockUploadService := new(MockUploadService)
+	handler := NewUploadHandler(mockFileService, mockUploadService, nil, nil)
+
+	mockUploadService.On("ResolveName", mock.Anything, int64(1), int64(0), "page.html", entities.ConflictOverwrite).
+		Return("page.html", nil, nil)
+	mockFileService.On("Upload", mock.Anything, mock.Anything, "image/png", mock.Anything).
+		Return(nil, fmt.Errorf("%w: text/html", appFile.ErrTypeNotAllowed))
+
+	body := new(bytes.Buffer)
+	writer := multipart.NewWriter(body)
+	header := textproto.MIMEHeader{}
+	header.Set("Content-Disposition", `form-data; name="file"; filename="page.html"`)
+	header.Set("Content-Type", "image/png")
+	part, err := writer.CreatePart(header)
+	require.NoError(t, err)
+	_, err = part.Write([]byte("<html><script>alert(1)</script></html>"))
+	require.NoError(t, err)
+	writer.Close()
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set("userID", int(1))
+	c.Request = httptest.NewRequest("POST", "/upload", body)
+	c.Request.Header.Set("Content-Type", writer.FormDataContentType())
+
+	handler.UploadFile(c)
+
+	assert.Equal(t, http.StatusUnsupportedMediaType, w.Code)
+	mockUploadService.AssertNotCalled(t, "RegisterUploadedFile", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestUploadHandler_DownloadFile_RiskyType(t *testing.T) {
+	mockFileService := new(MockFileService)
+	mockUploadService := new(MockUploadService)
+	handler := NewUploadHandler(mockFileService, mockUploadService, nil, nil)
+
+	upload := &entities.Upload{UUID: "u1", Name: "отчёт\"\r\n.html", ContentType: "text/html; charset=utf-8", Size: 4}
+	mockUploadService.On("GetByUUID", mock.Anything, "u1", int64(1)).Return(upload, nil)
+	mockFileService.On("Download", mock.Anything, "u1").Return(io.NopCloser(strings.NewReader("<b/>")), nil)
+
+	w := newStreamRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set("userID", int(1))
+	c.Request = httptest.NewRequest("GET", "/download?uuid=u1", nil)
+
+	handler.DownloadFile(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "nosniff", w.Header().Get("X-Content-Type-Options"))
+	assert.Equal(t, `attachment; filename=_____.html; filename*=UTF-8''%D0%BE%D1%82%D1%87%D1%91%D1%82.html`, w.Header().Get("Content-Disposition"))
+}
+
 func TestUploadHandler_ListFiles_Success(t *testing.T) {
 	// Setup
 	mockFileService := new(MockFileService)
 	mockUploadService := new(MockUploadService)
-	handler := NewUploadHandler(mockFileService, mockUploadService)
+	handler := NewUploadHandler(mockFileService, mockUploadService, nil, nil)
 
 	// Mock expectations
 	uploads := &entities.Uploads{
@@ -255,12 +333,11 @@ func TestUploadHandler_DeleteFile_Success(t *testing.T) {
 	// Setup
 	mockFileService := new(MockFileService)
 	mockUploadService := new(MockUploadService)
-	handler := NewUploadHandler(mockFileService, mockUploadService)
+	handler := NewUploadHandler(mockFileService, mockUploadService, nil, nil)
 
 	// Mock expectations
 	uuid := "aaaaaaaa-aaaa-bbbb-cccc-aaaabbbbcccc"
 	mockUploadService.On("Delete", mock.Anything, uuid, int64(1)).Return(nil)
-	mockFileService.On("Delete", mock.Anything, uuid).Return(nil)
 
 	// Create proper router for the test
 	router := gin.Default()
@@ -279,6 +356,27 @@ func TestUploadHandler_DeleteFile_Success(t *testing.T) {
 	// Assert
 	assert.Equal(t, http.StatusNoContent, w.Code)
 	assert.Empty(t, w.Body.String()) // Проверяем что тело ответа пустое
-	mockFileService.AssertExpectations(t)
+	// Файл удаляется только при очистке корзины
+	mockFileService.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	mockUploadService.AssertExpectations(t)
+}
+
+func TestUploadHandler_DeleteFile_NotFound(t *testing.T) {
+	mockUploadService := new(MockUploadService)
+	handler := NewUploadHandler(new(MockFileService), mockUploadService, nil, nil)
+
+	uuid := "aaaaaaaa-aaaa-bbbb-cccc-aaaabbbbcccc"
+	mockUploadService.On("Delete", mock.Anything, uuid, int64(1)).Return(appUpload.ErrFileNotFound)
+
+	router := gin.Default()
+	router.DELETE("/file", func(c *gin.Context) {
+		c.Set("userID", int(1))
+		handler.DeleteFile(c)
+	})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("DELETE", "/file?uuid="+uuid, nil))
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
 	mockUploadService.AssertExpectations(t)
 }
@@ -0,0 +1,132 @@
+package handlers_upload
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/aube/auth/internal/application/dto"
+	appUpload "github.com/aube/auth/internal/application/upload"
+	appVersion "github.com/aube/auth/internal/application/version"
+	"github.com/aube/auth/internal/domain/entities"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ListVersions returns versions of an upload (?uuid= or ?name=&folder_id=),
+// newest first; the current one is marked.
+func (h *Handler) ListVersions(c *gin.Context) {
+
+	upload, err := h.getUpload(c)
+	if err != nil {
+		return
+	}
+
+	versions, err := h.VersionService.List(c.Request.Context(), upload.ID)
+	if err != nil {
+		h.log.Debug().Err(err).Msg("ListVersions")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list versions"})
+		return
+	}
+
+	rows := make([]dto.FileVersionResponse, len(*versions))
+	for i, version := range *versions {
+		rows[i] = dto.NewFileVersionResponse(&version)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"upload": dto.NewUploadResponse(upload),
+		"rows":   rows,
+	})
+}
+
+// PromoteVersion makes an older version of an upload current.
+func (h *Handler) PromoteVersion(c *gin.Context) {
+
+	userID := int64(c.GetInt("userID"))
+
+	var req dto.VersionPromoteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.Debug().Err(err).Msg("PromoteVersion1")
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if req.UUID == "" && req.Name == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "File UUID or Name is required"})
+		return
+	}
+	if req.Version <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Version is required"})
+		return
+	}
+
+	var (
+		upload *entities.Upload
+		err    error
+	)
+	if req.UUID != "" {
+		upload, err = h.UploadService.GetByUUID(c.Request.Context(), req.UUID, userID)
+	} else {
+		upload, err = h.UploadService.GetByName(c.Request.Context(), req.Name, req.FolderID, userID)
+	}
+	if err != nil {
+		h.log.Debug().Err(err).Msg("PromoteVersion2")
+		h.versionError(c, err)
+		return
+	}
+
+	if err := h.VersionService.Promote(c.Request.Context(), upload.ID, userID, req.Version); err != nil {
+		h.log.Debug().Err(err).Msg("PromoteVersion3")
+		h.versionError(c, err)
+		return
+	}
+
+	// UUID текущей версии сменился, имя — нет
+	upload, err = h.UploadService.GetByName(c.Request.Context(), upload.Name, upload.FolderID, userID)
+	if err != nil {
+		h.log.Debug().Err(err).Msg("PromoteVersion4")
+		h.versionError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewUploadResponse(upload))
+}
+
+// withVersion returns the upload with the content of the version requested
+// by ?version=; without it the current content is served.
+func (h *Handler) withVersion(c *gin.Context, upload *entities.Upload) (*entities.Upload, error) {
+
+	value := c.Query("version")
+	if value == "" {
+		return upload, nil
+	}
+
+	number, err := strconv.Atoi(value)
+	if err != nil || number <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid version"})
+		return nil, errors.New("bad request")
+	}
+
+	version, err := h.VersionService.Get(c.Request.Context(), upload.ID, number)
+	if err != nil {
+		h.log.Debug().Err(err).Msg("withVersion")
+		h.versionError(c, err)
+		return nil, err
+	}
+
+	versioned := *upload
+	versioned.UUID = version.UUID
+	versioned.Size = version.Size
+	versioned.ContentType = version.ContentType
+	versioned.Version = version.Version
+	versioned.ScanStatus = version.ScanStatus
+	return &versioned, nil
+}
+
+func (h *Handler) versionError(c *gin.Context, err error) {
+	if errors.Is(err, appVersion.ErrVersionNotFound) || errors.Is(err, appUpload.ErrFileNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to change version"})
+}
@@ -0,0 +1,132 @@
+package handlers_upload
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	appVersion "github.com/aube/auth/internal/application/version"
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockVersionService реализует VersionService интерфейс
+type MockVersionService struct {
+	mock.Mock
+}
+
+func (m *MockVersionService) AddUploadVersion(ctx context.Context, existing *entities.Upload, userID int64, file *entities.File, contentType string) (*entities.Upload, error) {
+	args := m.Called(ctx, existing, userID, file, contentType)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Upload), args.Error(1)
+}
+
+func (m *MockVersionService) Get(ctx context.Context, fileID int64, version int) (*entities.FileVersion, error) {
+	args := m.Called(ctx, fileID, version)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.FileVersion), args.Error(1)
+}
+
+func (m *MockVersionService) List(ctx context.Context, fileID int64) (*entities.FileVersions, error) {
+	args := m.Called(ctx, fileID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.FileVersions), args.Error(1)
+}
+
+func (m *MockVersionService) Promote(ctx context.Context, fileID int64, userID int64, version int) error {
+	return m.Called(ctx, fileID, userID, version).Error(0)
+}
+
+func TestUploadHandler_UploadFile_NewVersion(t *testing.T) {
+	mockFileService := new(MockFileService)
+	mockUploadService := new(MockUploadService)
+	mockVersionService := new(MockVersionService)
+	handler := NewUploadHandler(mockFileService, mockUploadService, mockVersionService, nil)
+
+	existing := &entities.Upload{ID: 7, UUID: "old-uuid", Name: "test.txt", Category: "docs", Version: 1}
+	file := &entities.File{Name: "new-uuid", Size: 12}
+	mockUploadService.On("ResolveName", mock.Anything, int64(1), int64(0), "test.txt", entities.ConflictOverwrite).
+		Return("test.txt", existing, nil)
+	mockFileService.On("Upload", mock.Anything, int64(12), mock.Anything, mock.Anything).Return(file, nil)
+	mockVersionService.On("AddUploadVersion", mock.Anything, existing, int64(1), file, mock.Anything).
+		Return(&entities.Upload{ID: 7, UUID: "new-uuid", Name: "test.txt", Category: "docs", Version: 2}, nil)
+
+	body := new(bytes.Buffer)
+	writer := multipart.NewWriter(body)
+	part, err := writer.CreateFormFile("file", "test.txt")
+	require.NoError(t, err)
+	_, err = part.Write([]byte("test content"))
+	require.NoError(t, err)
+	writer.Close()
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set("userID", 1)
+	c.Request = httptest.NewRequest("POST", "/upload", body)
+	c.Request.Header.Set("Content-Type", writer.FormDataContentType())
+
+	handler.UploadFile(c)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Contains(t, w.Body.String(), `"uuid":"new-uuid"`)
+	assert.Contains(t, w.Body.String(), `"version":2`)
+	mockUploadService.AssertNotCalled(t, "RegisterUploadedFile", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	// Прежнее содержимое остаётся в хранилище как версия
+	mockFileService.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+}
+
+func TestUploadHandler_DownloadFile_Version(t *testing.T) {
+	mockFileService := new(MockFileService)
+	mockUploadService := new(MockUploadService)
+	mockVersionService := new(MockVersionService)
+	handler := NewUploadHandler(mockFileService, mockUploadService, mockVersionService, nil)
+
+	upload := &entities.Upload{ID: 7, UUID: "new-uuid", Name: "test.txt", ContentType: "text/plain", Size: 3, Version: 2}
+	mockUploadService.On("GetByUUID", mock.Anything, "new-uuid", int64(1)).Return(upload, nil)
+	mockVersionService.On("Get", mock.Anything, int64(7), 1).Return(&entities.FileVersion{
+		FileID: 7, Version: 1, UUID: "old-uuid", Size: 5, ContentType: "text/markdown", CreatedAt: time.Now(),
+	}, nil)
+	mockFileService.On("Download", mock.Anything, "old-uuid").Return(io.NopCloser(bytes.NewReader([]byte("first"))), nil)
+
+	w := newStreamRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set("userID", 1)
+	c.Request = httptest.NewRequest("GET", "/upload?uuid=new-uuid&version=1", nil)
+
+	handler.DownloadFile(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/markdown", w.Header().Get("Content-Type"))
+	assert.Equal(t, "5", w.Header().Get("Content-Length"))
+	assert.Equal(t, "first", w.Body.String())
+}
+
+func TestUploadHandler_PromoteVersion_NotFound(t *testing.T) {
+	mockUploadService := new(MockUploadService)
+	mockVersionService := new(MockVersionService)
+	handler := NewUploadHandler(nil, mockUploadService, mockVersionService, nil)
+
+	upload := &entities.Upload{ID: 7, UUID: "new-uuid", Name: "test.txt", Version: 2}
+	mockUploadService.On("GetByName", mock.Anything, "test.txt", int64(0), int64(1)).Return(upload, nil)
+	mockVersionService.On("Promote", mock.Anything, int64(7), int64(1), 9).Return(appVersion.ErrVersionNotFound)
+
+	c, w := folderContext("POST", "/upload/versions/promote", `{"name":"test.txt","version":9}`)
+
+	handler.PromoteVersion(c)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
@@ -60,3 +61,45 @@ func AuthMiddleware(jwtSecret string) gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+// OptionalAuthMiddleware sets the userID like AuthMiddleware when a valid
+// token is present, but lets anonymous requests through.
+// Handlers distinguish editors from visitors by c.GetInt("userID") > 0.
+func OptionalAuthMiddleware(jwtSecret string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		authHeader := c.GetHeader("Authorization")
+		if !strings.HasPrefix(authHeader, "Bearer ") {
+			c.Next()
+			return
+		}
+
+		token, err := jwt.Parse(authHeader[len("Bearer "):], func(token *jwt.Token) (interface{}, error) {
+			return []byte(jwtSecret), nil
+		})
+		if err == nil {
+			if claims, ok := token.Claims.(jwt.MapClaims); ok {
+				if userID, ok := claims["sub"].(float64); ok {
+					c.Set("userID", int(userID))
+				}
+			}
+		}
+		c.Next()
+	}
+}
+
+// AdminMiddleware lets through users listed in adminIDs; it goes after
+// AuthMiddleware. Without admins configured everyone gets 403.
+func AdminMiddleware(adminIDs []int64) gin.HandlerFunc {
+	admins := make(map[int64]bool, len(adminIDs))
+	for _, id := range adminIDs {
+		admins[id] = true
+	}
+
+	return func(c *gin.Context) {
+		if !admins[int64(c.GetInt("userID"))] {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "admin access required"})
+			return
+		}
+		c.Next()
+	}
+}
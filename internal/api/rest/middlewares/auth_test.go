@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -43,3 +44,70 @@ func TestAuthMiddleware_InvalidToken(t *testing.T) {
 	assert.Equal(t, http.StatusUnauthorized, w.Code)
 	assert.Contains(t, w.Body.String(), "invalid token")
 }
+
+func TestOptionalAuthMiddleware(t *testing.T) {
+	jwtSecret := "test-secret"
+	valid, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"sub": 7}).SignedString([]byte(jwtSecret))
+
+	tests := []struct {
+		name   string
+		header string
+		userID int
+	}{
+		{"anonymous", "", 0},
+		{"invalid token", "Bearer invalid-token", 0},
+		{"valid token", "Bearer " + valid, 7},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			_, r := gin.CreateTestContext(w)
+			r.Use(OptionalAuthMiddleware(jwtSecret))
+			r.GET("/test", func(c *gin.Context) {
+				assert.Equal(t, tt.userID, c.GetInt("userID"))
+				c.String(http.StatusOK, "ok")
+			})
+
+			req, _ := http.NewRequest("GET", "/test", nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+		})
+	}
+}
+
+func TestAdminMiddleware(t *testing.T) {
+	tests := []struct {
+		name   string
+		userID int
+		status int
+	}{
+		{"admin", 7, http.StatusOK},
+		{"user", 8, http.StatusForbidden},
+		{"anonymous", 0, http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			_, r := gin.CreateTestContext(w)
+			r.Use(func(c *gin.Context) {
+				if tt.userID > 0 {
+					c.Set("userID", tt.userID)
+				}
+			}, AdminMiddleware([]int64{7}))
+			r.GET("/test", func(c *gin.Context) {
+				c.String(http.StatusOK, "ok")
+			})
+
+			req, _ := http.NewRequest("GET", "/test", nil)
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.status, w.Code)
+		})
+	}
+}
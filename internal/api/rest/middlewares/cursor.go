@@ -0,0 +1,75 @@
+package middlewares
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/aube/auth/internal/application/dto"
+	"github.com/aube/auth/internal/utils/query"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CursorMiddleware parses cursor pagination parameters; used after PaginationMiddleware.
+// Parameters:
+//   - cursor: next_cursor or prev_cursor of a previous response
+//   - limit: page size, overrides size (max: 1000)
+//   - total=false: skips counting the total
+//
+// Behavior:
+//   - Sets "cursor", "skipTotal" and "cursors" in the request context,
+//     "limit" when given.
+//   - Aborts with 400 on an invalid or forged cursor.
+func CursorMiddleware(secret string) gin.HandlerFunc {
+	cursors := query.NewCursors(secret)
+
+	return func(c *gin.Context) {
+		if lStr := c.Query("limit"); lStr != "" {
+			if l, err := strconv.Atoi(lStr); err == nil && l > 0 {
+				c.Set("limit", min(l, maxPageSize))
+			}
+		}
+
+		if token := c.Query("cursor"); token != "" {
+			cursor, err := cursors.Decode(token)
+			if err != nil {
+				c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "invalid cursor"})
+				return
+			}
+			c.Set("cursor", cursor)
+		}
+
+		c.Set("skipTotal", c.Query("total") == "false")
+		c.Set("cursors", cursors)
+		c.Next()
+	}
+}
+
+// WithCursor sets the cursor and total options of the request on filter.
+// Returns: filter or a new query when it is nil.
+func WithCursor(c *gin.Context, filter *query.Query) *query.Query {
+	if filter == nil {
+		filter = query.New()
+	}
+	if cursor, ok := c.Get("cursor"); ok {
+		filter.Cursor = cursor.(*query.Cursor)
+	}
+	filter.SkipTotal = c.GetBool("skipTotal")
+	return filter
+}
+
+// SignCursors turns the Next and Prev cursors of pagination into tokens.
+func SignCursors(c *gin.Context, pagination *dto.Pagination) error {
+	value, ok := c.Get("cursors")
+	if !ok || pagination == nil {
+		return nil
+	}
+	cursors := value.(*query.Cursors)
+
+	var err error
+	if pagination.NextCursor, err = cursors.Encode(pagination.Next); err != nil {
+		return err
+	}
+	pagination.PrevCursor, err = cursors.Encode(pagination.Prev)
+	return err
+}
@@ -0,0 +1,72 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/aube/auth/internal/application/dto"
+	"github.com/aube/auth/internal/utils/query"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func cursorRouter(handler gin.HandlerFunc) *gin.Engine {
+	_, r := gin.CreateTestContext(httptest.NewRecorder())
+	r.Use(PaginationMiddleware(), CursorMiddleware("secret"))
+	r.GET("/test", handler)
+	return r
+}
+
+func TestCursorMiddleware_RoundTrip(t *testing.T) {
+	var got *query.Query
+	r := cursorRouter(func(c *gin.Context) {
+		got = WithCursor(c, nil)
+		pagination := &dto.Pagination{Next: got.CursorAt([]any{int64(42)}, false)}
+		require.NoError(t, SignCursors(c, pagination))
+		c.JSON(http.StatusOK, gin.H{"limit": c.GetInt("limit"), "next": pagination.NextCursor})
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest("GET", "/test?limit=5&total=false", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"limit":5`)
+	assert.Nil(t, got.Cursor)
+	assert.True(t, got.SkipTotal)
+
+	token, err := query.NewCursors("secret").Encode(&query.Cursor{Key: []any{int64(42)}})
+	require.NoError(t, err)
+	assert.Contains(t, w.Body.String(), token)
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest("GET", "/test?cursor="+url.QueryEscape(token), nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, &query.Cursor{Key: []any{int64(42)}}, got.Cursor)
+	assert.False(t, got.SkipTotal)
+}
+
+func TestCursorMiddleware_InvalidCursor(t *testing.T) {
+	token, err := query.NewCursors("other").Encode(&query.Cursor{Key: []any{int64(1)}})
+	require.NoError(t, err)
+	r := cursorRouter(func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest("GET", "/test?cursor="+url.QueryEscape(token), nil))
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestCursorMiddleware_LimitCapped(t *testing.T) {
+	r := cursorRouter(func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"limit": c.GetInt("limit")})
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest("GET", "/test?limit=5000", nil))
+
+	assert.Contains(t, w.Body.String(), `"limit":1001`)
+}
@@ -7,6 +7,9 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
+// maxPageSize is the maximum allowed page size +1 for unlimited scroll.
+const maxPageSize = 1000 + 1
+
 // PaginationMiddleware parses pagination query parameters (page, size) and calculates offset/limit.
 // Returns: Gin middleware function.
 // Defaults:
@@ -21,7 +24,6 @@ func PaginationMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		page := 1
 		size := 10
-		maxSize := 1000 + 1 // Maximum allowed page size +1 for unlimited scroll
 
 		if pStr := c.Query("page"); pStr != "" {
 			if p, err := strconv.Atoi(pStr); err == nil && p > 0 {
@@ -31,8 +33,8 @@ func PaginationMiddleware() gin.HandlerFunc {
 
 		if sStr := c.Query("size"); sStr != "" {
 			if s, err := strconv.Atoi(sStr); err == nil && s > 0 {
-				if s > maxSize {
-					size = maxSize
+				if s > maxPageSize {
+					size = maxPageSize
 				} else {
 					size = s
 				}
@@ -0,0 +1,20 @@
+package rest
+
+import (
+	"github.com/aube/auth/internal/api/rest/handlers_batch"
+	"github.com/aube/auth/internal/api/rest/middlewares"
+	appBatch "github.com/aube/auth/internal/application/batch"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupBatchRouter(api *gin.RouterGroup, batchService *appBatch.BatchService, jwtSecret string) {
+	batchHandler := handlers_batch.NewBatchHandler(batchService)
+
+	// Защищённые маршруты
+	authApi := api.Group("/")
+	authApi.Use(middlewares.AuthMiddleware(jwtSecret))
+	{
+		authApi.POST("/batch", batchHandler.Execute)
+	}
+}
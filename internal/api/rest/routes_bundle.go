@@ -0,0 +1,21 @@
+package rest
+
+import (
+	"github.com/aube/auth/internal/api/rest/handlers_bundle"
+	"github.com/aube/auth/internal/api/rest/middlewares"
+	appBundle "github.com/aube/auth/internal/application/bundle"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupBundleRouter(api *gin.RouterGroup, bundleService *appBundle.BundleService, jwtSecret string) {
+	bundleHandler := handlers_bundle.NewBundleHandler(bundleService)
+
+	// Защищённые маршруты
+	authApi := api.Group("/")
+	authApi.Use(middlewares.AuthMiddleware(jwtSecret))
+	{
+		authApi.GET("/pages/export", bundleHandler.Export)
+		authApi.POST("/pages/import", bundleHandler.Import)
+	}
+}
@@ -0,0 +1,24 @@
+package rest
+
+import (
+	"github.com/aube/auth/internal/api/rest/handlers_content_type"
+	"github.com/aube/auth/internal/api/rest/middlewares"
+	appContentType "github.com/aube/auth/internal/application/contenttype"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupContentTypeRouter(api *gin.RouterGroup, contentTypeService *appContentType.ContentTypeService, jwtSecret string) {
+	contentTypeHandler := handlers_content_type.NewContentTypeHandler(contentTypeService)
+
+	// Защищённые маршруты
+	authApi := api.Group("/")
+	authApi.Use(middlewares.AuthMiddleware(jwtSecret))
+	{
+		authApi.GET("/content-types", contentTypeHandler.List)
+		authApi.GET("/content-type", contentTypeHandler.Get)
+		authApi.POST("/content-type", contentTypeHandler.Create)
+		authApi.PUT("/content-type", contentTypeHandler.Update)
+		authApi.DELETE("/content-type", contentTypeHandler.Delete)
+	}
+}
@@ -0,0 +1,21 @@
+package rest
+
+import (
+	"github.com/aube/auth/internal/api/rest/handlers_site"
+	appFeed "github.com/aube/auth/internal/application/feed"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupFeedRouter(
+	r *gin.Engine,
+	feedService *appFeed.FeedService,
+	site SiteConfig,
+) {
+	feedHandler := handlers_site.NewFeedHandler(feedService, site.URL, site.Name, site.FeedFullContent)
+
+	// Ленты RSS/Atom: все категории и отдельная категория
+	r.GET("/feeds.rss", feedHandler.AllFeed)
+	r.GET("/feeds.atom", feedHandler.AllFeed)
+	r.GET("/feeds/:file", feedHandler.Feed)
+}
@@ -0,0 +1,27 @@
+package rest
+
+import (
+	"github.com/aube/auth/internal/api/rest/handlers_upload"
+	"github.com/aube/auth/internal/api/rest/middlewares"
+	appFolder "github.com/aube/auth/internal/application/folder"
+	appUpload "github.com/aube/auth/internal/application/upload"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupFolderRouter(api *gin.RouterGroup, folderService *appFolder.FolderService, uploadService *appUpload.UploadService, jwtSecret string) {
+	folderHandler := handlers_upload.NewFolderHandler(folderService, uploadService)
+
+	// Защищённые маршруты
+	authApi := api.Group("/")
+	authApi.Use(middlewares.AuthMiddleware(jwtSecret))
+	{
+		authApi.GET("/folder", folderHandler.GetFolder)
+		authApi.POST("/folder", folderHandler.CreateFolder)
+		authApi.DELETE("/folder", folderHandler.DeleteFolder)
+		authApi.POST("/folder/rename", folderHandler.RenameFolder)
+		authApi.POST("/folder/move", folderHandler.MoveFolder)
+		authApi.GET("/folder/children", middlewares.PaginationMiddleware(), middlewares.CursorMiddleware(jwtSecret), folderHandler.ListFolder)
+		authApi.POST("/upload/move", folderHandler.MoveFile)
+	}
+}
@@ -5,12 +5,13 @@ import (
 	"github.com/aube/auth/internal/api/rest/middlewares"
 	appFile "github.com/aube/auth/internal/application/file"
 	appImage "github.com/aube/auth/internal/application/image"
+	appVersion "github.com/aube/auth/internal/application/version"
 
 	"github.com/gin-gonic/gin"
 )
 
-func SetupImagesRouter(api *gin.RouterGroup, fileService *appFile.FileService, imageService *appImage.ImageService, jwtSecret string) {
-	imageHandler := handlers_image.NewImageHandler(fileService, imageService)
+func SetupImagesRouter(api *gin.RouterGroup, fileService *appFile.FileService, imageService *appImage.ImageService, versionService *appVersion.VersionService, jwtSecret string) {
+	imageHandler := handlers_image.NewImageHandler(fileService, imageService, versionService)
 
 	// Защищённые маршруты
 	authApi := api.Group("/")
@@ -19,8 +20,10 @@ func SetupImagesRouter(api *gin.RouterGroup, fileService *appFile.FileService, i
 		authApi.GET("/image", imageHandler.DownloadFile)
 		authApi.POST("/image", imageHandler.UploadImage)
 		authApi.DELETE("/image", imageHandler.DeleteFile)
+		authApi.GET("/image/versions", imageHandler.ListVersions)
+		authApi.POST("/image/versions/promote", imageHandler.PromoteVersion)
 	}
-	authApi.Use(middlewares.PaginationMiddleware())
+	authApi.Use(middlewares.PaginationMiddleware(), middlewares.CursorMiddleware(jwtSecret))
 	{
 		authApi.GET("/images", imageHandler.ListFiles)
 	}
@@ -0,0 +1,25 @@
+package rest
+
+import (
+	"github.com/aube/auth/internal/api/rest/handlers_menu"
+	"github.com/aube/auth/internal/api/rest/middlewares"
+	appMenu "github.com/aube/auth/internal/application/menu"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupMenuRouter(api *gin.RouterGroup, menuService *appMenu.MenuService, jwtSecret string) {
+	menuHandler := handlers_menu.NewMenuHandler(menuService)
+
+	// Публичные маршруты
+	api.GET("/menu/:name", menuHandler.GetMenu)
+	api.GET("/menus", menuHandler.List)
+
+	// Защищённые маршруты
+	authApi := api.Group("/")
+	authApi.Use(middlewares.AuthMiddleware(jwtSecret))
+	{
+		authApi.PUT("/menu", menuHandler.Save)
+		authApi.DELETE("/menu", menuHandler.Delete)
+	}
+}
@@ -0,0 +1,38 @@
+package rest
+
+import (
+	"github.com/aube/auth/internal/api/rest/handlers_node"
+	"github.com/aube/auth/internal/api/rest/middlewares"
+	appNode "github.com/aube/auth/internal/application/node"
+	appPage "github.com/aube/auth/internal/application/page"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupNodeRouter(
+	api *gin.RouterGroup,
+	nodeService *appNode.NodeService,
+	pageService *appPage.PageService,
+	jwtSecret string,
+) {
+	nodeHandler := handlers_node.NewNodeHandler(nodeService, pageService)
+
+	// Публичные маршруты
+	api.GET("/node", nodeHandler.GetByParam)
+	api.GET("/nodes", nodeHandler.GetTree)
+	api.GET("/node/breadcrumbs", nodeHandler.GetBreadcrumbs)
+	api.GET("/node/resolve", middlewares.OptionalAuthMiddleware(jwtSecret), nodeHandler.Resolve)
+	api.GET("/node/pages", nodeHandler.ListNodePages)
+
+	// Защищённые маршруты
+	authApi := api.Group("/")
+	authApi.Use(middlewares.AuthMiddleware(jwtSecret))
+	{
+		authApi.POST("/node", nodeHandler.Create)
+		authApi.PUT("/node", nodeHandler.Update)
+		authApi.DELETE("/node", nodeHandler.Delete)
+		authApi.POST("/node/move", nodeHandler.Move)
+		authApi.POST("/node/page", nodeHandler.AttachPage)
+		authApi.DELETE("/node/page", nodeHandler.DetachPage)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"github.com/aube/auth/internal/api/rest/handlers_page"
 	"github.com/aube/auth/internal/api/rest/middlewares"
 	appPage "github.com/aube/auth/internal/application/page"
+	appTranslation "github.com/aube/auth/internal/application/translation"
 
 	"github.com/gin-gonic/gin"
 )
@@ -11,22 +12,41 @@ import (
 func SetupPageRouter(
 	api *gin.RouterGroup,
 	pageService *appPage.PageService,
+	lockService *appPage.LockService,
+	translationService *appTranslation.TranslationService,
 	jwtSecret string,
 ) {
-	pageHandler := handlers_page.NewPageHandler(pageService, jwtSecret)
+	pageHandler := handlers_page.NewPageHandler(pageService, translationService, lockService, jwtSecret)
 
-	// Защищённые маршруты
-	authApi := api.Group("/")
-	authApi.GET("/page", pageHandler.GetByParam)
-	authApi.Use(middlewares.PaginationMiddleware())
+	// Публичные маршруты: посетителям отдаются только опубликованные страницы
+	publicApi := api.Group("/")
+	publicApi.Use(middlewares.OptionalAuthMiddleware(jwtSecret))
 	{
-		authApi.GET("/pages", pageHandler.ListPages)
+		publicApi.GET("/page", pageHandler.GetByParam)
+		publicApi.GET("/page/preview", pageHandler.GetPreview)
+		publicApi.GET("/page/translations", pageHandler.ListTranslations)
+		publicApi.GET("/pages", middlewares.PaginationMiddleware(), middlewares.CursorMiddleware(jwtSecret), pageHandler.ListPages)
+		publicApi.GET("/pages/search", middlewares.PaginationMiddleware(), pageHandler.SearchPages)
 	}
 
+	// Защищённые маршруты
+	authApi := api.Group("/")
+
 	authApi.Use(middlewares.AuthMiddleware(jwtSecret))
 	{
 		authApi.POST("/page", pageHandler.Create)
 		authApi.PUT("/page", pageHandler.Update)
 		authApi.DELETE("/page", pageHandler.Delete)
+		authApi.PUT("/page/status", pageHandler.SetStatus)
+		authApi.GET("/page/lock", pageHandler.GetLock)
+		authApi.POST("/page/lock", pageHandler.LockPage)
+		authApi.DELETE("/page/lock", pageHandler.UnlockPage)
+		authApi.PUT("/page/translation", pageHandler.SaveTranslation)
+		authApi.DELETE("/page/translation", pageHandler.DeleteTranslation)
+		authApi.POST("/page/preview", pageHandler.CreatePreview)
+		authApi.GET("/page/revision", pageHandler.GetRevision)
+		authApi.GET("/page/revisions/diff", pageHandler.DiffRevisions)
+		authApi.POST("/page/revision/restore", pageHandler.RestoreRevision)
+		authApi.GET("/page/revisions", middlewares.PaginationMiddleware(), middlewares.CursorMiddleware(jwtSecret), pageHandler.ListRevisions)
 	}
 }
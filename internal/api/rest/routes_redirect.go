@@ -0,0 +1,27 @@
+package rest
+
+import (
+	"github.com/aube/auth/internal/api/rest/handlers_redirect"
+	"github.com/aube/auth/internal/api/rest/middlewares"
+	appRedirect "github.com/aube/auth/internal/application/redirect"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupRedirectRouter(api *gin.RouterGroup, redirectService *appRedirect.RedirectService, jwtSecret string) {
+	redirectHandler := handlers_redirect.NewRedirectHandler(redirectService)
+
+	// Публичные маршруты
+	api.GET("/redirect/resolve", redirectHandler.Resolve)
+
+	// Защищённые маршруты
+	authApi := api.Group("/")
+	authApi.Use(middlewares.AuthMiddleware(jwtSecret))
+	{
+		authApi.GET("/redirects", middlewares.PaginationMiddleware(), middlewares.CursorMiddleware(jwtSecret), redirectHandler.List)
+		authApi.GET("/redirect", redirectHandler.GetByID)
+		authApi.POST("/redirect", redirectHandler.Create)
+		authApi.PUT("/redirect", redirectHandler.Update)
+		authApi.DELETE("/redirect", redirectHandler.Delete)
+	}
+}
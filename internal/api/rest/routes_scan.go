@@ -0,0 +1,22 @@
+package rest
+
+import (
+	"github.com/aube/auth/internal/api/rest/handlers_upload"
+	"github.com/aube/auth/internal/api/rest/middlewares"
+	appScan "github.com/aube/auth/internal/application/scan"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupScanRouter(api *gin.RouterGroup, scanService *appScan.ScanService, adminIDs []int64, jwtSecret string) {
+	quarantineHandler := handlers_upload.NewQuarantineHandler(scanService)
+
+	// Только для администраторов
+	adminApi := api.Group("/admin")
+	adminApi.Use(middlewares.AuthMiddleware(jwtSecret), middlewares.AdminMiddleware(adminIDs))
+	{
+		adminApi.POST("/quarantine/rescan", quarantineHandler.Rescan)
+		adminApi.POST("/quarantine/release", quarantineHandler.Release)
+		adminApi.GET("/quarantine", middlewares.PaginationMiddleware(), middlewares.CursorMiddleware(jwtSecret), quarantineHandler.ListQuarantine)
+	}
+}
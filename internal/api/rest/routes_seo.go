@@ -0,0 +1,22 @@
+package rest
+
+import (
+	"github.com/aube/auth/internal/api/rest/handlers_site"
+	appSitemap "github.com/aube/auth/internal/application/sitemap"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupSeoRouter(
+	r *gin.Engine,
+	sitemapService *appSitemap.SitemapService,
+	site SiteConfig,
+	apiPath string,
+) {
+	seoHandler := handlers_site.NewSeoHandler(sitemapService, site.URL, site.Robots, apiPath)
+
+	// Маршруты для поисковых роботов
+	r.GET("/robots.txt", seoHandler.Robots)
+	r.GET("/sitemap.xml", seoHandler.Sitemap)
+	r.GET("/sitemap/:file", seoHandler.SitemapFile)
+}
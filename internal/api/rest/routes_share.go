@@ -0,0 +1,29 @@
+package rest
+
+import (
+	"github.com/aube/auth/internal/api/rest/handlers_upload"
+	"github.com/aube/auth/internal/api/rest/middlewares"
+	appFile "github.com/aube/auth/internal/application/file"
+	appScan "github.com/aube/auth/internal/application/scan"
+	appShare "github.com/aube/auth/internal/application/share"
+	appUpload "github.com/aube/auth/internal/application/upload"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupShareRouter(r *gin.Engine, api *gin.RouterGroup, fileService *appFile.FileService, uploadService *appUpload.UploadService, shareService *appShare.ShareService, scanService *appScan.ScanService, jwtSecret string) {
+	shareHandler := handlers_upload.NewShareHandler(fileService, uploadService, shareService, scanService, jwtSecret)
+
+	// Публичные ссылки на файлы
+	r.GET(handlers_upload.SharePath+":token", shareHandler.DownloadShared)
+
+	// Защищённые маршруты
+	authApi := api.Group("/")
+	authApi.Use(middlewares.AuthMiddleware(jwtSecret))
+	{
+		authApi.POST("/upload/share", shareHandler.CreateShare)
+		authApi.DELETE("/upload/share", shareHandler.RevokeShare)
+		authApi.POST("/upload/presign", shareHandler.CreatePresigned)
+		authApi.GET("/upload/shares", middlewares.PaginationMiddleware(), middlewares.CursorMiddleware(jwtSecret), shareHandler.ListShares)
+	}
+}
@@ -2,13 +2,18 @@ package rest
 
 import (
 	"net/http"
+	"strings"
 
 	"github.com/aube/auth/internal/api/rest/handlers_common"
 
 	"github.com/gin-gonic/gin"
 )
 
-func SetupStaticRouter(r *gin.Engine, apiPath string) *gin.Engine {
+// SetupStaticRouter serves the SPA and static files.
+// site: Optional handler rendering pages with the site theme; when set it
+// serves "/" and every unknown non-API path instead of the SPA.
+// redirect: Optional middleware applying redirect rules before the fallback.
+func SetupStaticRouter(r *gin.Engine, apiPath string, site gin.HandlerFunc, redirect gin.HandlerFunc) *gin.Engine {
 	// Загрузка шаблонов (только index.html)
 	r.LoadHTMLGlob("internal/api/rest/templates/*")
 
@@ -20,20 +25,33 @@ func SetupStaticRouter(r *gin.Engine, apiPath string) *gin.Engine {
 	// Состояние апи
 	r.GET(apiPath+"/state", webHandler.AppState200)
 
-	// Все остальные GET запросы (кроме API) возвращают SPA
-	r.GET("/", webHandler.ServeSPA)
+	fallback := webHandler.ServeSPA
+	if site != nil {
+		fallback = site
+	}
+	// Редиректы проверяются до отдачи SPA или страницы
+	if redirect != nil {
+		next := fallback
+		fallback = func(c *gin.Context) {
+			if redirect(c); !c.IsAborted() {
+				next(c)
+			}
+		}
+	}
+
+	// Все остальные GET запросы (кроме API) возвращают SPA или страницы сайта
+	r.GET("/", fallback)
 	r.GET("/login", webHandler.ServeSPA)
 	r.GET("/register", webHandler.ServeSPA)
 	r.GET("/profile", webHandler.ServeSPA)
 
 	// Обработка 404 для API
 	r.NoRoute(func(c *gin.Context) {
-		if c.Request.URL.Path[:4] == "/api" {
+		if strings.HasPrefix(c.Request.URL.Path, apiPath) {
 			c.JSON(http.StatusNotFound, gin.H{"error": "Not found"})
 			return
 		}
-		// Для всех остальных запросов возвращаем SPA
-		webHandler.ServeSPA(c)
+		fallback(c)
 	})
 
 	return r
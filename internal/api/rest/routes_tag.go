@@ -0,0 +1,25 @@
+package rest
+
+import (
+	"github.com/aube/auth/internal/api/rest/handlers_tag"
+	"github.com/aube/auth/internal/api/rest/middlewares"
+	appTag "github.com/aube/auth/internal/application/tag"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupTagRouter(api *gin.RouterGroup, tagService *appTag.TagService, jwtSecret string) {
+	tagHandler := handlers_tag.NewTagHandler(tagService)
+
+	// Защищённые маршруты
+	authApi := api.Group("/")
+	authApi.Use(middlewares.AuthMiddleware(jwtSecret))
+	{
+		authApi.GET("/tags", tagHandler.List)
+		authApi.GET("/tags/item", tagHandler.GetItemTags)
+		authApi.PUT("/tags/item", tagHandler.SetItemTags)
+		authApi.POST("/tags/rename", tagHandler.Rename)
+		authApi.POST("/tags/merge", tagHandler.Merge)
+		authApi.GET("/tags/facets", tagHandler.Facets)
+	}
+}
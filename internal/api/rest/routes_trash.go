@@ -0,0 +1,22 @@
+package rest
+
+import (
+	"github.com/aube/auth/internal/api/rest/handlers_trash"
+	"github.com/aube/auth/internal/api/rest/middlewares"
+	appTrash "github.com/aube/auth/internal/application/trash"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupTrashRouter(api *gin.RouterGroup, trashService *appTrash.TrashService, jwtSecret string) {
+	trashHandler := handlers_trash.NewTrashHandler(trashService)
+
+	// Защищённые маршруты
+	authApi := api.Group("/")
+	authApi.Use(middlewares.AuthMiddleware(jwtSecret))
+	{
+		authApi.GET("/trash", middlewares.PaginationMiddleware(), middlewares.CursorMiddleware(jwtSecret), trashHandler.List)
+		authApi.POST("/trash/restore", trashHandler.Restore)
+		authApi.DELETE("/trash", trashHandler.Purge)
+	}
+}
@@ -3,14 +3,18 @@ package rest
 import (
 	"github.com/aube/auth/internal/api/rest/handlers_upload"
 	"github.com/aube/auth/internal/api/rest/middlewares"
+	appArchive "github.com/aube/auth/internal/application/archive"
 	appFile "github.com/aube/auth/internal/application/file"
+	appScan "github.com/aube/auth/internal/application/scan"
 	appUpload "github.com/aube/auth/internal/application/upload"
+	appVersion "github.com/aube/auth/internal/application/version"
 
 	"github.com/gin-gonic/gin"
 )
 
-func SetupUploadsRouter(api *gin.RouterGroup, fileService *appFile.FileService, uploadService *appUpload.UploadService, jwtSecret string) {
-	uploadHandler := handlers_upload.NewUploadHandler(fileService, uploadService)
+func SetupUploadsRouter(api *gin.RouterGroup, fileService *appFile.FileService, uploadService *appUpload.UploadService, archiveService *appArchive.ArchiveService, versionService *appVersion.VersionService, scanService *appScan.ScanService, jwtSecret string) {
+	uploadHandler := handlers_upload.NewUploadHandler(fileService, uploadService, versionService, scanService)
+	archiveHandler := handlers_upload.NewArchiveHandler(archiveService)
 
 	// Защищённые маршруты
 	authApi := api.Group("/")
@@ -19,8 +23,12 @@ func SetupUploadsRouter(api *gin.RouterGroup, fileService *appFile.FileService,
 		authApi.GET("/upload", uploadHandler.DownloadFile)
 		authApi.POST("/upload", uploadHandler.UploadFile)
 		authApi.DELETE("/upload", uploadHandler.DeleteFile)
+		authApi.GET("/upload/versions", uploadHandler.ListVersions)
+		authApi.POST("/upload/versions/promote", uploadHandler.PromoteVersion)
+		authApi.GET("/uploads/archive", archiveHandler.DownloadArchive)
+		authApi.POST("/uploads/archive", archiveHandler.UploadArchive)
 	}
-	authApi.Use(middlewares.PaginationMiddleware())
+	authApi.Use(middlewares.PaginationMiddleware(), middlewares.CursorMiddleware(jwtSecret))
 	{
 		authApi.GET("/uploads", uploadHandler.ListFiles)
 	}
@@ -5,14 +5,46 @@ import (
 	"log"
 	"net/http"
 
+	"github.com/aube/auth/internal/api/rest/handlers_site"
+	appArchive "github.com/aube/auth/internal/application/archive"
+	appBatch "github.com/aube/auth/internal/application/batch"
+	appBundle "github.com/aube/auth/internal/application/bundle"
+	appContentType "github.com/aube/auth/internal/application/contenttype"
+	appFeed "github.com/aube/auth/internal/application/feed"
 	appFile "github.com/aube/auth/internal/application/file"
+	appFolder "github.com/aube/auth/internal/application/folder"
 	appImage "github.com/aube/auth/internal/application/image"
+	appMenu "github.com/aube/auth/internal/application/menu"
+	appNode "github.com/aube/auth/internal/application/node"
 	appPage "github.com/aube/auth/internal/application/page"
+	appRedirect "github.com/aube/auth/internal/application/redirect"
+	appScan "github.com/aube/auth/internal/application/scan"
+	appShare "github.com/aube/auth/internal/application/share"
+	appSitemap "github.com/aube/auth/internal/application/sitemap"
+	appTag "github.com/aube/auth/internal/application/tag"
+	appTranslation "github.com/aube/auth/internal/application/translation"
+	appTrash "github.com/aube/auth/internal/application/trash"
 	appUpload "github.com/aube/auth/internal/application/upload"
 	appUser "github.com/aube/auth/internal/application/user"
+	appVersion "github.com/aube/auth/internal/application/version"
+	"github.com/aube/auth/internal/infrastructure/views"
 	"github.com/gin-gonic/gin"
 )
 
+// SiteConfig holds settings of the public site.
+// Views: Theme for server-side page rendering (nil serves the SPA instead)
+// URL: Public base URL, e.g. https://example.com (empty: taken from the request)
+// Name: Site name used as the feed title (empty: host name)
+// Robots: robots.txt content (empty: default)
+// FeedFullContent: Include full page content in RSS/Atom feeds
+type SiteConfig struct {
+	Views           *views.Views
+	URL             string
+	Name            string
+	Robots          string
+	FeedFullContent bool
+}
+
 // Server represents the HTTP server with router and HTTP server configurations.
 // router: The Gin router handling all routes.
 // httpServer: The underlying HTTP server.
@@ -23,27 +55,88 @@ type Server struct {
 
 // NewServer initializes a new Server instance with configured routes and services.
 // userService: Service for user operations.
+// pageService: Service for page operations.
+// lockService: Service for page edit locks.
+// translationService: Service for page translations and site locales.
+// contentTypeService: Service for page content types.
+// nodeService: Service for the site tree.
+// menuService: Service for navigation menus.
+// redirectService: Service for URL redirects.
 // fileService: Service for file storage operations.
 // uploadService: Service for upload metadata operations.
+// archiveService: Service for ZIP download and import of uploads.
+// shareService: Service for public links to uploads.
+// folderService: Service for folders of uploads.
+// uploadVersionService: Service for versions of uploads.
+// imageVersionService: Service for versions of images.
+// scanService: Service for malware scans of uploads.
+// sitemapService: Service for sitemap.xml.
+// feedService: Service for RSS/Atom feeds.
+// bundleService: Service for page import/export.
+// trashService: Service for deleted pages, uploads and images.
+// tagService: Service for tags and facets.
+// batchService: Service for batch operations on pages and files.
+// adminIDs: Users allowed to review quarantined files.
+// site: Public site settings (theme, URL, robots.txt).
 // jwtSecret: Secret key for JWT token generation and validation.
 // apiPath: Base path for API routes (e.g., "/api").
 // Returns: A configured *Server instance.
 func NewServer(
 	userService *appUser.UserService,
 	pageService *appPage.PageService,
+	lockService *appPage.LockService,
+	translationService *appTranslation.TranslationService,
+	contentTypeService *appContentType.ContentTypeService,
+	nodeService *appNode.NodeService,
+	menuService *appMenu.MenuService,
+	redirectService *appRedirect.RedirectService,
 	fileService *appFile.FileService,
 	imgFileService *appFile.FileService,
 	uploadService *appUpload.UploadService,
+	archiveService *appArchive.ArchiveService,
+	shareService *appShare.ShareService,
+	folderService *appFolder.FolderService,
 	imageService *appImage.ImageService,
+	uploadVersionService *appVersion.VersionService,
+	imageVersionService *appVersion.VersionService,
+	scanService *appScan.ScanService,
+	sitemapService *appSitemap.SitemapService,
+	feedService *appFeed.FeedService,
+	bundleService *appBundle.BundleService,
+	trashService *appTrash.TrashService,
+	tagService *appTag.TagService,
+	batchService *appBatch.BatchService,
+	adminIDs []int64,
+	site SiteConfig,
 	jwtSecret string,
 	apiPath string,
 ) *Server {
 	router, apiGroup := NewRouter(apiPath)
 	SetupUserRouter(apiGroup, userService, jwtSecret)
-	SetupPageRouter(apiGroup, pageService, jwtSecret)
-	SetupUploadsRouter(apiGroup, fileService, uploadService, jwtSecret)
-	SetupImagesRouter(apiGroup, imgFileService, imageService, jwtSecret)
-	SetupStaticRouter(router, apiPath)
+	SetupPageRouter(apiGroup, pageService, lockService, translationService, jwtSecret)
+	SetupContentTypeRouter(apiGroup, contentTypeService, jwtSecret)
+	SetupNodeRouter(apiGroup, nodeService, pageService, jwtSecret)
+	SetupMenuRouter(apiGroup, menuService, jwtSecret)
+	SetupRedirectRouter(apiGroup, redirectService, jwtSecret)
+	SetupUploadsRouter(apiGroup, fileService, uploadService, archiveService, uploadVersionService, scanService, jwtSecret)
+	SetupShareRouter(router, apiGroup, fileService, uploadService, shareService, scanService, jwtSecret)
+	SetupScanRouter(apiGroup, scanService, adminIDs, jwtSecret)
+	SetupFolderRouter(apiGroup, folderService, uploadService, jwtSecret)
+	SetupImagesRouter(apiGroup, imgFileService, imageService, imageVersionService, jwtSecret)
+	SetupBundleRouter(apiGroup, bundleService, jwtSecret)
+	SetupTrashRouter(apiGroup, trashService, jwtSecret)
+	SetupTagRouter(apiGroup, tagService, jwtSecret)
+	SetupBatchRouter(apiGroup, batchService, jwtSecret)
+
+	SetupSeoRouter(router, sitemapService, site, apiPath)
+	SetupFeedRouter(router, feedService, site)
+
+	var render gin.HandlerFunc
+	if site.Views != nil {
+		render = handlers_site.NewSiteHandler(pageService, nodeService, translationService, site.Views, site.URL).Render
+	}
+	redirect := handlers_site.NewRedirectHandler(redirectService).Redirect
+	SetupStaticRouter(router, apiPath, render, redirect)
 
 	return &Server{
 		router: router,
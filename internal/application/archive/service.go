@@ -0,0 +1,335 @@
+// Package archive streams uploads as ZIP archives and imports ZIP
+// archives as uploads.
+package archive
+
+import (
+	"archive/zip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"path"
+	"strconv"
+
+	"github.com/aube/auth/internal/application/dto"
+	appFile "github.com/aube/auth/internal/application/file"
+	appUpload "github.com/aube/auth/internal/application/upload"
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/aube/auth/internal/utils/logger"
+	"github.com/aube/auth/internal/utils/query"
+	"github.com/rs/zerolog"
+)
+
+var (
+	ErrNoFiles = errors.New("no files to archive")
+	// ErrTooManyFiles is returned when a download or an import has more than Limits.MaxFiles files.
+	ErrTooManyFiles = errors.New("too many files in archive")
+	// ErrArchiveTooLarge is returned when the files of an imported archive
+	// unpack to more than Limits.MaxTotalSize.
+	ErrArchiveTooLarge = errors.New("archive is too large")
+	ErrInvalidArchive  = errors.New("invalid zip archive")
+)
+
+// Limits protect imports from zip bombs and bound downloads.
+// MaxRatio is the highest allowed uncompressed/compressed size of an entry,
+// checked for entries larger than ratioThreshold.
+type Limits struct {
+	MaxFiles     int
+	MaxFileSize  int64
+	MaxTotalSize int64
+	MaxRatio     int64
+}
+
+var DefaultLimits = Limits{
+	MaxFiles:     1000,
+	MaxFileSize:  100 << 20,
+	MaxTotalSize: 1 << 30,
+	MaxRatio:     100,
+}
+
+// ratioThreshold: small files compress well and are harmless
+const ratioThreshold = 1 << 20
+
+// FileStore stores contents of uploads.
+type FileStore interface {
+	Delete(ctx context.Context, id string) error
+	Download(ctx context.Context, uuid string) (io.ReadCloser, error)
+	Upload(ctx context.Context, size int64, declaredType string, data io.Reader) (*entities.File, error)
+}
+
+// UploadStore stores metadata of uploads.
+type UploadStore interface {
+	GetByName(ctx context.Context, name string, folderID int64, userID int64) (*entities.Upload, error)
+	ListByUserID(ctx context.Context, userID int64, offset, limit int, filter *query.Query) (*entities.Uploads, *dto.Pagination, error)
+	RegisterUploadedFile(ctx context.Context, userID int64, folderID int64, file *entities.File, name, category, contentType, description string) (*entities.Upload, error)
+}
+
+// VersionStore keeps older contents of uploads replaced by an import.
+type VersionStore interface {
+	AddUploadVersion(ctx context.Context, existing *entities.Upload, userID int64, file *entities.File, contentType string) (*entities.Upload, error)
+}
+
+// ScanQueue scans imported files for malware and keeps files it blocks
+// out of downloaded archives.
+type ScanQueue interface {
+	CheckDownload(status string) error
+	InitialStatus() string
+	Submit(ctx context.Context, uuid string) error
+}
+
+type ArchiveService struct {
+	files    FileStore
+	uploads  UploadStore
+	versions VersionStore
+	scans    ScanQueue
+	limits   Limits
+	log      zerolog.Logger
+}
+
+// NewArchiveService creates the service; scans may be nil.
+func NewArchiveService(files FileStore, uploads UploadStore, versions VersionStore, scans ScanQueue, limits Limits) *ArchiveService {
+	return &ArchiveService{
+		files:    files,
+		uploads:  uploads,
+		versions: versions,
+		scans:    scans,
+		limits:   limits,
+		log:      logger.Get().With().Str("archive", "service").Logger(),
+	}
+}
+
+// Uploads returns live uploads of the user with the UUIDs or, without
+// them, in the category, ordered by name.
+func (s *ArchiveService) Uploads(ctx context.Context, userID int64, category string, uuids []string) (*entities.Uploads, error) {
+	if len(uuids) > s.limits.MaxFiles {
+		return nil, ErrTooManyFiles
+	}
+
+	filter := query.New().Add("deleted", query.OpEq, false)
+	if len(uuids) > 0 {
+		values := make([]any, len(uuids))
+		for i, uuid := range uuids {
+			values[i] = uuid
+		}
+		filter.Add("uuid", query.OpIn, values)
+	} else {
+		filter.Add("category", query.OpEq, category)
+	}
+	filter.Sort = []query.Sort{{Field: "name"}}
+	filter.SkipTotal = true
+
+	uploads, _, err := s.uploads.ListByUserID(ctx, userID, 0, s.limits.MaxFiles+1, filter)
+	if err != nil {
+		s.log.Debug().Err(err).Msg("Uploads")
+		return nil, err
+	}
+	if len(*uploads) == 0 {
+		return nil, ErrNoFiles
+	}
+	if len(*uploads) > s.limits.MaxFiles {
+		return nil, ErrTooManyFiles
+	}
+
+	return uploads, nil
+}
+
+// Write streams uploads to w as a ZIP archive. Entries keep the upload
+// names, repeated names get a " (N)" suffix. Files missing in storage
+// or blocked by the malware scan are skipped; once writing started other
+// errors leave a broken archive.
+func (s *ArchiveService) Write(ctx context.Context, w io.Writer, uploads *entities.Uploads) error {
+	zw := zip.NewWriter(w)
+	taken := make(map[string]bool, len(*uploads))
+
+	for _, upload := range *uploads {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if s.scans != nil {
+			if err := s.scans.CheckDownload(upload.ScanStatus); err != nil {
+				s.log.Warn().Str("uuid", upload.UUID).Err(err).Msg("archive: file skipped")
+				continue
+			}
+		}
+
+		content, err := s.files.Download(ctx, upload.UUID)
+		if err != nil {
+			if errors.Is(err, appFile.ErrFileNotFound) {
+				s.log.Warn().Str("uuid", upload.UUID).Msg("archive: file not found in storage")
+				continue
+			}
+			s.log.Debug().Err(err).Msg("Write1")
+			return err
+		}
+
+		name, ok := entities.ArchiveEntryName(upload.Name)
+		if !ok {
+			name = upload.UUID
+		}
+		header := &zip.FileHeader{
+			Name:     entities.UniqueName(name, taken),
+			Method:   zip.Deflate,
+			Modified: upload.UploadedAt,
+		}
+		entry, err := zw.CreateHeader(header)
+		if err == nil {
+			_, err = io.Copy(entry, content)
+		}
+		content.Close()
+		if err != nil {
+			s.log.Debug().Err(err).Msg("Write2")
+			return err
+		}
+	}
+
+	return zw.Close()
+}
+
+// Import registers files of a ZIP archive as uploads of the user.
+// Directories are flattened: entries are stored in the root folder under
+// their base names, repeated names get a " (N)" suffix, an existing upload
+// with the same name is replaced as on a single upload.
+// An archive with too many files or unpacking to more than MaxTotalSize
+// is rejected as a whole; unsafe, too large or too compressed entries
+// are rejected one by one.
+func (s *ArchiveService) Import(ctx context.Context, userID int64, r io.ReaderAt, size int64, category, description string) (entities.ArchiveEntries, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		s.log.Debug().Err(err).Msg("Import1")
+		return nil, fmt.Errorf("%w: %v", ErrInvalidArchive, err)
+	}
+
+	var (
+		files []*zip.File
+		total uint64
+	)
+	for _, f := range zr.File {
+		if f.FileInfo().IsDir() {
+			continue
+		}
+		files = append(files, f)
+		total += f.UncompressedSize64
+	}
+	if len(files) == 0 {
+		return nil, ErrNoFiles
+	}
+	if len(files) > s.limits.MaxFiles {
+		return nil, ErrTooManyFiles
+	}
+	if total > uint64(s.limits.MaxTotalSize) {
+		return nil, ErrArchiveTooLarge
+	}
+
+	entries := make(entities.ArchiveEntries, len(files))
+	taken := make(map[string]bool, len(files))
+	for i, f := range files {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		entries[i] = entities.ArchiveEntry{Name: f.Name, Status: entities.ArchiveEntryRejected}
+		name, ok := entities.ArchiveEntryName(f.Name)
+		if !ok {
+			entries[i].Reason = "unsafe path"
+			continue
+		}
+		if reason := s.checkEntry(f); reason != "" {
+			entries[i].Reason = reason
+			continue
+		}
+
+		upload, err := s.importEntry(ctx, userID, f, entities.UniqueName(name, taken), category, description)
+		if errors.Is(err, appFile.ErrTypeNotAllowed) {
+			entries[i].Reason = err.Error()
+			continue
+		}
+		if err != nil {
+			s.log.Debug().Err(err).Str("entry", f.Name).Msg("Import2")
+			entries[i].Status = entities.ArchiveEntryFailed
+			entries[i].Reason = err.Error()
+			continue
+		}
+		entries[i].Status = entities.ArchiveEntryOK
+		entries[i].Upload = upload
+	}
+
+	return entries, nil
+}
+
+// checkEntry returns why the entry is rejected, "" if it is accepted.
+// Declared sizes are enforced while reading: archive/zip fails
+// on entries longer than declared.
+func (s *ArchiveService) checkEntry(f *zip.File) string {
+	if f.UncompressedSize64 > uint64(s.limits.MaxFileSize) {
+		return "file is too large"
+	}
+	if f.UncompressedSize64 > ratioThreshold &&
+		(f.CompressedSize64 == 0 || f.UncompressedSize64/f.CompressedSize64 > uint64(s.limits.MaxRatio)) {
+		return "compression ratio is too high"
+	}
+	return ""
+}
+
+func (s *ArchiveService) importEntry(ctx context.Context, userID int64, f *zip.File, name, category, description string) (*entities.Upload, error) {
+	content, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer content.Close()
+
+	// Записи архива загружаются в корень; как при обычной загрузке,
+	// файл с тем же именем становится новой версией существующего
+	existing, err := s.uploads.GetByName(ctx, name, 0, userID)
+	if err != nil && !errors.Is(err, appUpload.ErrFileNotFound) {
+		return nil, err
+	}
+
+	// Тип по расширению служит только подсказкой для определения по содержимому
+	file, err := s.files.Upload(ctx, int64(f.UncompressedSize64), contentType(name), content)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.scans != nil {
+		file.ScanStatus = s.scans.InitialStatus()
+	}
+
+	var upload *entities.Upload
+	if existing != nil {
+		upload, err = s.versions.AddUploadVersion(ctx, existing, userID, file, file.ContentType)
+	} else {
+		upload, err = s.uploads.RegisterUploadedFile(ctx, userID, 0, file, name, category, file.ContentType, description)
+	}
+	if err != nil {
+		if err := s.files.Delete(ctx, file.Name); err != nil {
+			s.log.Debug().Err(err).Msg("importEntry")
+		}
+		return nil, err
+	}
+
+	if s.scans != nil {
+		if err := s.scans.Submit(ctx, upload.UUID); err != nil {
+			s.log.Error().Err(err).Msg("importEntry")
+		}
+	}
+
+	return upload, nil
+}
+
+// contentType guesses the MIME type of an entry by its extension.
+func contentType(name string) string {
+	if t := mime.TypeByExtension(path.Ext(name)); t != "" {
+		return t
+	}
+	return "application/octet-stream"
+}
+
+// FileName is the name of a downloaded archive.
+func FileName(category string, count int) string {
+	if name, ok := entities.ArchiveEntryName(category); ok && category != "" {
+		return name + ".zip"
+	}
+	return "uploads-" + strconv.Itoa(count) + ".zip"
+}
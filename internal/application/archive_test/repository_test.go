@@ -0,0 +1,80 @@
+package archive_test
+
+import (
+	"context"
+	"io"
+
+	"github.com/aube/auth/internal/application/dto"
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/aube/auth/internal/utils/query"
+	"github.com/stretchr/testify/mock"
+)
+
+type FileStore struct {
+	mock.Mock
+}
+
+func (m *FileStore) Delete(ctx context.Context, id string) error {
+	return m.Called(ctx, id).Error(0)
+}
+
+func (m *FileStore) Download(ctx context.Context, uuid string) (io.ReadCloser, error) {
+	args := m.Called(ctx, uuid)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(io.ReadCloser), args.Error(1)
+}
+
+// Upload reads the content, so that archive reading errors surface.
+func (m *FileStore) Upload(ctx context.Context, size int64, declaredType string, data io.Reader) (*entities.File, error) {
+	content, err := io.ReadAll(data)
+	if err != nil {
+		return nil, err
+	}
+	args := m.Called(ctx, size, declaredType, string(content))
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.File), args.Error(1)
+}
+
+type UploadStore struct {
+	mock.Mock
+}
+
+func (m *UploadStore) GetByName(ctx context.Context, name string, folderID int64, userID int64) (*entities.Upload, error) {
+	args := m.Called(ctx, name, folderID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Upload), args.Error(1)
+}
+
+func (m *UploadStore) ListByUserID(ctx context.Context, userID int64, offset, limit int, filter *query.Query) (*entities.Uploads, *dto.Pagination, error) {
+	args := m.Called(ctx, userID, offset, limit, filter)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+	return args.Get(0).(*entities.Uploads), args.Get(1).(*dto.Pagination), args.Error(2)
+}
+
+func (m *UploadStore) RegisterUploadedFile(ctx context.Context, userID int64, folderID int64, file *entities.File, name, category, contentType, description string) (*entities.Upload, error) {
+	args := m.Called(ctx, userID, folderID, file, name, category, contentType, description)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Upload), args.Error(1)
+}
+
+type VersionStore struct {
+	mock.Mock
+}
+
+func (m *VersionStore) AddUploadVersion(ctx context.Context, existing *entities.Upload, userID int64, file *entities.File, contentType string) (*entities.Upload, error) {
+	args := m.Called(ctx, existing, userID, file, contentType)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Upload), args.Error(1)
+}
@@ -0,0 +1,215 @@
+package archive_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	appArchive "github.com/aube/auth/internal/application/archive"
+	"github.com/aube/auth/internal/application/dto"
+	appFile "github.com/aube/auth/internal/application/file"
+	appScan "github.com/aube/auth/internal/application/scan"
+	appUpload "github.com/aube/auth/internal/application/upload"
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/aube/auth/internal/utils/query"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func buildZip(t *testing.T, files map[string]string) *bytes.Reader {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range files {
+		w, err := zw.Create(name)
+		require.NoError(t, err)
+		_, err = w.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, zw.Close())
+	return bytes.NewReader(buf.Bytes())
+}
+
+func TestArchiveService_Uploads_ByCategory(t *testing.T) {
+	files, uploads := new(FileStore), new(UploadStore)
+	service := appArchive.NewArchiveService(files, uploads, nil, nil, appArchive.DefaultLimits)
+
+	uploads.On("ListByUserID", mock.Anything, int64(1), 0, appArchive.DefaultLimits.MaxFiles+1, mock.MatchedBy(func(q *query.Query) bool {
+		return q.SkipTotal && len(q.Filters) == 2 && q.Filters[1] == query.Filter{Field: "category", Op: query.OpEq, Value: "docs"}
+	})).Return(&entities.Uploads{{UUID: "a", Name: "a.txt"}}, &dto.Pagination{}, nil)
+
+	list, err := service.Uploads(context.Background(), 1, "docs", nil)
+
+	require.NoError(t, err)
+	assert.Len(t, *list, 1)
+}
+
+func TestArchiveService_Uploads_Empty(t *testing.T) {
+	files, uploads := new(FileStore), new(UploadStore)
+	service := appArchive.NewArchiveService(files, uploads, nil, nil, appArchive.DefaultLimits)
+
+	uploads.On("ListByUserID", mock.Anything, int64(1), 0, mock.Anything, mock.Anything).
+		Return(&entities.Uploads{}, &dto.Pagination{}, nil)
+
+	_, err := service.Uploads(context.Background(), 1, "", []string{"a"})
+
+	assert.ErrorIs(t, err, appArchive.ErrNoFiles)
+}
+
+func TestArchiveService_Write(t *testing.T) {
+	files, uploads := new(FileStore), new(UploadStore)
+	service := appArchive.NewArchiveService(files, uploads, nil, nil, appArchive.DefaultLimits)
+
+	files.On("Download", mock.Anything, "u1").Return(io.NopCloser(strings.NewReader("one")), nil)
+	files.On("Download", mock.Anything, "u2").Return(io.NopCloser(strings.NewReader("two")), nil)
+	files.On("Download", mock.Anything, "u3").Return(nil, appFile.ErrFileNotFound)
+
+	var buf bytes.Buffer
+	err := service.Write(context.Background(), &buf, &entities.Uploads{
+		{UUID: "u1", Name: "report.pdf", UploadedAt: time.Now()},
+		{UUID: "u3", Name: "lost.txt"},
+		{UUID: "u2", Name: "report.pdf"},
+	})
+	require.NoError(t, err)
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	require.Len(t, zr.File, 2)
+	assert.Equal(t, "report.pdf", zr.File[0].Name)
+	assert.Equal(t, "report (1).pdf", zr.File[1].Name)
+
+	content, err := zr.File[1].Open()
+	require.NoError(t, err)
+	data, _ := io.ReadAll(content)
+	assert.Equal(t, "two", string(data))
+}
+
+func TestArchiveService_Write_SkipsQuarantined(t *testing.T) {
+	files, uploads := new(FileStore), new(UploadStore)
+	service := appArchive.NewArchiveService(files, uploads, nil, appScan.NewScanService(nil, nil, nil, false), appArchive.DefaultLimits)
+
+	files.On("Download", mock.Anything, "u1").Return(io.NopCloser(strings.NewReader("one")), nil)
+
+	var buf bytes.Buffer
+	err := service.Write(context.Background(), &buf, &entities.Uploads{
+		{UUID: "u1", Name: "clean.txt", ScanStatus: entities.ScanStatusClean},
+		{UUID: "u2", Name: "virus.exe", ScanStatus: entities.ScanStatusInfected},
+	})
+	require.NoError(t, err)
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	require.Len(t, zr.File, 1)
+	assert.Equal(t, "clean.txt", zr.File[0].Name)
+	files.AssertNotCalled(t, "Download", mock.Anything, "u2")
+}
+
+func TestArchiveService_Import(t *testing.T) {
+	files, uploads := new(FileStore), new(UploadStore)
+	service := appArchive.NewArchiveService(files, uploads, nil, nil, appArchive.DefaultLimits)
+
+	archive := buildZip(t, map[string]string{
+		"a/notes.pdf":      "first",
+		"../../etc/passwd": "root",
+	})
+
+	file := &entities.File{Name: "f1", Size: 5, ContentType: "application/pdf"}
+	uploads.On("GetByName", mock.Anything, "notes.pdf", int64(0), int64(2)).Return(nil, appUpload.ErrFileNotFound)
+	files.On("Upload", mock.Anything, int64(5), "application/pdf", "first").Return(file, nil)
+	uploads.On("RegisterUploadedFile", mock.Anything, int64(2), int64(0), file, "notes.pdf", "docs", "application/pdf", "").
+		Return(&entities.Upload{UUID: "f1", Name: "notes.pdf"}, nil)
+
+	entries, err := service.Import(context.Background(), 2, archive, archive.Size(), "docs", "")
+
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	statuses := map[string]string{}
+	for _, entry := range entries {
+		statuses[entry.Name] = entry.Status
+	}
+	assert.Equal(t, entities.ArchiveEntryOK, statuses["a/notes.pdf"])
+	assert.Equal(t, entities.ArchiveEntryRejected, statuses["../../etc/passwd"])
+	files.AssertNumberOfCalls(t, "Upload", 1)
+}
+
+func TestArchiveService_Import_NewVersion(t *testing.T) {
+	files, uploads, versions := new(FileStore), new(UploadStore), new(VersionStore)
+	service := appArchive.NewArchiveService(files, uploads, versions, nil, appArchive.DefaultLimits)
+
+	archive := buildZip(t, map[string]string{"notes.txt": "second"})
+
+	file := &entities.File{Name: "f2", Size: 6, ContentType: "text/plain; charset=utf-8"}
+	existing := &entities.Upload{ID: 7, UUID: "f1", Name: "notes.txt", Category: "docs", Version: 1}
+	uploads.On("GetByName", mock.Anything, "notes.txt", int64(0), int64(2)).Return(existing, nil)
+	files.On("Upload", mock.Anything, int64(6), "text/plain; charset=utf-8", "second").Return(file, nil)
+	versions.On("AddUploadVersion", mock.Anything, existing, int64(2), file, "text/plain; charset=utf-8").
+		Return(&entities.Upload{ID: 7, UUID: "f2", Name: "notes.txt", Category: "docs", Version: 2}, nil)
+
+	entries, err := service.Import(context.Background(), 2, archive, archive.Size(), "", "")
+
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Equal(t, entities.ArchiveEntryOK, entries[0].Status)
+	assert.Equal(t, "f2", entries[0].Upload.UUID)
+	assert.Equal(t, 2, entries[0].Upload.Version)
+	assert.Equal(t, "docs", entries[0].Upload.Category)
+	uploads.AssertNotCalled(t, "RegisterUploadedFile", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	files.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+}
+
+func TestArchiveService_Import_TypeNotAllowed(t *testing.T) {
+	files, uploads := new(FileStore), new(UploadStore)
+	service := appArchive.NewArchiveService(files, uploads, nil, nil, appArchive.DefaultLimits)
+
+	archive := buildZip(t, map[string]string{"page.html": "<html>"})
+
+	uploads.On("GetByName", mock.Anything, "page.html", int64(0), int64(2)).Return(nil, appUpload.ErrFileNotFound)
+	files.On("Upload", mock.Anything, int64(6), mock.Anything, "<html>").
+		Return(nil, fmt.Errorf("%w: text/html", appFile.ErrTypeNotAllowed))
+
+	entries, err := service.Import(context.Background(), 2, archive, archive.Size(), "", "")
+
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, entities.ArchiveEntryRejected, entries[0].Status)
+	assert.Contains(t, entries[0].Reason, "text/html")
+}
+
+func TestArchiveService_Import_Limits(t *testing.T) {
+	files, uploads := new(FileStore), new(UploadStore)
+	limits := appArchive.Limits{MaxFiles: 1, MaxFileSize: 1 << 30, MaxTotalSize: 1 << 30, MaxRatio: 100}
+	service := appArchive.NewArchiveService(files, uploads, nil, nil, limits)
+
+	archive := buildZip(t, map[string]string{"a.txt": "a", "b.txt": "b"})
+	_, err := service.Import(context.Background(), 2, archive, archive.Size(), "", "")
+	assert.ErrorIs(t, err, appArchive.ErrTooManyFiles)
+
+	limits.MaxFiles, limits.MaxTotalSize = 10, 1
+	service = appArchive.NewArchiveService(files, uploads, nil, nil, limits)
+	_, err = service.Import(context.Background(), 2, archive, archive.Size(), "", "")
+	assert.ErrorIs(t, err, appArchive.ErrArchiveTooLarge)
+
+	_, err = service.Import(context.Background(), 2, strings.NewReader("not a zip"), 9, "", "")
+	assert.ErrorIs(t, err, appArchive.ErrInvalidArchive)
+}
+
+func TestArchiveService_Import_CompressionRatio(t *testing.T) {
+	files, uploads := new(FileStore), new(UploadStore)
+	service := appArchive.NewArchiveService(files, uploads, nil, nil, appArchive.DefaultLimits)
+
+	// 4 МБ нулей сжимаются примерно в тысячу раз
+	archive := buildZip(t, map[string]string{"zeros.bin": strings.Repeat("\x00", 4<<20)})
+
+	entries, err := service.Import(context.Background(), 2, archive, archive.Size(), "", "")
+
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, entities.ArchiveEntryRejected, entries[0].Status)
+	files.AssertNotCalled(t, "Upload", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
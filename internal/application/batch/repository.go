@@ -0,0 +1,37 @@
+// Package batch applies one action to many pages, uploads or images
+// and reports the result per item.
+package batch
+
+import (
+	"context"
+	"errors"
+)
+
+var (
+	// ErrBatchTooLarge is returned when a batch has more than MaxBatchSize items.
+	ErrBatchTooLarge = errors.New("too many items in batch")
+	ErrEmptyBatch    = errors.New("no items in batch")
+)
+
+// BatchRepository applies batch actions. Each method runs in one
+// transaction and returns the keys of the items it changed; items that
+// are missing, belong to another user or are not in the required state
+// are skipped. fileType is "upload" or "image".
+//
+// Methods:
+//
+//   - DeleteFiles: Moves live files to the trash
+//   - MoveFiles: Sets the category of live files
+//   - TagFiles: Adds tags to live files, keeping their other tags
+//   - RestoreFiles: Restores files from the trash; files whose name is taken
+//     by a live file are returned as conflicts
+//   - SetPagesPublished: Publishes or unpublishes live pages, clearing their schedule
+//   - DeletePages: Moves live pages to the trash
+type BatchRepository interface {
+	DeleteFiles(ctx context.Context, fileType string, userID int64, uuids []string) ([]string, error)
+	MoveFiles(ctx context.Context, fileType string, userID int64, uuids []string, category string) ([]string, error)
+	TagFiles(ctx context.Context, fileType string, userID int64, uuids []string, tags []string) ([]string, error)
+	RestoreFiles(ctx context.Context, fileType string, userID int64, uuids []string) (restored []string, conflicts []string, err error)
+	SetPagesPublished(ctx context.Context, ids []int64, published bool) ([]int64, error)
+	DeletePages(ctx context.Context, ids []int64) ([]int64, error)
+}
@@ -0,0 +1,191 @@
+package batch
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"sync"
+
+	"github.com/aube/auth/internal/application/dto"
+	appTag "github.com/aube/auth/internal/application/tag"
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/aube/auth/internal/utils/logger"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+)
+
+// MaxBatchSize limits items of one batch.
+const MaxBatchSize = 500
+
+type BatchService struct {
+	repo BatchRepository
+	log  zerolog.Logger
+
+	mu        sync.RWMutex
+	listeners []func()
+}
+
+func NewBatchService(repo BatchRepository) *BatchService {
+	return &BatchService{
+		repo: repo,
+		log:  logger.Get().With().Str("batch", "service").Logger(),
+	}
+}
+
+// OnChange registers fn to be called after a batch changed pages.
+// Used by caches built from pages (menus).
+func (s *BatchService) OnChange(fn func()) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.listeners = append(s.listeners, fn)
+}
+
+func (s *BatchService) notifyChange() {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, fn := range s.listeners {
+		fn()
+	}
+}
+
+// Execute applies the action to the requested items of the user.
+// Results follow the order of the request; a failed item does not stop
+// the others, only a storage error fails the whole batch.
+func (s *BatchService) Execute(ctx context.Context, userID int64, req dto.BatchRequest) (entities.BatchResults, error) {
+	if !entities.IsValidBatchAction(req.Type, req.Action) {
+		return nil, fmt.Errorf("%w: %s of %s", entities.ErrInvalidBatchAction, req.Action, req.Type)
+	}
+	if req.Type == entities.BatchTypePage {
+		return s.executePages(ctx, req)
+	}
+	return s.executeFiles(ctx, userID, req)
+}
+
+func (s *BatchService) executePages(ctx context.Context, req dto.BatchRequest) (entities.BatchResults, error) {
+	if err := checkSize(len(req.IDs)); err != nil {
+		return nil, err
+	}
+
+	results := make(entities.BatchResults, len(req.IDs))
+	var ids []int64
+	for i, id := range req.IDs {
+		results[i] = entities.BatchResult{ID: id, Status: entities.BatchStatusInvalid}
+		if id > 0 && !slices.Contains(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+
+	var (
+		changed []int64
+		err     error
+	)
+	if len(ids) > 0 {
+		switch req.Action {
+		case entities.BatchActionPublish, entities.BatchActionUnpublish:
+			changed, err = s.repo.SetPagesPublished(ctx, ids, req.Action == entities.BatchActionPublish)
+		case entities.BatchActionDelete:
+			changed, err = s.repo.DeletePages(ctx, ids)
+		}
+		if err != nil {
+			s.log.Debug().Err(err).Msg("executePages")
+			return nil, err
+		}
+	}
+
+	for i := range results {
+		switch {
+		case results[i].ID <= 0:
+		case slices.Contains(changed, results[i].ID):
+			results[i].Status = entities.BatchStatusOK
+		default:
+			results[i].Status = entities.BatchStatusNotFound
+		}
+	}
+
+	if len(changed) > 0 {
+		s.notifyChange()
+	}
+	s.log.Debug().Msg("BATCH " + req.Action + " pages: " + strconv.Itoa(len(changed)) + "/" + strconv.Itoa(len(results)))
+	return results, nil
+}
+
+func (s *BatchService) executeFiles(ctx context.Context, userID int64, req dto.BatchRequest) (entities.BatchResults, error) {
+	if err := checkSize(len(req.UUIDs)); err != nil {
+		return nil, err
+	}
+
+	var tags []string
+	if req.Action == entities.BatchActionTag {
+		var err error
+		if tags, err = entities.NormalizeTags(req.Tags); err != nil {
+			return nil, err
+		}
+		if len(tags) == 0 {
+			return nil, entities.ErrInvalidTag
+		}
+		if len(tags) > appTag.MaxItemTags {
+			return nil, appTag.ErrTooManyTags
+		}
+	}
+
+	results := make(entities.BatchResults, len(req.UUIDs))
+	var uuids []string
+	for i, value := range req.UUIDs {
+		results[i] = entities.BatchResult{UUID: value, Status: entities.BatchStatusInvalid}
+		parsed, err := uuid.Parse(value)
+		if err != nil {
+			continue
+		}
+		results[i].UUID = parsed.String()
+		if !slices.Contains(uuids, results[i].UUID) {
+			uuids = append(uuids, results[i].UUID)
+		}
+	}
+
+	var (
+		changed, conflicts []string
+		err                error
+	)
+	if len(uuids) > 0 {
+		switch req.Action {
+		case entities.BatchActionDelete:
+			changed, err = s.repo.DeleteFiles(ctx, req.Type, userID, uuids)
+		case entities.BatchActionMove:
+			changed, err = s.repo.MoveFiles(ctx, req.Type, userID, uuids, req.Category)
+		case entities.BatchActionTag:
+			changed, err = s.repo.TagFiles(ctx, req.Type, userID, uuids, tags)
+		case entities.BatchActionRestore:
+			changed, conflicts, err = s.repo.RestoreFiles(ctx, req.Type, userID, uuids)
+		}
+		if err != nil {
+			s.log.Debug().Err(err).Msg("executeFiles")
+			return nil, err
+		}
+	}
+
+	for i := range results {
+		switch {
+		case results[i].Status == entities.BatchStatusInvalid && !slices.Contains(uuids, results[i].UUID):
+		case slices.Contains(changed, results[i].UUID):
+			results[i].Status = entities.BatchStatusOK
+		case slices.Contains(conflicts, results[i].UUID):
+			results[i].Status = entities.BatchStatusConflict
+		default:
+			results[i].Status = entities.BatchStatusNotFound
+		}
+	}
+
+	s.log.Debug().Msg("BATCH " + req.Action + " " + req.Type + "s: " + strconv.Itoa(len(changed)) + "/" + strconv.Itoa(len(results)))
+	return results, nil
+}
+
+func checkSize(n int) error {
+	if n == 0 {
+		return ErrEmptyBatch
+	}
+	if n > MaxBatchSize {
+		return fmt.Errorf("%w: %d, max %d", ErrBatchTooLarge, n, MaxBatchSize)
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package batch_test
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type BatchRepository struct {
+	mock.Mock
+}
+
+func (m *BatchRepository) DeleteFiles(ctx context.Context, fileType string, userID int64, uuids []string) ([]string, error) {
+	args := m.Called(ctx, fileType, userID, uuids)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *BatchRepository) MoveFiles(ctx context.Context, fileType string, userID int64, uuids []string, category string) ([]string, error) {
+	args := m.Called(ctx, fileType, userID, uuids, category)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *BatchRepository) TagFiles(ctx context.Context, fileType string, userID int64, uuids []string, tags []string) ([]string, error) {
+	args := m.Called(ctx, fileType, userID, uuids, tags)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *BatchRepository) RestoreFiles(ctx context.Context, fileType string, userID int64, uuids []string) ([]string, []string, error) {
+	args := m.Called(ctx, fileType, userID, uuids)
+	return args.Get(0).([]string), args.Get(1).([]string), args.Error(2)
+}
+
+func (m *BatchRepository) SetPagesPublished(ctx context.Context, ids []int64, published bool) ([]int64, error) {
+	args := m.Called(ctx, ids, published)
+	return args.Get(0).([]int64), args.Error(1)
+}
+
+func (m *BatchRepository) DeletePages(ctx context.Context, ids []int64) ([]int64, error) {
+	args := m.Called(ctx, ids)
+	return args.Get(0).([]int64), args.Error(1)
+}
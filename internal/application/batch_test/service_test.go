@@ -0,0 +1,145 @@
+package batch_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	appBatch "github.com/aube/auth/internal/application/batch"
+	"github.com/aube/auth/internal/application/dto"
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	uuid1 = "0b6f5a3e-8d7c-4e1a-9f2b-3c4d5e6f7a8b"
+	uuid2 = "1c7a6b4f-9e8d-4f2b-8a3c-4d5e6f7a8b9c"
+)
+
+func TestBatchService_InvalidAction(t *testing.T) {
+	mockRepo := new(BatchRepository)
+	service := appBatch.NewBatchService(mockRepo)
+
+	_, err := service.Execute(context.Background(), 1, dto.BatchRequest{Type: entities.BatchTypePage, Action: entities.BatchActionMove, IDs: []int64{1}})
+	assert.ErrorIs(t, err, entities.ErrInvalidBatchAction)
+
+	_, err = service.Execute(context.Background(), 1, dto.BatchRequest{Type: "node", Action: entities.BatchActionDelete, IDs: []int64{1}})
+	assert.ErrorIs(t, err, entities.ErrInvalidBatchAction)
+}
+
+func TestBatchService_Size(t *testing.T) {
+	mockRepo := new(BatchRepository)
+	service := appBatch.NewBatchService(mockRepo)
+
+	_, err := service.Execute(context.Background(), 1, dto.BatchRequest{Type: entities.BatchTypeUpload, Action: entities.BatchActionDelete})
+	assert.ErrorIs(t, err, appBatch.ErrEmptyBatch)
+
+	_, err = service.Execute(context.Background(), 1, dto.BatchRequest{
+		Type:   entities.BatchTypePage,
+		Action: entities.BatchActionDelete,
+		IDs:    make([]int64, appBatch.MaxBatchSize+1),
+	})
+	assert.ErrorIs(t, err, appBatch.ErrBatchTooLarge)
+}
+
+func TestBatchService_DeleteFiles_Results(t *testing.T) {
+	mockRepo := new(BatchRepository)
+	service := appBatch.NewBatchService(mockRepo)
+
+	mockRepo.On("DeleteFiles", mock.Anything, entities.BatchTypeUpload, int64(3), []string{uuid1, uuid2}).Return([]string{uuid1}, nil)
+
+	results, err := service.Execute(context.Background(), 3, dto.BatchRequest{
+		Type:   entities.BatchTypeUpload,
+		Action: entities.BatchActionDelete,
+		UUIDs:  []string{"0B6F5A3E-8D7C-4E1A-9F2B-3C4D5E6F7A8B", "bad", uuid2, uuid1},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, entities.BatchResults{
+		{UUID: uuid1, Status: entities.BatchStatusOK},
+		{UUID: "bad", Status: entities.BatchStatusInvalid},
+		{UUID: uuid2, Status: entities.BatchStatusNotFound},
+		{UUID: uuid1, Status: entities.BatchStatusOK},
+	}, results)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestBatchService_RestoreFiles_Conflict(t *testing.T) {
+	mockRepo := new(BatchRepository)
+	service := appBatch.NewBatchService(mockRepo)
+
+	mockRepo.On("RestoreFiles", mock.Anything, entities.BatchTypeImage, int64(3), []string{uuid1, uuid2}).
+		Return([]string{uuid2}, []string{uuid1}, nil)
+
+	results, err := service.Execute(context.Background(), 3, dto.BatchRequest{
+		Type:   entities.BatchTypeImage,
+		Action: entities.BatchActionRestore,
+		UUIDs:  []string{uuid1, uuid2},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, entities.BatchStatusConflict, results[0].Status)
+	assert.Equal(t, entities.BatchStatusOK, results[1].Status)
+}
+
+func TestBatchService_TagFiles_NormalizesTags(t *testing.T) {
+	mockRepo := new(BatchRepository)
+	service := appBatch.NewBatchService(mockRepo)
+
+	mockRepo.On("TagFiles", mock.Anything, entities.BatchTypeUpload, int64(3), []string{uuid1}, []string{"summer-sale"}).
+		Return([]string{uuid1}, nil)
+
+	_, err := service.Execute(context.Background(), 3, dto.BatchRequest{
+		Type:   entities.BatchTypeUpload,
+		Action: entities.BatchActionTag,
+		UUIDs:  []string{uuid1},
+		Tags:   []string{"Summer Sale"},
+	})
+	require.NoError(t, err)
+
+	_, err = service.Execute(context.Background(), 3, dto.BatchRequest{
+		Type:   entities.BatchTypeUpload,
+		Action: entities.BatchActionTag,
+		UUIDs:  []string{uuid1},
+	})
+	assert.ErrorIs(t, err, entities.ErrInvalidTag)
+	mockRepo.AssertNumberOfCalls(t, "TagFiles", 1)
+}
+
+func TestBatchService_PublishPages_NotifiesChange(t *testing.T) {
+	mockRepo := new(BatchRepository)
+	service := appBatch.NewBatchService(mockRepo)
+
+	changed := 0
+	service.OnChange(func() { changed++ })
+
+	mockRepo.On("SetPagesPublished", mock.Anything, []int64{5, 6}, true).Return([]int64{6}, nil)
+
+	results, err := service.Execute(context.Background(), 1, dto.BatchRequest{
+		Type:   entities.BatchTypePage,
+		Action: entities.BatchActionPublish,
+		IDs:    []int64{5, 6, 0},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, entities.BatchResults{
+		{ID: 5, Status: entities.BatchStatusNotFound},
+		{ID: 6, Status: entities.BatchStatusOK},
+		{ID: 0, Status: entities.BatchStatusInvalid},
+	}, results)
+	assert.Equal(t, 1, changed)
+}
+
+func TestBatchService_RepositoryError(t *testing.T) {
+	mockRepo := new(BatchRepository)
+	service := appBatch.NewBatchService(mockRepo)
+	dbErr := errors.New("db down")
+
+	mockRepo.On("DeletePages", mock.Anything, []int64{5}).Return([]int64(nil), dbErr)
+
+	_, err := service.Execute(context.Background(), 1, dto.BatchRequest{Type: entities.BatchTypePage, Action: entities.BatchActionDelete, IDs: []int64{5}})
+
+	assert.ErrorIs(t, err, dbErr)
+}
@@ -0,0 +1,47 @@
+// Package bundle exports pages into portable archives and imports them.
+package bundle
+
+import (
+	"context"
+	"errors"
+
+	"github.com/aube/auth/internal/domain/entities"
+)
+
+var (
+	ErrNothingToExport   = errors.New("no pages selected for export")
+	ErrInvalidBundle     = errors.New("invalid bundle")
+	ErrUnsupportedBundle = errors.New("unsupported bundle version")
+	ErrInvalidStrategy   = errors.New("unknown import strategy")
+)
+
+// BundleRepository reads and writes pages with their revisions,
+// translations and attached images.
+//
+// Methods:
+//
+//   - SubtreePageIDs: Pages bound to the node or its descendants, in tree order
+//   - ExportPage: Page with revisions, translations and images
+//   - FindPageID: Id of the page with the name, 0 when there is none
+//   - TranslationNameTaken: Whether another page than pageID uses the name in the locale
+//   - ImageExists: Whether an image with the UUID is stored
+//   - ContentTypeExists: Whether the content type of typed pages is defined
+//   - ImportPage: Stores the page in one transaction; with targetID it replaces
+//     that page, its revisions, translations and images. Images missing on the
+//     site are registered for userID. Returns the page id
+type BundleRepository interface {
+	SubtreePageIDs(ctx context.Context, nodeID int64) ([]int64, error)
+	ExportPage(ctx context.Context, id int64) (*entities.BundlePage, error)
+	FindPageID(ctx context.Context, name string) (int64, error)
+	TranslationNameTaken(ctx context.Context, locale string, name string, pageID int64) (bool, error)
+	ImageExists(ctx context.Context, uuid string) (bool, error)
+	ContentTypeExists(ctx context.Context, name string) (bool, error)
+	ImportPage(ctx context.Context, page *entities.BundlePage, targetID int64, userID int64) (int64, error)
+}
+
+// FieldValidator checks field values of typed pages against their content
+// type the way pages saved by editors are checked; images in stored are
+// taken as existing.
+type FieldValidator interface {
+	ValidateFields(ctx context.Context, page *entities.Page, stored ...string) error
+}
@@ -0,0 +1,559 @@
+package bundle
+
+import (
+	"archive/zip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aube/auth/internal/application/dto"
+	appFile "github.com/aube/auth/internal/application/file"
+	"github.com/aube/auth/internal/application/render"
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/aube/auth/internal/domain/valueobjects"
+	"github.com/aube/auth/internal/utils/logger"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+)
+
+const (
+	manifestName = "manifest.json"
+	blobDir      = "blobs/"
+	// maxManifestSize limits the manifest read from an uploaded bundle.
+	maxManifestSize = 64 << 20
+	// maxNameSuffix limits the numeric suffixes tried when renaming.
+	maxNameSuffix = 100
+)
+
+// BundleService moves pages between sites.
+// A bundle is a ZIP archive: manifest.json (entities.BundleManifest) and
+// the attached image files as blobs/<uuid>.
+type BundleService struct {
+	repo     BundleRepository
+	files    appFile.FileRepository
+	fields   FieldValidator
+	renderer *render.Renderer
+	log      zerolog.Logger
+
+	mu        sync.RWMutex
+	listeners []func()
+}
+
+// NewBundleService creates the service.
+// files: Storage of image files
+// fields: Checks field values of imported typed pages
+func NewBundleService(repo BundleRepository, files appFile.FileRepository, fields FieldValidator) *BundleService {
+	return &BundleService{
+		repo:     repo,
+		files:    files,
+		fields:   fields,
+		renderer: render.NewRenderer(),
+		log:      logger.Get().With().Str("bundle", "service").Logger(),
+	}
+}
+
+// OnChange registers fn to be called after an import changed pages.
+func (s *BundleService) OnChange(fn func()) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.listeners = append(s.listeners, fn)
+}
+
+func (s *BundleService) notifyChange() {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, fn := range s.listeners {
+		fn()
+	}
+}
+
+// Export writes a bundle with the selected pages to w.
+// Returns the written manifest.
+func (s *BundleService) Export(ctx context.Context, w io.Writer, req dto.BundleExportRequest) (*entities.BundleManifest, error) {
+	ids, err := s.selectPages(ctx, req)
+	if err != nil {
+		s.log.Debug().Err(err).Msg("Export1")
+		return nil, err
+	}
+
+	manifest := &entities.BundleManifest{
+		Format:    entities.BundleFormat,
+		Version:   entities.BundleVersion,
+		CreatedAt: time.Now().UTC(),
+		Pages:     make([]entities.BundlePage, 0, len(ids)),
+	}
+
+	zw := zip.NewWriter(w)
+	// Файл изображения, прикреплённого к нескольким страницам, пишется один раз
+	written := make(map[string]entities.BundleImage)
+	for _, id := range ids {
+		page, err := s.repo.ExportPage(ctx, id)
+		if err != nil {
+			s.log.Debug().Err(err).Int64("page", id).Msg("Export2")
+			return nil, err
+		}
+
+		for i := range page.Images {
+			img := &page.Images[i]
+			if blob, ok := written[img.UUID]; ok {
+				img.SHA256, img.Size = blob.SHA256, blob.Size
+				continue
+			}
+			if err := s.writeBlob(ctx, zw, img); err != nil {
+				s.log.Debug().Err(err).Str("image", img.UUID).Msg("Export3")
+				return nil, err
+			}
+			written[img.UUID] = *img
+		}
+		manifest.Pages = append(manifest.Pages, *page)
+	}
+
+	mw, err := zw.Create(manifestName)
+	if err != nil {
+		return nil, err
+	}
+	enc := json.NewEncoder(mw)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(manifest); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+
+	return manifest, nil
+}
+
+// Import reads a bundle and stores its pages.
+// Pages are imported one by one: a page that cannot be stored is reported
+// as failed and does not stop the others. Errors are returned only for
+// a bundle that cannot be read.
+func (s *BundleService) Import(ctx context.Context, r io.ReaderAt, size int64, req dto.BundleImportRequest) (*entities.ImportReport, error) {
+	if req.Strategy == "" {
+		req.Strategy = entities.ImportSkip
+	}
+	if !entities.IsValidImportStrategy(req.Strategy) {
+		return nil, ErrInvalidStrategy
+	}
+
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		s.log.Debug().Err(err).Msg("Import1")
+		return nil, fmt.Errorf("%w: %v", ErrInvalidBundle, err)
+	}
+	manifest, blobs, err := readManifest(zr)
+	if err != nil {
+		s.log.Debug().Err(err).Msg("Import2")
+		return nil, err
+	}
+
+	run := &importRun{
+		req:    req,
+		blobs:  blobs,
+		claims: make(map[string]bool),
+		stored: make(map[string]bool),
+		report: &entities.ImportReport{
+			DryRun:  req.DryRun,
+			Version: manifest.Version,
+			Pages:   make([]entities.ImportResult, 0, len(manifest.Pages)),
+		},
+	}
+
+	changed := false
+	for i := range manifest.Pages {
+		res := s.importPage(ctx, run, &manifest.Pages[i])
+		switch res.Action {
+		case entities.ImportActionFail:
+			s.log.Debug().Str("page", res.Name).Str("error", res.Error).Msg("Import3")
+			run.report.Failed++
+		case entities.ImportActionSkip:
+		default:
+			changed = !req.DryRun
+		}
+		run.report.Pages = append(run.report.Pages, res)
+	}
+
+	if changed {
+		s.notifyChange()
+	}
+	return run.report, nil
+}
+
+// importRun is the state of one import.
+// claims: Page and translation names taken by pages imported earlier,
+// needed because a dry run does not write them
+// stored: Image files known to be on the site
+type importRun struct {
+	req    dto.BundleImportRequest
+	blobs  map[string]*zip.File
+	claims map[string]bool
+	stored map[string]bool
+	report *entities.ImportReport
+}
+
+func (s *BundleService) importPage(ctx context.Context, run *importRun, page *entities.BundlePage) entities.ImportResult {
+	res := entities.ImportResult{Name: page.Name, TargetName: page.Name}
+	fail := func(err error) entities.ImportResult {
+		res.Action = entities.ImportActionFail
+		res.Error = err.Error()
+		return res
+	}
+
+	existing, err := s.repo.FindPageID(ctx, page.Name)
+	if err != nil {
+		return fail(err)
+	}
+
+	var targetID int64
+	switch {
+	case existing == 0 && !run.claims[pageClaim(page.Name)]:
+		res.Action = entities.ImportActionCreate
+	case run.req.Strategy == entities.ImportSkip:
+		res.Action = entities.ImportActionSkip
+		res.PageID = existing
+		return res
+	case run.req.Strategy == entities.ImportOverwrite && existing > 0:
+		res.Action = entities.ImportActionOverwrite
+		res.PageID = existing
+		targetID = existing
+	default:
+		name, err := s.freeName(page.Name, func(name string) (bool, error) {
+			if run.claims[pageClaim(name)] {
+				return true, nil
+			}
+			id, err := s.repo.FindPageID(ctx, name)
+			return id > 0, err
+		})
+		if err != nil {
+			return fail(err)
+		}
+		res.Action = entities.ImportActionRename
+		res.TargetName = name
+	}
+
+	// Типы страниц не переносятся: тип должен быть заведён на сайте заранее
+	if page.ContentType != "" {
+		exists, err := s.repo.ContentTypeExists(ctx, page.ContentType)
+		if err != nil {
+			return fail(err)
+		}
+		if !exists {
+			return fail(errors.New("content type " + page.ContentType + " not found"))
+		}
+	}
+
+	target := *page
+	target.Name = res.TargetName
+	target.Translations, err = s.translationNames(ctx, run, page.Translations, targetID)
+	if err != nil {
+		return fail(err)
+	}
+	if err := s.prepare(ctx, &target, targetID); err != nil {
+		return fail(err)
+	}
+
+	var missing []entities.BundleImage
+	for _, img := range page.Images {
+		if run.stored[img.UUID] {
+			continue
+		}
+		exists, err := s.repo.ImageExists(ctx, img.UUID)
+		if err != nil {
+			return fail(err)
+		}
+		if !exists {
+			missing = append(missing, img)
+		}
+	}
+
+	if !run.req.DryRun {
+		id, err := s.storePage(ctx, run, &target, targetID, missing)
+		if err != nil {
+			return fail(err)
+		}
+		res.PageID = id
+	}
+
+	run.claims[pageClaim(target.Name)] = true
+	for _, t := range target.Translations {
+		run.claims[translationClaim(t.Locale, t.Name)] = true
+	}
+	for _, img := range missing {
+		run.stored[img.UUID] = true
+	}
+	run.report.Images += len(missing)
+	return res
+}
+
+// prepare renders the content of the page and its translations and checks
+// its field values: HTML and fields from a bundle are not trusted.
+func (s *BundleService) prepare(ctx context.Context, page *entities.BundlePage, targetID int64) error {
+	var err error
+	page.ContentHTML, page.ContentShort, err = s.render(page.ContentFormat, page.Content, page.ContentShort)
+	if err != nil {
+		return err
+	}
+	for i := range page.Translations {
+		t := &page.Translations[i]
+		if t.ContentFormat == "" {
+			t.ContentFormat = entities.ContentFormatHTML
+		}
+		if t.ContentHTML, t.ContentShort, err = s.render(t.ContentFormat, t.Content, t.ContentShort); err != nil {
+			return err
+		}
+	}
+
+	if page.ContentType == "" {
+		return nil
+	}
+	typed := &entities.Page{ID: targetID, ContentType: page.ContentType, Fields: page.Fields}
+	images := make([]string, len(page.Images))
+	for i, img := range page.Images {
+		images[i] = img.UUID
+	}
+	if err := s.fields.ValidateFields(ctx, typed, images...); err != nil {
+		return err
+	}
+	page.Fields = typed.Fields
+	return nil
+}
+
+// render returns the sanitized HTML of the content and the short content,
+// generated from the HTML when short is empty.
+func (s *BundleService) render(format, content, short string) (string, string, error) {
+	contentHTML, err := s.renderer.Render(format, content)
+	if err != nil {
+		return "", "", err
+	}
+	if short == "" {
+		short = s.renderer.Excerpt(contentHTML, render.ExcerptLength)
+	}
+	return contentHTML, short, nil
+}
+
+// storePage writes missing image files and the page; files written for
+// a page that then fails to be stored are removed.
+func (s *BundleService) storePage(ctx context.Context, run *importRun, page *entities.BundlePage, targetID int64, missing []entities.BundleImage) (int64, error) {
+	var written []string
+	cleanup := func() {
+		for _, id := range written {
+			if err := s.files.Delete(ctx, id); err != nil {
+				s.log.Error().Err(err).Str("image", id).Msg("storePage")
+			}
+		}
+	}
+
+	for _, img := range missing {
+		if err := s.storeBlob(ctx, run.blobs[img.UUID], img); err != nil {
+			cleanup()
+			return 0, err
+		}
+		written = append(written, img.UUID)
+	}
+
+	id, err := s.repo.ImportPage(ctx, page, targetID, run.req.UserID)
+	if err != nil {
+		cleanup()
+		return 0, err
+	}
+	return id, nil
+}
+
+// translationNames checks that translation names are free in their locales.
+// With the rename strategy taken names get a suffix, otherwise they fail the page.
+func (s *BundleService) translationNames(ctx context.Context, run *importRun, translations []entities.BundleTranslation, pageID int64) ([]entities.BundleTranslation, error) {
+	res := make([]entities.BundleTranslation, len(translations))
+	for i, t := range translations {
+		taken := func(name string) (bool, error) {
+			if run.claims[translationClaim(t.Locale, name)] {
+				return true, nil
+			}
+			return s.repo.TranslationNameTaken(ctx, t.Locale, name, pageID)
+		}
+
+		busy, err := taken(t.Name)
+		if err != nil {
+			return nil, err
+		}
+		if busy {
+			if run.req.Strategy != entities.ImportRename {
+				return nil, errors.New("translation name is taken: " + t.Locale + "/" + t.Name)
+			}
+			if t.Name, err = s.freeName(t.Name, taken); err != nil {
+				return nil, err
+			}
+		}
+		res[i] = t
+	}
+	return res, nil
+}
+
+// freeName returns name with the first numeric suffix that is not taken.
+func (s *BundleService) freeName(name string, taken func(string) (bool, error)) (string, error) {
+	slug, err := valueobjects.NewSlug(name)
+	if err != nil {
+		return "", err
+	}
+
+	for n := 2; n <= maxNameSuffix; n++ {
+		candidate := slug.WithSuffix(n).String()
+		busy, err := taken(candidate)
+		if err != nil {
+			return "", err
+		}
+		if !busy {
+			return candidate, nil
+		}
+	}
+
+	return "", errors.New("cannot find a free name for " + name)
+}
+
+// selectPages returns ids of the listed pages followed by the pages of the
+// node subtree, without duplicates.
+func (s *BundleService) selectPages(ctx context.Context, req dto.BundleExportRequest) ([]int64, error) {
+	ids := append([]int64(nil), req.PageIDs...)
+	if req.NodeID > 0 {
+		subtree, err := s.repo.SubtreePageIDs(ctx, req.NodeID)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, subtree...)
+	}
+
+	seen := make(map[int64]bool, len(ids))
+	res := ids[:0]
+	for _, id := range ids {
+		if id > 0 && !seen[id] {
+			seen[id] = true
+			res = append(res, id)
+		}
+	}
+
+	if len(res) == 0 {
+		return nil, ErrNothingToExport
+	}
+	return res, nil
+}
+
+// writeBlob copies an image file into the archive, filling its checksum and size.
+func (s *BundleService) writeBlob(ctx context.Context, zw *zip.Writer, img *entities.BundleImage) error {
+	src, err := s.files.GetFileContent(ctx, img.UUID)
+	if err != nil {
+		return fmt.Errorf("image %s: %w", img.UUID, err)
+	}
+	defer src.Close()
+
+	// Изображения уже сжаты
+	dst, err := zw.CreateHeader(&zip.FileHeader{Name: blobDir + img.UUID, Method: zip.Store})
+	if err != nil {
+		return err
+	}
+
+	h := sha256.New()
+	n, err := io.Copy(io.MultiWriter(dst, h), src)
+	if err != nil {
+		return fmt.Errorf("image %s: %w", img.UUID, err)
+	}
+
+	img.SHA256 = hex.EncodeToString(h.Sum(nil))
+	img.Size = n
+	return nil
+}
+
+// storeBlob saves an image file from the archive under its UUID and
+// verifies its checksum; a damaged file is removed.
+func (s *BundleService) storeBlob(ctx context.Context, f *zip.File, img entities.BundleImage) error {
+	src, err := f.Open()
+	if err != nil {
+		return fmt.Errorf("%w: image %s: %v", ErrInvalidBundle, img.UUID, err)
+	}
+	defer src.Close()
+
+	h := sha256.New()
+	file := entities.NewFile(img.UUID, "", img.Size)
+	if err := s.files.Save(ctx, file, io.TeeReader(io.LimitReader(src, img.Size+1), h)); err != nil {
+		return err
+	}
+
+	if hex.EncodeToString(h.Sum(nil)) != img.SHA256 {
+		if err := s.files.Delete(ctx, img.UUID); err != nil {
+			s.log.Error().Err(err).Str("image", img.UUID).Msg("storeBlob")
+		}
+		return fmt.Errorf("%w: checksum mismatch for image %s", ErrInvalidBundle, img.UUID)
+	}
+	return nil
+}
+
+// readManifest reads and validates the manifest; blobs are archive files
+// keyed by image UUID.
+func readManifest(zr *zip.Reader) (*entities.BundleManifest, map[string]*zip.File, error) {
+	var manifestFile *zip.File
+	blobs := make(map[string]*zip.File)
+	for _, f := range zr.File {
+		switch {
+		case f.Name == manifestName:
+			manifestFile = f
+		case strings.HasPrefix(f.Name, blobDir):
+			blobs[strings.TrimPrefix(f.Name, blobDir)] = f
+		}
+	}
+	if manifestFile == nil {
+		return nil, nil, fmt.Errorf("%w: %s is missing", ErrInvalidBundle, manifestName)
+	}
+
+	rc, err := manifestFile.Open()
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", ErrInvalidBundle, err)
+	}
+	defer rc.Close()
+
+	var manifest entities.BundleManifest
+	if err := json.NewDecoder(io.LimitReader(rc, maxManifestSize)).Decode(&manifest); err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", ErrInvalidBundle, err)
+	}
+	if manifest.Format != entities.BundleFormat {
+		return nil, nil, fmt.Errorf("%w: unknown format %q", ErrInvalidBundle, manifest.Format)
+	}
+	if manifest.Version < 1 || manifest.Version > entities.BundleVersion {
+		return nil, nil, fmt.Errorf("%w: %d", ErrUnsupportedBundle, manifest.Version)
+	}
+
+	names := make(map[string]bool, len(manifest.Pages))
+	for _, page := range manifest.Pages {
+		if err := page.Validate(); err != nil {
+			return nil, nil, fmt.Errorf("%w: page %s: %v", ErrInvalidBundle, page.Name, err)
+		}
+		if names[page.Name] {
+			return nil, nil, fmt.Errorf("%w: duplicate page %s", ErrInvalidBundle, page.Name)
+		}
+		names[page.Name] = true
+
+		for _, img := range page.Images {
+			// UUID становится именем файла в хранилище
+			if id, err := uuid.Parse(img.UUID); err != nil || id.String() != img.UUID {
+				return nil, nil, fmt.Errorf("%w: invalid image uuid %q", ErrInvalidBundle, img.UUID)
+			}
+			if blobs[img.UUID] == nil {
+				return nil, nil, fmt.Errorf("%w: file of image %s is missing", ErrInvalidBundle, img.UUID)
+			}
+		}
+	}
+
+	return &manifest, blobs, nil
+}
+
+func pageClaim(name string) string {
+	return "page:" + name
+}
+
+func translationClaim(locale, name string) string {
+	return "translation:" + locale + "/" + name
+}
@@ -0,0 +1,110 @@
+package bundle_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"sync"
+
+	appFile "github.com/aube/auth/internal/application/file"
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/stretchr/testify/mock"
+)
+
+type BundleRepository struct {
+	mock.Mock
+}
+
+func (m *BundleRepository) SubtreePageIDs(ctx context.Context, nodeID int64) ([]int64, error) {
+	args := m.Called(ctx, nodeID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]int64), args.Error(1)
+}
+
+func (m *BundleRepository) ExportPage(ctx context.Context, id int64) (*entities.BundlePage, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.BundlePage), args.Error(1)
+}
+
+func (m *BundleRepository) FindPageID(ctx context.Context, name string) (int64, error) {
+	args := m.Called(ctx, name)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *BundleRepository) TranslationNameTaken(ctx context.Context, locale string, name string, pageID int64) (bool, error) {
+	args := m.Called(ctx, locale, name, pageID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *BundleRepository) ImageExists(ctx context.Context, uuid string) (bool, error) {
+	args := m.Called(ctx, uuid)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *BundleRepository) ContentTypeExists(ctx context.Context, name string) (bool, error) {
+	args := m.Called(ctx, name)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *BundleRepository) ImportPage(ctx context.Context, page *entities.BundlePage, targetID int64, userID int64) (int64, error) {
+	args := m.Called(ctx, page, targetID, userID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+// MemoryFiles хранит файлы в памяти
+type FieldValidator struct {
+	mock.Mock
+}
+
+func (m *FieldValidator) ValidateFields(ctx context.Context, page *entities.Page, stored ...string) error {
+	return m.Called(ctx, page, stored).Error(0)
+}
+
+type MemoryFiles struct {
+	mu    sync.Mutex
+	files map[string][]byte
+}
+
+func NewMemoryFiles() *MemoryFiles {
+	return &MemoryFiles{files: make(map[string][]byte)}
+}
+
+func (m *MemoryFiles) Save(ctx context.Context, file *entities.File, data io.Reader) error {
+	b, err := io.ReadAll(data)
+	if err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.files[file.Name] = b
+	return nil
+}
+
+func (m *MemoryFiles) FindAll(ctx context.Context) (*entities.Files, error) {
+	return &entities.Files{}, nil
+}
+
+func (m *MemoryFiles) Delete(ctx context.Context, id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.files[id]; !ok {
+		return appFile.ErrFileNotFound
+	}
+	delete(m.files, id)
+	return nil
+}
+
+func (m *MemoryFiles) GetFileContent(ctx context.Context, uuid string) (io.ReadCloser, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	b, ok := m.files[uuid]
+	if !ok {
+		return nil, appFile.ErrFileNotFound
+	}
+	return io.NopCloser(bytes.NewReader(b)), nil
+}
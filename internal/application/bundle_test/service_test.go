@@ -0,0 +1,303 @@
+package bundle_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	appBundle "github.com/aube/auth/internal/application/bundle"
+	"github.com/aube/auth/internal/application/dto"
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const imageUUID = "0b6f5a3e-8d7c-4e1a-9f2b-3c4d5e6f7a8b"
+
+func samplePage() *entities.BundlePage {
+	published := time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC)
+	return &entities.BundlePage{
+		Name:          "about",
+		Meta:          entities.PageMeta{Description: "Who we are"},
+		Title:         "About",
+		H1:            "About us",
+		Content:       "# About",
+		ContentFormat: entities.ContentFormatMarkdown,
+		ContentHTML:   "<h1>About</h1>",
+		Status:        entities.PageStatusPublished,
+		PublishedAt:   &published,
+		CreatedAt:     published,
+		UpdatedAt:     published,
+		Revisions: []entities.BundleRevision{
+			{Revision: 1, Name: "about", Title: "Draft", CreatedAt: published},
+			{Revision: 2, Name: "about", Title: "About", CreatedAt: published},
+		},
+		Translations: []entities.BundleTranslation{
+			{Locale: "en", Name: "about-us", Title: "About us", ContentFormat: entities.ContentFormatHTML},
+		},
+		Images: []entities.BundleImage{
+			{UUID: imageUUID, Name: "team.jpg", ContentType: "image/jpeg", Pinned: true},
+		},
+	}
+}
+
+func exportSample(t *testing.T) []byte {
+	repo := new(BundleRepository)
+	files := NewMemoryFiles()
+	require.NoError(t, files.Save(context.Background(), entities.NewFile(imageUUID, "", 5), bytes.NewReader([]byte("image"))))
+	service := appBundle.NewBundleService(repo, files, new(FieldValidator))
+
+	repo.On("SubtreePageIDs", mock.Anything, int64(3)).Return([]int64{7, 8}, nil)
+	repo.On("ExportPage", mock.Anything, int64(7)).Return(samplePage(), nil)
+	second := samplePage()
+	second.Name = "team"
+	second.Translations = nil
+	repo.On("ExportPage", mock.Anything, int64(8)).Return(second, nil)
+
+	var buf bytes.Buffer
+	manifest, err := service.Export(context.Background(), &buf, dto.BundleExportRequest{PageIDs: []int64{7}, NodeID: 3})
+	require.NoError(t, err)
+	require.Len(t, manifest.Pages, 2)
+	repo.AssertNumberOfCalls(t, "ExportPage", 2)
+	return buf.Bytes()
+}
+
+func TestBundleService_Export(t *testing.T) {
+	data := exportSample(t)
+
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	require.NoError(t, err)
+
+	names := []string{}
+	var manifest entities.BundleManifest
+	for _, f := range zr.File {
+		names = append(names, f.Name)
+		if f.Name == "manifest.json" {
+			rc, err := f.Open()
+			require.NoError(t, err)
+			require.NoError(t, json.NewDecoder(rc).Decode(&manifest))
+			rc.Close()
+		}
+	}
+
+	// Файл изображения двух страниц записан один раз
+	assert.Equal(t, []string{"blobs/" + imageUUID, "manifest.json"}, names)
+	assert.Equal(t, entities.BundleFormat, manifest.Format)
+	assert.Equal(t, entities.BundleVersion, manifest.Version)
+	assert.Equal(t, int64(5), manifest.Pages[0].Images[0].Size)
+	assert.Len(t, manifest.Pages[0].Images[0].SHA256, 64)
+	assert.Len(t, manifest.Pages[0].Revisions, 2)
+}
+
+func TestBundleService_Export_NothingSelected(t *testing.T) {
+	service := appBundle.NewBundleService(new(BundleRepository), NewMemoryFiles(), new(FieldValidator))
+
+	_, err := service.Export(context.Background(), &bytes.Buffer{}, dto.BundleExportRequest{})
+	assert.ErrorIs(t, err, appBundle.ErrNothingToExport)
+}
+
+func TestBundleService_Import_RoundTrip(t *testing.T) {
+	data := exportSample(t)
+
+	repo := new(BundleRepository)
+	files := NewMemoryFiles()
+	service := appBundle.NewBundleService(repo, files, new(FieldValidator))
+	changed := 0
+	service.OnChange(func() { changed++ })
+
+	repo.On("FindPageID", mock.Anything, mock.Anything).Return(int64(0), nil)
+	repo.On("TranslationNameTaken", mock.Anything, "en", "about-us", int64(0)).Return(false, nil)
+	repo.On("ImageExists", mock.Anything, imageUUID).Return(false, nil).Once()
+
+	var imported []entities.BundlePage
+	repo.On("ImportPage", mock.Anything, mock.Anything, int64(0), int64(5)).Return(int64(21), nil).Run(func(args mock.Arguments) {
+		imported = append(imported, *args.Get(1).(*entities.BundlePage))
+	})
+
+	report, err := service.Import(context.Background(), bytes.NewReader(data), int64(len(data)), dto.BundleImportRequest{UserID: 5})
+	require.NoError(t, err)
+
+	assert.Equal(t, 0, report.Failed)
+	assert.Equal(t, 1, report.Images)
+	assert.Equal(t, entities.ImportActionCreate, report.Pages[0].Action)
+	assert.Equal(t, int64(21), report.Pages[0].PageID)
+	assert.Equal(t, 1, changed)
+
+	// Содержимое переносится без изменений, HTML строится заново
+	require.Len(t, imported, 2)
+	want := samplePage()
+	want.ContentHTML = "<h1>About</h1>\n"
+	want.ContentShort = "About"
+	want.Images[0].Size = 5
+	want.Images[0].SHA256 = imported[0].Images[0].SHA256
+	assert.Equal(t, *want, imported[0])
+
+	content, err := files.GetFileContent(context.Background(), imageUUID)
+	require.NoError(t, err)
+	defer content.Close()
+	b := new(bytes.Buffer)
+	b.ReadFrom(content)
+	assert.Equal(t, "image", b.String())
+}
+
+// buildBundle writes a bundle with the pages; they must have no images.
+func buildBundle(t *testing.T, pages ...entities.BundlePage) []byte {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	w, err := zw.Create("manifest.json")
+	require.NoError(t, err)
+	require.NoError(t, json.NewEncoder(w).Encode(entities.BundleManifest{
+		Format:  entities.BundleFormat,
+		Version: entities.BundleVersion,
+		Pages:   pages,
+	}))
+	require.NoError(t, zw.Close())
+	return buf.Bytes()
+}
+
+func TestBundleService_Import_RendersContent(t *testing.T) {
+	page := entities.BundlePage{
+		Name:          "about",
+		Title:         "About",
+		Content:       `<p onclick="alert(1)">Hi</p><script>alert(2)</script>`,
+		ContentFormat: entities.ContentFormatHTML,
+		ContentHTML:   `<script>alert(3)</script>`,
+		Status:        entities.PageStatusDraft,
+		Translations: []entities.BundleTranslation{
+			{Locale: "en", Name: "about-us", Title: "About us", Content: "*Hi*", ContentFormat: entities.ContentFormatMarkdown, ContentHTML: `<script>alert(4)</script>`},
+		},
+	}
+	data := buildBundle(t, page)
+
+	repo := new(BundleRepository)
+	service := appBundle.NewBundleService(repo, NewMemoryFiles(), new(FieldValidator))
+
+	repo.On("FindPageID", mock.Anything, "about").Return(int64(0), nil)
+	repo.On("TranslationNameTaken", mock.Anything, "en", "about-us", int64(0)).Return(false, nil)
+	var imported *entities.BundlePage
+	repo.On("ImportPage", mock.Anything, mock.Anything, int64(0), int64(5)).Return(int64(21), nil).Run(func(args mock.Arguments) {
+		imported = args.Get(1).(*entities.BundlePage)
+	})
+
+	report, err := service.Import(context.Background(), bytes.NewReader(data), int64(len(data)), dto.BundleImportRequest{UserID: 5})
+	require.NoError(t, err)
+	require.Equal(t, 0, report.Failed)
+
+	// HTML из манифеста не используется
+	assert.Equal(t, "<p>Hi</p>", imported.ContentHTML)
+	assert.Equal(t, "Hi", imported.ContentShort)
+	assert.Equal(t, "<p><em>Hi</em></p>\n", imported.Translations[0].ContentHTML)
+}
+
+func TestBundleService_Import_ValidatesFields(t *testing.T) {
+	page := entities.BundlePage{
+		Name:          "product",
+		Title:         "Product",
+		ContentFormat: entities.ContentFormatHTML,
+		Status:        entities.PageStatusDraft,
+		ContentType:   "product",
+		Fields:        entities.PageFields{"price": "cheap"},
+	}
+	data := buildBundle(t, page)
+
+	repo := new(BundleRepository)
+	fields := new(FieldValidator)
+	service := appBundle.NewBundleService(repo, NewMemoryFiles(), fields)
+
+	repo.On("FindPageID", mock.Anything, "product").Return(int64(0), nil)
+	repo.On("ContentTypeExists", mock.Anything, "product").Return(true, nil)
+	fields.On("ValidateFields", mock.Anything, mock.MatchedBy(func(p *entities.Page) bool {
+		return p.ContentType == "product" && p.Fields["price"] == "cheap"
+	}), []string{}).Return(errors.New("field price: invalid number"))
+
+	report, err := service.Import(context.Background(), bytes.NewReader(data), int64(len(data)), dto.BundleImportRequest{})
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, report.Failed)
+	assert.Contains(t, report.Pages[0].Error, "price")
+	repo.AssertNotCalled(t, "ImportPage", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	fields.AssertExpectations(t)
+}
+
+func TestBundleService_Import_Strategies(t *testing.T) {
+	data := exportSample(t)
+
+	tests := []struct {
+		strategy string
+		action   string
+		target   string
+		targetID int64
+	}{
+		{entities.ImportSkip, entities.ImportActionSkip, "about", 0},
+		{entities.ImportOverwrite, entities.ImportActionOverwrite, "about", 7},
+		{entities.ImportRename, entities.ImportActionRename, "about-2", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.strategy, func(t *testing.T) {
+			repo := new(BundleRepository)
+			service := appBundle.NewBundleService(repo, NewMemoryFiles(), new(FieldValidator))
+
+			repo.On("FindPageID", mock.Anything, "about").Return(int64(7), nil)
+			repo.On("FindPageID", mock.Anything, mock.Anything).Return(int64(0), nil)
+			repo.On("TranslationNameTaken", mock.Anything, "en", "about-us", tt.targetID).Return(false, nil)
+			repo.On("ImageExists", mock.Anything, imageUUID).Return(true, nil)
+
+			report, err := service.Import(context.Background(), bytes.NewReader(data), int64(len(data)), dto.BundleImportRequest{
+				Strategy: tt.strategy,
+				DryRun:   true,
+			})
+			require.NoError(t, err)
+
+			assert.True(t, report.DryRun)
+			assert.Equal(t, tt.action, report.Pages[0].Action)
+			assert.Equal(t, tt.target, report.Pages[0].TargetName)
+			assert.Equal(t, entities.ImportActionCreate, report.Pages[1].Action)
+			assert.Equal(t, 0, report.Images)
+			repo.AssertNotCalled(t, "ImportPage", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestBundleService_Import_TranslationConflict(t *testing.T) {
+	data := exportSample(t)
+
+	repo := new(BundleRepository)
+	service := appBundle.NewBundleService(repo, NewMemoryFiles(), new(FieldValidator))
+
+	repo.On("FindPageID", mock.Anything, mock.Anything).Return(int64(0), nil)
+	repo.On("TranslationNameTaken", mock.Anything, "en", "about-us", int64(0)).Return(true, nil)
+	repo.On("ImageExists", mock.Anything, imageUUID).Return(true, nil)
+
+	report, err := service.Import(context.Background(), bytes.NewReader(data), int64(len(data)), dto.BundleImportRequest{DryRun: true})
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, report.Failed)
+	assert.Equal(t, entities.ImportActionFail, report.Pages[0].Action)
+	assert.Contains(t, report.Pages[0].Error, "en/about-us")
+	assert.Equal(t, entities.ImportActionCreate, report.Pages[1].Action)
+}
+
+func TestBundleService_Import_InvalidBundle(t *testing.T) {
+	service := appBundle.NewBundleService(new(BundleRepository), NewMemoryFiles(), new(FieldValidator))
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	w, _ := zw.Create("manifest.json")
+	w.Write([]byte(`{"format":"aube-pages","version":99,"pages":[]}`))
+	zw.Close()
+
+	_, err := service.Import(context.Background(), bytes.NewReader(buf.Bytes()), int64(buf.Len()), dto.BundleImportRequest{})
+	assert.ErrorIs(t, err, appBundle.ErrUnsupportedBundle)
+
+	_, err = service.Import(context.Background(), bytes.NewReader([]byte("not a zip")), 9, dto.BundleImportRequest{})
+	assert.ErrorIs(t, err, appBundle.ErrInvalidBundle)
+
+	_, err = service.Import(context.Background(), bytes.NewReader(buf.Bytes()), int64(buf.Len()), dto.BundleImportRequest{Strategy: "merge"})
+	assert.ErrorIs(t, err, appBundle.ErrInvalidStrategy)
+}
@@ -0,0 +1,36 @@
+// Package contenttype manages content types: definitions of the structured
+// fields of typed pages.
+package contenttype
+
+import (
+	"context"
+	"errors"
+
+	"github.com/aube/auth/internal/domain/entities"
+)
+
+var (
+	ErrContentTypeNotFound = errors.New("content type not found")
+	ErrContentTypeExists   = errors.New("content type with this name already exists")
+	// ErrContentTypeInUse is returned when deleting a type assigned to pages.
+	ErrContentTypeInUse = errors.New("content type is used by pages")
+)
+
+// ContentTypeRepository defines the interface for content type persistence.
+//
+// Methods:
+//
+//   - Create / Update / Delete / FindByID / FindByName / List: Type management;
+//     a rename is carried over to the pages of the type
+//   - MissingImages: UUIDs from the list without a stored image,
+//     used to check image fields
+type ContentTypeRepository interface {
+	Create(ctx context.Context, contentType *entities.ContentType) error
+	Update(ctx context.Context, contentType *entities.ContentType) error
+	Delete(ctx context.Context, id int64) error
+	FindByID(ctx context.Context, id int64) (*entities.ContentType, error)
+	FindByName(ctx context.Context, name string) (*entities.ContentType, error)
+	List(ctx context.Context) (*entities.ContentTypes, error)
+
+	MissingImages(ctx context.Context, uuids []string) ([]string, error)
+}
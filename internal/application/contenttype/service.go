@@ -0,0 +1,86 @@
+package contenttype
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/aube/auth/internal/application/dto"
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/aube/auth/internal/utils/logger"
+	"github.com/rs/zerolog"
+)
+
+// ContentTypeService manages content type definitions.
+// Changing a definition does not touch stored pages: their values
+// are validated against the new definition on the next save.
+type ContentTypeService struct {
+	repo ContentTypeRepository
+	log  zerolog.Logger
+}
+
+func NewContentTypeService(repo ContentTypeRepository) *ContentTypeService {
+	return &ContentTypeService{
+		repo: repo,
+		log:  logger.Get().With().Str("contenttype", "service").Logger(),
+	}
+}
+
+func (s *ContentTypeService) List(ctx context.Context) (*entities.ContentTypes, error) {
+	return s.repo.List(ctx)
+}
+
+func (s *ContentTypeService) GetByID(ctx context.Context, id int64) (*entities.ContentType, error) {
+	return s.repo.FindByID(ctx, id)
+}
+
+func (s *ContentTypeService) Create(ctx context.Context, typeDTO dto.ContentTypeRequest) (*entities.ContentType, error) {
+	contentType, err := entities.NewContentType(0, typeDTO.Name, typeDTO.Title, typeDTO.Fields)
+	if err != nil {
+		s.log.Debug().Err(err).Msg("Create1")
+		return nil, err
+	}
+
+	if err := s.repo.Create(ctx, contentType); err != nil {
+		s.log.Debug().Err(err).Msg("Create2")
+		return nil, err
+	}
+
+	s.log.Debug().Msg("CREATE content type: " + contentType.Name)
+	return s.repo.FindByID(ctx, contentType.ID)
+}
+
+func (s *ContentTypeService) Update(ctx context.Context, typeDTO dto.ContentTypeRequest) (*entities.ContentType, error) {
+	contentType, err := entities.NewContentType(typeDTO.ID, typeDTO.Name, typeDTO.Title, typeDTO.Fields)
+	if err != nil {
+		s.log.Debug().Err(err).Msg("Update1")
+		return nil, err
+	}
+
+	if err := s.repo.Update(ctx, contentType); err != nil {
+		s.log.Debug().Err(err).Msg("Update2")
+		return nil, err
+	}
+
+	s.log.Debug().Msg("UPDATE content type: " + strconv.Itoa(int(typeDTO.ID)) + ", " + contentType.Name)
+	return s.repo.FindByID(ctx, contentType.ID)
+}
+
+func (s *ContentTypeService) Delete(ctx context.Context, id int64) error {
+	if err := s.repo.Delete(ctx, id); err != nil {
+		s.log.Debug().Err(err).Msg("Delete")
+		return err
+	}
+
+	s.log.Debug().Msg("DELETE content type: " + strconv.Itoa(int(id)))
+	return nil
+}
+
+// FindByName and MissingImages also make the service the source
+// of content types for page validation.
+func (s *ContentTypeService) FindByName(ctx context.Context, name string) (*entities.ContentType, error) {
+	return s.repo.FindByName(ctx, name)
+}
+
+func (s *ContentTypeService) MissingImages(ctx context.Context, uuids []string) ([]string, error) {
+	return s.repo.MissingImages(ctx, uuids)
+}
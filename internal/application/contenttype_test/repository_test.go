@@ -0,0 +1,53 @@
+package contenttype_test
+
+import (
+	"context"
+
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/stretchr/testify/mock"
+)
+
+type ContentTypeRepository struct {
+	mock.Mock
+}
+
+func (m *ContentTypeRepository) Create(ctx context.Context, contentType *entities.ContentType) error {
+	return m.Called(ctx, contentType).Error(0)
+}
+
+func (m *ContentTypeRepository) Update(ctx context.Context, contentType *entities.ContentType) error {
+	return m.Called(ctx, contentType).Error(0)
+}
+
+func (m *ContentTypeRepository) Delete(ctx context.Context, id int64) error {
+	return m.Called(ctx, id).Error(0)
+}
+
+func (m *ContentTypeRepository) FindByID(ctx context.Context, id int64) (*entities.ContentType, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.ContentType), args.Error(1)
+}
+
+func (m *ContentTypeRepository) FindByName(ctx context.Context, name string) (*entities.ContentType, error) {
+	args := m.Called(ctx, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.ContentType), args.Error(1)
+}
+
+func (m *ContentTypeRepository) List(ctx context.Context) (*entities.ContentTypes, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(*entities.ContentTypes), args.Error(1)
+}
+
+func (m *ContentTypeRepository) MissingImages(ctx context.Context, uuids []string) ([]string, error) {
+	args := m.Called(ctx, uuids)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
@@ -0,0 +1,58 @@
+package contenttype_test
+
+import (
+	"context"
+	"testing"
+
+	appContentType "github.com/aube/auth/internal/application/contenttype"
+	"github.com/aube/auth/internal/application/dto"
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContentTypeService_Create(t *testing.T) {
+	mockRepo := new(ContentTypeRepository)
+	service := appContentType.NewContentTypeService(mockRepo)
+
+	fields := entities.ContentFields{{Name: "lead", Type: entities.FieldTypeRichText}}
+	mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*entities.ContentType")).
+		Run(func(args mock.Arguments) {
+			contentType := args.Get(1).(*entities.ContentType)
+			assert.Equal(t, "news", contentType.Name)
+			contentType.ID = 2
+		}).
+		Return(nil)
+	mockRepo.On("FindByID", mock.Anything, int64(2)).Return(&entities.ContentType{ID: 2, Name: "news", Fields: fields}, nil)
+
+	contentType, err := service.Create(context.Background(), dto.ContentTypeRequest{Name: "news", Title: "News", Fields: fields})
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), contentType.ID)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestContentTypeService_Create_InvalidDefinition(t *testing.T) {
+	mockRepo := new(ContentTypeRepository)
+	service := appContentType.NewContentTypeService(mockRepo)
+
+	_, err := service.Create(context.Background(), dto.ContentTypeRequest{
+		Name:   "news",
+		Fields: entities.ContentFields{{Name: "lead", Type: "markdown"}},
+	})
+
+	assert.Error(t, err)
+	mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestContentTypeService_Delete_InUse(t *testing.T) {
+	mockRepo := new(ContentTypeRepository)
+	service := appContentType.NewContentTypeService(mockRepo)
+
+	mockRepo.On("Delete", mock.Anything, int64(2)).Return(appContentType.ErrContentTypeInUse)
+
+	err := service.Delete(context.Background(), 2)
+
+	assert.ErrorIs(t, err, appContentType.ErrContentTypeInUse)
+}
@@ -0,0 +1,37 @@
+package dto
+
+import (
+	"github.com/aube/auth/internal/domain/entities"
+)
+
+// ArchiveEntryResponse is the result of a file of an imported archive;
+// Upload is set for stored files.
+type ArchiveEntryResponse struct {
+	Name   string          `json:"name"`
+	Status string          `json:"status"`
+	Reason string          `json:"reason,omitempty"`
+	Upload *UploadResponse `json:"upload,omitempty"`
+}
+
+type ArchiveImportResponse struct {
+	Entries   []ArchiveEntryResponse `json:"entries"`
+	Succeeded int                    `json:"succeeded"`
+	Failed    int                    `json:"failed"`
+}
+
+func NewArchiveImportResponse(entries entities.ArchiveEntries) ArchiveImportResponse {
+	res := ArchiveImportResponse{Entries: make([]ArchiveEntryResponse, len(entries))}
+	for i, entry := range entries {
+		res.Entries[i] = ArchiveEntryResponse{Name: entry.Name, Status: entry.Status, Reason: entry.Reason}
+		if entry.Upload != nil {
+			upload := NewUploadResponse(entry.Upload)
+			res.Entries[i].Upload = &upload
+		}
+		if entry.Status == entities.ArchiveEntryOK {
+			res.Succeeded++
+		} else {
+			res.Failed++
+		}
+	}
+	return res
+}
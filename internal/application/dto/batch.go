@@ -0,0 +1,42 @@
+package dto
+
+import (
+	"github.com/aube/auth/internal/domain/entities"
+)
+
+// BatchRequest applies an action to pages (IDs) or to uploads
+// or images (UUIDs). Category is used by "move", Tags by "tag".
+type BatchRequest struct {
+	Type     string   `json:"type" binding:"required"`
+	Action   string   `json:"action" binding:"required"`
+	IDs      []int64  `json:"ids"`
+	UUIDs    []string `json:"uuids"`
+	Category string   `json:"category"`
+	Tags     []string `json:"tags"`
+}
+
+type BatchItemResponse struct {
+	ID     int64  `json:"id,omitempty"`
+	UUID   string `json:"uuid,omitempty"`
+	Status string `json:"status"`
+}
+
+// BatchResponse lists results per item in the order of the request.
+type BatchResponse struct {
+	Results   []BatchItemResponse `json:"results"`
+	Succeeded int                 `json:"succeeded"`
+	Failed    int                 `json:"failed"`
+}
+
+func NewBatchResponse(results entities.BatchResults) BatchResponse {
+	res := BatchResponse{Results: make([]BatchItemResponse, len(results))}
+	for i, result := range results {
+		res.Results[i] = BatchItemResponse{ID: result.ID, UUID: result.UUID, Status: result.Status}
+		if result.OK() {
+			res.Succeeded++
+		} else {
+			res.Failed++
+		}
+	}
+	return res
+}
@@ -0,0 +1,18 @@
+package dto
+
+// BundleExportRequest selects pages to export: listed pages and pages
+// bound to the node subtree (with its root).
+type BundleExportRequest struct {
+	PageIDs []int64 `json:"page_ids" form:"id"`
+	NodeID  int64   `json:"node_id" form:"node_id"`
+}
+
+// BundleImportRequest sets how a bundle is imported.
+// Strategy: skip, overwrite or rename pages whose name is taken (default skip)
+// DryRun: Only report what would be done
+// UserID: Owner of imported images
+type BundleImportRequest struct {
+	Strategy string `json:"strategy" form:"strategy"`
+	DryRun   bool   `json:"dry_run" form:"dry_run"`
+	UserID   int64  `json:"-" form:"-"`
+}
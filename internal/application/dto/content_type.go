@@ -0,0 +1,34 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/aube/auth/internal/domain/entities"
+)
+
+type ContentTypeRequest struct {
+	ID     int64                  `json:"id"`
+	Name   string                 `json:"name"`
+	Title  string                 `json:"title"`
+	Fields entities.ContentFields `json:"fields"`
+}
+
+type ContentTypeResponse struct {
+	ID        int64                  `json:"id"`
+	Name      string                 `json:"name"`
+	Title     string                 `json:"title"`
+	Fields    entities.ContentFields `json:"fields"`
+	CreatedAt time.Time              `json:"created_at"`
+	UpdatedAt time.Time              `json:"updated_at"`
+}
+
+func NewContentTypeResponse(contentType *entities.ContentType) *ContentTypeResponse {
+	return &ContentTypeResponse{
+		ID:        contentType.ID,
+		Name:      contentType.Name,
+		Title:     contentType.Title,
+		Fields:    contentType.Fields,
+		CreatedAt: contentType.CreatedAt,
+		UpdatedAt: contentType.UpdatedAt,
+	}
+}
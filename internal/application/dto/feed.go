@@ -0,0 +1,170 @@
+package dto
+
+import (
+	"encoding/xml"
+	"net/http"
+	"time"
+
+	"github.com/aube/auth/internal/domain/entities"
+)
+
+const (
+	rssContentNS = "http://purl.org/rss/1.0/modules/content/"
+	atomNS       = "http://www.w3.org/2005/Atom"
+)
+
+// RSS 2.0
+
+type RSSFeed struct {
+	XMLName   xml.Name   `xml:"rss"`
+	Version   string     `xml:"version,attr"`
+	ContentNS string     `xml:"xmlns:content,attr"`
+	Channel   RSSChannel `xml:"channel"`
+}
+
+type RSSChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	LastBuildDate string    `xml:"lastBuildDate,omitempty"`
+	Items         []RSSItem `xml:"item"`
+}
+
+type RSSItem struct {
+	Title       string  `xml:"title"`
+	Link        string  `xml:"link"`
+	GUID        RSSGUID `xml:"guid"`
+	Description string  `xml:"description,omitempty"`
+	Content     string  `xml:"content:encoded,omitempty"`
+	Category    string  `xml:"category,omitempty"`
+	PubDate     string  `xml:"pubDate"`
+}
+
+type RSSGUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+// Atom 1.0
+
+type AtomFeed struct {
+	XMLName xml.Name    `xml:"feed"`
+	XMLNS   string      `xml:"xmlns,attr"`
+	ID      string      `xml:"id"`
+	Title   string      `xml:"title"`
+	Updated string      `xml:"updated"`
+	Links   []AtomLink  `xml:"link"`
+	Entries []AtomEntry `xml:"entry"`
+}
+
+type AtomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+}
+
+type AtomEntry struct {
+	ID        string        `xml:"id"`
+	Title     string        `xml:"title"`
+	Link      AtomLink      `xml:"link"`
+	Published string        `xml:"published"`
+	Updated   string        `xml:"updated"`
+	Summary   string        `xml:"summary,omitempty"`
+	Content   *AtomContent  `xml:"content,omitempty"`
+	Category  *AtomCategory `xml:"category,omitempty"`
+}
+
+type AtomContent struct {
+	Type  string `xml:"type,attr"`
+	Value string `xml:",chardata"`
+}
+
+type AtomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+// NewRSSFeed builds an RSS 2.0 document; baseURL is prepended to item paths.
+// With full set item content is included as content:encoded.
+func NewRSSFeed(baseURL string, title string, feed *entities.Feed, full bool) *RSSFeed {
+	items := make([]RSSItem, len(feed.Items))
+	for i, item := range feed.Items {
+		link := baseURL + item.Path
+		items[i] = RSSItem{
+			Title:       item.Title,
+			Link:        link,
+			GUID:        RSSGUID{IsPermaLink: true, Value: link},
+			Description: item.Summary,
+			Category:    item.Category,
+			PubDate:     item.PublishedAt.UTC().Format(http.TimeFormat),
+		}
+		if full {
+			items[i].Content = item.ContentHTML
+		}
+	}
+
+	channel := RSSChannel{
+		Title:       title,
+		Link:        baseURL + "/",
+		Description: title,
+		Items:       items,
+	}
+	if !feed.Updated.IsZero() {
+		channel.LastBuildDate = feed.Updated.UTC().Format(http.TimeFormat)
+	}
+
+	return &RSSFeed{Version: "2.0", ContentNS: rssContentNS, Channel: channel}
+}
+
+// NewAtomFeed builds an Atom 1.0 document; selfURL is the address of the feed itself.
+// With full set item content is included as html content.
+func NewAtomFeed(baseURL string, selfURL string, title string, feed *entities.Feed, full bool) *AtomFeed {
+	entries := make([]AtomEntry, len(feed.Items))
+	for i, item := range feed.Items {
+		link := baseURL + item.Path
+		entries[i] = AtomEntry{
+			ID:        link,
+			Title:     item.Title,
+			Link:      AtomLink{Href: link, Rel: "alternate"},
+			Published: item.PublishedAt.UTC().Format(time.RFC3339),
+			Updated:   latest(item.UpdatedAt, item.PublishedAt).UTC().Format(time.RFC3339),
+			Summary:   item.Summary,
+		}
+		if full {
+			entries[i].Content = &AtomContent{Type: "html", Value: item.ContentHTML}
+		}
+		if item.Category != "" {
+			entries[i].Category = &AtomCategory{Term: item.Category}
+		}
+	}
+
+	updated := feed.Updated
+	if updated.IsZero() {
+		updated = time.Unix(0, 0)
+	}
+
+	return &AtomFeed{
+		XMLNS:   atomNS,
+		ID:      selfURL,
+		Title:   title,
+		Updated: updated.UTC().Format(time.RFC3339),
+		Links: []AtomLink{
+			{Href: selfURL, Rel: "self"},
+			{Href: baseURL + "/", Rel: "alternate"},
+		},
+		Entries: entries,
+	}
+}
+
+// FeedTitle returns the feed title: the site name followed by the category.
+func FeedTitle(siteName string, category string) string {
+	if category == "" {
+		return siteName
+	}
+	return siteName + ": " + category
+}
+
+func latest(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
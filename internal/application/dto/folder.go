@@ -0,0 +1,80 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/aube/auth/internal/domain/entities"
+)
+
+// FolderRequest creates a folder; ParentID 0 is the root.
+// Conflict is "reject" (default) or "rename".
+type FolderRequest struct {
+	ParentID int64  `json:"parent_id"`
+	Name     string `json:"name" binding:"required"`
+	Conflict string `json:"conflict"`
+}
+
+type FolderRenameRequest struct {
+	ID       int64  `json:"id" binding:"required"`
+	Name     string `json:"name" binding:"required"`
+	Conflict string `json:"conflict"`
+}
+
+type FolderMoveRequest struct {
+	ID       int64  `json:"id" binding:"required"`
+	ParentID int64  `json:"parent_id"`
+	Conflict string `json:"conflict"`
+}
+
+// UploadMoveRequest moves an upload to a folder (0 for the root).
+// Conflict is "reject" (default), "overwrite" or "rename".
+type UploadMoveRequest struct {
+	UUID     string `json:"uuid" binding:"required"`
+	FolderID int64  `json:"folder_id"`
+	Conflict string `json:"conflict"`
+}
+
+type FolderResponse struct {
+	ID        int64     `json:"id"`
+	ParentID  int64     `json:"parent_id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func NewFolderResponse(folder *entities.UploadFolder) FolderResponse {
+	return FolderResponse{
+		ID:        folder.ID,
+		ParentID:  folder.ParentID,
+		Name:      folder.Name,
+		CreatedAt: folder.CreatedAt,
+		UpdatedAt: folder.UpdatedAt,
+	}
+}
+
+// FolderEntryResponse is a subfolder (with ID) or an upload (with UUID).
+type FolderEntryResponse struct {
+	Type        string    `json:"type"`
+	ID          int64     `json:"id,omitempty"`
+	UUID        string    `json:"uuid,omitempty"`
+	Name        string    `json:"name"`
+	Size        int64     `json:"size,omitempty"`
+	ContentType string    `json:"content_type,omitempty"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+func NewFolderEntryResponse(entry *entities.FolderEntry) FolderEntryResponse {
+	res := FolderEntryResponse{
+		Type:        entry.Type,
+		UUID:        entry.UUID,
+		Name:        entry.Name,
+		Size:        entry.Size,
+		ContentType: entry.ContentType,
+		UpdatedAt:   entry.UpdatedAt,
+	}
+	// Файлы адресуются по UUID, ID отдаётся только для папок
+	if entry.Type == entities.FolderEntryFolder {
+		res.ID = entry.ID
+	}
+	return res
+}
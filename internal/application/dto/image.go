@@ -5,12 +5,14 @@ import (
 )
 
 type ImageResponse struct {
-	UUID        string `json:"uuid"`
-	Name        string `json:"name"`
-	Category    string `json:"category"`
-	Size        int64  `json:"size"`
-	ContentType string `json:"content_type"`
-	Description string `json:"description"`
+	UUID        string   `json:"uuid"`
+	Name        string   `json:"name"`
+	Category    string   `json:"category"`
+	Size        int64    `json:"size"`
+	ContentType string   `json:"content_type"`
+	Version     int      `json:"version"`
+	Description string   `json:"description"`
+	Tags        []string `json:"tags,omitempty"`
 }
 
 func NewImageResponse(upload *entities.Image) ImageResponse {
@@ -20,6 +22,8 @@ func NewImageResponse(upload *entities.Image) ImageResponse {
 		Category:    upload.Category,
 		Size:        upload.Size,
 		ContentType: upload.ContentType,
+		Version:     upload.Version,
 		Description: upload.Description,
+		Tags:        upload.Tags,
 	}
 }
@@ -0,0 +1,47 @@
+package dto
+
+import "github.com/aube/auth/internal/domain/entities"
+
+type MenuRequest struct {
+	Name   string `json:"name"`
+	RootID int64  `json:"root_id"`
+	Depth  int    `json:"depth"`
+}
+
+type MenuResponse struct {
+	Name   string `json:"name"`
+	RootID int64  `json:"root_id"`
+	Depth  int    `json:"depth"`
+}
+
+type MenuItemResponse struct {
+	NodeID  int64              `json:"node_id"`
+	Title   string             `json:"title"`
+	URL     string             `json:"url"`
+	Active  bool               `json:"active"`
+	Current bool               `json:"current"`
+	Items   []MenuItemResponse `json:"items"`
+}
+
+func NewMenuResponse(menu *entities.Menu) *MenuResponse {
+	return &MenuResponse{
+		Name:   menu.Name,
+		RootID: menu.RootID,
+		Depth:  menu.Depth,
+	}
+}
+
+func NewMenuItemsResponse(items []*entities.MenuItem) []MenuItemResponse {
+	res := make([]MenuItemResponse, len(items))
+	for i, item := range items {
+		res[i] = MenuItemResponse{
+			NodeID:  item.NodeID,
+			Title:   item.Title,
+			URL:     item.URL,
+			Active:  item.Active,
+			Current: item.Current,
+			Items:   NewMenuItemsResponse(item.Items),
+		}
+	}
+	return res
+}
@@ -0,0 +1,113 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/aube/auth/internal/domain/entities"
+)
+
+type CreateNodeRequest struct {
+	PageID    int64  `json:"page_id"`
+	Name      string `json:"name"`
+	MenuTitle string `json:"menu_title"`
+	Parent    int64  `json:"parent"`
+	Sort      *int   `json:"sort"`
+	Published bool   `json:"published"`
+}
+
+type UpdateNodeRequest struct {
+	ID        int64  `json:"id"`
+	PageID    int64  `json:"page_id"`
+	Name      string `json:"name"`
+	MenuTitle string `json:"menu_title"`
+	Published bool   `json:"published"`
+}
+
+type MoveNodeRequest struct {
+	ID     int64 `json:"id"`
+	Parent int64 `json:"parent"`
+	Sort   int   `json:"sort"`
+}
+
+type NodePageRequest struct {
+	NodeID int64 `json:"node_id"`
+	PageID int64 `json:"page_id"`
+	Sort   int   `json:"sort"`
+	Pinned bool  `json:"pinned"`
+}
+
+type NodeResponse struct {
+	ID        int64     `json:"id"`
+	PageID    int64     `json:"page_id"`
+	Name      string    `json:"name"`
+	MenuTitle string    `json:"menu_title"`
+	Path      string    `json:"path"`
+	Parent    int64     `json:"parent"`
+	Sort      int       `json:"sort"`
+	Level     int       `json:"level"`
+	Children  int       `json:"children"`
+	Published bool      `json:"published"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type NodeTreeResponse struct {
+	NodeResponse
+	Items []NodeTreeResponse `json:"items"`
+}
+
+type NodePageResponse struct {
+	NodeID int64 `json:"node_id"`
+	PageID int64 `json:"page_id"`
+	Sort   int   `json:"sort"`
+	Pinned bool  `json:"pinned"`
+}
+
+func NewNodeResponse(node *entities.Node) *NodeResponse {
+	return &NodeResponse{
+		ID:        node.ID,
+		PageID:    node.PageID,
+		Name:      node.Name,
+		MenuTitle: node.MenuTitle,
+		Path:      node.Path,
+		Parent:    node.Parent,
+		Sort:      node.Sort,
+		Level:     node.Level,
+		Children:  node.Children,
+		Published: node.Published,
+		CreatedAt: node.CreatedAt,
+		UpdatedAt: node.UpdatedAt,
+	}
+}
+
+func NewNodeTreeResponse(items []*entities.NodeTree) []NodeTreeResponse {
+	res := make([]NodeTreeResponse, len(items))
+	for i, item := range items {
+		res[i] = NodeTreeResponse{
+			NodeResponse: *NewNodeResponse(&item.Node),
+			Items:        NewNodeTreeResponse(item.Items),
+		}
+	}
+	return res
+}
+
+func NewNodesResponse(nodes *entities.Nodes) []NodeResponse {
+	res := make([]NodeResponse, len(*nodes))
+	for i, node := range *nodes {
+		res[i] = *NewNodeResponse(&node)
+	}
+	return res
+}
+
+func NewNodePagesResponse(links *entities.NodePages) []NodePageResponse {
+	res := make([]NodePageResponse, len(*links))
+	for i, link := range *links {
+		res[i] = NodePageResponse{
+			NodeID: link.NodeID,
+			PageID: link.PageID,
+			Sort:   link.Sort,
+			Pinned: link.Pinned,
+		}
+	}
+	return res
+}
@@ -7,54 +7,174 @@ import (
 )
 
 type CreatePageRequest struct {
-	Name         string `json:"name"`
-	Meta         string `json:"meta"`
-	Title        string `json:"title"`
-	Category     string `json:"category"`
-	Template     string `json:"template"`
-	H1           string `json:"h1"`
-	Content      string `json:"content"`
-	ContentShort string `json:"content_short"`
+	Name          string              `json:"name"`
+	Meta          entities.PageMeta   `json:"meta"`
+	Title         string              `json:"title"`
+	Category      string              `json:"category"`
+	Template      string              `json:"template"`
+	H1            string              `json:"h1"`
+	Content       string              `json:"content"`
+	ContentShort  string              `json:"content_short"`
+	ContentFormat string              `json:"content_format"`
+	Status        string              `json:"status"`
+	PublishAt     *time.Time          `json:"publish_at"`
+	UnpublishAt   *time.Time          `json:"unpublish_at"`
+	ContentType   string              `json:"content_type"`
+	Fields        entities.PageFields `json:"fields"`
+	AuthorID      int64               `json:"-"`
 }
 
+// UpdatePageRequest replaces the page. Without content_type the current type
+// is kept ("" removes it); without fields the current values are kept
+// while the type does not change. With version (or If-Match) the save
+// fails when the page has been changed since that version.
 type UpdatePageRequest struct {
-	ID           int64  `json:"id"`
-	Name         string `json:"name"`
-	Meta         string `json:"meta"`
-	Title        string `json:"title"`
-	Category     string `json:"category"`
-	Template     string `json:"template"`
-	H1           string `json:"h1"`
-	Content      string `json:"content"`
-	ContentShort string `json:"content_short"`
+	ID            int64               `json:"id"`
+	Name          string              `json:"name"`
+	Meta          entities.PageMeta   `json:"meta"`
+	Title         string              `json:"title"`
+	Category      string              `json:"category"`
+	Template      string              `json:"template"`
+	H1            string              `json:"h1"`
+	Content       string              `json:"content"`
+	ContentShort  string              `json:"content_short"`
+	ContentFormat string              `json:"content_format"`
+	Status        string              `json:"status"`
+	PublishAt     *time.Time          `json:"publish_at"`
+	UnpublishAt   *time.Time          `json:"unpublish_at"`
+	ContentType   *string             `json:"content_type"`
+	Fields        entities.PageFields `json:"fields"`
+	Version       int                 `json:"version"`
+	AuthorID      int64               `json:"-"`
+}
+
+type PageStatusRequest struct {
+	ID          int64      `json:"id"`
+	Status      string     `json:"status"`
+	PublishAt   *time.Time `json:"publish_at"`
+	UnpublishAt *time.Time `json:"unpublish_at"`
+	Version     int        `json:"version"`
+}
+
+type PageLockRequest struct {
+	ID int64 `json:"id"`
+}
+
+type PageLockResponse struct {
+	PageID     int64     `json:"page_id"`
+	UserID     int64     `json:"user_id"`
+	Username   string    `json:"username"`
+	AcquiredAt time.Time `json:"acquired_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+}
+
+// PagePreviewRequest asks for a preview link; TTL is in seconds.
+type PagePreviewRequest struct {
+	ID  int64 `json:"id"`
+	TTL int   `json:"ttl"`
+}
+
+type PagePreviewResponse struct {
+	Token     string    `json:"token"`
+	URL       string    `json:"url"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+type PageSearchRequest struct {
+	Query string `form:"q"`
+	Lang  string `form:"lang"`
+}
+
+type PageSearchResponse struct {
+	ID             int64      `json:"id"`
+	Name           string     `json:"name"`
+	Title          string     `json:"title"`
+	Category       string     `json:"category"`
+	H1             string     `json:"h1"`
+	ContentShort   string     `json:"content_short"`
+	Status         string     `json:"status"`
+	PublishedAt    *time.Time `json:"published_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+	Rank           float64    `json:"rank"`
+	TitleHighlight string     `json:"title_highlight"`
+	Snippet        string     `json:"snippet"`
 }
 
 type PageResponse struct {
-	ID           int64     `json:"id"`
-	Name         string    `json:"name"`
-	Meta         string    `json:"meta"`
-	Title        string    `json:"title"`
-	Category     string    `json:"category"`
-	Template     string    `json:"template"`
-	H1           string    `json:"h1"`
-	Content      string    `json:"content"`
-	ContentShort string    `json:"content_short"`
-	CreatedAt    time.Time `json:"created_at"`
-	UpdatedAt    time.Time `json:"updated_at"`
+	ID            int64               `json:"id"`
+	Name          string              `json:"name"`
+	Meta          entities.PageMeta   `json:"meta"`
+	Title         string              `json:"title"`
+	Category      string              `json:"category"`
+	Template      string              `json:"template"`
+	H1            string              `json:"h1"`
+	Content       string              `json:"content"`
+	ContentShort  string              `json:"content_short"`
+	ContentFormat string              `json:"content_format"`
+	ContentHTML   string              `json:"content_html"`
+	Status        string              `json:"status"`
+	PublishAt     *time.Time          `json:"publish_at"`
+	UnpublishAt   *time.Time          `json:"unpublish_at"`
+	PublishedAt   *time.Time          `json:"published_at"`
+	CreatedAt     time.Time           `json:"created_at"`
+	UpdatedAt     time.Time           `json:"updated_at"`
+	Locale        string              `json:"locale,omitempty"`
+	ContentType   string              `json:"content_type,omitempty"`
+	Fields        entities.PageFields `json:"fields,omitempty"`
+	Version       int                 `json:"version"`
+	Tags          []string            `json:"tags,omitempty"`
 }
 
 func NewPageResponse(page *entities.PageWithTime) *PageResponse {
 	return &PageResponse{
-		ID:           page.ID,
-		Name:         page.Name,
-		Meta:         page.Meta,
-		Title:        page.Title,
-		Category:     page.Category,
-		Template:     page.Template,
-		H1:           page.H1,
-		Content:      page.Content,
-		ContentShort: page.ContentShort,
-		CreatedAt:    page.CreatedAt,
-		UpdatedAt:    page.UpdatedAt,
+		ID:            page.ID,
+		Name:          page.Name,
+		Meta:          page.Meta,
+		Title:         page.Title,
+		Category:      page.Category,
+		Template:      page.Template,
+		H1:            page.H1,
+		Content:       page.Content,
+		ContentShort:  page.ContentShort,
+		ContentFormat: page.ContentFormat,
+		ContentHTML:   page.ContentHTML,
+		Status:        page.Status,
+		PublishAt:     page.PublishAt,
+		UnpublishAt:   page.UnpublishAt,
+		PublishedAt:   page.PublishedAt,
+		CreatedAt:     page.CreatedAt,
+		UpdatedAt:     page.UpdatedAt,
+		Locale:        page.Locale,
+		ContentType:   page.ContentType,
+		Fields:        page.Fields,
+		Version:       page.Version,
+		Tags:          page.Tags,
+	}
+}
+
+func NewPageLockResponse(lock *entities.PageLock) *PageLockResponse {
+	return &PageLockResponse{
+		PageID:     lock.PageID,
+		UserID:     lock.UserID,
+		Username:   lock.Username,
+		AcquiredAt: lock.AcquiredAt,
+		ExpiresAt:  lock.ExpiresAt,
+	}
+}
+
+func NewPageSearchResponse(result *entities.PageSearchResult) *PageSearchResponse {
+	return &PageSearchResponse{
+		ID:             result.ID,
+		Name:           result.Name,
+		Title:          result.Title,
+		Category:       result.Category,
+		H1:             result.H1,
+		ContentShort:   result.ContentShort,
+		Status:         result.Status,
+		PublishedAt:    result.PublishedAt,
+		UpdatedAt:      result.UpdatedAt,
+		Rank:           result.Rank,
+		TitleHighlight: result.TitleHighlight,
+		Snippet:        result.Snippet,
 	}
 }
@@ -0,0 +1,66 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/aube/auth/internal/utils/diff"
+)
+
+type RestoreRevisionRequest struct {
+	ID       int64 `json:"id"`
+	Revision int   `json:"revision"`
+}
+
+type PageRevisionResponse struct {
+	PageID       int64               `json:"page_id"`
+	Revision     int                 `json:"revision"`
+	Name         string              `json:"name"`
+	Meta         entities.PageMeta   `json:"meta"`
+	Title        string              `json:"title"`
+	Category     string              `json:"category,omitempty"`
+	Template     string              `json:"template,omitempty"`
+	H1           string              `json:"h1,omitempty"`
+	Content      string              `json:"content,omitempty"`
+	ContentShort string              `json:"content_short,omitempty"`
+	ContentType  string              `json:"content_type,omitempty"`
+	Fields       entities.PageFields `json:"fields,omitempty"`
+	AuthorID     int64               `json:"author_id"`
+	CreatedAt    time.Time           `json:"created_at"`
+}
+
+// PageFieldDiff describes changes of one page field between two revisions.
+// Single-line fields carry Old/New values, multi-line fields carry Hunks.
+type PageFieldDiff struct {
+	Field   string      `json:"field"`
+	Changed bool        `json:"changed"`
+	Old     string      `json:"old,omitempty"`
+	New     string      `json:"new,omitempty"`
+	Hunks   []diff.Hunk `json:"hunks,omitempty"`
+}
+
+type PageRevisionDiff struct {
+	PageID int64           `json:"page_id"`
+	From   int             `json:"from"`
+	To     int             `json:"to"`
+	Fields []PageFieldDiff `json:"fields"`
+}
+
+func NewPageRevisionResponse(rev *entities.PageRevision) *PageRevisionResponse {
+	return &PageRevisionResponse{
+		PageID:       rev.PageID,
+		Revision:     rev.Revision,
+		Name:         rev.Name,
+		Meta:         rev.Meta,
+		Title:        rev.Title,
+		Category:     rev.Category,
+		Template:     rev.Template,
+		H1:           rev.H1,
+		Content:      rev.Content,
+		ContentShort: rev.ContentShort,
+		ContentType:  rev.ContentType,
+		Fields:       rev.Fields,
+		AuthorID:     rev.AuthorID,
+		CreatedAt:    rev.CreatedAt,
+	}
+}
@@ -0,0 +1,51 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/aube/auth/internal/domain/entities"
+)
+
+type PageTranslationRequest struct {
+	PageID        int64             `json:"page_id"`
+	Locale        string            `json:"locale"`
+	Name          string            `json:"name"`
+	Meta          entities.PageMeta `json:"meta"`
+	Title         string            `json:"title"`
+	H1            string            `json:"h1"`
+	Content       string            `json:"content"`
+	ContentShort  string            `json:"content_short"`
+	ContentFormat string            `json:"content_format"`
+}
+
+type PageTranslationResponse struct {
+	PageID        int64             `json:"page_id"`
+	Locale        string            `json:"locale"`
+	Name          string            `json:"name"`
+	Meta          entities.PageMeta `json:"meta"`
+	Title         string            `json:"title"`
+	H1            string            `json:"h1"`
+	Content       string            `json:"content"`
+	ContentShort  string            `json:"content_short"`
+	ContentFormat string            `json:"content_format"`
+	ContentHTML   string            `json:"content_html"`
+	CreatedAt     time.Time         `json:"created_at"`
+	UpdatedAt     time.Time         `json:"updated_at"`
+}
+
+func NewPageTranslationResponse(translation *entities.PageTranslation) *PageTranslationResponse {
+	return &PageTranslationResponse{
+		PageID:        translation.PageID,
+		Locale:        translation.Locale,
+		Name:          translation.Name,
+		Meta:          translation.Meta,
+		Title:         translation.Title,
+		H1:            translation.H1,
+		Content:       translation.Content,
+		ContentShort:  translation.ContentShort,
+		ContentFormat: translation.ContentFormat,
+		ContentHTML:   translation.ContentHTML,
+		CreatedAt:     translation.CreatedAt,
+		UpdatedAt:     translation.UpdatedAt,
+	}
+}
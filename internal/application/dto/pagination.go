@@ -1,15 +1,24 @@
 // Package dto contains data transfer objects for API requests and responses.
 package dto
 
+import "github.com/aube/auth/internal/utils/query"
+
 // Pagination represents pagination metadata for API responses.
 // Fields:
 //   - Size: Number of items per page (default: 10).
-//   - Page: Current page number (default: 1).
-//   - Total: Total number of items available.
+//   - Page: Current page number (default: 1), omitted in cursor mode.
+//   - Total: Total number of items available, -1 when not counted (total=false).
+//   - NextCursor, PrevCursor: Signed cursors of the adjacent pages.
 //
 // Used in list operations to provide pagination context.
 type Pagination struct {
-	Size  int `json:"size"`
-	Page  int `json:"page"`
-	Total int `json:"total"`
+	Size       int    `json:"size"`
+	Page       int    `json:"page,omitempty"`
+	Total      int    `json:"total"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	PrevCursor string `json:"prev_cursor,omitempty"`
+
+	// Next and Prev are filled by repositories and signed by the handlers
+	Next *query.Cursor `json:"-"`
+	Prev *query.Cursor `json:"-"`
 }
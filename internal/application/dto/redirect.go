@@ -0,0 +1,39 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/aube/auth/internal/domain/entities"
+)
+
+type RedirectRequest struct {
+	ID     int64  `json:"id"`
+	Source string `json:"source"`
+	Target string `json:"target"`
+	Kind   string `json:"kind"`
+	Code   int    `json:"code"`
+}
+
+type RedirectResponse struct {
+	ID        int64     `json:"id"`
+	Source    string    `json:"source"`
+	Target    string    `json:"target"`
+	Kind      string    `json:"kind"`
+	Code      int       `json:"code"`
+	Auto      bool      `json:"auto"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func NewRedirectResponse(redirect *entities.Redirect) *RedirectResponse {
+	return &RedirectResponse{
+		ID:        redirect.ID,
+		Source:    redirect.Source,
+		Target:    redirect.Target,
+		Kind:      redirect.Kind,
+		Code:      redirect.Code,
+		Auto:      redirect.Auto,
+		CreatedAt: redirect.CreatedAt,
+		UpdatedAt: redirect.UpdatedAt,
+	}
+}
@@ -0,0 +1,40 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/aube/auth/internal/domain/entities"
+)
+
+// ScanRequest names a stored file for rescan or release; uuid of the
+// current content of an upload or of an older version.
+type ScanRequest struct {
+	UUID string `json:"uuid" binding:"required"`
+}
+
+// QuarantineResponse is an upload under review with its owner and scan verdict.
+type QuarantineResponse struct {
+	UUID        string    `json:"uuid"`
+	Name        string    `json:"name"`
+	UserID      int64     `json:"user_id"`
+	Size        int64     `json:"size"`
+	ContentType string    `json:"content_type"`
+	Version     int       `json:"version"`
+	ScanStatus  string    `json:"scan_status"`
+	ScanDetail  string    `json:"scan_detail"`
+	UploadedAt  time.Time `json:"uploaded_at"`
+}
+
+func NewQuarantineResponse(upload *entities.Upload) QuarantineResponse {
+	return QuarantineResponse{
+		UUID:        upload.UUID,
+		Name:        upload.Name,
+		UserID:      upload.UserID,
+		Size:        upload.Size,
+		ContentType: upload.ContentType,
+		Version:     upload.Version,
+		ScanStatus:  upload.ScanStatus,
+		ScanDetail:  upload.ScanDetail,
+		UploadedAt:  upload.UploadedAt,
+	}
+}
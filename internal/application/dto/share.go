@@ -0,0 +1,62 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/aube/auth/internal/domain/entities"
+)
+
+// UploadShareRequest asks for a public link to an upload.
+// TTL is in seconds, 0 for a link without expiry; MaxDownloads 0 is unlimited;
+// Disposition is "attachment" (default) or "inline".
+type UploadShareRequest struct {
+	UUID         string `json:"uuid" binding:"required"`
+	TTL          int    `json:"ttl"`
+	Password     string `json:"password"`
+	MaxDownloads int    `json:"max_downloads"`
+	Disposition  string `json:"disposition"`
+}
+
+type UploadShareResponse struct {
+	ID           int64      `json:"id"`
+	Token        string     `json:"token"`
+	URL          string     `json:"url"`
+	UUID         string     `json:"uuid"`
+	Name         string     `json:"name"`
+	HasPassword  bool       `json:"has_password"`
+	ExpiresAt    *time.Time `json:"expires_at,omitempty"`
+	MaxDownloads int        `json:"max_downloads,omitempty"`
+	Downloads    int        `json:"downloads"`
+	Disposition  string     `json:"disposition"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+// NewUploadShareResponse builds the response; url is the public link.
+func NewUploadShareResponse(share *entities.UploadShare, url string) UploadShareResponse {
+	return UploadShareResponse{
+		ID:           share.ID,
+		Token:        share.Token,
+		URL:          url,
+		UUID:         share.Upload.UUID,
+		Name:         share.Upload.Name,
+		HasPassword:  share.HasPassword(),
+		ExpiresAt:    share.ExpiresAt,
+		MaxDownloads: share.MaxDownloads,
+		Downloads:    share.Downloads,
+		Disposition:  share.Disposition,
+		CreatedAt:    share.CreatedAt,
+	}
+}
+
+// UploadPresignRequest asks for a short-lived signed link; TTL is in seconds.
+type UploadPresignRequest struct {
+	UUID        string `json:"uuid" binding:"required"`
+	TTL         int    `json:"ttl"`
+	Disposition string `json:"disposition"`
+}
+
+type UploadPresignResponse struct {
+	Token     string    `json:"token"`
+	URL       string    `json:"url"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
@@ -0,0 +1,70 @@
+package dto
+
+import (
+	"encoding/xml"
+	"strconv"
+
+	"github.com/aube/auth/internal/domain/entities"
+)
+
+const (
+	sitemapXMLNS = "http://www.sitemaps.org/schemas/sitemap/0.9"
+	xhtmlXMLNS   = "http://www.w3.org/1999/xhtml"
+)
+
+type SitemapURLSet struct {
+	XMLName    xml.Name     `xml:"urlset"`
+	XMLNS      string       `xml:"xmlns,attr"`
+	XMLNSXHTML string       `xml:"xmlns:xhtml,attr,omitempty"`
+	URLs       []SitemapURL `xml:"url"`
+}
+
+type SitemapURL struct {
+	Loc        string             `xml:"loc"`
+	LastMod    string             `xml:"lastmod,omitempty"`
+	Alternates []SitemapAlternate `xml:"xhtml:link,omitempty"`
+}
+
+// SitemapAlternate is an hreflang link to a locale variant of the URL.
+type SitemapAlternate struct {
+	Rel      string `xml:"rel,attr"`
+	HrefLang string `xml:"hreflang,attr"`
+	Href     string `xml:"href,attr"`
+}
+
+type SitemapIndex struct {
+	XMLName  xml.Name     `xml:"sitemapindex"`
+	XMLNS    string       `xml:"xmlns,attr"`
+	Sitemaps []SitemapURL `xml:"sitemap"`
+}
+
+// NewSitemapURLSet builds a sitemap file; baseURL is prepended to entry paths.
+func NewSitemapURLSet(baseURL string, entries *entities.SitemapEntries) *SitemapURLSet {
+	set := &SitemapURLSet{XMLNS: sitemapXMLNS, URLs: make([]SitemapURL, len(*entries))}
+	for i, entry := range *entries {
+		set.URLs[i] = SitemapURL{
+			Loc:     baseURL + entry.Path,
+			LastMod: entry.LastMod.UTC().Format("2006-01-02"),
+		}
+		for _, alt := range entry.Alternates {
+			set.URLs[i].Alternates = append(set.URLs[i].Alternates, SitemapAlternate{
+				Rel:      "alternate",
+				HrefLang: alt.Locale,
+				Href:     baseURL + alt.Path,
+			})
+		}
+		if len(entry.Alternates) > 0 {
+			set.XMLNSXHTML = xhtmlXMLNS
+		}
+	}
+	return set
+}
+
+// NewSitemapIndex lists sitemap files {baseURL}/sitemap/{n}.xml.
+func NewSitemapIndex(baseURL string, files int) *SitemapIndex {
+	sitemaps := make([]SitemapURL, files)
+	for i := range sitemaps {
+		sitemaps[i] = SitemapURL{Loc: baseURL + "/sitemap/" + strconv.Itoa(i+1) + ".xml"}
+	}
+	return &SitemapIndex{XMLNS: sitemapXMLNS, Sitemaps: sitemaps}
+}
@@ -0,0 +1,63 @@
+package dto
+
+import "github.com/aube/auth/internal/domain/entities"
+
+// TagItemRequest sets tags of an item: a page by ID,
+// an upload or an image by UUID.
+type TagItemRequest struct {
+	Type string   `json:"type" form:"type"`
+	ID   int64    `json:"id" form:"id"`
+	UUID string   `json:"uuid" form:"uuid"`
+	Tags []string `json:"tags"`
+}
+
+// TagRenameRequest renames tag From of the items kind to To.
+type TagRenameRequest struct {
+	Type string `json:"type" binding:"required"`
+	From string `json:"from" binding:"required"`
+	To   string `json:"to" binding:"required"`
+}
+
+// TagMergeRequest merges tags From into To.
+type TagMergeRequest struct {
+	Type string   `json:"type" binding:"required"`
+	From []string `json:"from" binding:"required"`
+	To   string   `json:"to" binding:"required"`
+}
+
+type TagResponse struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+type FacetResponse struct {
+	Value string `json:"value"`
+	Count int    `json:"count"`
+}
+
+type FacetsResponse struct {
+	Tags       []FacetResponse `json:"tags"`
+	Categories []FacetResponse `json:"categories"`
+}
+
+func NewTagResponse(tag *entities.Tag) TagResponse {
+	return TagResponse{
+		Name:  tag.Name,
+		Count: tag.Count,
+	}
+}
+
+func NewFacetsResponse(facets *entities.Facets) *FacetsResponse {
+	return &FacetsResponse{
+		Tags:       newFacetResponses(facets.Tags),
+		Categories: newFacetResponses(facets.Categories),
+	}
+}
+
+func newFacetResponses(facets []entities.Facet) []FacetResponse {
+	res := make([]FacetResponse, len(facets))
+	for i, facet := range facets {
+		res[i] = FacetResponse{Value: facet.Value, Count: facet.Count}
+	}
+	return res
+}
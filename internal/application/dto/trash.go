@@ -0,0 +1,48 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/aube/auth/internal/domain/entities"
+)
+
+// TrashRequest references a deleted item: a page by ID,
+// an upload or an image by UUID.
+type TrashRequest struct {
+	Type string `json:"type" form:"type"`
+	ID   int64  `json:"id" form:"id"`
+	UUID string `json:"uuid" form:"uuid"`
+}
+
+type TrashItemResponse struct {
+	Type      string     `json:"type"`
+	ID        int64      `json:"id,omitempty"`
+	UUID      string     `json:"uuid,omitempty"`
+	Name      string     `json:"name"`
+	Title     string     `json:"title,omitempty"`
+	Size      int64      `json:"size,omitempty"`
+	DeletedAt time.Time  `json:"deleted_at"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// NewTrashItemResponse builds the response; retention 0 means the item
+// is never purged automatically.
+func NewTrashItemResponse(item *entities.TrashItem, retention time.Duration) TrashItemResponse {
+	res := TrashItemResponse{
+		Type:      item.Type,
+		UUID:      item.UUID,
+		Name:      item.Name,
+		Title:     item.Title,
+		Size:      item.Size,
+		DeletedAt: item.DeletedAt,
+	}
+	// Файлы адресуются по UUID, внутренний ID отдаётся только для страниц
+	if !item.HasBlob() {
+		res.ID = item.ID
+	}
+	if retention > 0 {
+		expiresAt := item.DeletedAt.Add(retention)
+		res.ExpiresAt = &expiresAt
+	}
+	return res
+}
@@ -9,17 +9,25 @@ import (
 // Fields:
 //   - UUID: Unique identifier of the uploaded file.
 //   - Name: Original filename.
+//   - FolderID: Folder of the file, 0 for the root.
 //   - Category: User-defined file category.
 //   - Size: File size in bytes.
 //   - ContentType: MIME type of the file.
+//   - Version: Number of the current version.
 //   - Description: User-provided file description.
+//   - Tags: File tags.
+//   - ScanStatus: Malware scan status, empty if the file was not scanned.
 type UploadResponse struct {
-	UUID        string `json:"uuid"`
-	Name        string `json:"name"`
-	Category    string `json:"category"`
-	Size        int64  `json:"size"`
-	ContentType string `json:"content_type"`
-	Description string `json:"description"`
+	UUID        string   `json:"uuid"`
+	Name        string   `json:"name"`
+	FolderID    int64    `json:"folder_id"`
+	Category    string   `json:"category"`
+	Size        int64    `json:"size"`
+	ContentType string   `json:"content_type"`
+	Version     int      `json:"version"`
+	Description string   `json:"description"`
+	Tags        []string `json:"tags,omitempty"`
+	ScanStatus  string   `json:"scan_status,omitempty"`
 }
 
 // NewUploadResponse creates an UploadResponse from an entities.Upload.
@@ -29,9 +37,13 @@ func NewUploadResponse(upload *entities.Upload) UploadResponse {
 	return UploadResponse{
 		UUID:        upload.UUID,
 		Name:        upload.Name,
+		FolderID:    upload.FolderID,
 		Category:    upload.Category,
 		Size:        upload.Size,
 		ContentType: upload.ContentType,
+		Version:     upload.Version,
 		Description: upload.Description,
+		Tags:        upload.Tags,
+		ScanStatus:  upload.ScanStatus,
 	}
 }
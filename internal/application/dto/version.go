@@ -0,0 +1,36 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/aube/auth/internal/domain/entities"
+)
+
+// VersionPromoteRequest makes the version of the file current;
+// the file is looked up by UUID or by name and folder.
+type VersionPromoteRequest struct {
+	UUID     string `json:"uuid"`
+	Name     string `json:"name"`
+	FolderID int64  `json:"folder_id"`
+	Version  int    `json:"version"`
+}
+
+type FileVersionResponse struct {
+	Version     int       `json:"version"`
+	Size        int64     `json:"size"`
+	ContentType string    `json:"content_type"`
+	ScanStatus  string    `json:"scan_status,omitempty"`
+	Current     bool      `json:"current"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+func NewFileVersionResponse(version *entities.FileVersion) FileVersionResponse {
+	return FileVersionResponse{
+		Version:     version.Version,
+		Size:        version.Size,
+		ContentType: version.ContentType,
+		ScanStatus:  version.ScanStatus,
+		Current:     version.Current,
+		CreatedAt:   version.CreatedAt,
+	}
+}
@@ -0,0 +1,16 @@
+package feed
+
+import (
+	"context"
+	"errors"
+
+	"github.com/aube/auth/internal/domain/entities"
+)
+
+var ErrFeedNotFound = errors.New("feed not found")
+
+// FeedRepository lists published pages newest first.
+// An empty category lists pages of all categories.
+type FeedRepository interface {
+	ListItems(ctx context.Context, category string, limit int) (*entities.FeedItems, error)
+}
@@ -0,0 +1,55 @@
+package feed
+
+import (
+	"context"
+
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/aube/auth/internal/utils/logger"
+	"github.com/rs/zerolog"
+)
+
+// DefaultLimit is the number of feed items used when the limit is not configured.
+const DefaultLimit = 20
+
+// FeedService builds syndication feeds of published pages.
+type FeedService struct {
+	repo  FeedRepository
+	limit int
+	log   zerolog.Logger
+}
+
+func NewFeedService(repo FeedRepository, limit int) *FeedService {
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+	return &FeedService{
+		repo:  repo,
+		limit: limit,
+		log:   logger.Get().With().Str("feed", "service").Logger(),
+	}
+}
+
+// Feed returns the latest published pages of category ("" for all categories).
+// A category without published pages is reported as ErrFeedNotFound.
+func (s *FeedService) Feed(ctx context.Context, category string) (*entities.Feed, error) {
+	items, err := s.repo.ListItems(ctx, category, s.limit)
+	if err != nil {
+		s.log.Debug().Err(err).Msg("Feed")
+		return nil, err
+	}
+	if category != "" && len(*items) == 0 {
+		return nil, ErrFeedNotFound
+	}
+
+	feed := &entities.Feed{Category: category, Items: *items}
+	for _, item := range *items {
+		if item.UpdatedAt.After(feed.Updated) {
+			feed.Updated = item.UpdatedAt
+		}
+		if item.PublishedAt.After(feed.Updated) {
+			feed.Updated = item.PublishedAt
+		}
+	}
+
+	return feed, nil
+}
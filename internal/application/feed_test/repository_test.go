@@ -0,0 +1,20 @@
+package feed_test
+
+import (
+	"context"
+
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/stretchr/testify/mock"
+)
+
+type FeedRepository struct {
+	mock.Mock
+}
+
+func (m *FeedRepository) ListItems(ctx context.Context, category string, limit int) (*entities.FeedItems, error) {
+	args := m.Called(ctx, category, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.FeedItems), args.Error(1)
+}
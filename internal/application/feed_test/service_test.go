@@ -0,0 +1,49 @@
+package feed_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	appFeed "github.com/aube/auth/internal/application/feed"
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFeedService_Feed(t *testing.T) {
+	repo := new(FeedRepository)
+	service := appFeed.NewFeedService(repo, 5)
+
+	published := time.Date(2025, 5, 20, 10, 0, 0, 0, time.UTC)
+	updated := time.Date(2025, 5, 21, 10, 0, 0, 0, time.UTC)
+	items := &entities.FeedItems{
+		{PageID: 2, Title: "Second", PublishedAt: published, UpdatedAt: published},
+		{PageID: 1, Title: "First", PublishedAt: published.Add(-time.Hour), UpdatedAt: updated},
+	}
+	repo.On("ListItems", mock.Anything, "news", 5).Return(items, nil)
+
+	feed, err := service.Feed(context.Background(), "news")
+	require.NoError(t, err)
+	assert.Equal(t, "news", feed.Category)
+	assert.Len(t, feed.Items, 2)
+	assert.Equal(t, updated, feed.Updated)
+}
+
+func TestFeedService_FeedEmptyCategory(t *testing.T) {
+	repo := new(FeedRepository)
+	service := appFeed.NewFeedService(repo, 0)
+
+	repo.On("ListItems", mock.Anything, "missing", appFeed.DefaultLimit).Return(&entities.FeedItems{}, nil)
+	repo.On("ListItems", mock.Anything, "", appFeed.DefaultLimit).Return(&entities.FeedItems{}, nil)
+
+	_, err := service.Feed(context.Background(), "missing")
+	assert.ErrorIs(t, err, appFeed.ErrFeedNotFound)
+
+	// Общая лента отдаётся и без страниц
+	feed, err := service.Feed(context.Background(), "")
+	require.NoError(t, err)
+	assert.Empty(t, feed.Items)
+	assert.True(t, feed.Updated.IsZero())
+}
@@ -0,0 +1,32 @@
+package file
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// sniffLen is how much content http.DetectContentType looks at.
+const sniffLen = 512
+
+// DetectType detects the type of the content by its first bytes and
+// returns a reader of the whole content, the read bytes included.
+func DetectType(data io.Reader) (string, io.Reader, error) {
+	head := make([]byte, sniffLen)
+	n, err := io.ReadFull(data, head)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return "", nil, err
+	}
+	head = head[:n]
+
+	detected := http.DetectContentType(head)
+	// SVG распознаётся как XML или текст, а исполняется браузером как HTML
+	if (strings.HasPrefix(detected, "text/xml") || strings.HasPrefix(detected, "text/plain")) &&
+		bytes.Contains(bytes.ToLower(head), []byte("<svg")) {
+		detected = "image/svg+xml"
+	}
+
+	return detected, io.MultiReader(bytes.NewReader(head), data), nil
+}
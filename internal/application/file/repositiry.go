@@ -9,8 +9,12 @@ import (
 	"github.com/aube/auth/internal/domain/entities"
 )
 
-// ErrFileNotFound is returned when a requested file cannot be located in storage.
-var ErrFileNotFound = errors.New("file not found")
+var (
+	// ErrFileNotFound is returned when a requested file cannot be located in storage.
+	ErrFileNotFound = errors.New("file not found")
+	// ErrTypeNotAllowed is returned for uploads of a type the policy rejects.
+	ErrTypeNotAllowed = errors.New("file type is not allowed")
+)
 
 // FileRepository defines the interface for file persistence operations.
 // Implementations should handle actual file storage (e.g., disk, cloud storage).
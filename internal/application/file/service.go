@@ -3,6 +3,7 @@ package file
 
 import (
 	"context"
+	"fmt"
 	"io"
 
 	"github.com/aube/auth/internal/domain/entities"
@@ -16,39 +17,67 @@ import (
 //
 // Fields:
 //   - repo: Underlying storage repository
+//   - types: Types of files the storage accepts
 //   - log: Structured logger instance
 
 type FileService struct {
-	repo FileRepository
-	log  zerolog.Logger
+	repo  FileRepository
+	types entities.TypePolicy
+	log   zerolog.Logger
 }
 
 // NewFileService creates a new FileService instance.
 // repo: Storage repository implementation
+// types: Types of files the storage accepts
 // Returns: Configured *FileService
-func NewFileService(repo FileRepository) *FileService {
+func NewFileService(repo FileRepository, types entities.TypePolicy) *FileService {
 	return &FileService{
-		repo: repo,
-		log:  logger.Get().With().Str("file", "service").Logger(),
+		repo:  repo,
+		types: types,
+		log:   logger.Get().With().Str("file", "service").Logger(),
 	}
 }
 
 // Upload handles file upload business logic:
-// 1. Generates a new UUID for the file
-// 2. Creates file metadata entity
-// 3. Delegates storage to repository
+// 1. Detects the type from the content and checks it against the policy
+// 2. Generates a new UUID for the file
+// 3. Creates file metadata entity
+// 4. Delegates storage to repository
 //
 // ctx: Context for cancellation/timeout
 // size: File size in bytes
+// declaredType: Content-Type claimed by the client, may be empty
 // data: File content stream
-// Returns: (*entities.File, error) - created file metadata
-func (s *FileService) Upload(ctx context.Context, size int64, data io.Reader) (*entities.File, error) {
+// Returns: (*entities.File, error) - created file metadata,
+// ErrTypeNotAllowed when the policy rejects the type (nothing is stored)
+func (s *FileService) Upload(ctx context.Context, size int64, declaredType string, data io.Reader) (*entities.File, error) {
+	detected, data, err := DetectType(data)
+	if err != nil {
+		s.log.Debug().Err(err).Msg("Upload1")
+		return nil, err
+	}
+
+	// Заявленный тип не должен открывать путь содержимому, которое
+	// политика запрещает: проверяется и определённый по содержимому тип
+	contentType := entities.ResolveContentType(declaredType, detected)
+	for _, t := range []string{detected, contentType} {
+		if !s.types.Allows(t) {
+			return nil, fmt.Errorf("%w: %s", ErrTypeNotAllowed, entities.MediaType(t))
+		}
+	}
+
 	file := entities.NewFile(
 		generateFileUUID(),
 		"", // Путь будет установлен в репозитории
 		size,
 	)
+	file.ContentType = contentType
+	file.DeclaredType = declaredType
 	if err := s.repo.Save(ctx, file, data); err != nil {
+		// Недописанный файл не должен остаться в хранилище
+		if err := s.repo.Delete(ctx, file.Name); err != nil {
+			s.log.Debug().Err(err).Msg("Upload2")
+		}
 		return nil, err
 	}
 
@@ -17,7 +17,7 @@ import (
 func TestFileService_Upload_Success(t *testing.T) {
 	// Setup
 	mockRepo := new(FileRepository)
-	service := file.NewFileService(mockRepo)
+	service := file.NewFileService(mockRepo, entities.TypePolicy{})
 
 	// Test data
 	testContent := []byte("test file content")
@@ -37,7 +37,7 @@ func TestFileService_Upload_Success(t *testing.T) {
 		Return(nil)
 
 	// Execute
-	file, err := service.Upload(context.Background(), int64(len(testContent)), bytes.NewReader(testContent))
+	file, err := service.Upload(context.Background(), int64(len(testContent)), "text/plain", bytes.NewReader(testContent))
 
 	// Assert
 	require.NoError(t, err)
@@ -49,15 +49,16 @@ func TestFileService_Upload_Success(t *testing.T) {
 func TestFileService_Upload_RepositoryError(t *testing.T) {
 	// Setup
 	mockRepo := new(FileRepository)
-	service := file.NewFileService(mockRepo)
+	service := file.NewFileService(mockRepo, entities.TypePolicy{})
 
 	// Mock expectations
 	expectedError := errors.New("repository error")
 	mockRepo.On("Save", mock.Anything, mock.Anything, mock.Anything).
 		Return(expectedError)
+	mockRepo.On("Delete", mock.Anything, mock.AnythingOfType("string")).Return(nil)
 
 	// Execute
-	file, err := service.Upload(context.Background(), 123, bytes.NewReader([]byte("test")))
+	file, err := service.Upload(context.Background(), 123, "", bytes.NewReader([]byte("test")))
 
 	// Assert
 	assert.Nil(t, file)
@@ -68,7 +69,7 @@ func TestFileService_Upload_RepositoryError(t *testing.T) {
 func TestFileService_Download_Success(t *testing.T) {
 	// Setup
 	mockRepo := new(FileRepository)
-	service := file.NewFileService(mockRepo)
+	service := file.NewFileService(mockRepo, entities.TypePolicy{})
 
 	// Test data
 	expectedContent := []byte("test content")
@@ -94,7 +95,7 @@ func TestFileService_Download_Success(t *testing.T) {
 func TestFileService_Download_NotFound(t *testing.T) {
 	// Setup
 	mockRepo := new(FileRepository)
-	service := file.NewFileService(mockRepo)
+	service := file.NewFileService(mockRepo, entities.TypePolicy{})
 
 	// Mock expectations
 	mockRepo.On("GetFileContent", mock.Anything, "not-found").
@@ -112,7 +113,7 @@ func TestFileService_Download_NotFound(t *testing.T) {
 func TestFileService_Delete_Success(t *testing.T) {
 	// Setup
 	mockRepo := new(FileRepository)
-	service := file.NewFileService(mockRepo)
+	service := file.NewFileService(mockRepo, entities.TypePolicy{})
 
 	// Mock expectations
 	mockRepo.On("Delete", mock.Anything, "test-uuid").Return(nil)
@@ -128,7 +129,7 @@ func TestFileService_Delete_Success(t *testing.T) {
 func TestFileService_Delete_Error(t *testing.T) {
 	// Setup
 	mockRepo := new(FileRepository)
-	service := file.NewFileService(mockRepo)
+	service := file.NewFileService(mockRepo, entities.TypePolicy{})
 
 	// Mock expectations
 	expectedError := errors.New("delete error")
@@ -141,3 +142,59 @@ func TestFileService_Delete_Error(t *testing.T) {
 	assert.Equal(t, expectedError, err)
 	mockRepo.AssertExpectations(t)
 }
+
+func TestFileService_Upload_DetectsType(t *testing.T) {
+	mockRepo := new(FileRepository)
+	service := file.NewFileService(mockRepo, entities.TypePolicy{})
+
+	testContent := []byte("<!DOCTYPE html><script>alert(1)</script>")
+	mockRepo.On("Save", mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			// Прочитанное для определения типа тоже сохраняется
+			data, err := io.ReadAll(args.Get(2).(io.Reader))
+			assert.NoError(t, err)
+			assert.Equal(t, testContent, data)
+		}).
+		Return(nil)
+
+	file, err := service.Upload(context.Background(), int64(len(testContent)), "image/png", bytes.NewReader(testContent))
+
+	require.NoError(t, err)
+	assert.Equal(t, "text/html; charset=utf-8", file.ContentType)
+	assert.Equal(t, "image/png", file.DeclaredType)
+}
+
+func TestFileService_Upload_TypeNotAllowed(t *testing.T) {
+	mockRepo := new(FileRepository)
+	service := file.NewFileService(mockRepo, entities.NewTypePolicy("image/*", "image/svg+xml"))
+
+	svg := `<?xml version="1.0"?><svg xmlns="http://www.w3.org/2000/svg" onload="alert(1)"/>`
+	_, err := service.Upload(context.Background(), int64(len(svg)), "image/png", bytes.NewReader([]byte(svg)))
+
+	assert.ErrorIs(t, err, file.ErrTypeNotAllowed)
+	mockRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestFileService_Upload_DeclaredTypeNotTrusted(t *testing.T) {
+	mockRepo := new(FileRepository)
+	service := file.NewFileService(mockRepo, entities.NewTypePolicy("image/*", ""))
+
+	// Содержимое без сигнатуры объявлено картинкой
+	for _, content := range []string{"plain text", "\x00\x01\x02\x03"} {
+		_, err := service.Upload(context.Background(), int64(len(content)), "image/png", bytes.NewReader([]byte(content)))
+
+		assert.ErrorIs(t, err, file.ErrTypeNotAllowed)
+	}
+	mockRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestFileService_Upload_DeclaredTypeChecked(t *testing.T) {
+	mockRepo := new(FileRepository)
+	service := file.NewFileService(mockRepo, entities.NewTypePolicy("", "text/csv"))
+
+	content := "a,b\n1,2\n"
+	_, err := service.Upload(context.Background(), int64(len(content)), "text/csv", bytes.NewReader([]byte(content)))
+
+	assert.ErrorIs(t, err, file.ErrTypeNotAllowed)
+	mockRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything, mock.Anything)
+}
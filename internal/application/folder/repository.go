@@ -0,0 +1,45 @@
+// Package folder provides virtual folders of uploads.
+package folder
+
+import (
+	"context"
+	"errors"
+
+	"github.com/aube/auth/internal/application/dto"
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/aube/auth/internal/utils/query"
+)
+
+var (
+	// ErrFolderNotFound is returned when a folder of the user cannot be found.
+	ErrFolderNotFound = errors.New("folder not found")
+	// ErrFolderExists is returned when the parent already has a folder with the name.
+	ErrFolderExists = errors.New("folder with this name already exists")
+	// ErrFolderCycle is returned when a folder is moved into itself or its subfolder.
+	ErrFolderCycle = errors.New("folder cannot be moved into its own subfolder")
+)
+
+// FolderRepository defines the interface for folder persistence.
+// Folders are scoped by owner: a folder of another user is not found.
+//
+// Methods:
+//
+//   - Create: Stores a folder under its parent, fills ID
+//     Returns ErrFolderExists for a taken name, ErrFolderNotFound for a missing parent
+//   - Update: Saves name and parent; moving a folder into its subtree returns ErrFolderCycle
+//   - Delete: Deletes a folder with its subfolders, their uploads go to the trash
+//     Returns the number of trashed uploads
+//   - FindByID: Single folder lookup
+//   - ListAncestors: Ancestors of a folder including itself, root first
+//   - NamesWithPrefix: Names of subfolders of parent starting with prefix
+//   - ListChildren: Paginated subfolders and uploads of a folder (0 for the root)
+type FolderRepository interface {
+	Create(ctx context.Context, folder *entities.UploadFolder) error
+	Update(ctx context.Context, folder *entities.UploadFolder) error
+	Delete(ctx context.Context, id int64, userID int64) (int64, error)
+
+	FindByID(ctx context.Context, id int64, userID int64) (*entities.UploadFolder, error)
+	ListAncestors(ctx context.Context, id int64, userID int64) (*entities.UploadFolders, error)
+	NamesWithPrefix(ctx context.Context, userID int64, parentID int64, prefix string) ([]string, error)
+	ListChildren(ctx context.Context, userID int64, folderID int64, offset, limit int, filter *query.Query) (*entities.FolderEntries, *dto.Pagination, error)
+}
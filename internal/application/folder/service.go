@@ -0,0 +1,171 @@
+package folder
+
+import (
+	"context"
+	"time"
+
+	"github.com/aube/auth/internal/application/dto"
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/aube/auth/internal/utils/logger"
+	"github.com/aube/auth/internal/utils/query"
+	"github.com/rs/zerolog"
+)
+
+// ListSchema lists the folder entry fields clients may filter and sort by.
+var ListSchema = query.Schema{
+	"type":       {Type: query.TypeString},
+	"name":       {Type: query.TypeString, Sortable: true},
+	"size":       {Type: query.TypeInt, Sortable: true},
+	"updated_at": {Type: query.TypeTime, Sortable: true},
+}
+
+// FolderService manages folders of uploads. Names are unique among
+// the subfolders of a parent; a taken name is rejected or, with the
+// rename policy, replaced by a free "name (N)". Folders cannot be
+// overwritten.
+type FolderService struct {
+	repo FolderRepository
+	log  zerolog.Logger
+}
+
+func NewFolderService(repo FolderRepository) *FolderService {
+	return &FolderService{
+		repo: repo,
+		log:  logger.Get().With().Str("folder", "service").Logger(),
+	}
+}
+
+func (s *FolderService) Create(ctx context.Context, userID int64, req dto.FolderRequest) (*entities.UploadFolder, error) {
+	folder, err := entities.NewUploadFolder(userID, req.ParentID, req.Name, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkParent(ctx, req.ParentID, userID); err != nil {
+		return nil, err
+	}
+	if folder.Name, err = s.resolveName(ctx, userID, req.ParentID, folder.Name, req.Conflict); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Create(ctx, folder); err != nil {
+		s.log.Debug().Err(err).Msg("Create")
+		return nil, err
+	}
+
+	return folder, nil
+}
+
+// Get returns a folder with its ancestors, root first, the folder last.
+func (s *FolderService) Get(ctx context.Context, id int64, userID int64) (*entities.UploadFolders, error) {
+	return s.repo.ListAncestors(ctx, id, userID)
+}
+
+func (s *FolderService) Rename(ctx context.Context, id int64, userID int64, name string, policy string) (*entities.UploadFolder, error) {
+	name, err := entities.ValidateFolderName(name)
+	if err != nil {
+		return nil, err
+	}
+	folder, err := s.repo.FindByID(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if folder.Name == name {
+		return folder, nil
+	}
+
+	if folder.Name, err = s.resolveName(ctx, userID, folder.ParentID, name, policy); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Update(ctx, folder); err != nil {
+		s.log.Debug().Err(err).Msg("Rename")
+		return nil, err
+	}
+
+	return folder, nil
+}
+
+// Move moves a folder with its contents under another parent (0 for the root).
+func (s *FolderService) Move(ctx context.Context, id int64, userID int64, parentID int64, policy string) (*entities.UploadFolder, error) {
+	folder, err := s.repo.FindByID(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if folder.ParentID == parentID {
+		return folder, nil
+	}
+	if parentID == id {
+		return nil, ErrFolderCycle
+	}
+	if err := s.checkParent(ctx, parentID, userID); err != nil {
+		return nil, err
+	}
+
+	if folder.Name, err = s.resolveName(ctx, userID, parentID, folder.Name, policy); err != nil {
+		return nil, err
+	}
+	folder.ParentID = parentID
+	if err := s.repo.Update(ctx, folder); err != nil {
+		s.log.Debug().Err(err).Msg("Move")
+		return nil, err
+	}
+
+	return folder, nil
+}
+
+// Delete removes a folder with its subfolders; their uploads go to the
+// trash and are restored into the root. Returns the number of trashed uploads.
+func (s *FolderService) Delete(ctx context.Context, id int64, userID int64) (int64, error) {
+	trashed, err := s.repo.Delete(ctx, id, userID)
+	if err != nil {
+		s.log.Debug().Err(err).Msg("Delete")
+		return 0, err
+	}
+	return trashed, nil
+}
+
+// List returns subfolders and uploads of a folder (0 for the root).
+// Subfolders always come first, the client sort (name by default) applies within each kind.
+func (s *FolderService) List(ctx context.Context, userID int64, folderID int64, offset, limit int, filter *query.Query) (*entities.FolderEntries, *dto.Pagination, error) {
+	if err := s.checkParent(ctx, folderID, userID); err != nil {
+		return nil, nil, err
+	}
+
+	filter = filter.Clone()
+	sort := []query.Sort{{Field: "type", Desc: true}}
+	if len(filter.Sort) == 0 {
+		filter.Sort = []query.Sort{{Field: "name"}}
+	}
+	filter.Sort = append(sort, filter.Sort...)
+
+	return s.repo.ListChildren(ctx, userID, folderID, offset, limit, filter)
+}
+
+// checkParent verifies the folder exists; 0 is the root.
+func (s *FolderService) checkParent(ctx context.Context, id int64, userID int64) error {
+	if id == 0 {
+		return nil
+	}
+	_, err := s.repo.FindByID(ctx, id, userID)
+	return err
+}
+
+// resolveName applies the conflict policy (reject by default) to a folder name under parent.
+func (s *FolderService) resolveName(ctx context.Context, userID int64, parentID int64, name string, policy string) (string, error) {
+	if policy == "" {
+		policy = entities.ConflictReject
+	}
+	if policy != entities.ConflictReject && policy != entities.ConflictRename {
+		return "", entities.ErrInvalidConflictPolicy
+	}
+	if policy == entities.ConflictReject {
+		// Занятое имя отклоняет уникальный индекс
+		return name, nil
+	}
+
+	names, err := s.repo.NamesWithPrefix(ctx, userID, parentID, entities.NameStem(name))
+	if err != nil {
+		s.log.Debug().Err(err).Msg("resolveName")
+		return "", err
+	}
+	return entities.FreeName(name, names), nil
+}
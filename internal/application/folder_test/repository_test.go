@@ -0,0 +1,59 @@
+package folder_test
+
+import (
+	"context"
+
+	"github.com/aube/auth/internal/application/dto"
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/aube/auth/internal/utils/query"
+	"github.com/stretchr/testify/mock"
+)
+
+type FolderRepository struct {
+	mock.Mock
+}
+
+func (m *FolderRepository) Create(ctx context.Context, folder *entities.UploadFolder) error {
+	return m.Called(ctx, folder).Error(0)
+}
+
+func (m *FolderRepository) Update(ctx context.Context, folder *entities.UploadFolder) error {
+	return m.Called(ctx, folder).Error(0)
+}
+
+func (m *FolderRepository) Delete(ctx context.Context, id int64, userID int64) (int64, error) {
+	args := m.Called(ctx, id, userID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *FolderRepository) FindByID(ctx context.Context, id int64, userID int64) (*entities.UploadFolder, error) {
+	args := m.Called(ctx, id, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.UploadFolder), args.Error(1)
+}
+
+func (m *FolderRepository) ListAncestors(ctx context.Context, id int64, userID int64) (*entities.UploadFolders, error) {
+	args := m.Called(ctx, id, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.UploadFolders), args.Error(1)
+}
+
+func (m *FolderRepository) NamesWithPrefix(ctx context.Context, userID int64, parentID int64, prefix string) ([]string, error) {
+	args := m.Called(ctx, userID, parentID, prefix)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *FolderRepository) ListChildren(ctx context.Context, userID int64, folderID int64, offset, limit int, filter *query.Query) (*entities.FolderEntries, *dto.Pagination, error) {
+	args := m.Called(ctx, userID, folderID, offset, limit, filter)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+	return args.Get(0).(*entities.FolderEntries), args.Get(1).(*dto.Pagination), args.Error(2)
+}
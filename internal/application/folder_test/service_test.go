@@ -0,0 +1,88 @@
+package folder_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aube/auth/internal/application/dto"
+	appFolder "github.com/aube/auth/internal/application/folder"
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/aube/auth/internal/utils/query"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFolderService_Create(t *testing.T) {
+	repo := new(FolderRepository)
+	service := appFolder.NewFolderService(repo)
+	ctx := context.Background()
+
+	repo.On("FindByID", ctx, int64(3), int64(1)).Return(&entities.UploadFolder{ID: 3, UserID: 1}, nil)
+	repo.On("FindByID", ctx, int64(9), int64(1)).Return(nil, appFolder.ErrFolderNotFound)
+	repo.On("NamesWithPrefix", ctx, int64(1), int64(3), "docs").Return([]string{"docs", "docs (1)"}, nil)
+	repo.On("Create", ctx, mock.AnythingOfType("*entities.UploadFolder")).Return(nil)
+
+	folder, err := service.Create(ctx, 1, dto.FolderRequest{ParentID: 3, Name: " docs "})
+	require.NoError(t, err)
+	assert.Equal(t, "docs", folder.Name)
+	assert.Equal(t, int64(3), folder.ParentID)
+
+	folder, err = service.Create(ctx, 1, dto.FolderRequest{ParentID: 3, Name: "docs", Conflict: entities.ConflictRename})
+	require.NoError(t, err)
+	assert.Equal(t, "docs (2)", folder.Name)
+
+	_, err = service.Create(ctx, 1, dto.FolderRequest{ParentID: 3, Name: "docs", Conflict: entities.ConflictOverwrite})
+	assert.ErrorIs(t, err, entities.ErrInvalidConflictPolicy)
+
+	_, err = service.Create(ctx, 1, dto.FolderRequest{ParentID: 9, Name: "docs"})
+	assert.ErrorIs(t, err, appFolder.ErrFolderNotFound)
+
+	_, err = service.Create(ctx, 1, dto.FolderRequest{Name: "a/b"})
+	assert.ErrorIs(t, err, entities.ErrInvalidFolderName)
+
+	repo.AssertNumberOfCalls(t, "Create", 2)
+}
+
+func TestFolderService_Move(t *testing.T) {
+	repo := new(FolderRepository)
+	service := appFolder.NewFolderService(repo)
+	ctx := context.Background()
+
+	repo.On("FindByID", ctx, int64(5), int64(1)).Return(&entities.UploadFolder{ID: 5, UserID: 1, ParentID: 3, Name: "docs"}, nil)
+	repo.On("FindByID", ctx, int64(7), int64(1)).Return(&entities.UploadFolder{ID: 7, UserID: 1, Name: "archive"}, nil)
+	repo.On("Update", ctx, mock.AnythingOfType("*entities.UploadFolder")).Return(nil)
+
+	_, err := service.Move(ctx, 5, 1, 5, "")
+	assert.ErrorIs(t, err, appFolder.ErrFolderCycle)
+
+	folder, err := service.Move(ctx, 5, 1, 7, "")
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), folder.ParentID)
+	assert.Equal(t, "docs", folder.Name)
+
+	// Перемещение в ту же папку ничего не меняет
+	_, err = service.Move(ctx, 7, 1, 0, "")
+	require.NoError(t, err)
+	repo.AssertNumberOfCalls(t, "Update", 1)
+}
+
+func TestFolderService_List(t *testing.T) {
+	repo := new(FolderRepository)
+	service := appFolder.NewFolderService(repo)
+	ctx := context.Background()
+
+	entries := &entities.FolderEntries{{Type: entities.FolderEntryFolder, ID: 4, Name: "docs"}}
+	pagination := &dto.Pagination{Page: 1, Size: 10, Total: 1}
+	repo.On("ListChildren", ctx, int64(1), int64(0), 0, 10, mock.MatchedBy(func(q *query.Query) bool {
+		return len(q.Sort) == 2 && q.Sort[0] == query.Sort{Field: "type", Desc: true} && q.Sort[1].Field == "size"
+	})).Return(entries, pagination, nil)
+
+	filter := query.New()
+	filter.Sort = []query.Sort{{Field: "size"}}
+	result, _, err := service.List(ctx, 1, 0, 0, 10, filter)
+
+	require.NoError(t, err)
+	assert.Equal(t, entries, result)
+	assert.Len(t, filter.Sort, 1)
+}
@@ -6,13 +6,14 @@ import (
 
 	"github.com/aube/auth/internal/application/dto"
 	"github.com/aube/auth/internal/domain/entities"
+	"github.com/aube/auth/internal/utils/query"
 )
 
 var ErrFileNotFound = errors.New("file not found")
 
 type ImageRepository interface {
 	Create(ctx context.Context, userID int64, image *entities.Image) error
-	ListByUserID(ctx context.Context, userID int64, offset, limit int, params map[string]any) (*entities.Images, *dto.Pagination, error)
+	ListByUserID(ctx context.Context, userID int64, offset, limit int, filter *query.Query) (*entities.Images, *dto.Pagination, error)
 	GetByUUID(ctx context.Context, uuid string, userID int64) (*entities.Image, error)
 	GetByName(ctx context.Context, name string, userID int64) (*entities.Image, error)
 	Delete(ctx context.Context, uuid string, userID int64) error
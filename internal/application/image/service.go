@@ -5,8 +5,10 @@ import (
 	"time"
 
 	"github.com/aube/auth/internal/application/dto"
+	appTag "github.com/aube/auth/internal/application/tag"
 	"github.com/aube/auth/internal/domain/entities"
 	"github.com/aube/auth/internal/utils/logger"
+	"github.com/aube/auth/internal/utils/query"
 	"github.com/rs/zerolog"
 )
 
@@ -43,8 +45,23 @@ func (s *ImageService) RegisterUploadedImage(
 	return image, nil
 }
 
-func (s *ImageService) ListByUserID(ctx context.Context, userID int64, offset, limit int, params map[string]any) (*entities.Images, *dto.Pagination, error) {
-	return s.repo.ListByUserID(ctx, userID, offset, limit, params)
+// ListSchema lists the image fields clients may filter and sort by.
+var ListSchema = query.Schema{
+	"name":         {Type: query.TypeString, Sortable: true},
+	"category":     {Type: query.TypeString, Sortable: true},
+	"content_type": {Type: query.TypeString, Sortable: true},
+	"size":         {Type: query.TypeInt, Sortable: true},
+	"description":  {Type: query.TypeString},
+	"created_at":   {Type: query.TypeTime, Sortable: true},
+	"updated_at":   {Type: query.TypeTime, Sortable: true},
+	"tags":         {Type: query.TypeString, List: true},
+}
+
+func (s *ImageService) ListByUserID(ctx context.Context, userID int64, offset, limit int, filter *query.Query) (*entities.Images, *dto.Pagination, error) {
+	if err := appTag.NormalizeFilter(filter); err != nil {
+		return nil, nil, err
+	}
+	return s.repo.ListByUserID(ctx, userID, offset, limit, filter)
 }
 
 func (s *ImageService) GetByUUID(ctx context.Context, uuid string, userID int64) (*entities.Image, error) {
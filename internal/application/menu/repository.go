@@ -0,0 +1,26 @@
+// Package menu builds navigation menus from the site tree.
+package menu
+
+import (
+	"context"
+	"errors"
+
+	"github.com/aube/auth/internal/domain/entities"
+)
+
+// ErrMenuNotFound is returned when a requested menu is not configured.
+var ErrMenuNotFound = errors.New("menu not found")
+
+// MenuRepository defines persistence of menu definitions.
+type MenuRepository interface {
+	FindByName(ctx context.Context, name string) (*entities.Menu, error)
+	List(ctx context.Context) (*entities.Menus, error)
+	Save(ctx context.Context, menu *entities.Menu) error
+	Delete(ctx context.Context, name string) error
+}
+
+// NodeSource provides the flat site tree a menu is built from.
+// Implemented by the node repository.
+type NodeSource interface {
+	ListSubtree(ctx context.Context, rootID int64, depth int) (*entities.Nodes, error)
+}
@@ -0,0 +1,137 @@
+package menu
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aube/auth/internal/application/dto"
+	appNode "github.com/aube/auth/internal/application/node"
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/aube/auth/internal/utils/logger"
+	"github.com/rs/zerolog"
+)
+
+type cachedMenu struct {
+	items     []*entities.MenuItem
+	expiresAt time.Time
+}
+
+// MenuService builds menus and keeps them in an in-memory cache.
+// The cache is dropped by Invalidate (subscribed to node and page changes)
+// and entries additionally expire after ttl.
+type MenuService struct {
+	repo  MenuRepository
+	nodes NodeSource
+	ttl   time.Duration
+	log   zerolog.Logger
+
+	mu    sync.RWMutex
+	cache map[string]cachedMenu
+}
+
+func NewMenuService(repo MenuRepository, nodes NodeSource, ttl time.Duration) *MenuService {
+	return &MenuService{
+		repo:  repo,
+		nodes: nodes,
+		ttl:   ttl,
+		log:   logger.Get().With().Str("menu", "service").Logger(),
+		cache: make(map[string]cachedMenu),
+	}
+}
+
+// GetMenu returns menu items with the active trail marked for currentPath.
+func (s *MenuService) GetMenu(ctx context.Context, name string, currentPath string) ([]*entities.MenuItem, error) {
+	items, err := s.getItems(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if currentPath != "" {
+		currentPath = entities.NormalizeNodePath(currentPath)
+	}
+	return entities.WithActiveTrail(items, currentPath), nil
+}
+
+func (s *MenuService) List(ctx context.Context) (*entities.Menus, error) {
+	return s.repo.List(ctx)
+}
+
+func (s *MenuService) Save(ctx context.Context, menuDTO dto.MenuRequest) (*entities.Menu, error) {
+	menu, err := entities.NewMenu(0, strings.TrimSpace(menuDTO.Name), menuDTO.RootID, menuDTO.Depth)
+	if err != nil {
+		s.log.Debug().Err(err).Msg("Save1")
+		return nil, err
+	}
+
+	if err := s.repo.Save(ctx, menu); err != nil {
+		s.log.Debug().Err(err).Msg("Save2")
+		return nil, err
+	}
+
+	s.Invalidate()
+	return menu, nil
+}
+
+func (s *MenuService) Delete(ctx context.Context, name string) error {
+	if err := s.repo.Delete(ctx, name); err != nil {
+		s.log.Debug().Err(err).Msg("Delete")
+		return err
+	}
+
+	s.Invalidate()
+	return nil
+}
+
+// Invalidate drops all cached menus.
+func (s *MenuService) Invalidate() {
+	s.mu.Lock()
+	s.cache = make(map[string]cachedMenu)
+	s.mu.Unlock()
+	s.log.Debug().Msg("menu cache invalidated")
+}
+
+func (s *MenuService) getItems(ctx context.Context, name string) ([]*entities.MenuItem, error) {
+	s.mu.RLock()
+	cached, ok := s.cache[name]
+	s.mu.RUnlock()
+	if ok && time.Now().Before(cached.expiresAt) {
+		return cached.items, nil
+	}
+
+	menu, err := s.repo.FindByName(ctx, name)
+	if err != nil {
+		s.log.Debug().Err(err).Msg("getItems1")
+		return nil, err
+	}
+
+	nodes, err := s.nodes.ListSubtree(ctx, menu.RootID, menu.Depth)
+	if errors.Is(err, appNode.ErrNodeNotFound) {
+		// Корневой узел удалён — меню пустое, а не ошибка
+		nodes, err = &entities.Nodes{}, nil
+	}
+	if err != nil {
+		s.log.Debug().Err(err).Msg("getItems2")
+		return nil, err
+	}
+
+	tree := entities.BuildNodeTree(*nodes, 0)
+	if menu.RootID > 0 {
+		// Верхний уровень меню — дети корневого узла
+		tree = nil
+		for _, node := range *nodes {
+			if node.ID == menu.RootID && node.Published {
+				tree = entities.BuildNodeTree(*nodes, node.ID)
+			}
+		}
+	}
+	items := entities.BuildMenuItems(tree)
+
+	s.mu.Lock()
+	s.cache[name] = cachedMenu{items: items, expiresAt: time.Now().Add(s.ttl)}
+	s.mu.Unlock()
+
+	return items, nil
+}
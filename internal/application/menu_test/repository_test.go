@@ -0,0 +1,45 @@
+package menu_test
+
+import (
+	"context"
+
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/stretchr/testify/mock"
+)
+
+type MenuRepository struct {
+	mock.Mock
+}
+
+func (m *MenuRepository) FindByName(ctx context.Context, name string) (*entities.Menu, error) {
+	args := m.Called(ctx, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Menu), args.Error(1)
+}
+
+func (m *MenuRepository) List(ctx context.Context) (*entities.Menus, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(*entities.Menus), args.Error(1)
+}
+
+func (m *MenuRepository) Save(ctx context.Context, menu *entities.Menu) error {
+	return m.Called(ctx, menu).Error(0)
+}
+
+func (m *MenuRepository) Delete(ctx context.Context, name string) error {
+	return m.Called(ctx, name).Error(0)
+}
+
+type NodeSource struct {
+	mock.Mock
+}
+
+func (m *NodeSource) ListSubtree(ctx context.Context, rootID int64, depth int) (*entities.Nodes, error) {
+	args := m.Called(ctx, rootID, depth)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Nodes), args.Error(1)
+}
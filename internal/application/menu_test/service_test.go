@@ -0,0 +1,87 @@
+package menu_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	appMenu "github.com/aube/auth/internal/application/menu"
+	appNode "github.com/aube/auth/internal/application/node"
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMenuService_GetMenu_Subtree(t *testing.T) {
+	mockRepo := new(MenuRepository)
+	mockNodes := new(NodeSource)
+	service := appMenu.NewMenuService(mockRepo, mockNodes, time.Minute)
+
+	mockRepo.On("FindByName", mock.Anything, "footer").
+		Return(&entities.Menu{Name: "footer", RootID: 1, Depth: 1}, nil)
+	mockNodes.On("ListSubtree", mock.Anything, int64(1), 1).
+		Return(&entities.Nodes{
+			{ID: 1, Name: "info", Path: "/info", Published: true},
+			{ID: 2, Name: "terms", Path: "/info/terms", Parent: 1, Published: true},
+			{ID: 3, Name: "privacy", Path: "/info/privacy", Parent: 1, Published: true},
+		}, nil)
+
+	items, err := service.GetMenu(context.Background(), "footer", "info/privacy/")
+
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+	assert.Equal(t, "/info/terms", items[0].URL)
+	assert.False(t, items[0].Active)
+	assert.True(t, items[1].Current)
+}
+
+func TestMenuService_GetMenu_CachedUntilInvalidate(t *testing.T) {
+	mockRepo := new(MenuRepository)
+	mockNodes := new(NodeSource)
+	service := appMenu.NewMenuService(mockRepo, mockNodes, time.Minute)
+
+	mockRepo.On("FindByName", mock.Anything, "main").
+		Return(&entities.Menu{Name: "main"}, nil)
+	mockNodes.On("ListSubtree", mock.Anything, int64(0), 0).
+		Return(&entities.Nodes{{ID: 1, Name: "about", Path: "/about", Published: true}}, nil)
+
+	_, err := service.GetMenu(context.Background(), "main", "")
+	require.NoError(t, err)
+	_, err = service.GetMenu(context.Background(), "main", "/about")
+	require.NoError(t, err)
+	mockNodes.AssertNumberOfCalls(t, "ListSubtree", 1)
+
+	service.Invalidate()
+
+	_, err = service.GetMenu(context.Background(), "main", "")
+	require.NoError(t, err)
+	mockNodes.AssertNumberOfCalls(t, "ListSubtree", 2)
+}
+
+func TestMenuService_GetMenu_MissingRoot(t *testing.T) {
+	mockRepo := new(MenuRepository)
+	mockNodes := new(NodeSource)
+	service := appMenu.NewMenuService(mockRepo, mockNodes, time.Minute)
+
+	mockRepo.On("FindByName", mock.Anything, "sidebar").
+		Return(&entities.Menu{Name: "sidebar", RootID: 9}, nil)
+	mockNodes.On("ListSubtree", mock.Anything, int64(9), 0).
+		Return(nil, appNode.ErrNodeNotFound)
+
+	items, err := service.GetMenu(context.Background(), "sidebar", "")
+
+	require.NoError(t, err)
+	assert.Empty(t, items)
+}
+
+func TestMenuService_GetMenu_NotFound(t *testing.T) {
+	mockRepo := new(MenuRepository)
+	service := appMenu.NewMenuService(mockRepo, new(NodeSource), time.Minute)
+
+	mockRepo.On("FindByName", mock.Anything, "nope").Return(nil, appMenu.ErrMenuNotFound)
+
+	_, err := service.GetMenu(context.Background(), "nope", "")
+
+	assert.ErrorIs(t, err, appMenu.ErrMenuNotFound)
+}
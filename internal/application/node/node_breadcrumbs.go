@@ -0,0 +1,24 @@
+package node
+
+import (
+	"context"
+
+	"github.com/aube/auth/internal/domain/entities"
+)
+
+// GetBreadcrumbs returns the chain of nodes from the top level down to the node of a page.
+func (s *NodeService) GetBreadcrumbs(ctx context.Context, pageID int64) (*entities.Nodes, error) {
+	node, err := s.repo.FindByPageID(ctx, pageID)
+	if err != nil {
+		s.log.Debug().Err(err).Msg("GetBreadcrumbs1")
+		return nil, err
+	}
+
+	nodes, err := s.repo.ListAncestors(ctx, node.ID)
+	if err != nil {
+		s.log.Debug().Err(err).Msg("GetBreadcrumbs2")
+		return nil, err
+	}
+
+	return nodes, nil
+}
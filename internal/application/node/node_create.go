@@ -0,0 +1,54 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/aube/auth/internal/application/dto"
+	"github.com/aube/auth/internal/domain/entities"
+)
+
+func (s *NodeService) Create(ctx context.Context, nodeDTO dto.CreateNodeRequest) (*entities.Node, error) {
+	name, err := entities.CheckName(nodeDTO.Name, "")
+	if err != nil {
+		s.log.Debug().Err(err).Msg("Create1")
+		return nil, fmt.Errorf("%w: %v", entities.ErrInvalidNode, err)
+	}
+
+	// Без sort узел добавляется в конец, sort: 0 - в начало
+	sort := entities.NodeSortLast
+	if nodeDTO.Sort != nil {
+		sort = *nodeDTO.Sort
+	}
+
+	node, err := entities.NewNode(
+		0,
+		nodeDTO.PageID,
+		name,
+		nodeDTO.MenuTitle,
+		nodeDTO.Parent,
+		sort,
+		nodeDTO.Published,
+	)
+	if err != nil {
+		s.log.Debug().Err(err).Msg("Create2")
+		return nil, err
+	}
+
+	if err := s.repo.Create(ctx, node); err != nil {
+		s.log.Debug().Err(err).Msg("Create3")
+		return nil, err
+	}
+
+	createdNode, err := s.repo.FindByID(ctx, node.ID)
+	if err != nil {
+		s.log.Debug().Err(err).Msg("Create4")
+		return nil, err
+	}
+
+	s.notifyChange()
+	s.notifyPathChange(ctx, "", createdNode.Path)
+	s.log.Debug().Msg("CREATE node: " + strconv.Itoa(int(node.ID)) + ", " + createdNode.Path)
+	return createdNode, nil
+}
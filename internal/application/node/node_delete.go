@@ -0,0 +1,18 @@
+package node
+
+import (
+	"context"
+	"strconv"
+)
+
+func (s *NodeService) Delete(ctx context.Context, id int64) error {
+	err := s.repo.Delete(ctx, id)
+	if err != nil {
+		s.log.Debug().Err(err).Msg("Delete")
+		return err
+	}
+
+	s.notifyChange()
+	s.log.Debug().Msg("DELETE node: " + strconv.Itoa(int(id)))
+	return nil
+}
@@ -0,0 +1,28 @@
+package node
+
+import (
+	"context"
+
+	"github.com/aube/auth/internal/domain/entities"
+)
+
+func (s *NodeService) GetByID(ctx context.Context, id int64) (*entities.Node, error) {
+	node, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		s.log.Debug().Err(err).Msg("GetByID")
+		return nil, err
+	}
+
+	return node, nil
+}
+
+// Resolve finds the node addressed by a URL path.
+func (s *NodeService) Resolve(ctx context.Context, path string) (*entities.Node, error) {
+	node, err := s.repo.FindByPath(ctx, entities.NormalizeNodePath(path))
+	if err != nil {
+		s.log.Debug().Err(err).Msg("Resolve")
+		return nil, err
+	}
+
+	return node, nil
+}
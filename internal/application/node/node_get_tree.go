@@ -0,0 +1,35 @@
+package node
+
+import (
+	"context"
+
+	"github.com/aube/auth/internal/domain/entities"
+)
+
+// GetTree returns the nested tree below rootID.
+// rootID: 0 for the whole site, otherwise the returned slice holds the root node itself
+// depth: Number of levels below the root to include (0 for unlimited)
+func (s *NodeService) GetTree(ctx context.Context, rootID int64, depth int) ([]*entities.NodeTree, error) {
+	nodes, err := s.repo.ListSubtree(ctx, rootID, depth)
+	if err != nil {
+		s.log.Debug().Err(err).Msg("GetTree")
+		return nil, err
+	}
+
+	if rootID == 0 {
+		return entities.BuildNodeTree(*nodes, 0), nil
+	}
+
+	for _, node := range *nodes {
+		if node.ID != rootID {
+			continue
+		}
+		for _, item := range entities.BuildNodeTree(*nodes, node.Parent) {
+			if item.ID == rootID {
+				return []*entities.NodeTree{item}, nil
+			}
+		}
+	}
+
+	return nil, ErrNodeNotFound
+}
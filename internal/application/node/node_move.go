@@ -0,0 +1,53 @@
+package node
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/aube/auth/internal/application/dto"
+	"github.com/aube/auth/internal/domain/entities"
+)
+
+// Move places a node with its subtree under a new parent at the given sort position.
+// The cycle check here gives an early answer; the repository repeats it
+// inside the locked transaction, which is what makes concurrent moves safe.
+func (s *NodeService) Move(ctx context.Context, moveDTO dto.MoveNodeRequest) (*entities.Node, error) {
+	if moveDTO.ID == moveDTO.Parent {
+		return nil, ErrNodeCycle
+	}
+
+	if moveDTO.Parent > 0 {
+		ancestors, err := s.repo.ListAncestors(ctx, moveDTO.Parent)
+		if err != nil {
+			s.log.Debug().Err(err).Msg("Move1")
+			return nil, err
+		}
+		for _, ancestor := range *ancestors {
+			if ancestor.ID == moveDTO.ID {
+				return nil, ErrNodeCycle
+			}
+		}
+	}
+
+	current, err := s.repo.FindByID(ctx, moveDTO.ID)
+	if err != nil {
+		s.log.Debug().Err(err).Msg("Move2")
+		return nil, err
+	}
+
+	if err := s.repo.Move(ctx, moveDTO.ID, moveDTO.Parent, moveDTO.Sort); err != nil {
+		s.log.Debug().Err(err).Msg("Move2")
+		return nil, err
+	}
+
+	movedNode, err := s.repo.FindByID(ctx, moveDTO.ID)
+	if err != nil {
+		s.log.Debug().Err(err).Msg("Move3")
+		return nil, err
+	}
+
+	s.notifyChange()
+	s.notifyPathChange(ctx, current.Path, movedNode.Path)
+	s.log.Debug().Msg("MOVE node: " + strconv.Itoa(int(moveDTO.ID)) + ", " + movedNode.Path)
+	return movedNode, nil
+}
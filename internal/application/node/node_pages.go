@@ -0,0 +1,48 @@
+package node
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aube/auth/internal/application/dto"
+	"github.com/aube/auth/internal/domain/entities"
+)
+
+func (s *NodeService) ListNodePages(ctx context.Context, nodeID int64) (*entities.NodePages, error) {
+	return s.repo.ListNodePages(ctx, nodeID)
+}
+
+func (s *NodeService) AttachPage(ctx context.Context, linkDTO dto.NodePageRequest) error {
+	if linkDTO.NodeID <= 0 || linkDTO.PageID <= 0 {
+		return fmt.Errorf("%w: node_id and page_id are required", entities.ErrInvalidNode)
+	}
+
+	if _, err := s.repo.FindByID(ctx, linkDTO.NodeID); err != nil {
+		s.log.Debug().Err(err).Msg("AttachPage1")
+		return err
+	}
+
+	err := s.repo.AttachPage(ctx, &entities.NodePage{
+		NodeID: linkDTO.NodeID,
+		PageID: linkDTO.PageID,
+		Sort:   linkDTO.Sort,
+		Pinned: linkDTO.Pinned,
+	})
+	if err != nil {
+		s.log.Debug().Err(err).Msg("AttachPage2")
+		return err
+	}
+
+	s.notifyChange()
+	return nil
+}
+
+func (s *NodeService) DetachPage(ctx context.Context, nodeID int64, pageID int64) error {
+	if err := s.repo.DetachPage(ctx, nodeID, pageID); err != nil {
+		s.log.Debug().Err(err).Msg("DetachPage")
+		return err
+	}
+
+	s.notifyChange()
+	return nil
+}
@@ -0,0 +1,54 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/aube/auth/internal/application/dto"
+	"github.com/aube/auth/internal/domain/entities"
+)
+
+func (s *NodeService) Update(ctx context.Context, nodeDTO dto.UpdateNodeRequest) (*entities.Node, error) {
+	current, err := s.repo.FindByID(ctx, nodeDTO.ID)
+	if err != nil {
+		s.log.Debug().Err(err).Msg("Update1")
+		return nil, err
+	}
+
+	name, err := entities.CheckName(nodeDTO.Name, current.Name)
+	if err != nil {
+		s.log.Debug().Err(err).Msg("Update2")
+		return nil, fmt.Errorf("%w: %v", entities.ErrInvalidNode, err)
+	}
+
+	node, err := entities.NewNode(
+		nodeDTO.ID,
+		nodeDTO.PageID,
+		name,
+		nodeDTO.MenuTitle,
+		current.Parent,
+		current.Sort,
+		nodeDTO.Published,
+	)
+	if err != nil {
+		s.log.Debug().Err(err).Msg("Update3")
+		return nil, err
+	}
+
+	if err := s.repo.Update(ctx, node); err != nil {
+		s.log.Debug().Err(err).Msg("Update4")
+		return nil, err
+	}
+
+	updatedNode, err := s.repo.FindByID(ctx, node.ID)
+	if err != nil {
+		s.log.Debug().Err(err).Msg("Update5")
+		return nil, err
+	}
+
+	s.notifyChange()
+	s.notifyPathChange(ctx, current.Path, updatedNode.Path)
+	s.log.Debug().Msg("UPDATE node: " + strconv.Itoa(int(node.ID)) + ", " + updatedNode.Path)
+	return updatedNode, nil
+}
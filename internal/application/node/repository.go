@@ -0,0 +1,50 @@
+// Package node provides the site tree: nodes, their hierarchy and linked pages.
+package node
+
+import (
+	"context"
+	"errors"
+
+	"github.com/aube/auth/internal/domain/entities"
+)
+
+var (
+	// ErrNodeNotFound is returned when a requested node cannot be found.
+	ErrNodeNotFound = errors.New("node not found")
+	// ErrNodeExists is returned when a sibling with the same name already exists.
+	ErrNodeExists = errors.New("node with this path already exists")
+	// ErrNodeCycle is returned when a node is moved under itself or its descendant.
+	ErrNodeCycle = errors.New("node cannot be moved into its own subtree")
+)
+
+// NodeRepository defines the interface for site tree persistence.
+// Mutating methods must serialize tree changes and keep path, level
+// and children of every affected node consistent.
+//
+// Methods:
+//
+//   - Create: Stores a node under its parent, fills ID, Path and Level
+//   - Update: Updates node fields, recomputes subtree paths on rename
+//   - Move: Moves a node (with its subtree) under a new parent at a sort position
+//   - Delete: Soft-deletes a node with its subtree
+//   - FindByID / FindByPath / FindByPageID: Single node lookups
+//   - ListSubtree: Flat list of a node and its descendants down to depth
+//     (rootID 0 lists the whole tree, depth 0 is unlimited)
+//   - ListAncestors: Ancestors of a node including itself, root first
+//   - ListNodePages / AttachPage / DetachPage: node_page links
+type NodeRepository interface {
+	Create(ctx context.Context, node *entities.Node) error
+	Update(ctx context.Context, node *entities.Node) error
+	Move(ctx context.Context, id int64, parent int64, sort int) error
+	Delete(ctx context.Context, id int64) error
+
+	FindByID(ctx context.Context, id int64) (*entities.Node, error)
+	FindByPath(ctx context.Context, path string) (*entities.Node, error)
+	FindByPageID(ctx context.Context, pageID int64) (*entities.Node, error)
+	ListSubtree(ctx context.Context, rootID int64, depth int) (*entities.Nodes, error)
+	ListAncestors(ctx context.Context, id int64) (*entities.Nodes, error)
+
+	ListNodePages(ctx context.Context, nodeID int64) (*entities.NodePages, error)
+	AttachPage(ctx context.Context, link *entities.NodePage) error
+	DetachPage(ctx context.Context, nodeID int64, pageID int64) error
+}
@@ -0,0 +1,61 @@
+package node
+
+import (
+	"context"
+	"sync"
+
+	"github.com/aube/auth/internal/utils/logger"
+	"github.com/rs/zerolog"
+)
+
+type NodeService struct {
+	repo NodeRepository
+	log  zerolog.Logger
+
+	mu            sync.RWMutex
+	listeners     []func()
+	pathListeners []func(ctx context.Context, oldPath, newPath string)
+}
+
+func NewNodeService(repo NodeRepository) *NodeService {
+	return &NodeService{
+		repo: repo,
+		log:  logger.Get().With().Str("node", "service").Logger(),
+	}
+}
+
+// OnChange registers fn to be called after every successful tree mutation.
+// Used by caches built from the tree (menus).
+func (s *NodeService) OnChange(fn func()) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.listeners = append(s.listeners, fn)
+}
+
+func (s *NodeService) notifyChange() {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, fn := range s.listeners {
+		fn()
+	}
+}
+
+// OnPathChange registers fn to be called after a node path changes
+// (rename or move, descendants move with it) or a new path appears
+// (create, oldPath is empty). Used to maintain redirects.
+func (s *NodeService) OnPathChange(fn func(ctx context.Context, oldPath, newPath string)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pathListeners = append(s.pathListeners, fn)
+}
+
+func (s *NodeService) notifyPathChange(ctx context.Context, oldPath, newPath string) {
+	if oldPath == newPath {
+		return
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, fn := range s.pathListeners {
+		fn(ctx, oldPath, newPath)
+	}
+}
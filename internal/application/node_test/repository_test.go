@@ -0,0 +1,84 @@
+package node_test
+
+import (
+	"context"
+
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/stretchr/testify/mock"
+)
+
+type NodeRepository struct {
+	mock.Mock
+}
+
+func (m *NodeRepository) Create(ctx context.Context, node *entities.Node) error {
+	return m.Called(ctx, node).Error(0)
+}
+
+func (m *NodeRepository) Update(ctx context.Context, node *entities.Node) error {
+	return m.Called(ctx, node).Error(0)
+}
+
+func (m *NodeRepository) Move(ctx context.Context, id int64, parent int64, sort int) error {
+	return m.Called(ctx, id, parent, sort).Error(0)
+}
+
+func (m *NodeRepository) Delete(ctx context.Context, id int64) error {
+	return m.Called(ctx, id).Error(0)
+}
+
+func (m *NodeRepository) FindByID(ctx context.Context, id int64) (*entities.Node, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Node), args.Error(1)
+}
+
+func (m *NodeRepository) FindByPath(ctx context.Context, path string) (*entities.Node, error) {
+	args := m.Called(ctx, path)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Node), args.Error(1)
+}
+
+func (m *NodeRepository) FindByPageID(ctx context.Context, pageID int64) (*entities.Node, error) {
+	args := m.Called(ctx, pageID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Node), args.Error(1)
+}
+
+func (m *NodeRepository) ListSubtree(ctx context.Context, rootID int64, depth int) (*entities.Nodes, error) {
+	args := m.Called(ctx, rootID, depth)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Nodes), args.Error(1)
+}
+
+func (m *NodeRepository) ListAncestors(ctx context.Context, id int64) (*entities.Nodes, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Nodes), args.Error(1)
+}
+
+func (m *NodeRepository) ListNodePages(ctx context.Context, nodeID int64) (*entities.NodePages, error) {
+	args := m.Called(ctx, nodeID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.NodePages), args.Error(1)
+}
+
+func (m *NodeRepository) AttachPage(ctx context.Context, link *entities.NodePage) error {
+	return m.Called(ctx, link).Error(0)
+}
+
+func (m *NodeRepository) DetachPage(ctx context.Context, nodeID int64, pageID int64) error {
+	return m.Called(ctx, nodeID, pageID).Error(0)
+}
@@ -0,0 +1,198 @@
+package node_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aube/auth/internal/application/dto"
+	appNode "github.com/aube/auth/internal/application/node"
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNodeService_Create_Success(t *testing.T) {
+	mockRepo := new(NodeRepository)
+	service := appNode.NewNodeService(mockRepo)
+
+	mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*entities.Node")).
+		Run(func(args mock.Arguments) {
+			node := args.Get(1).(*entities.Node)
+			assert.Equal(t, "team", node.Name)
+			assert.Equal(t, int64(1), node.Parent)
+			assert.Equal(t, entities.NodeSortLast, node.Sort)
+			node.ID = 7
+		}).
+		Return(nil)
+	mockRepo.On("FindByID", mock.Anything, int64(7)).
+		Return(&entities.Node{ID: 7, Name: "team", Path: "/about/team", Parent: 1, Level: 1}, nil)
+
+	node, err := service.Create(context.Background(), dto.CreateNodeRequest{
+		PageID: 3,
+		Name:   "team",
+		Parent: 1,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "/about/team", node.Path)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestNodeService_Create_FirstPosition(t *testing.T) {
+	mockRepo := new(NodeRepository)
+	service := appNode.NewNodeService(mockRepo)
+
+	mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(node *entities.Node) bool {
+		return node.Sort == 0
+	})).
+		Run(func(args mock.Arguments) { args.Get(1).(*entities.Node).ID = 8 }).
+		Return(nil)
+	mockRepo.On("FindByID", mock.Anything, int64(8)).
+		Return(&entities.Node{ID: 8, Name: "first", Path: "/first"}, nil)
+
+	first := 0
+	_, err := service.Create(context.Background(), dto.CreateNodeRequest{Name: "first", Sort: &first})
+
+	require.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestNodeService_Create_InvalidName(t *testing.T) {
+	mockRepo := new(NodeRepository)
+	service := appNode.NewNodeService(mockRepo)
+
+	_, err := service.Create(context.Background(), dto.CreateNodeRequest{Name: "a/b"})
+
+	assert.Error(t, err)
+	mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestNodeService_Update_KeepsLegacyName(t *testing.T) {
+	mockRepo := new(NodeRepository)
+	service := appNode.NewNodeService(mockRepo)
+
+	legacy := &entities.Node{ID: 4, Name: "О нас", Path: "/О нас"}
+	mockRepo.On("FindByID", mock.Anything, int64(4)).Return(legacy, nil)
+	mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(node *entities.Node) bool {
+		return node.Name == "О нас" && node.MenuTitle == "About"
+	})).Return(nil)
+
+	_, err := service.Update(context.Background(), dto.UpdateNodeRequest{ID: 4, Name: "О нас", MenuTitle: "About"})
+	require.NoError(t, err)
+
+	_, err = service.Update(context.Background(), dto.UpdateNodeRequest{ID: 4, Name: "О компании"})
+	assert.ErrorIs(t, err, entities.ErrInvalidNode)
+	mockRepo.AssertNumberOfCalls(t, "Update", 1)
+}
+
+func TestNodeService_Move_IntoOwnSubtree(t *testing.T) {
+	mockRepo := new(NodeRepository)
+	service := appNode.NewNodeService(mockRepo)
+
+	// 5 -> 3 -> 1: moving 1 under 5 would create a cycle
+	mockRepo.On("ListAncestors", mock.Anything, int64(5)).
+		Return(&entities.Nodes{{ID: 1}, {ID: 3}, {ID: 5}}, nil)
+
+	_, err := service.Move(context.Background(), dto.MoveNodeRequest{ID: 1, Parent: 5})
+
+	assert.ErrorIs(t, err, appNode.ErrNodeCycle)
+	mockRepo.AssertNotCalled(t, "Move", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestNodeService_Move_UnderItself(t *testing.T) {
+	mockRepo := new(NodeRepository)
+	service := appNode.NewNodeService(mockRepo)
+
+	_, err := service.Move(context.Background(), dto.MoveNodeRequest{ID: 2, Parent: 2})
+
+	assert.ErrorIs(t, err, appNode.ErrNodeCycle)
+}
+
+func TestNodeService_Move_Success(t *testing.T) {
+	mockRepo := new(NodeRepository)
+	service := appNode.NewNodeService(mockRepo)
+
+	mockRepo.On("ListAncestors", mock.Anything, int64(2)).
+		Return(&entities.Nodes{{ID: 2}}, nil)
+	mockRepo.On("Move", mock.Anything, int64(4), int64(2), 1).Return(nil)
+	mockRepo.On("FindByID", mock.Anything, int64(4)).
+		Return(&entities.Node{ID: 4, Name: "team", Path: "/news/team", Parent: 2, Sort: 1}, nil)
+
+	node, err := service.Move(context.Background(), dto.MoveNodeRequest{ID: 4, Parent: 2, Sort: 1})
+
+	require.NoError(t, err)
+	assert.Equal(t, "/news/team", node.Path)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestNodeService_Move_NotifiesPathChange(t *testing.T) {
+	mockRepo := new(NodeRepository)
+	service := appNode.NewNodeService(mockRepo)
+
+	mockRepo.On("ListAncestors", mock.Anything, int64(2)).
+		Return(&entities.Nodes{{ID: 2}}, nil)
+	mockRepo.On("FindByID", mock.Anything, int64(4)).
+		Return(&entities.Node{ID: 4, Name: "team", Path: "/about/team", Parent: 1}, nil).Once()
+	mockRepo.On("Move", mock.Anything, int64(4), int64(2), 0).Return(nil)
+	mockRepo.On("FindByID", mock.Anything, int64(4)).
+		Return(&entities.Node{ID: 4, Name: "team", Path: "/news/team", Parent: 2}, nil)
+
+	var paths [][2]string
+	service.OnPathChange(func(ctx context.Context, oldPath, newPath string) {
+		paths = append(paths, [2]string{oldPath, newPath})
+	})
+
+	_, err := service.Move(context.Background(), dto.MoveNodeRequest{ID: 4, Parent: 2})
+
+	require.NoError(t, err)
+	assert.Equal(t, [][2]string{{"/about/team", "/news/team"}}, paths)
+}
+
+func TestNodeService_GetTree_Subtree(t *testing.T) {
+	mockRepo := new(NodeRepository)
+	service := appNode.NewNodeService(mockRepo)
+
+	mockRepo.On("ListSubtree", mock.Anything, int64(1), 1).
+		Return(&entities.Nodes{
+			{ID: 1, Name: "about", Parent: 0},
+			{ID: 3, Name: "team", Parent: 1},
+		}, nil)
+
+	tree, err := service.GetTree(context.Background(), 1, 1)
+
+	require.NoError(t, err)
+	require.Len(t, tree, 1)
+	assert.Equal(t, int64(1), tree[0].ID)
+	require.Len(t, tree[0].Items, 1)
+	assert.Equal(t, "team", tree[0].Items[0].Name)
+}
+
+func TestNodeService_GetBreadcrumbs(t *testing.T) {
+	mockRepo := new(NodeRepository)
+	service := appNode.NewNodeService(mockRepo)
+
+	mockRepo.On("FindByPageID", mock.Anything, int64(10)).
+		Return(&entities.Node{ID: 3, PageID: 10}, nil)
+	mockRepo.On("ListAncestors", mock.Anything, int64(3)).
+		Return(&entities.Nodes{{ID: 1}, {ID: 3}}, nil)
+
+	nodes, err := service.GetBreadcrumbs(context.Background(), 10)
+
+	require.NoError(t, err)
+	assert.Len(t, *nodes, 2)
+	assert.Equal(t, int64(1), (*nodes)[0].ID)
+}
+
+func TestNodeService_Resolve_NormalizesPath(t *testing.T) {
+	mockRepo := new(NodeRepository)
+	service := appNode.NewNodeService(mockRepo)
+
+	mockRepo.On("FindByPath", mock.Anything, "/about/team").
+		Return(&entities.Node{ID: 3, Path: "/about/team"}, nil)
+
+	node, err := service.Resolve(context.Background(), "about/team/")
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), node.ID)
+}
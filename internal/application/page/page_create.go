@@ -6,14 +6,23 @@ import (
 	"strconv"
 
 	"github.com/aube/auth/internal/application/dto"
+	"github.com/aube/auth/internal/application/render"
 	"github.com/aube/auth/internal/domain/entities"
 )
 
 func (s *PageService) Create(ctx context.Context, pageDTO dto.CreatePageRequest) (*entities.PageWithTime, error) {
+	// Без имени оно строится из заголовка и делается уникальным
+	name, err := s.pageName(ctx, pageDTO.Name, pageDTO.Title)
+	if err != nil {
+		s.log.Debug().Err(err).Msg("Create1")
+		return nil, err
+	}
+	pageDTO.Name = name
+
 	// Проверяем, существует ли страница с таким именем
 	id, err := s.repo.GetIDByName(ctx, pageDTO.Name)
 	if err != nil {
-		s.log.Debug().Err(err).Msg("Create1")
+		s.log.Debug().Err(err).Msg("Create2")
 		return nil, err
 	}
 	if id > 0 {
@@ -33,23 +42,52 @@ func (s *PageService) Create(ctx context.Context, pageDTO dto.CreatePageRequest)
 		pageDTO.ContentShort,
 	)
 	if err != nil {
-		s.log.Debug().Err(err).Msg("Create2")
+		s.log.Debug().Err(err).Msg("Create3")
+		return nil, err
+	}
+	if pageDTO.Status == "" {
+		pageDTO.Status = entities.PageStatusDraft
+	}
+	if pageDTO.ContentFormat == "" {
+		pageDTO.ContentFormat = entities.ContentFormatHTML
+	}
+	if !render.IsValidFormat(pageDTO.ContentFormat) {
+		s.log.Debug().Str("format", pageDTO.ContentFormat).Msg("Create4")
+		return nil, ErrInvalidContentFormat
+	}
+	if err := page.SetStatus(pageDTO.Status, pageDTO.PublishAt, pageDTO.UnpublishAt); err != nil {
+		s.log.Debug().Err(err).Msg("Create5")
+		return nil, err
+	}
+	page.ContentFormat = pageDTO.ContentFormat
+	if err := s.render(page); err != nil {
+		s.log.Debug().Err(err).Msg("Create6")
+		return nil, err
+	}
+	page.ContentType = pageDTO.ContentType
+	page.Fields = pageDTO.Fields
+	if err := s.validateFields(ctx, page); err != nil {
+		s.log.Debug().Err(err).Msg("Create7")
 		return nil, err
 	}
 
 	// Сохраняем в репозитории
-	if err := s.repo.Create(ctx, page); err != nil {
-		s.log.Debug().Err(err).Msg("Create3")
+	if err := s.repo.Create(ctx, page, entities.NewPageRevision(page, pageDTO.AuthorID)); err != nil {
+		s.log.Debug().Err(err).Msg("Create8")
 		return nil, err
 	}
 
 	// Получаем сохранённый результат
 	createdPage, err := s.repo.FindByID(ctx, page.ID)
 	if err != nil {
-		s.log.Debug().Err(err).Msg("Update4")
+		s.log.Debug().Err(err).Msg("Create9")
 		return nil, err
 	}
 
+	s.pruneRevisions(ctx, createdPage.ID)
+
+	s.notifyChange()
+	s.notifyPathChange(ctx, "", entities.PagePath(createdPage.Name))
 	s.log.Debug().Msg("CREATE page: " + strconv.Itoa(int(page.ID)) + ", " + pageDTO.Name)
 	return createdPage, nil
 }
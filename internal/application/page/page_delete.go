@@ -12,6 +12,7 @@ func (s *PageService) Delete(ctx context.Context, id int64) error {
 		return err
 	}
 
+	s.notifyChange()
 	s.log.Debug().Msg("DELETE page: " + strconv.Itoa(int(id)))
 	return nil
 }
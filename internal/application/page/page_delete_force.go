@@ -11,6 +11,7 @@ func (s *PageService) DeleteForce(ctx context.Context, id int64) error {
 		s.log.Debug().Err(err).Msg("Delete")
 		return err
 	}
+	s.notifyChange()
 	s.log.Debug().Msg("DELETE! page: " + strconv.Itoa(int(id)))
 	return nil
 }
@@ -0,0 +1,121 @@
+package page
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/aube/auth/internal/utils/query"
+)
+
+// FieldFilterPrefix marks ListPages filters by a field value
+// ("fields.color" eq "red"); they require a "content_type" eq filter.
+const FieldFilterPrefix = "fields."
+
+// ValidateFields checks the field values of a page as Create and Update do.
+// Images listed in stored are taken as existing: imports store them
+// together with the page.
+func (s *PageService) ValidateFields(ctx context.Context, page *entities.Page, stored ...string) error {
+	return s.validateFields(ctx, page, stored...)
+}
+
+// validateFields checks the field values of a typed page against its
+// content type, sanitizes rich text and checks that referenced images
+// and pages exist. Untyped pages have no fields.
+func (s *PageService) validateFields(ctx context.Context, page *entities.Page, stored ...string) error {
+	if page.ContentType == "" {
+		if len(page.Fields) > 0 {
+			return errors.New("page without content type cannot have fields")
+		}
+		page.Fields = entities.PageFields{}
+		return nil
+	}
+	if s.types == nil {
+		return errors.New("content types are not supported")
+	}
+
+	contentType, err := s.types.FindByName(ctx, page.ContentType)
+	if err != nil {
+		return err
+	}
+	fields, refs, err := contentType.ValidateFields(page.Fields, func(value string) (string, error) {
+		return s.renderer.Render(entities.ContentFormatHTML, value)
+	})
+	if err != nil {
+		return err
+	}
+
+	images := make([]string, 0, len(refs.Images))
+	for _, id := range refs.Images {
+		if !slices.Contains(stored, id) {
+			images = append(images, id)
+		}
+	}
+	missing, err := s.types.MissingImages(ctx, images)
+	if err != nil {
+		return err
+	}
+	if len(missing) > 0 {
+		return errors.New("image " + missing[0] + " not found")
+	}
+
+	checked := make(map[int64]bool, len(refs.Pages))
+	for _, id := range refs.Pages {
+		if checked[id] || id == page.ID {
+			continue
+		}
+		checked[id] = true
+		if _, err := s.repo.FindByID(ctx, id); err != nil {
+			if errors.Is(err, ErrPageNotFound) {
+				return fmt.Errorf("page %d not found", id)
+			}
+			return err
+		}
+	}
+
+	page.Fields = fields
+	return nil
+}
+
+// fieldFilter replaces "fields.<name>" filters with a single JSON containment
+// condition, converting values to the types of the content type fields.
+func (s *PageService) fieldFilter(ctx context.Context, filter *query.Query) error {
+	filters := filter.Extract(FieldFilterPrefix)
+	if len(filters) == 0 {
+		return nil
+	}
+
+	value, _ := filter.Value("content_type")
+	typeName, _ := value.(string)
+	if typeName == "" || s.types == nil {
+		return fmt.Errorf("%w: content type is required", ErrInvalidFieldFilter)
+	}
+	contentType, err := s.types.FindByName(ctx, typeName)
+	if err != nil {
+		return err
+	}
+
+	values := entities.PageFields{}
+	for _, f := range filters {
+		name := strings.TrimPrefix(f.Field, FieldFilterPrefix)
+		field, ok := contentType.Field(name)
+		if !ok {
+			return fmt.Errorf("%w: unknown field %s", ErrInvalidFieldFilter, name)
+		}
+		str, ok := f.Value.(string)
+		if !ok || f.Op != query.OpEq {
+			return fmt.Errorf("%w: %s", ErrInvalidFieldFilter, name)
+		}
+		parsed, err := field.ParseFilter(str)
+		if err != nil {
+			return fmt.Errorf("%w: %s", ErrInvalidFieldFilter, name)
+		}
+		values[name] = parsed
+	}
+
+	filter.Add("fields", query.OpContains, values)
+	return nil
+}
@@ -4,9 +4,38 @@ import (
 	"context"
 
 	"github.com/aube/auth/internal/application/dto"
+	appTag "github.com/aube/auth/internal/application/tag"
 	"github.com/aube/auth/internal/domain/entities"
+	"github.com/aube/auth/internal/utils/query"
 )
 
-func (s *PageService) ListPages(ctx context.Context, offset, limit int, params map[string]any) (*entities.PagesWithTimes, *dto.Pagination, error) {
-	return s.repo.ListPages(ctx, offset, limit, params)
+// ListSchema lists the page fields clients may filter and sort by.
+var ListSchema = query.Schema{
+	"id":           {Type: query.TypeInt, Sortable: true},
+	"name":         {Type: query.TypeString, Sortable: true},
+	"title":        {Type: query.TypeString, Sortable: true},
+	"category":     {Type: query.TypeString, Sortable: true},
+	"template":     {Type: query.TypeString},
+	"status":       {Type: query.TypeString, Sortable: true},
+	"content_type": {Type: query.TypeString, Sortable: true, Nullable: true},
+	"created_at":   {Type: query.TypeTime, Sortable: true},
+	"updated_at":   {Type: query.TypeTime, Sortable: true},
+	"published_at": {Type: query.TypeTime, Sortable: true, Nullable: true},
+	"publish_at":   {Type: query.TypeTime, Sortable: true, Nullable: true},
+	"unpublish_at": {Type: query.TypeTime, Sortable: true, Nullable: true},
+	"tags":         {Type: query.TypeString, List: true},
+}
+
+// ListPages returns pages matching filter; "fields.<name>" filters
+// select typed pages by field values (see FieldFilterPrefix).
+func (s *PageService) ListPages(ctx context.Context, offset, limit int, filter *query.Query) (*entities.PagesWithTimes, *dto.Pagination, error) {
+	filter = filter.Clone()
+	if err := appTag.NormalizeFilter(filter); err != nil {
+		return nil, nil, err
+	}
+	if err := s.fieldFilter(ctx, filter); err != nil {
+		s.log.Debug().Err(err).Msg("ListPages")
+		return nil, nil, err
+	}
+	return s.repo.ListPages(ctx, offset, limit, filter)
 }
@@ -0,0 +1,67 @@
+package page
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/aube/auth/internal/utils/logger"
+	"github.com/rs/zerolog"
+)
+
+// DefaultLockTTL is used when no lock lifetime is configured.
+const DefaultLockTTL = 2 * time.Minute
+
+// LockService manages advisory edit locks. An editor takes the lock when
+// opening a page and renews it (heartbeat) while editing; an abandoned
+// lock expires after ttl. Saving is not blocked by locks, conflicting
+// saves are detected by page versions.
+type LockService struct {
+	repo PageLockRepository
+	ttl  time.Duration
+	log  zerolog.Logger
+}
+
+func NewLockService(repo PageLockRepository, ttl time.Duration) *LockService {
+	if ttl <= 0 {
+		ttl = DefaultLockTTL
+	}
+	return &LockService{
+		repo: repo,
+		ttl:  ttl,
+		log:  logger.Get().With().Str("page_lock", "service").Logger(),
+	}
+}
+
+// Lock takes or renews the lock of the user. When another user holds it,
+// a LockedError with that lock is returned.
+func (s *LockService) Lock(ctx context.Context, pageID int64, userID int64) (*entities.PageLock, error) {
+	lock, err := s.repo.Acquire(ctx, pageID, userID, s.ttl)
+	if err != nil {
+		s.log.Debug().Err(err).Msg("Lock")
+		if errors.Is(err, ErrPageLocked) && lock != nil {
+			return nil, &LockedError{Lock: lock}
+		}
+		return nil, err
+	}
+
+	s.log.Debug().Msg("LOCK page: " + strconv.Itoa(int(pageID)) + ", user " + strconv.Itoa(int(userID)))
+	return lock, nil
+}
+
+func (s *LockService) Unlock(ctx context.Context, pageID int64, userID int64) error {
+	if err := s.repo.Release(ctx, pageID, userID); err != nil {
+		s.log.Debug().Err(err).Msg("Unlock")
+		return err
+	}
+
+	s.log.Debug().Msg("UNLOCK page: " + strconv.Itoa(int(pageID)) + ", user " + strconv.Itoa(int(userID)))
+	return nil
+}
+
+// GetLock shows who is editing the page.
+func (s *LockService) GetLock(ctx context.Context, pageID int64) (*entities.PageLock, error) {
+	return s.repo.Find(ctx, pageID)
+}
@@ -0,0 +1,69 @@
+package page
+
+import (
+	"context"
+
+	"github.com/aube/auth/internal/application/render"
+	"github.com/aube/auth/internal/domain/entities"
+)
+
+const renderBatchSize = 100
+
+// render fills ContentHTML from the page source and generates
+// ContentShort from the rendered text when it is blank.
+func (s *PageService) render(page *entities.Page) error {
+	// Страницы без формата созданы до его появления и содержат HTML
+	if page.ContentFormat == "" {
+		page.ContentFormat = entities.ContentFormatHTML
+	}
+
+	contentHTML, err := s.renderer.Render(page.ContentFormat, page.Content)
+	if err != nil {
+		return err
+	}
+
+	page.ContentHTML = contentHTML
+	if page.ContentShort == "" {
+		page.ContentShort = s.renderer.Excerpt(contentHTML, render.ExcerptLength)
+	}
+	return nil
+}
+
+// RenderPending renders pages stored before content rendering existed.
+// Returns the number of rendered pages.
+func (s *PageService) RenderPending(ctx context.Context) (int, error) {
+	var (
+		afterID  int64
+		rendered int
+	)
+
+	for {
+		pages, err := s.repo.ListUnrendered(ctx, afterID, renderBatchSize)
+		if err != nil {
+			s.log.Debug().Err(err).Msg("RenderPending1")
+			return rendered, err
+		}
+		if len(*pages) == 0 {
+			break
+		}
+
+		for _, stored := range *pages {
+			afterID = stored.ID
+			page := stored.ToPage()
+			if err := s.render(page); err != nil {
+				s.log.Warn().Err(err).Int64("page", page.ID).Msg("failed to render page")
+				continue
+			}
+			if err := s.repo.UpdateRendered(ctx, page.ID, page.ContentHTML, page.ContentShort); err != nil {
+				s.log.Debug().Err(err).Msg("RenderPending2")
+				return rendered, err
+			}
+			rendered++
+		}
+	}
+
+	if rendered > 0 {
+		s.notifyChange()
+	}
+	return rendered, nil
+}
@@ -0,0 +1,142 @@
+package page
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/aube/auth/internal/application/dto"
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/aube/auth/internal/utils/diff"
+	"github.com/aube/auth/internal/utils/query"
+)
+
+// diffContext is the number of unchanged lines shown around each change.
+const diffContext = 3
+
+func (s *PageService) ListRevisions(ctx context.Context, pageID int64, offset, limit int, filter *query.Query) (*entities.PageRevisions, *dto.Pagination, error) {
+	return s.revisions.ListRevisions(ctx, pageID, offset, limit, filter)
+}
+
+func (s *PageService) GetRevision(ctx context.Context, pageID int64, revision int) (*entities.PageRevision, error) {
+	rev, err := s.revisions.FindRevision(ctx, pageID, revision)
+	if err != nil {
+		s.log.Debug().Err(err).Msg("GetRevision")
+		return nil, err
+	}
+
+	return rev, nil
+}
+
+// DiffRevisions compares two revisions of a page field by field.
+// Multi-line fields are compared line by line and returned as hunks.
+func (s *PageService) DiffRevisions(ctx context.Context, pageID int64, from, to int) (*dto.PageRevisionDiff, error) {
+	oldRev, err := s.revisions.FindRevision(ctx, pageID, from)
+	if err != nil {
+		s.log.Debug().Err(err).Msg("DiffRevisions1")
+		return nil, err
+	}
+	newRev, err := s.revisions.FindRevision(ctx, pageID, to)
+	if err != nil {
+		s.log.Debug().Err(err).Msg("DiffRevisions2")
+		return nil, err
+	}
+
+	fields := []struct {
+		name      string
+		old, new  string
+		multiline bool
+	}{
+		{"name", oldRev.Name, newRev.Name, false},
+		{"meta", metaText(oldRev.Meta), metaText(newRev.Meta), true},
+		{"title", oldRev.Title, newRev.Title, false},
+		{"category", oldRev.Category, newRev.Category, false},
+		{"template", oldRev.Template, newRev.Template, false},
+		{"h1", oldRev.H1, newRev.H1, false},
+		{"content", oldRev.Content, newRev.Content, true},
+		{"content_short", oldRev.ContentShort, newRev.ContentShort, true},
+		{"content_format", oldRev.ContentFormat, newRev.ContentFormat, false},
+		{"content_type", oldRev.ContentType, newRev.ContentType, false},
+		{"fields", fieldsText(oldRev.Fields), fieldsText(newRev.Fields), true},
+	}
+
+	res := &dto.PageRevisionDiff{
+		PageID: pageID,
+		From:   from,
+		To:     to,
+		Fields: make([]dto.PageFieldDiff, 0, len(fields)),
+	}
+	for _, f := range fields {
+		fd := dto.PageFieldDiff{Field: f.name, Changed: f.old != f.new}
+		if fd.Changed {
+			if f.multiline {
+				fd.Hunks = diff.Hunks(diff.Lines(f.old, f.new), diffContext)
+			} else {
+				fd.Old, fd.New = f.old, f.new
+			}
+		}
+		res.Fields = append(res.Fields, fd)
+	}
+
+	return res, nil
+}
+
+// RestoreRevision writes the content of an old revision back to the page.
+// The restore itself is saved as a new revision, history is never rewritten.
+func (s *PageService) RestoreRevision(ctx context.Context, pageID int64, revision int, authorID int64) (*entities.PageWithTime, error) {
+	rev, err := s.revisions.FindRevision(ctx, pageID, revision)
+	if err != nil {
+		s.log.Debug().Err(err).Msg("RestoreRevision")
+		return nil, err
+	}
+
+	s.log.Debug().Msg("RESTORE page: " + strconv.Itoa(int(pageID)) + ", revision " + strconv.Itoa(revision))
+	return s.Update(ctx, dto.UpdatePageRequest{
+		ID:            pageID,
+		Name:          rev.Name,
+		Meta:          rev.Meta,
+		Title:         rev.Title,
+		Category:      rev.Category,
+		Template:      rev.Template,
+		H1:            rev.H1,
+		Content:       rev.Content,
+		ContentShort:  rev.ContentShort,
+		ContentFormat: rev.ContentFormat,
+		ContentType:   &rev.ContentType,
+		Fields:        rev.Fields,
+		AuthorID:      authorID,
+	})
+}
+
+// pruneRevisions applies the retention policy after a save. The page and
+// its revision are already stored: a failure is logged, not returned.
+func (s *PageService) pruneRevisions(ctx context.Context, pageID int64) {
+	if s.retention.KeepLast <= 0 && s.retention.KeepDays <= 0 {
+		return
+	}
+
+	var olderThan time.Time
+	if s.retention.KeepDays > 0 {
+		olderThan = time.Now().AddDate(0, 0, -s.retention.KeepDays)
+	}
+	if _, err := s.revisions.PruneRevisions(ctx, pageID, s.retention.KeepLast, olderThan); err != nil {
+		// Не удалось почистить — не повод отменять сохранение
+		s.log.Error().Err(err).Msg("pruneRevisions")
+	}
+}
+
+// metaText formats meta as indented JSON so that it is diffed line by line.
+func metaText(meta entities.PageMeta) string {
+	data, _ := json.MarshalIndent(meta, "", "  ")
+	return string(data)
+}
+
+// fieldsText formats field values as indented JSON; untyped pages have no fields.
+func fieldsText(fields entities.PageFields) string {
+	if len(fields) == 0 {
+		return ""
+	}
+	data, _ := json.MarshalIndent(fields, "", "  ")
+	return string(data)
+}
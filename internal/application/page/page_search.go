@@ -0,0 +1,34 @@
+package page
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/aube/auth/internal/application/dto"
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/aube/auth/internal/utils/query"
+	"github.com/aube/auth/internal/utils/search"
+)
+
+// Имя конфигурации полнотекстового поиска PostgreSQL (english, russian, simple...)
+var searchLanguageRegex = regexp.MustCompile(`^[a-z_]{0,63}$`)
+
+// Search runs a full-text search over page title, h1, content and content_short.
+// See search.ToTsQuery for the supported query syntax.
+func (s *PageService) Search(ctx context.Context, searchDTO dto.PageSearchRequest, offset, limit int, filter *query.Query) (*entities.PageSearchResults, *dto.Pagination, error) {
+	tsQuery := search.ToTsQuery(searchDTO.Query)
+	if tsQuery == "" {
+		return nil, nil, ErrEmptySearchQuery
+	}
+	if !searchLanguageRegex.MatchString(searchDTO.Lang) {
+		return nil, nil, ErrInvalidSearchLanguage
+	}
+
+	results, pagination, err := s.repo.SearchPages(ctx, tsQuery, searchDTO.Lang, offset, limit, filter)
+	if err != nil {
+		s.log.Debug().Err(err).Msg("Search")
+		return nil, nil, err
+	}
+
+	return results, pagination, nil
+}
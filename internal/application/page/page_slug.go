@@ -0,0 +1,43 @@
+package page
+
+import (
+	"context"
+	"errors"
+
+	"github.com/aube/auth/internal/domain/valueobjects"
+)
+
+// maxNameSuffix limits the numeric suffixes tried for a generated page name.
+const maxNameSuffix = 100
+
+// pageName returns the normalized page name.
+// An empty name is generated from the title: transliterated and, when
+// taken, suffixed with "-2", "-3"... until it is unique.
+func (s *PageService) pageName(ctx context.Context, name string, title string) (string, error) {
+	if name != "" {
+		slug, err := valueobjects.NewSlug(name)
+		if err != nil {
+			return "", err
+		}
+		return slug.String(), nil
+	}
+
+	slug, err := valueobjects.SlugFromTitle(title)
+	if err != nil {
+		return "", errors.New("name cannot be empty")
+	}
+
+	candidate := slug
+	for n := 2; n <= maxNameSuffix; n++ {
+		id, err := s.repo.GetIDByName(ctx, candidate.String())
+		if err != nil {
+			return "", err
+		}
+		if id == 0 {
+			return candidate.String(), nil
+		}
+		candidate = slug.WithSuffix(n)
+	}
+
+	return "", errors.New("cannot generate a unique name for " + slug.String())
+}
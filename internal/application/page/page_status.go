@@ -0,0 +1,86 @@
+package page
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/aube/auth/internal/application/dto"
+	"github.com/aube/auth/internal/domain/entities"
+)
+
+// SetStatus changes the publication status and schedule of a page
+// without touching its content.
+func (s *PageService) SetStatus(ctx context.Context, statusDTO dto.PageStatusRequest) (*entities.PageWithTime, error) {
+	current, err := s.repo.FindByID(ctx, statusDTO.ID)
+	if err != nil {
+		s.log.Debug().Err(err).Msg("SetStatus1")
+		return nil, err
+	}
+
+	if statusDTO.Version > 0 && statusDTO.Version != current.Version {
+		return nil, &VersionConflictError{Current: current}
+	}
+
+	// Сохраняется только прочитанная версия
+	page := current.ToPage()
+	if err := page.SetStatus(statusDTO.Status, statusDTO.PublishAt, statusDTO.UnpublishAt); err != nil {
+		s.log.Debug().Err(err).Msg("SetStatus2")
+		return nil, err
+	}
+
+	// Статус не входит в ревизию
+	if err := s.repo.Update(ctx, page, nil); err != nil {
+		s.log.Debug().Err(err).Msg("SetStatus3")
+		return nil, s.conflict(ctx, statusDTO.ID, err)
+	}
+
+	updatedPage, err := s.repo.FindByID(ctx, statusDTO.ID)
+	if err != nil {
+		s.log.Debug().Err(err).Msg("SetStatus4")
+		return nil, err
+	}
+
+	s.notifyChange()
+	s.log.Debug().Msg("STATUS page: " + strconv.Itoa(int(statusDTO.ID)) + ", " + statusDTO.Status)
+	return updatedPage, nil
+}
+
+// PublishScheduled applies publish_at/unpublish_at of all pages due at now.
+func (s *PageService) PublishScheduled(ctx context.Context, now time.Time) (published int64, unpublished int64, err error) {
+	published, err = s.repo.PublishDue(ctx, now)
+	if err != nil {
+		s.log.Debug().Err(err).Msg("PublishScheduled1")
+		return 0, 0, err
+	}
+
+	unpublished, err = s.repo.UnpublishDue(ctx, now)
+	if err != nil {
+		s.log.Debug().Err(err).Msg("PublishScheduled2")
+		return published, 0, err
+	}
+
+	if published > 0 || unpublished > 0 {
+		s.notifyChange()
+		s.log.Info().Int64("published", published).Int64("unpublished", unpublished).Msg("scheduled pages applied")
+	}
+
+	return published, unpublished, nil
+}
+
+// RunScheduler calls PublishScheduled every interval until ctx is done.
+func (s *PageService) RunScheduler(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if _, _, err := s.PublishScheduled(ctx, now); err != nil {
+				s.log.Error().Err(err).Msg("page scheduler")
+			}
+		}
+	}
+}
@@ -6,15 +6,28 @@ import (
 	"strconv"
 
 	"github.com/aube/auth/internal/application/dto"
+	"github.com/aube/auth/internal/application/render"
 	"github.com/aube/auth/internal/domain/entities"
 )
 
 func (s *PageService) Update(ctx context.Context, pageDTO dto.UpdatePageRequest) (*entities.PageWithTime, error) {
+	current, err := s.repo.FindByID(ctx, pageDTO.ID)
+	if err != nil {
+		s.log.Debug().Err(err).Msg("Update1")
+		return nil, err
+	}
+
+	name, err := entities.CheckName(pageDTO.Name, current.Name)
+	if err != nil {
+		s.log.Debug().Err(err).Msg("Update2")
+		return nil, err
+	}
+	pageDTO.Name = name
 
 	// Проверяем, существует ли другая страница с таким именем
 	id, err := s.repo.GetIDByName(ctx, pageDTO.Name)
 	if err != nil {
-		s.log.Debug().Err(err).Msg("Update1")
+		s.log.Debug().Err(err).Msg("Update3")
 		return nil, err
 	}
 	if id > 0 && id != pageDTO.ID {
@@ -34,21 +47,82 @@ func (s *PageService) Update(ctx context.Context, pageDTO dto.UpdatePageRequest)
 		pageDTO.ContentShort,
 	)
 	if err != nil {
-		s.log.Debug().Err(err).Msg("Update2")
+		s.log.Debug().Err(err).Msg("Update4")
 		return nil, err
 	}
-	// Сохраняем в репозитории
-	if err := s.repo.Update(ctx, page); err != nil {
-		s.log.Debug().Err(err).Msg("Update3")
+
+	// Сохранение поверх чужих изменений отклоняется
+	if pageDTO.Version > 0 && pageDTO.Version != current.Version {
+		return nil, &VersionConflictError{Current: current}
+	}
+	page.Version = pageDTO.Version
+	// Без статуса или формата в запросе сохраняем текущие значения
+	if pageDTO.Status == "" {
+		pageDTO.Status = current.Status
+		pageDTO.PublishAt = current.PublishAt
+		pageDTO.UnpublishAt = current.UnpublishAt
+	}
+	if pageDTO.ContentFormat == "" {
+		pageDTO.ContentFormat = current.ContentFormat
+	}
+	// Страницы без формата созданы до его появления и содержат HTML
+	if pageDTO.ContentFormat != "" && !render.IsValidFormat(pageDTO.ContentFormat) {
+		s.log.Debug().Str("format", pageDTO.ContentFormat).Msg("Update5")
+		return nil, ErrInvalidContentFormat
+	}
+	// Без типа сохраняем текущий тип, без полей — текущие значения того же типа
+	page.ContentType = current.ContentType
+	if pageDTO.ContentType != nil {
+		page.ContentType = *pageDTO.ContentType
+	}
+	page.Fields = pageDTO.Fields
+	if page.Fields == nil && page.ContentType == current.ContentType {
+		page.Fields = current.Fields
+	}
+	if err := page.SetStatus(pageDTO.Status, pageDTO.PublishAt, pageDTO.UnpublishAt); err != nil {
+		s.log.Debug().Err(err).Msg("Update6")
 		return nil, err
 	}
+	page.ContentFormat = pageDTO.ContentFormat
+	if err := s.render(page); err != nil {
+		s.log.Debug().Err(err).Msg("Update7")
+		return nil, err
+	}
+	if err := s.validateFields(ctx, page); err != nil {
+		s.log.Debug().Err(err).Msg("Update8")
+		return nil, err
+	}
+	// Сохраняем в репозитории
+	if err := s.repo.Update(ctx, page, entities.NewPageRevision(page, pageDTO.AuthorID)); err != nil {
+		s.log.Debug().Err(err).Msg("Update9")
+		return nil, s.conflict(ctx, pageDTO.ID, err)
+	}
 	// Получаем сохранённый результат
 	updatedPage, err := s.repo.FindByID(ctx, pageDTO.ID)
 	if err != nil {
-		s.log.Debug().Err(err).Msg("Update4")
+		s.log.Debug().Err(err).Msg("Update10")
 		return nil, err
 	}
 
+	s.pruneRevisions(ctx, updatedPage.ID)
+
+	s.notifyChange()
+	if current.Name != updatedPage.Name {
+		s.notifyPathChange(ctx, entities.PagePath(current.Name), entities.PagePath(updatedPage.Name))
+	}
 	s.log.Debug().Msg("UPDATE page: " + strconv.Itoa(int(pageDTO.ID)) + ", " + pageDTO.Name)
 	return updatedPage, nil
 }
+
+// conflict replaces ErrVersionConflict with VersionConflictError
+// carrying the page saved by the other editor.
+func (s *PageService) conflict(ctx context.Context, id int64, err error) error {
+	if !errors.Is(err, ErrVersionConflict) {
+		return err
+	}
+	current, findErr := s.repo.FindByID(ctx, id)
+	if findErr != nil {
+		return findErr
+	}
+	return &VersionConflictError{Current: current}
+}
@@ -3,21 +3,114 @@ package page
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/aube/auth/internal/application/dto"
 	"github.com/aube/auth/internal/domain/entities"
+	"github.com/aube/auth/internal/utils/query"
 )
 
-var ErrPageNotFound = errors.New("page not found")
+var (
+	ErrPageNotFound     = errors.New("page not found")
+	ErrRevisionNotFound = errors.New("page revision not found")
+
+	ErrEmptySearchQuery      = errors.New("search query is empty")
+	ErrInvalidSearchLanguage = errors.New("unknown search language")
+
+	// ErrInvalidFieldFilter is returned for a field filter of an unknown
+	// or non-scalar field, a bad value or a filter without a content type.
+	ErrInvalidFieldFilter = errors.New("invalid field filter")
+	// ErrInvalidContentFormat is returned for a content_format the renderer does not know.
+	ErrInvalidContentFormat = errors.New(`content_format must be "html" or "markdown"`)
+
+	// ErrVersionConflict is returned when a save is based on an outdated version.
+	ErrVersionConflict = errors.New("page was changed by another editor")
+	// ErrPageLocked is returned when another editor holds the edit lock.
+	ErrPageLocked   = errors.New("page is being edited by another user")
+	ErrLockNotFound = errors.New("page is not locked")
+)
+
+// VersionConflictError is returned instead of ErrVersionConflict by the service
+// and carries the current page, so that the editor can merge the changes.
+type VersionConflictError struct {
+	Current *entities.PageWithTime
+}
+
+func (e *VersionConflictError) Error() string { return ErrVersionConflict.Error() }
+func (e *VersionConflictError) Unwrap() error { return ErrVersionConflict }
+
+// LockedError is returned instead of ErrPageLocked and carries the lock
+// of the other editor.
+type LockedError struct {
+	Lock *entities.PageLock
+}
+
+func (e *LockedError) Error() string { return ErrPageLocked.Error() }
+func (e *LockedError) Unwrap() error { return ErrPageLocked }
 
 type PageRepository interface {
-	Create(ctx context.Context, page *entities.Page) error
-	Update(ctx context.Context, page *entities.Page) error
+	// Create and Update store the revision, when it is set, in the same
+	// transaction as the page, assigning its page id and number.
+	Create(ctx context.Context, page *entities.Page, revision *entities.PageRevision) error
+	// Update increments the page version; with page.Version set it fails
+	// with ErrVersionConflict unless that version is stored.
+	Update(ctx context.Context, page *entities.Page, revision *entities.PageRevision) error
 	GetIDByName(ctx context.Context, name string) (int64, error)
 	Delete(ctx context.Context, id int64) error
 	DeleteForce(ctx context.Context, id int64) error
 
 	FindByName(ctx context.Context, name string) (*entities.PageWithTime, error)
 	FindByID(ctx context.Context, id int64) (*entities.PageWithTime, error)
-	ListPages(ctx context.Context, limit int, offset int, params map[string]any) (*entities.PagesWithTimes, *dto.Pagination, error)
+	ListPages(ctx context.Context, limit int, offset int, filter *query.Query) (*entities.PagesWithTimes, *dto.Pagination, error)
+
+	// PublishDue/UnpublishDue apply the publish_at/unpublish_at schedule
+	// and return the number of affected pages.
+	PublishDue(ctx context.Context, now time.Time) (int64, error)
+	UnpublishDue(ctx context.Context, now time.Time) (int64, error)
+
+	// ListUnrendered returns pages with content but without rendered HTML,
+	// ordered by id and starting after afterID.
+	ListUnrendered(ctx context.Context, afterID int64, limit int) (*entities.PagesWithTimes, error)
+	UpdateRendered(ctx context.Context, id int64, contentHTML string, contentShort string) error
+
+	// SearchPages finds pages matching a to_tsquery expression, most relevant first.
+	// lang is a PostgreSQL text search configuration, empty for the default one.
+	SearchPages(ctx context.Context, tsQuery string, lang string, offset, limit int, filter *query.Query) (*entities.PageSearchResults, *dto.Pagination, error)
+}
+
+// PageRevisionRepository reads and prunes immutable page snapshots;
+// they are written by PageRepository together with the page.
+//
+// Methods:
+//
+//   - ListRevisions: Paginated revisions of a page, newest first;
+//     filter carries the cursor only
+//   - FindRevision: Single revision by page and number
+//   - PruneRevisions: Removes revisions beyond keepLast or created before olderThan
+//     (zero values disable a rule); the newest revision is always kept
+type PageRevisionRepository interface {
+	ListRevisions(ctx context.Context, pageID int64, offset, limit int, filter *query.Query) (*entities.PageRevisions, *dto.Pagination, error)
+	FindRevision(ctx context.Context, pageID int64, revision int) (*entities.PageRevision, error)
+	PruneRevisions(ctx context.Context, pageID int64, keepLast int, olderThan time.Time) (int64, error)
+}
+
+// ContentTypeSource provides content type definitions for typed pages
+// and checks images referenced by field values.
+type ContentTypeSource interface {
+	FindByName(ctx context.Context, name string) (*entities.ContentType, error)
+	MissingImages(ctx context.Context, uuids []string) ([]string, error)
+}
+
+// PageLockRepository stores advisory edit locks.
+//
+// Methods:
+//
+//   - Acquire: Takes or renews the lock of userID for ttl; returns
+//     ErrPageLocked and the current lock when another user holds an unexpired lock
+//   - Find: Unexpired lock of the page, ErrLockNotFound when there is none
+//   - Release: Removes the lock of userID, ErrLockNotFound when the user holds none
+type PageLockRepository interface {
+	Acquire(ctx context.Context, pageID int64, userID int64, ttl time.Duration) (*entities.PageLock, error)
+	Find(ctx context.Context, pageID int64) (*entities.PageLock, error)
+	Release(ctx context.Context, pageID int64, userID int64) error
 }
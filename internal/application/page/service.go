@@ -1,18 +1,78 @@
 package page
 
 import (
+	"context"
+	"sync"
+
+	"github.com/aube/auth/internal/application/render"
 	"github.com/aube/auth/internal/utils/logger"
 	"github.com/rs/zerolog"
 )
 
+// RevisionRetention limits how many page revisions are kept.
+// KeepLast: number of newest revisions to keep (0 for unlimited)
+// KeepDays: age in days after which revisions are removed (0 for unlimited)
+// The newest revision of a page is never removed.
+type RevisionRetention struct {
+	KeepLast int
+	KeepDays int
+}
+
 type PageService struct {
-	repo PageRepository
-	log  zerolog.Logger
+	repo      PageRepository
+	revisions PageRevisionRepository
+	types     ContentTypeSource
+	retention RevisionRetention
+	renderer  *render.Renderer
+	log       zerolog.Logger
+
+	mu            sync.RWMutex
+	listeners     []func()
+	pathListeners []func(ctx context.Context, oldPath, newPath string)
 }
 
-func NewPageService(repo PageRepository) *PageService {
+// NewPageService creates the page service.
+// types may be nil when content types are not used; typed pages are rejected then.
+func NewPageService(repo PageRepository, revisions PageRevisionRepository, types ContentTypeSource, retention RevisionRetention) *PageService {
 	return &PageService{
-		repo: repo,
-		log:  logger.Get().With().Str("page", "service").Logger(),
+		repo:      repo,
+		revisions: revisions,
+		types:     types,
+		retention: retention,
+		renderer:  render.NewRenderer(),
+		log:       logger.Get().With().Str("page", "service").Logger(),
+	}
+}
+
+// OnChange registers fn to be called after every successful page mutation.
+// Used by caches built from pages (menus).
+func (s *PageService) OnChange(fn func()) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.listeners = append(s.listeners, fn)
+}
+
+func (s *PageService) notifyChange() {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, fn := range s.listeners {
+		fn()
+	}
+}
+
+// OnPathChange registers fn to be called after a page changes its public path
+// (rename) or takes a new one (create, oldPath is empty).
+// Used to maintain redirects.
+func (s *PageService) OnPathChange(fn func(ctx context.Context, oldPath, newPath string)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pathListeners = append(s.pathListeners, fn)
+}
+
+func (s *PageService) notifyPathChange(ctx context.Context, oldPath, newPath string) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, fn := range s.pathListeners {
+		fn(ctx, oldPath, newPath)
 	}
 }
@@ -0,0 +1,222 @@
+package page_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aube/auth/internal/application/dto"
+	appPage "github.com/aube/auth/internal/application/page"
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/aube/auth/internal/utils/query"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newsType() *entities.ContentType {
+	return &entities.ContentType{Name: "news", Fields: entities.ContentFields{
+		{Name: "lead", Type: entities.FieldTypeRichText, Required: true},
+		{Name: "rating", Type: entities.FieldTypeNumber},
+		{Name: "cover", Type: entities.FieldTypeImage},
+		{Name: "source", Type: entities.FieldTypePage},
+	}}
+}
+
+func TestPageService_Create_ValidatesFields(t *testing.T) {
+	mockRepo := new(PageRepository)
+	mockRevisions := new(PageRevisionRepository)
+	mockTypes := new(ContentTypeSource)
+	service := appPage.NewPageService(mockRepo, mockRevisions, mockTypes, appPage.RevisionRetention{})
+
+	cover := "0b6f5a3e-8d7c-4e1a-9f2b-3c4d5e6f7a8b"
+	mockRepo.On("GetIDByName", mock.Anything, "launch").Return(int64(0), nil)
+	mockTypes.On("FindByName", mock.Anything, "news").Return(newsType(), nil)
+	mockTypes.On("MissingImages", mock.Anything, []string{cover}).Return(nil, nil)
+	mockRepo.On("FindByID", mock.Anything, int64(3)).Return(&entities.PageWithTime{ID: 3, Name: "source"}, nil)
+	mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*entities.Page"), mock.Anything).
+		Run(func(args mock.Arguments) {
+			page := args.Get(1).(*entities.Page)
+			assert.Equal(t, "news", page.ContentType)
+			assert.Equal(t, "<b>Big</b> day", page.Fields["lead"])
+			assert.Equal(t, int64(3), page.Fields["source"])
+			page.ID = 5
+		}).
+		Return(nil)
+	mockRepo.On("FindByID", mock.Anything, int64(5)).Return(&entities.PageWithTime{ID: 5, Name: "launch"}, nil)
+
+	_, err := service.Create(context.Background(), dto.CreatePageRequest{
+		Name:        "launch",
+		Status:      entities.PageStatusDraft,
+		ContentType: "news",
+		Fields: entities.PageFields{
+			"lead":   `<b>Big</b> day<script>alert(1)</script>`,
+			"cover":  cover,
+			"source": float64(3),
+		},
+	})
+
+	require.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPageService_ValidateFields_StoredImages(t *testing.T) {
+	mockTypes := new(ContentTypeSource)
+	service := appPage.NewPageService(new(PageRepository), new(PageRevisionRepository), mockTypes, appPage.RevisionRetention{})
+
+	// Изображение записывается вместе со страницей, в базе его ещё нет
+	cover := "0b6f5a3e-8d7c-4e1a-9f2b-3c4d5e6f7a8b"
+	mockTypes.On("FindByName", mock.Anything, "news").Return(newsType(), nil)
+	mockTypes.On("MissingImages", mock.Anything, []string{}).Return(nil, nil)
+
+	page := &entities.Page{ContentType: "news", Fields: entities.PageFields{"lead": "Big day", "cover": cover}}
+	err := service.ValidateFields(context.Background(), page, cover)
+
+	require.NoError(t, err)
+	mockTypes.AssertExpectations(t)
+}
+
+func TestPageService_Create_InvalidFields(t *testing.T) {
+	tests := []struct {
+		name   string
+		fields entities.PageFields
+		setup  func(types *ContentTypeSource, repo *PageRepository)
+	}{
+		{"missing required", entities.PageFields{"rating": float64(5)}, nil},
+		{"missing image", entities.PageFields{"lead": "x", "cover": "0b6f5a3e-8d7c-4e1a-9f2b-3c4d5e6f7a8b"}, func(types *ContentTypeSource, repo *PageRepository) {
+			types.On("MissingImages", mock.Anything, mock.Anything).Return([]string{"0b6f5a3e-8d7c-4e1a-9f2b-3c4d5e6f7a8b"}, nil)
+		}},
+		{"missing page", entities.PageFields{"lead": "x", "source": float64(9)}, func(types *ContentTypeSource, repo *PageRepository) {
+			types.On("MissingImages", mock.Anything, mock.Anything).Return(nil, nil)
+			repo.On("FindByID", mock.Anything, int64(9)).Return(nil, appPage.ErrPageNotFound)
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(PageRepository)
+			mockTypes := new(ContentTypeSource)
+			service := appPage.NewPageService(mockRepo, new(PageRevisionRepository), mockTypes, appPage.RevisionRetention{})
+
+			mockRepo.On("GetIDByName", mock.Anything, "launch").Return(int64(0), nil)
+			mockTypes.On("FindByName", mock.Anything, "news").Return(newsType(), nil)
+			if tt.setup != nil {
+				tt.setup(mockTypes, mockRepo)
+			}
+
+			_, err := service.Create(context.Background(), dto.CreatePageRequest{Name: "launch", ContentType: "news", Fields: tt.fields})
+
+			assert.Error(t, err)
+			mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestPageService_Create_FieldsWithoutType(t *testing.T) {
+	mockRepo := new(PageRepository)
+	service := appPage.NewPageService(mockRepo, new(PageRevisionRepository), nil, appPage.RevisionRetention{})
+
+	mockRepo.On("GetIDByName", mock.Anything, "about").Return(int64(0), nil)
+
+	_, err := service.Create(context.Background(), dto.CreatePageRequest{Name: "about", Fields: entities.PageFields{"lead": "x"}})
+
+	assert.Error(t, err)
+	mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestPageService_Update_KeepsFields(t *testing.T) {
+	mockRepo := new(PageRepository)
+	mockRevisions := new(PageRevisionRepository)
+	mockTypes := new(ContentTypeSource)
+	service := appPage.NewPageService(mockRepo, mockRevisions, mockTypes, appPage.RevisionRetention{})
+
+	current := &entities.PageWithTime{
+		ID:          5,
+		Name:        "launch",
+		Status:      entities.PageStatusDraft,
+		ContentType: "news",
+		Fields:      entities.PageFields{"lead": "Big day", "rating": float64(4)},
+	}
+	mockRepo.On("GetIDByName", mock.Anything, "launch").Return(int64(5), nil)
+	mockRepo.On("FindByID", mock.Anything, int64(5)).Return(current, nil)
+	mockTypes.On("FindByName", mock.Anything, "news").Return(newsType(), nil)
+	mockTypes.On("MissingImages", mock.Anything, mock.Anything).Return(nil, nil)
+	mockRepo.On("Update", mock.Anything, mock.AnythingOfType("*entities.Page"), mock.Anything).
+		Run(func(args mock.Arguments) {
+			page := args.Get(1).(*entities.Page)
+			assert.Equal(t, "news", page.ContentType)
+			assert.Equal(t, float64(4), page.Fields["rating"])
+		}).
+		Return(nil)
+
+	_, err := service.Update(context.Background(), dto.UpdatePageRequest{ID: 5, Name: "launch", Title: "Launch"})
+
+	require.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPageService_Update_RemovesType(t *testing.T) {
+	mockRepo := new(PageRepository)
+	mockRevisions := new(PageRevisionRepository)
+	service := appPage.NewPageService(mockRepo, mockRevisions, new(ContentTypeSource), appPage.RevisionRetention{})
+
+	mockRepo.On("GetIDByName", mock.Anything, "launch").Return(int64(5), nil)
+	mockRepo.On("FindByID", mock.Anything, int64(5)).Return(&entities.PageWithTime{
+		ID:          5,
+		Name:        "launch",
+		Status:      entities.PageStatusDraft,
+		ContentType: "news",
+		Fields:      entities.PageFields{"lead": "Big day"},
+	}, nil)
+	mockRepo.On("Update", mock.Anything, mock.AnythingOfType("*entities.Page"), mock.Anything).
+		Run(func(args mock.Arguments) {
+			page := args.Get(1).(*entities.Page)
+			assert.Empty(t, page.ContentType)
+			assert.Empty(t, page.Fields)
+		}).
+		Return(nil)
+
+	untyped := ""
+	_, err := service.Update(context.Background(), dto.UpdatePageRequest{ID: 5, Name: "launch", ContentType: &untyped})
+
+	require.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPageService_ListPages_FieldFilter(t *testing.T) {
+	mockRepo := new(PageRepository)
+	mockTypes := new(ContentTypeSource)
+	service := appPage.NewPageService(mockRepo, new(PageRevisionRepository), mockTypes, appPage.RevisionRetention{})
+
+	mockTypes.On("FindByName", mock.Anything, "news").Return(newsType(), nil)
+	mockRepo.On("ListPages", mock.Anything, 0, 10, query.New().
+		Add("content_type", query.OpEq, "news").
+		Add("fields", query.OpContains, entities.PageFields{"rating": float64(5)}),
+	).Return(&entities.PagesWithTimes{}, &dto.Pagination{}, nil)
+
+	filter := query.New().
+		Add("content_type", query.OpEq, "news").
+		Add("fields.rating", query.OpEq, "5")
+	_, _, err := service.ListPages(context.Background(), 0, 10, filter)
+
+	require.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPageService_ListPages_InvalidFieldFilter(t *testing.T) {
+	mockTypes := new(ContentTypeSource)
+	service := appPage.NewPageService(new(PageRepository), new(PageRevisionRepository), mockTypes, appPage.RevisionRetention{})
+	mockTypes.On("FindByName", mock.Anything, "news").Return(newsType(), nil)
+
+	news := func() *query.Query { return query.New().Add("content_type", query.OpEq, "news") }
+	tests := []*query.Query{
+		query.New().Add("fields.rating", query.OpEq, "5"),
+		news().Add("fields.color", query.OpEq, "red"),
+		news().Add("fields.rating", query.OpEq, "five"),
+		news().Add("fields.lead", query.OpEq, "x"),
+		news().Add("fields.rating", query.OpGt, "5"),
+	}
+	for _, filter := range tests {
+		_, _, err := service.ListPages(context.Background(), 0, 10, filter)
+		assert.ErrorIs(t, err, appPage.ErrInvalidFieldFilter)
+	}
+}
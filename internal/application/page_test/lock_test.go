@@ -0,0 +1,89 @@
+package page_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aube/auth/internal/application/dto"
+	appPage "github.com/aube/auth/internal/application/page"
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLockService_Lock(t *testing.T) {
+	mockRepo := new(PageLockRepository)
+	service := appPage.NewLockService(mockRepo, 0)
+
+	lock := &entities.PageLock{PageID: 5, UserID: 1}
+	mockRepo.On("Acquire", mock.Anything, int64(5), int64(1), appPage.DefaultLockTTL).Return(lock, nil)
+
+	result, err := service.Lock(context.Background(), 5, 1)
+
+	require.NoError(t, err)
+	assert.Equal(t, lock, result)
+}
+
+func TestLockService_Lock_HeldByOther(t *testing.T) {
+	mockRepo := new(PageLockRepository)
+	service := appPage.NewLockService(mockRepo, 0)
+
+	holder := &entities.PageLock{PageID: 5, UserID: 2, Username: "editor"}
+	mockRepo.On("Acquire", mock.Anything, int64(5), int64(1), mock.Anything).Return(holder, appPage.ErrPageLocked)
+
+	_, err := service.Lock(context.Background(), 5, 1)
+
+	var locked *appPage.LockedError
+	require.ErrorAs(t, err, &locked)
+	assert.ErrorIs(t, err, appPage.ErrPageLocked)
+	assert.Equal(t, "editor", locked.Lock.Username)
+}
+
+func TestLockService_Unlock_NotFound(t *testing.T) {
+	mockRepo := new(PageLockRepository)
+	service := appPage.NewLockService(mockRepo, 0)
+	mockRepo.On("Release", mock.Anything, int64(5), int64(1)).Return(appPage.ErrLockNotFound)
+
+	err := service.Unlock(context.Background(), 5, 1)
+
+	assert.ErrorIs(t, err, appPage.ErrLockNotFound)
+}
+
+func TestPageService_Update_StaleVersion(t *testing.T) {
+	mockRepo := new(PageRepository)
+	service := appPage.NewPageService(mockRepo, new(PageRevisionRepository), nil, appPage.RevisionRetention{})
+
+	mockRepo.On("GetIDByName", mock.Anything, "about").Return(int64(5), nil)
+	mockRepo.On("FindByID", mock.Anything, int64(5)).Return(&entities.PageWithTime{ID: 5, Name: "about", Status: entities.PageStatusDraft, Version: 3}, nil)
+
+	_, err := service.Update(context.Background(), dto.UpdatePageRequest{ID: 5, Name: "about", Version: 2})
+
+	var conflict *appPage.VersionConflictError
+	require.ErrorAs(t, err, &conflict)
+	assert.ErrorIs(t, err, appPage.ErrVersionConflict)
+	assert.Equal(t, 3, conflict.Current.Version)
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestPageService_Update_ConcurrentSave(t *testing.T) {
+	mockRepo := new(PageRepository)
+	service := appPage.NewPageService(mockRepo, new(PageRevisionRepository), nil, appPage.RevisionRetention{})
+
+	mockRepo.On("GetIDByName", mock.Anything, "about").Return(int64(5), nil)
+	mockRepo.On("FindByID", mock.Anything, int64(5)).Return(&entities.PageWithTime{ID: 5, Name: "about", Status: entities.PageStatusDraft, Version: 2}, nil).Once()
+	// Другой редактор сохранил страницу между чтением и записью
+	mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(page *entities.Page) bool {
+		return page.Version == 2
+	}), mock.Anything).Return(appPage.ErrVersionConflict)
+	mockRepo.On("FindByID", mock.Anything, int64(5)).Return(&entities.PageWithTime{ID: 5, Name: "about", Status: entities.PageStatusDraft, Version: 3}, nil).Once()
+
+	_, err := service.Update(context.Background(), dto.UpdatePageRequest{ID: 5, Name: "about", Version: 2})
+
+	var conflict *appPage.VersionConflictError
+	require.ErrorAs(t, err, &conflict)
+	assert.Equal(t, 3, conflict.Current.Version)
+	assert.False(t, errors.Is(err, appPage.ErrPageNotFound))
+	mockRepo.AssertExpectations(t)
+}
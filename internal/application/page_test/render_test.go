@@ -0,0 +1,86 @@
+package page_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aube/auth/internal/application/dto"
+	appPage "github.com/aube/auth/internal/application/page"
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPageService_Create_RendersMarkdown(t *testing.T) {
+	mockRepo := new(PageRepository)
+	mockRevisions := new(PageRevisionRepository)
+	service := appPage.NewPageService(mockRepo, mockRevisions, nil, appPage.RevisionRetention{})
+
+	mockRepo.On("GetIDByName", mock.Anything, "about").Return(int64(0), nil)
+	mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*entities.Page"), mock.Anything).
+		Run(func(args mock.Arguments) {
+			page := args.Get(1).(*entities.Page)
+			assert.Equal(t, entities.ContentFormatMarkdown, page.ContentFormat)
+			assert.Equal(t, "<h1>About</h1>\n<p>We make <strong>things</strong>.</p>\n", page.ContentHTML)
+			assert.Equal(t, "About We make things.", page.ContentShort)
+			page.ID = 5
+		}).
+		Return(nil)
+	mockRepo.On("FindByID", mock.Anything, int64(5)).Return(&entities.PageWithTime{ID: 5, Name: "about"}, nil)
+	mockRevisions.On("PruneRevisions", mock.Anything, int64(5), 0, mock.Anything).Return(int64(0), nil)
+
+	_, err := service.Create(context.Background(), dto.CreatePageRequest{
+		Name:          "about",
+		Content:       "# About\n\nWe make **things**.",
+		ContentFormat: entities.ContentFormatMarkdown,
+	})
+
+	require.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPageService_Create_UnknownFormat(t *testing.T) {
+	mockRepo := new(PageRepository)
+	service := appPage.NewPageService(mockRepo, new(PageRevisionRepository), nil, appPage.RevisionRetention{})
+
+	mockRepo.On("GetIDByName", mock.Anything, "about").Return(int64(0), nil)
+
+	_, err := service.Create(context.Background(), dto.CreatePageRequest{Name: "about", ContentFormat: "rtf"})
+
+	assert.ErrorIs(t, err, appPage.ErrInvalidContentFormat)
+	mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestPageService_Update_UnknownFormat(t *testing.T) {
+	mockRepo := new(PageRepository)
+	service := appPage.NewPageService(mockRepo, new(PageRevisionRepository), nil, appPage.RevisionRetention{})
+
+	mockRepo.On("GetIDByName", mock.Anything, "about").Return(int64(5), nil)
+	mockRepo.On("FindByID", mock.Anything, int64(5)).
+		Return(&entities.PageWithTime{ID: 5, Name: "about", ContentFormat: entities.ContentFormatHTML}, nil)
+
+	_, err := service.Update(context.Background(), dto.UpdatePageRequest{ID: 5, Name: "about", ContentFormat: "rtf"})
+
+	assert.ErrorIs(t, err, appPage.ErrInvalidContentFormat)
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestPageService_RenderPending(t *testing.T) {
+	mockRepo := new(PageRepository)
+	service := appPage.NewPageService(mockRepo, new(PageRevisionRepository), nil, appPage.RevisionRetention{})
+
+	mockRepo.On("ListUnrendered", mock.Anything, int64(0), mock.Anything).Return(&entities.PagesWithTimes{
+		{ID: 3, Name: "a", Content: "<p>one</p>", ContentFormat: entities.ContentFormatHTML, ContentShort: "keep"},
+		{ID: 7, Name: "b", Content: "*two*", ContentFormat: entities.ContentFormatMarkdown},
+	}, nil)
+	mockRepo.On("ListUnrendered", mock.Anything, int64(7), mock.Anything).Return(&entities.PagesWithTimes{}, nil)
+	mockRepo.On("UpdateRendered", mock.Anything, int64(3), "<p>one</p>", "keep").Return(nil)
+	mockRepo.On("UpdateRendered", mock.Anything, int64(7), "<p><em>two</em></p>\n", "two").Return(nil)
+
+	rendered, err := service.RenderPending(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, rendered)
+	mockRepo.AssertExpectations(t)
+}
@@ -0,0 +1,151 @@
+package page_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/aube/auth/internal/application/dto"
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/aube/auth/internal/utils/query"
+	"github.com/stretchr/testify/mock"
+)
+
+type PageRepository struct {
+	mock.Mock
+}
+
+func (m *PageRepository) Create(ctx context.Context, page *entities.Page, revision *entities.PageRevision) error {
+	return m.Called(ctx, page, revision).Error(0)
+}
+
+func (m *PageRepository) Update(ctx context.Context, page *entities.Page, revision *entities.PageRevision) error {
+	return m.Called(ctx, page, revision).Error(0)
+}
+
+func (m *PageRepository) GetIDByName(ctx context.Context, name string) (int64, error) {
+	args := m.Called(ctx, name)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *PageRepository) Delete(ctx context.Context, id int64) error {
+	return m.Called(ctx, id).Error(0)
+}
+
+func (m *PageRepository) DeleteForce(ctx context.Context, id int64) error {
+	return m.Called(ctx, id).Error(0)
+}
+
+func (m *PageRepository) FindByName(ctx context.Context, name string) (*entities.PageWithTime, error) {
+	args := m.Called(ctx, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.PageWithTime), args.Error(1)
+}
+
+func (m *PageRepository) FindByID(ctx context.Context, id int64) (*entities.PageWithTime, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.PageWithTime), args.Error(1)
+}
+
+func (m *PageRepository) ListPages(ctx context.Context, offset, limit int, filter *query.Query) (*entities.PagesWithTimes, *dto.Pagination, error) {
+	args := m.Called(ctx, offset, limit, filter)
+	return args.Get(0).(*entities.PagesWithTimes), args.Get(1).(*dto.Pagination), args.Error(2)
+}
+
+func (m *PageRepository) PublishDue(ctx context.Context, now time.Time) (int64, error) {
+	args := m.Called(ctx, now)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *PageRepository) UnpublishDue(ctx context.Context, now time.Time) (int64, error) {
+	args := m.Called(ctx, now)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *PageRepository) SearchPages(ctx context.Context, tsQuery string, lang string, offset, limit int, filter *query.Query) (*entities.PageSearchResults, *dto.Pagination, error) {
+	args := m.Called(ctx, tsQuery, lang, offset, limit, filter)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+	return args.Get(0).(*entities.PageSearchResults), args.Get(1).(*dto.Pagination), args.Error(2)
+}
+
+func (m *PageRepository) ListUnrendered(ctx context.Context, afterID int64, limit int) (*entities.PagesWithTimes, error) {
+	args := m.Called(ctx, afterID, limit)
+	return args.Get(0).(*entities.PagesWithTimes), args.Error(1)
+}
+
+func (m *PageRepository) UpdateRendered(ctx context.Context, id int64, contentHTML string, contentShort string) error {
+	return m.Called(ctx, id, contentHTML, contentShort).Error(0)
+}
+
+type PageRevisionRepository struct {
+	mock.Mock
+}
+
+func (m *PageRevisionRepository) ListRevisions(ctx context.Context, pageID int64, offset, limit int, filter *query.Query) (*entities.PageRevisions, *dto.Pagination, error) {
+	args := m.Called(ctx, pageID, offset, limit, filter)
+	return args.Get(0).(*entities.PageRevisions), args.Get(1).(*dto.Pagination), args.Error(2)
+}
+
+func (m *PageRevisionRepository) FindRevision(ctx context.Context, pageID int64, revision int) (*entities.PageRevision, error) {
+	args := m.Called(ctx, pageID, revision)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.PageRevision), args.Error(1)
+}
+
+func (m *PageRevisionRepository) PruneRevisions(ctx context.Context, pageID int64, keepLast int, olderThan time.Time) (int64, error) {
+	args := m.Called(ctx, pageID, keepLast, olderThan)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+type ContentTypeSource struct {
+	mock.Mock
+}
+
+func (m *ContentTypeSource) FindByName(ctx context.Context, name string) (*entities.ContentType, error) {
+	args := m.Called(ctx, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.ContentType), args.Error(1)
+}
+
+func (m *ContentTypeSource) MissingImages(ctx context.Context, uuids []string) ([]string, error) {
+	args := m.Called(ctx, uuids)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+type PageLockRepository struct {
+	mock.Mock
+}
+
+func (m *PageLockRepository) Acquire(ctx context.Context, pageID int64, userID int64, ttl time.Duration) (*entities.PageLock, error) {
+	args := m.Called(ctx, pageID, userID, ttl)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.PageLock), args.Error(1)
+}
+
+func (m *PageLockRepository) Find(ctx context.Context, pageID int64) (*entities.PageLock, error) {
+	args := m.Called(ctx, pageID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.PageLock), args.Error(1)
+}
+
+func (m *PageLockRepository) Release(ctx context.Context, pageID int64, userID int64) error {
+	args := m.Called(ctx, pageID, userID)
+	return args.Error(0)
+}
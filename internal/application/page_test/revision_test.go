@@ -0,0 +1,154 @@
+package page_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aube/auth/internal/application/dto"
+	appPage "github.com/aube/auth/internal/application/page"
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPageService_Update_WritesRevision(t *testing.T) {
+	mockRepo := new(PageRepository)
+	mockRevisions := new(PageRevisionRepository)
+	service := appPage.NewPageService(mockRepo, mockRevisions, nil, appPage.RevisionRetention{KeepLast: 10})
+
+	saved := &entities.PageWithTime{ID: 5, Name: "about", Title: "About", Content: "text", Status: entities.PageStatusPublished}
+
+	mockRepo.On("FindByID", mock.Anything, int64(5)).Return(saved, nil)
+	mockRepo.On("GetIDByName", mock.Anything, "about").Return(int64(5), nil)
+	// Ревизия записывается в одной транзакции со страницей
+	mockRepo.On("Update", mock.Anything, mock.AnythingOfType("*entities.Page"), mock.AnythingOfType("*entities.PageRevision")).
+		Run(func(args mock.Arguments) {
+			rev := args.Get(2).(*entities.PageRevision)
+			assert.Equal(t, int64(5), rev.PageID)
+			assert.Equal(t, "text", rev.Content)
+			assert.Equal(t, int64(42), rev.AuthorID)
+		}).
+		Return(nil)
+	mockRevisions.On("PruneRevisions", mock.Anything, int64(5), 10, time.Time{}).Return(int64(0), nil)
+
+	page, err := service.Update(context.Background(), dto.UpdatePageRequest{
+		ID:       5,
+		Name:     "about",
+		Title:    "About",
+		Content:  "text",
+		AuthorID: 42,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, saved, page)
+	mockRevisions.AssertExpectations(t)
+}
+
+func TestPageService_Update_RevisionFailureFailsSave(t *testing.T) {
+	mockRepo := new(PageRepository)
+	mockRevisions := new(PageRevisionRepository)
+	service := appPage.NewPageService(mockRepo, mockRevisions, nil, appPage.RevisionRetention{KeepLast: 10})
+
+	current := &entities.PageWithTime{ID: 5, Name: "about", Title: "About", Content: "old", Status: entities.PageStatusPublished}
+
+	mockRepo.On("FindByID", mock.Anything, int64(5)).Return(current, nil).Once()
+	mockRepo.On("GetIDByName", mock.Anything, "about").Return(int64(5), nil)
+	mockRepo.On("Update", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("failed to create page revision: connection reset"))
+
+	_, err := service.Update(context.Background(), dto.UpdatePageRequest{ID: 5, Name: "about", Title: "About", Content: "text"})
+
+	require.Error(t, err)
+	mockRevisions.AssertNotCalled(t, "PruneRevisions", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPageService_DiffRevisions(t *testing.T) {
+	mockRevisions := new(PageRevisionRepository)
+	service := appPage.NewPageService(new(PageRepository), mockRevisions, nil, appPage.RevisionRetention{})
+
+	mockRevisions.On("FindRevision", mock.Anything, int64(5), 1).
+		Return(&entities.PageRevision{Revision: 1, Name: "about", Title: "Old", Content: "a\nb\nc"}, nil)
+	mockRevisions.On("FindRevision", mock.Anything, int64(5), 2).
+		Return(&entities.PageRevision{Revision: 2, Name: "about", Title: "New", Content: "a\nx\nc"}, nil)
+
+	res, err := service.DiffRevisions(context.Background(), 5, 1, 2)
+
+	require.NoError(t, err)
+	fields := make(map[string]dto.PageFieldDiff)
+	for _, f := range res.Fields {
+		fields[f.Field] = f
+	}
+	assert.False(t, fields["name"].Changed)
+	assert.True(t, fields["title"].Changed)
+	assert.Equal(t, "Old", fields["title"].Old)
+	assert.Equal(t, "New", fields["title"].New)
+	assert.True(t, fields["content"].Changed)
+	require.Len(t, fields["content"].Hunks, 1)
+	assert.Empty(t, fields["content"].Old)
+}
+
+func TestPageService_RestoreRevision(t *testing.T) {
+	mockRepo := new(PageRepository)
+	mockRevisions := new(PageRevisionRepository)
+	service := appPage.NewPageService(mockRepo, mockRevisions, nil, appPage.RevisionRetention{})
+
+	mockRevisions.On("FindRevision", mock.Anything, int64(5), 3).
+		Return(&entities.PageRevision{PageID: 5, Revision: 3, Name: "about", Content: "restored"}, nil)
+	mockRepo.On("GetIDByName", mock.Anything, "about").Return(int64(5), nil)
+	mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(p *entities.Page) bool {
+		return p.ID == 5 && p.Content == "restored"
+	}), mock.Anything).Return(nil)
+	mockRepo.On("FindByID", mock.Anything, int64(5)).
+		Return(&entities.PageWithTime{ID: 5, Name: "about", Content: "restored", Status: entities.PageStatusDraft}, nil)
+
+	page, err := service.RestoreRevision(context.Background(), 5, 3, 7)
+
+	require.NoError(t, err)
+	assert.Equal(t, "restored", page.Content)
+	mockRevisions.AssertNotCalled(t, "PruneRevisions", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPageService_RestoreRevision_ContentFormat(t *testing.T) {
+	mockRepo := new(PageRepository)
+	mockRevisions := new(PageRevisionRepository)
+	service := appPage.NewPageService(mockRepo, mockRevisions, nil, appPage.RevisionRetention{})
+
+	// Страницу перевели в HTML, а ревизия сохранена в markdown
+	mockRevisions.On("FindRevision", mock.Anything, int64(5), 3).Return(&entities.PageRevision{
+		PageID: 5, Revision: 3, Name: "about", Content: "# About", ContentFormat: entities.ContentFormatMarkdown,
+	}, nil)
+	mockRepo.On("FindByID", mock.Anything, int64(5)).Return(&entities.PageWithTime{
+		ID: 5, Name: "about", Content: "<p>about</p>", ContentFormat: entities.ContentFormatHTML, Status: entities.PageStatusDraft,
+	}, nil).Once()
+	mockRepo.On("GetIDByName", mock.Anything, "about").Return(int64(5), nil)
+	mockRepo.On("Update", mock.Anything, mock.AnythingOfType("*entities.Page"), mock.AnythingOfType("*entities.PageRevision")).
+		Run(func(args mock.Arguments) {
+			page := args.Get(1).(*entities.Page)
+			assert.Equal(t, entities.ContentFormatMarkdown, page.ContentFormat)
+			assert.Equal(t, "<h1>About</h1>\n", page.ContentHTML)
+			assert.Equal(t, entities.ContentFormatMarkdown, args.Get(2).(*entities.PageRevision).ContentFormat)
+		}).
+		Return(nil)
+	mockRepo.On("FindByID", mock.Anything, int64(5)).Return(&entities.PageWithTime{
+		ID: 5, Name: "about", Content: "# About", ContentFormat: entities.ContentFormatMarkdown, Status: entities.PageStatusDraft,
+	}, nil)
+
+	page, err := service.RestoreRevision(context.Background(), 5, 3, 7)
+
+	require.NoError(t, err)
+	assert.Equal(t, entities.ContentFormatMarkdown, page.ContentFormat)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPageService_RestoreRevision_NotFound(t *testing.T) {
+	mockRevisions := new(PageRevisionRepository)
+	service := appPage.NewPageService(new(PageRepository), mockRevisions, nil, appPage.RevisionRetention{})
+
+	mockRevisions.On("FindRevision", mock.Anything, int64(5), 9).Return(nil, appPage.ErrRevisionNotFound)
+
+	_, err := service.RestoreRevision(context.Background(), 5, 9, 7)
+
+	assert.ErrorIs(t, err, appPage.ErrRevisionNotFound)
+}
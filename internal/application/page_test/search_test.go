@@ -0,0 +1,48 @@
+package page_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aube/auth/internal/application/dto"
+	appPage "github.com/aube/auth/internal/application/page"
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/aube/auth/internal/utils/query"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPageService_Search(t *testing.T) {
+	mockRepo := new(PageRepository)
+	service := appPage.NewPageService(mockRepo, new(PageRevisionRepository), nil, appPage.RevisionRetention{})
+
+	filter := query.New().Add("status", query.OpEq, entities.PageStatusPublished)
+	found := &entities.PageSearchResults{{PageWithTime: entities.PageWithTime{ID: 1, Name: "about"}, Rank: 0.5}}
+	pagination := &dto.Pagination{Total: 1, Page: 1, Size: 10}
+
+	mockRepo.On("SearchPages", mock.Anything, "(quick <-> fox) & prog:*", "english", 0, 10, filter).
+		Return(found, pagination, nil)
+
+	results, p, err := service.Search(context.Background(), dto.PageSearchRequest{
+		Query: `"quick fox" prog*`,
+		Lang:  "english",
+	}, 0, 10, filter)
+
+	require.NoError(t, err)
+	assert.Equal(t, found, results)
+	assert.Equal(t, pagination, p)
+}
+
+func TestPageService_Search_InvalidInput(t *testing.T) {
+	mockRepo := new(PageRepository)
+	service := appPage.NewPageService(mockRepo, new(PageRevisionRepository), nil, appPage.RevisionRetention{})
+
+	_, _, err := service.Search(context.Background(), dto.PageSearchRequest{Query: " -- "}, 0, 10, nil)
+	assert.ErrorIs(t, err, appPage.ErrEmptySearchQuery)
+
+	_, _, err = service.Search(context.Background(), dto.PageSearchRequest{Query: "fox", Lang: "english'; --"}, 0, 10, nil)
+	assert.ErrorIs(t, err, appPage.ErrInvalidSearchLanguage)
+
+	mockRepo.AssertNotCalled(t, "SearchPages", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
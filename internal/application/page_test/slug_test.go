@@ -0,0 +1,93 @@
+package page_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aube/auth/internal/application/dto"
+	appPage "github.com/aube/auth/internal/application/page"
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPageService_Create_GeneratesUniqueName(t *testing.T) {
+	mockRepo := new(PageRepository)
+	mockRevisions := new(PageRevisionRepository)
+	service := appPage.NewPageService(mockRepo, mockRevisions, nil, appPage.RevisionRetention{})
+
+	mockRepo.On("GetIDByName", mock.Anything, "o-kompanii").Return(int64(3), nil)
+	mockRepo.On("GetIDByName", mock.Anything, "o-kompanii-2").Return(int64(0), nil)
+	mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*entities.Page"), mock.Anything).
+		Run(func(args mock.Arguments) {
+			page := args.Get(1).(*entities.Page)
+			assert.Equal(t, "o-kompanii-2", page.Name)
+			page.ID = 7
+		}).
+		Return(nil)
+	mockRepo.On("FindByID", mock.Anything, int64(7)).
+		Return(&entities.PageWithTime{ID: 7, Name: "o-kompanii-2", Title: "О компании"}, nil)
+
+	var paths [][2]string
+	service.OnPathChange(func(ctx context.Context, oldPath, newPath string) {
+		paths = append(paths, [2]string{oldPath, newPath})
+	})
+
+	page, err := service.Create(context.Background(), dto.CreatePageRequest{Title: "О компании"})
+	require.NoError(t, err)
+	assert.Equal(t, "o-kompanii-2", page.Name)
+	assert.Equal(t, [][2]string{{"", "/o-kompanii-2"}}, paths)
+}
+
+func TestPageService_Create_InvalidName(t *testing.T) {
+	service := appPage.NewPageService(new(PageRepository), new(PageRevisionRepository), nil, appPage.RevisionRetention{})
+
+	_, err := service.Create(context.Background(), dto.CreatePageRequest{Name: "about us"})
+	assert.Error(t, err)
+
+	_, err = service.Create(context.Background(), dto.CreatePageRequest{Title: "!!!"})
+	assert.Error(t, err)
+}
+
+func TestPageService_Update_RenameNotifiesPathChange(t *testing.T) {
+	mockRepo := new(PageRepository)
+	mockRevisions := new(PageRevisionRepository)
+	service := appPage.NewPageService(mockRepo, mockRevisions, nil, appPage.RevisionRetention{})
+
+	mockRepo.On("GetIDByName", mock.Anything, "company").Return(int64(0), nil)
+	mockRepo.On("FindByID", mock.Anything, int64(5)).
+		Return(&entities.PageWithTime{ID: 5, Name: "about", Status: entities.PageStatusPublished}, nil).Once()
+	mockRepo.On("Update", mock.Anything, mock.AnythingOfType("*entities.Page"), mock.Anything).Return(nil)
+	mockRepo.On("FindByID", mock.Anything, int64(5)).
+		Return(&entities.PageWithTime{ID: 5, Name: "company", Status: entities.PageStatusPublished}, nil)
+
+	var paths [][2]string
+	service.OnPathChange(func(ctx context.Context, oldPath, newPath string) {
+		paths = append(paths, [2]string{oldPath, newPath})
+	})
+
+	_, err := service.Update(context.Background(), dto.UpdatePageRequest{ID: 5, Name: "Company"})
+	require.NoError(t, err)
+	assert.Equal(t, [][2]string{{"/about", "/company"}}, paths)
+}
+
+func TestPageService_Update_KeepsLegacyName(t *testing.T) {
+	mockRepo := new(PageRepository)
+	mockRevisions := new(PageRevisionRepository)
+	service := appPage.NewPageService(mockRepo, mockRevisions, nil, appPage.RevisionRetention{})
+
+	legacy := &entities.PageWithTime{ID: 5, Name: "contacts.html", Status: entities.PageStatusPublished}
+	mockRepo.On("FindByID", mock.Anything, int64(5)).Return(legacy, nil)
+	mockRepo.On("GetIDByName", mock.Anything, "contacts.html").Return(int64(5), nil)
+	mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(page *entities.Page) bool {
+		return page.Name == "contacts.html"
+	}), mock.Anything).Return(nil)
+
+	_, err := service.Update(context.Background(), dto.UpdatePageRequest{ID: 5, Name: "contacts.html", Title: "Contacts"})
+	require.NoError(t, err)
+
+	_, err = service.Update(context.Background(), dto.UpdatePageRequest{ID: 5, Name: "new contacts.html"})
+	assert.Error(t, err)
+	mockRepo.AssertNumberOfCalls(t, "Update", 1)
+}
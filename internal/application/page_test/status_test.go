@@ -0,0 +1,94 @@
+package page_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aube/auth/internal/application/dto"
+	appPage "github.com/aube/auth/internal/application/page"
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPageService_SetStatus(t *testing.T) {
+	mockRepo := new(PageRepository)
+	service := appPage.NewPageService(mockRepo, new(PageRevisionRepository), nil, appPage.RevisionRetention{})
+
+	publishAt := time.Date(2025, 6, 1, 10, 0, 0, 0, time.UTC)
+	stored := &entities.PageWithTime{ID: 5, Name: "about", Content: "text", Status: entities.PageStatusDraft}
+
+	mockRepo.On("FindByID", mock.Anything, int64(5)).Return(stored, nil)
+	mockRepo.On("Update", mock.Anything, mock.AnythingOfType("*entities.Page"), mock.Anything).
+		Run(func(args mock.Arguments) {
+			page := args.Get(1).(*entities.Page)
+			assert.Equal(t, "text", page.Content)
+			assert.Equal(t, entities.PageStatusReview, page.Status)
+			assert.Equal(t, &publishAt, page.PublishAt)
+		}).
+		Return(nil)
+
+	changed := 0
+	service.OnChange(func() { changed++ })
+
+	_, err := service.SetStatus(context.Background(), dto.PageStatusRequest{
+		ID:        5,
+		Status:    entities.PageStatusReview,
+		PublishAt: &publishAt,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, changed)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPageService_SetStatus_Invalid(t *testing.T) {
+	mockRepo := new(PageRepository)
+	service := appPage.NewPageService(mockRepo, new(PageRevisionRepository), nil, appPage.RevisionRetention{})
+
+	mockRepo.On("FindByID", mock.Anything, int64(5)).
+		Return(&entities.PageWithTime{ID: 5, Name: "about", Status: entities.PageStatusDraft}, nil)
+
+	_, err := service.SetStatus(context.Background(), dto.PageStatusRequest{ID: 5, Status: "archived"})
+
+	assert.Error(t, err)
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestPageService_PublishScheduled(t *testing.T) {
+	mockRepo := new(PageRepository)
+	service := appPage.NewPageService(mockRepo, new(PageRevisionRepository), nil, appPage.RevisionRetention{})
+
+	now := time.Date(2025, 6, 1, 10, 0, 0, 0, time.UTC)
+	mockRepo.On("PublishDue", mock.Anything, now).Return(int64(2), nil)
+	mockRepo.On("UnpublishDue", mock.Anything, now).Return(int64(1), nil)
+
+	changed := 0
+	service.OnChange(func() { changed++ })
+
+	published, unpublished, err := service.PublishScheduled(context.Background(), now)
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), published)
+	assert.Equal(t, int64(1), unpublished)
+	assert.Equal(t, 1, changed)
+}
+
+func TestPageService_PublishScheduled_NothingDue(t *testing.T) {
+	mockRepo := new(PageRepository)
+	service := appPage.NewPageService(mockRepo, new(PageRevisionRepository), nil, appPage.RevisionRetention{})
+
+	now := time.Date(2025, 6, 1, 10, 0, 0, 0, time.UTC)
+	mockRepo.On("PublishDue", mock.Anything, now).Return(int64(0), nil)
+	mockRepo.On("UnpublishDue", mock.Anything, now).Return(int64(0), nil)
+
+	changed := 0
+	service.OnChange(func() { changed++ })
+
+	_, _, err := service.PublishScheduled(context.Background(), now)
+
+	require.NoError(t, err)
+	assert.Equal(t, 0, changed)
+}
@@ -0,0 +1,111 @@
+package redirect
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/aube/auth/internal/domain/entities"
+)
+
+// MaxHops limits how many chained internal redirects are followed.
+const MaxHops = 10
+
+type patternRule struct {
+	redirect entities.Redirect
+	pattern  *regexp.Regexp
+}
+
+// matcher finds the rule for a path: exact rules first, then wildcard
+// and regex rules in id order.
+type matcher struct {
+	exact    map[string]entities.Redirect
+	patterns []patternRule
+}
+
+// newMatcher compiles rules; rules that fail to compile are returned
+// separately so that a single broken rule does not disable the others.
+func newMatcher(redirects entities.Redirects) (*matcher, []entities.Redirect) {
+	m := &matcher{exact: make(map[string]entities.Redirect)}
+	var broken []entities.Redirect
+
+	for _, r := range redirects {
+		if r.Kind == entities.RedirectExact {
+			m.exact[r.Source] = r
+			continue
+		}
+		pattern, err := r.Pattern()
+		if err != nil || pattern == nil {
+			broken = append(broken, r)
+			continue
+		}
+		m.patterns = append(m.patterns, patternRule{redirect: r, pattern: pattern})
+	}
+
+	return m, broken
+}
+
+// match returns the target of the first rule matching path.
+func (m *matcher) match(path string) (string, int, bool) {
+	if r, ok := m.exact[path]; ok {
+		return r.Target, r.Code, true
+	}
+
+	for _, rule := range m.patterns {
+		groups := rule.pattern.FindStringSubmatchIndex(path)
+		if groups == nil {
+			continue
+		}
+		if rule.redirect.Kind == entities.RedirectRegex {
+			return string(rule.pattern.ExpandString(nil, rule.redirect.Target, path, groups)), rule.redirect.Code, true
+		}
+		return expandWildcard(rule.redirect.Target, path, groups), rule.redirect.Code, true
+	}
+
+	return "", 0, false
+}
+
+// resolve follows internal redirects starting at path and returns the final
+// target with the code of the first rule.
+func (m *matcher) resolve(path string) (string, int, error) {
+	target, code, ok := m.match(path)
+	if !ok {
+		return "", 0, ErrRedirectNotFound
+	}
+
+	visited := map[string]bool{path: true}
+	for hops := 1; ; hops++ {
+		if !strings.HasPrefix(target, "/") || strings.HasPrefix(target, "//") {
+			return target, code, nil
+		}
+
+		next := entities.NormalizeNodePath(strings.SplitN(target, "?", 2)[0])
+		if visited[next] || hops >= MaxHops {
+			return "", 0, ErrRedirectLoop
+		}
+		visited[next] = true
+
+		nextTarget, _, ok := m.match(next)
+		if !ok {
+			return target, code, nil
+		}
+		target = nextTarget
+	}
+}
+
+// expandWildcard replaces each "*" of target with the corresponding group.
+func expandWildcard(target string, path string, groups []int) string {
+	var b strings.Builder
+	group := 1
+	for _, part := range strings.SplitAfter(target, "*") {
+		if !strings.HasSuffix(part, "*") {
+			b.WriteString(part)
+			continue
+		}
+		b.WriteString(strings.TrimSuffix(part, "*"))
+		if 2*group+1 < len(groups) && groups[2*group] >= 0 {
+			b.WriteString(path[groups[2*group]:groups[2*group+1]])
+		}
+		group++
+	}
+	return b.String()
+}
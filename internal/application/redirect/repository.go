@@ -0,0 +1,47 @@
+// Package redirect manages URL redirects: manual rules and rules created
+// automatically when pages are renamed or nodes move.
+package redirect
+
+import (
+	"context"
+	"errors"
+
+	"github.com/aube/auth/internal/application/dto"
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/aube/auth/internal/utils/query"
+)
+
+var (
+	// ErrRedirectNotFound is returned when no rule matches or a rule does not exist.
+	ErrRedirectNotFound = errors.New("redirect not found")
+	// ErrRedirectExists is returned when a rule of the same kind has the same source.
+	ErrRedirectExists = errors.New("redirect with this source already exists")
+	// ErrRedirectLoop is returned when following redirects returns to a visited path
+	// or exceeds MaxHops.
+	ErrRedirectLoop = errors.New("redirect loop")
+)
+
+// RedirectRepository defines the interface for redirect persistence.
+//
+// Methods:
+//
+//   - Create / Update / Delete / FindByID / List: Rule management
+//   - ListAll: Every rule, ordered by id, for the in-memory matcher
+//   - SaveAuto: Inserts an automatic rule or retargets an existing automatic
+//     rule with the same kind and source; manual rules are left untouched
+//   - DeleteAuto: Removes automatic rules with source path (and, with subtree,
+//     sources below it), because the path is served by a page again
+//   - RetargetAuto: Points automatic exact rules targeting oldTarget (and, with
+//     subtree, paths below it) to newTarget
+type RedirectRepository interface {
+	Create(ctx context.Context, redirect *entities.Redirect) error
+	Update(ctx context.Context, redirect *entities.Redirect) error
+	Delete(ctx context.Context, id int64) error
+	FindByID(ctx context.Context, id int64) (*entities.Redirect, error)
+	List(ctx context.Context, offset, limit int, filter *query.Query) (*entities.Redirects, *dto.Pagination, error)
+	ListAll(ctx context.Context) (*entities.Redirects, error)
+
+	SaveAuto(ctx context.Context, redirect *entities.Redirect) error
+	DeleteAuto(ctx context.Context, path string, subtree bool) (int64, error)
+	RetargetAuto(ctx context.Context, oldTarget, newTarget string, subtree bool) (int64, error)
+}
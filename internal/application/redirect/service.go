@@ -0,0 +1,233 @@
+package redirect
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aube/auth/internal/application/dto"
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/aube/auth/internal/utils/logger"
+	"github.com/aube/auth/internal/utils/query"
+	"github.com/rs/zerolog"
+)
+
+// RedirectService manages redirect rules and resolves request paths.
+// Compiled rules are kept in memory; the cache is dropped on every change
+// made through the service and additionally expires after ttl.
+type RedirectService struct {
+	repo RedirectRepository
+	ttl  time.Duration
+	log  zerolog.Logger
+
+	mu        sync.RWMutex
+	matcher   *matcher
+	expiresAt time.Time
+}
+
+func NewRedirectService(repo RedirectRepository, ttl time.Duration) *RedirectService {
+	return &RedirectService{
+		repo: repo,
+		ttl:  ttl,
+		log:  logger.Get().With().Str("redirect", "service").Logger(),
+	}
+}
+
+func (s *RedirectService) List(ctx context.Context, offset, limit int, filter *query.Query) (*entities.Redirects, *dto.Pagination, error) {
+	return s.repo.List(ctx, offset, limit, filter)
+}
+
+func (s *RedirectService) GetByID(ctx context.Context, id int64) (*entities.Redirect, error) {
+	return s.repo.FindByID(ctx, id)
+}
+
+func (s *RedirectService) Create(ctx context.Context, redirectDTO dto.RedirectRequest) (*entities.Redirect, error) {
+	redirect, err := entities.NewRedirect(0, redirectDTO.Source, redirectDTO.Target, redirectDTO.Kind, redirectDTO.Code, false)
+	if err != nil {
+		s.log.Debug().Err(err).Msg("Create1")
+		return nil, err
+	}
+	if err := s.checkLoop(ctx, redirect); err != nil {
+		s.log.Debug().Err(err).Msg("Create2")
+		return nil, err
+	}
+
+	if err := s.repo.Create(ctx, redirect); err != nil {
+		s.log.Debug().Err(err).Msg("Create3")
+		return nil, err
+	}
+
+	s.Invalidate()
+	s.log.Debug().Msg("CREATE redirect: " + redirect.Source + " -> " + redirect.Target)
+	return s.repo.FindByID(ctx, redirect.ID)
+}
+
+// Update changes a rule; an edited automatic rule becomes a manual one.
+func (s *RedirectService) Update(ctx context.Context, redirectDTO dto.RedirectRequest) (*entities.Redirect, error) {
+	redirect, err := entities.NewRedirect(redirectDTO.ID, redirectDTO.Source, redirectDTO.Target, redirectDTO.Kind, redirectDTO.Code, false)
+	if err != nil {
+		s.log.Debug().Err(err).Msg("Update1")
+		return nil, err
+	}
+	if err := s.checkLoop(ctx, redirect); err != nil {
+		s.log.Debug().Err(err).Msg("Update2")
+		return nil, err
+	}
+
+	if err := s.repo.Update(ctx, redirect); err != nil {
+		s.log.Debug().Err(err).Msg("Update3")
+		return nil, err
+	}
+
+	s.Invalidate()
+	s.log.Debug().Msg("UPDATE redirect: " + strconv.Itoa(int(redirect.ID)))
+	return s.repo.FindByID(ctx, redirect.ID)
+}
+
+func (s *RedirectService) Delete(ctx context.Context, id int64) error {
+	if err := s.repo.Delete(ctx, id); err != nil {
+		s.log.Debug().Err(err).Msg("Delete")
+		return err
+	}
+
+	s.Invalidate()
+	return nil
+}
+
+// Resolve returns the redirect target and HTTP code for a request path.
+// Chains of internal redirects are followed, so the client gets the final
+// target in one hop. Returns ErrRedirectNotFound when no rule matches and
+// ErrRedirectLoop when the chain does not end.
+func (s *RedirectService) Resolve(ctx context.Context, path string) (string, int, error) {
+	m, err := s.getMatcher(ctx)
+	if err != nil {
+		return "", 0, err
+	}
+
+	target, code, err := m.resolve(entities.NormalizeNodePath(path))
+	if errors.Is(err, ErrRedirectLoop) {
+		s.log.Warn().Str("path", path).Msg("redirect loop")
+	}
+	return target, code, err
+}
+
+// TrackPage keeps links to a renamed page working (subscribed to page path changes).
+func (s *RedirectService) TrackPage(ctx context.Context, oldPath, newPath string) {
+	s.track(ctx, oldPath, newPath, false)
+}
+
+// TrackNode keeps links to a moved or renamed node and its subtree working
+// (subscribed to node path changes).
+func (s *RedirectService) TrackNode(ctx context.Context, oldPath, newPath string) {
+	s.track(ctx, oldPath, newPath, true)
+}
+
+// track drops automatic rules from newPath, which is served by a page again,
+// and redirects oldPath (with its subtree for nodes) to newPath.
+func (s *RedirectService) track(ctx context.Context, oldPath, newPath string, subtree bool) {
+	defer s.Invalidate()
+
+	if _, err := s.repo.DeleteAuto(ctx, newPath, subtree); err != nil {
+		s.log.Error().Err(err).Msg("track1")
+		return
+	}
+	if oldPath == "" || oldPath == newPath {
+		return
+	}
+
+	// Цепочки сокращаются: редиректы на старый путь ведут сразу на новый
+	if _, err := s.repo.RetargetAuto(ctx, oldPath, newPath, subtree); err != nil {
+		s.log.Error().Err(err).Msg("track2")
+		return
+	}
+
+	rules := []*entities.Redirect{{Source: oldPath, Target: newPath, Kind: entities.RedirectExact}}
+	if subtree {
+		rules = append(rules, &entities.Redirect{
+			Source: strings.TrimSuffix(oldPath, "/") + "/*",
+			Target: strings.TrimSuffix(newPath, "/") + "/*",
+			Kind:   entities.RedirectWildcard,
+		})
+	}
+	for _, rule := range rules {
+		redirect, err := entities.NewRedirect(0, rule.Source, rule.Target, rule.Kind, 0, true)
+		if err != nil {
+			s.log.Error().Err(err).Msg("track3")
+			return
+		}
+		if err := s.repo.SaveAuto(ctx, redirect); err != nil {
+			s.log.Error().Err(err).Msg("track4")
+			return
+		}
+	}
+
+	s.log.Debug().Msg("AUTO redirect: " + oldPath + " -> " + newPath)
+}
+
+// Invalidate drops the compiled rules.
+func (s *RedirectService) Invalidate() {
+	s.mu.Lock()
+	s.matcher = nil
+	s.mu.Unlock()
+}
+
+func (s *RedirectService) getMatcher(ctx context.Context) (*matcher, error) {
+	s.mu.RLock()
+	m, expiresAt := s.matcher, s.expiresAt
+	s.mu.RUnlock()
+	if m != nil && time.Now().Before(expiresAt) {
+		return m, nil
+	}
+
+	redirects, err := s.repo.ListAll(ctx)
+	if err != nil {
+		s.log.Debug().Err(err).Msg("getMatcher")
+		return nil, err
+	}
+
+	m, broken := newMatcher(*redirects)
+	for _, r := range broken {
+		s.log.Warn().Int64("id", r.ID).Str("source", r.Source).Msg("invalid redirect rule skipped")
+	}
+
+	s.mu.Lock()
+	s.matcher = m
+	s.expiresAt = time.Now().Add(s.ttl)
+	s.mu.Unlock()
+
+	return m, nil
+}
+
+// checkLoop rejects a rule that would make its own source redirect back to it.
+// Wildcard rules are checked with "*" replaced by a sample segment;
+// regex rules are only guarded at resolve time.
+func (s *RedirectService) checkLoop(ctx context.Context, redirect *entities.Redirect) error {
+	if !redirect.IsInternal() || redirect.Kind == entities.RedirectRegex {
+		return nil
+	}
+
+	redirects, err := s.repo.ListAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	rules := entities.Redirects{*redirect}
+	for _, r := range *redirects {
+		if r.ID != redirect.ID {
+			rules = append(rules, r)
+		}
+	}
+	m, _ := newMatcher(rules)
+
+	source := redirect.Source
+	if redirect.Kind == entities.RedirectWildcard {
+		source = strings.ReplaceAll(source, "*", "x")
+	}
+	if _, _, err := m.resolve(source); errors.Is(err, ErrRedirectLoop) {
+		return ErrRedirectLoop
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package redirect_test
+
+import (
+	"context"
+
+	"github.com/aube/auth/internal/application/dto"
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/aube/auth/internal/utils/query"
+	"github.com/stretchr/testify/mock"
+)
+
+type RedirectRepository struct {
+	mock.Mock
+}
+
+func (m *RedirectRepository) Create(ctx context.Context, redirect *entities.Redirect) error {
+	return m.Called(ctx, redirect).Error(0)
+}
+
+func (m *RedirectRepository) Update(ctx context.Context, redirect *entities.Redirect) error {
+	return m.Called(ctx, redirect).Error(0)
+}
+
+func (m *RedirectRepository) Delete(ctx context.Context, id int64) error {
+	return m.Called(ctx, id).Error(0)
+}
+
+func (m *RedirectRepository) FindByID(ctx context.Context, id int64) (*entities.Redirect, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Redirect), args.Error(1)
+}
+
+func (m *RedirectRepository) List(ctx context.Context, offset, limit int, filter *query.Query) (*entities.Redirects, *dto.Pagination, error) {
+	args := m.Called(ctx, offset, limit, filter)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+	return args.Get(0).(*entities.Redirects), args.Get(1).(*dto.Pagination), args.Error(2)
+}
+
+func (m *RedirectRepository) ListAll(ctx context.Context) (*entities.Redirects, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Redirects), args.Error(1)
+}
+
+func (m *RedirectRepository) SaveAuto(ctx context.Context, redirect *entities.Redirect) error {
+	return m.Called(ctx, redirect).Error(0)
+}
+
+func (m *RedirectRepository) DeleteAuto(ctx context.Context, path string, subtree bool) (int64, error) {
+	args := m.Called(ctx, path, subtree)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *RedirectRepository) RetargetAuto(ctx context.Context, oldTarget, newTarget string, subtree bool) (int64, error) {
+	args := m.Called(ctx, oldTarget, newTarget, subtree)
+	return args.Get(0).(int64), args.Error(1)
+}
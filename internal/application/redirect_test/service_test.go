@@ -0,0 +1,123 @@
+package redirect_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aube/auth/internal/application/dto"
+	appRedirect "github.com/aube/auth/internal/application/redirect"
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func rules() *entities.Redirects {
+	return &entities.Redirects{
+		{ID: 1, Source: "/old", Target: "/older", Kind: entities.RedirectExact, Code: 301},
+		{ID: 2, Source: "/older", Target: "/new", Kind: entities.RedirectExact, Code: 302},
+		{ID: 3, Source: "/blog/*/comments/*", Target: "/news/*#c-*", Kind: entities.RedirectWildcard, Code: 301},
+		{ID: 4, Source: `/p/(?P<id>\d+)`, Target: "/page?id=${id}", Kind: entities.RedirectRegex, Code: 308},
+		{ID: 5, Source: "/docs", Target: "https://docs.example.com/", Kind: entities.RedirectExact, Code: 302},
+		{ID: 6, Source: "/ping", Target: "/pong", Kind: entities.RedirectExact, Code: 301},
+		{ID: 7, Source: "/pong", Target: "/ping", Kind: entities.RedirectExact, Code: 301},
+		{ID: 8, Source: "/broken/(", Target: "/x", Kind: entities.RedirectRegex, Code: 301},
+	}
+}
+
+func TestRedirectService_Resolve(t *testing.T) {
+	repo := new(RedirectRepository)
+	repo.On("ListAll", mock.Anything).Return(rules(), nil).Once()
+	service := appRedirect.NewRedirectService(repo, time.Minute)
+
+	tests := []struct {
+		path   string
+		target string
+		code   int
+	}{
+		{"/old/", "/new", 301},
+		{"/older", "/new", 302},
+		{"/blog/hello/comments/42", "/news/hello#c-42", 301},
+		{"/p/17", "/page?id=17", 308},
+		{"/docs", "https://docs.example.com/", 302},
+	}
+	for _, tt := range tests {
+		target, code, err := service.Resolve(context.Background(), tt.path)
+		require.NoError(t, err, tt.path)
+		assert.Equal(t, tt.target, target, tt.path)
+		assert.Equal(t, tt.code, code, tt.path)
+	}
+
+	_, _, err := service.Resolve(context.Background(), "/ping")
+	assert.ErrorIs(t, err, appRedirect.ErrRedirectLoop)
+
+	_, _, err = service.Resolve(context.Background(), "/about")
+	assert.ErrorIs(t, err, appRedirect.ErrRedirectNotFound)
+
+	// Правила загружаются один раз и берутся из кэша
+	repo.AssertNumberOfCalls(t, "ListAll", 1)
+}
+
+func TestRedirectService_CreateRejectsLoop(t *testing.T) {
+	repo := new(RedirectRepository)
+	repo.On("ListAll", mock.Anything).Return(rules(), nil)
+	service := appRedirect.NewRedirectService(repo, time.Minute)
+
+	_, err := service.Create(context.Background(), dto.RedirectRequest{Source: "/new", Target: "/old"})
+	assert.ErrorIs(t, err, appRedirect.ErrRedirectLoop)
+
+	_, err = service.Create(context.Background(), dto.RedirectRequest{Source: "/a/*", Target: "/a/b/*", Kind: entities.RedirectWildcard})
+	assert.ErrorIs(t, err, appRedirect.ErrRedirectLoop)
+
+	repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestRedirectService_Create(t *testing.T) {
+	repo := new(RedirectRepository)
+	repo.On("ListAll", mock.Anything).Return(rules(), nil)
+	repo.On("Create", mock.Anything, mock.AnythingOfType("*entities.Redirect")).
+		Run(func(args mock.Arguments) {
+			redirect := args.Get(1).(*entities.Redirect)
+			assert.Equal(t, "/company", redirect.Source)
+			assert.False(t, redirect.Auto)
+			redirect.ID = 9
+		}).
+		Return(nil)
+	repo.On("FindByID", mock.Anything, int64(9)).
+		Return(&entities.Redirect{ID: 9, Source: "/company", Target: "/about", Kind: entities.RedirectExact, Code: 301}, nil)
+	service := appRedirect.NewRedirectService(repo, time.Minute)
+
+	redirect, err := service.Create(context.Background(), dto.RedirectRequest{Source: "/company/", Target: "/about"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(9), redirect.ID)
+}
+
+func TestRedirectService_TrackNode(t *testing.T) {
+	repo := new(RedirectRepository)
+	service := appRedirect.NewRedirectService(repo, time.Minute)
+
+	repo.On("DeleteAuto", mock.Anything, "/company/team", true).Return(int64(1), nil)
+	repo.On("RetargetAuto", mock.Anything, "/about/team", "/company/team", true).Return(int64(0), nil)
+	repo.On("SaveAuto", mock.Anything, mock.MatchedBy(func(r *entities.Redirect) bool {
+		return r.Auto && r.Kind == entities.RedirectExact && r.Source == "/about/team" && r.Target == "/company/team"
+	})).Return(nil).Once()
+	repo.On("SaveAuto", mock.Anything, mock.MatchedBy(func(r *entities.Redirect) bool {
+		return r.Auto && r.Kind == entities.RedirectWildcard && r.Source == "/about/team/*" && r.Target == "/company/team/*"
+	})).Return(nil).Once()
+
+	service.TrackNode(context.Background(), "/about/team", "/company/team")
+	repo.AssertExpectations(t)
+}
+
+func TestRedirectService_TrackPageCreate(t *testing.T) {
+	repo := new(RedirectRepository)
+	service := appRedirect.NewRedirectService(repo, time.Minute)
+
+	repo.On("DeleteAuto", mock.Anything, "/about", false).Return(int64(0), nil)
+
+	// Новая страница только освобождает свой адрес от автоматических редиректов
+	service.TrackPage(context.Background(), "", "/about")
+	repo.AssertExpectations(t)
+	repo.AssertNotCalled(t, "SaveAuto", mock.Anything, mock.Anything)
+}
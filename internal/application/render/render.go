@@ -0,0 +1,83 @@
+// Package render converts page content to safe HTML.
+package render
+
+import (
+	"bytes"
+	"errors"
+	"html"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/aube/auth/internal/domain/entities"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+)
+
+// ExcerptLength is the length of generated short content, in characters.
+const ExcerptLength = 300
+
+var ErrUnknownFormat = errors.New("unknown content format")
+
+// Renderer converts Markdown (CommonMark with tables and footnotes) to HTML
+// and sanitizes the result with an allowlist policy. Raw HTML is sanitized
+// with the same policy, so the output is always safe to embed in a page.
+type Renderer struct {
+	markdown goldmark.Markdown
+	policy   *bluemonday.Policy
+	text     *bluemonday.Policy
+}
+
+func NewRenderer() *Renderer {
+	policy := bluemonday.UGCPolicy()
+	// Атрибуты, которые использует разметка сносок и таблиц
+	policy.AllowAttrs("id").Matching(bluemonday.SpaceSeparatedTokens).Globally()
+	policy.AllowAttrs("role").Matching(bluemonday.SpaceSeparatedTokens).OnElements("a", "div", "section")
+	policy.AllowAttrs("class").Matching(bluemonday.SpaceSeparatedTokens).OnElements("a", "div", "section", "code", "pre", "sup", "li")
+	policy.AllowAttrs("style").OnElements("td", "th")
+	policy.AllowStyles("text-align").MatchingEnum("left", "right", "center").OnElements("td", "th")
+
+	return &Renderer{
+		markdown: goldmark.New(goldmark.WithExtensions(extension.Table, extension.Footnote)),
+		policy:   policy,
+		text:     bluemonday.StrictPolicy(),
+	}
+}
+
+// IsValidFormat reports whether format can be rendered.
+func IsValidFormat(format string) bool {
+	return format == entities.ContentFormatHTML || format == entities.ContentFormatMarkdown
+}
+
+// Render returns sanitized HTML for content in the given format.
+func (r *Renderer) Render(format string, content string) (string, error) {
+	switch format {
+	case entities.ContentFormatHTML:
+		return r.policy.Sanitize(content), nil
+	case entities.ContentFormatMarkdown:
+		var buf bytes.Buffer
+		if err := r.markdown.Convert([]byte(content), &buf); err != nil {
+			return "", err
+		}
+		return string(r.policy.SanitizeBytes(buf.Bytes())), nil
+	}
+	return "", ErrUnknownFormat
+}
+
+// Excerpt returns the plain text of rendered HTML, cut at a word boundary
+// to at most maxRunes characters (an ellipsis is added when cut).
+func (r *Renderer) Excerpt(renderedHTML string, maxRunes int) string {
+	text := html.UnescapeString(r.text.Sanitize(renderedHTML))
+	text = strings.Join(strings.Fields(text), " ")
+
+	if utf8.RuneCountInString(text) <= maxRunes {
+		return text
+	}
+
+	runes := []rune(text)[:maxRunes]
+	if i := strings.LastIndexByte(string(runes), ' '); i > 0 {
+		return string(runes)[:i] + "…"
+	}
+	return string(runes) + "…"
+}
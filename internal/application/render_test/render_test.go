@@ -0,0 +1,52 @@
+package render_test
+
+import (
+	"testing"
+
+	"github.com/aube/auth/internal/application/render"
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderer_Markdown(t *testing.T) {
+	r := render.NewRenderer()
+
+	out, err := r.Render(entities.ContentFormatMarkdown, "# Title\n\nSome *text*[^1].\n\n| a | b |\n|---|--:|\n| 1 | 2 |\n\n[^1]: Note")
+	require.NoError(t, err)
+
+	assert.Contains(t, out, "<h1>Title</h1>")
+	assert.Contains(t, out, "<em>text</em>")
+	assert.Contains(t, out, "<table>")
+	assert.Contains(t, out, `style="text-align: right"`)
+	assert.Contains(t, out, `class="footnotes"`)
+}
+
+func TestRenderer_Sanitizes(t *testing.T) {
+	r := render.NewRenderer()
+
+	out, err := r.Render(entities.ContentFormatHTML, `<p onclick="alert(1)">Hi<script>alert(1)</script> <a href="javascript:alert(1)">x</a></p>`)
+	require.NoError(t, err)
+	assert.NotContains(t, out, "onclick")
+	assert.NotContains(t, out, "<script")
+	assert.NotContains(t, out, "javascript:")
+	assert.Contains(t, out, "Hi")
+
+	out, err = r.Render(entities.ContentFormatMarkdown, "text <img src=x onerror=alert(1)> [x](javascript:alert(1))")
+	require.NoError(t, err)
+	assert.NotContains(t, out, "onerror")
+	assert.NotContains(t, out, "javascript:")
+}
+
+func TestRenderer_UnknownFormat(t *testing.T) {
+	_, err := render.NewRenderer().Render("rtf", "text")
+	assert.ErrorIs(t, err, render.ErrUnknownFormat)
+}
+
+func TestRenderer_Excerpt(t *testing.T) {
+	r := render.NewRenderer()
+
+	assert.Equal(t, "Hello & welcome", r.Excerpt("<h1>Hello</h1>\n<p>&amp; welcome</p>", 100))
+	assert.Equal(t, "one two…", r.Excerpt("<p>one two three</p>", 10))
+	assert.Equal(t, "пример…", r.Excerpt("<p>примерный</p>", 6))
+}
@@ -0,0 +1,52 @@
+// Package scan checks uploaded files for malware: new contents of uploads
+// are queued for a Scanner, infected files are quarantined and, when
+// configured, files are not downloadable until found clean.
+package scan
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/aube/auth/internal/application/dto"
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/aube/auth/internal/utils/query"
+)
+
+var (
+	// ErrQuarantined is returned for downloads of infected files.
+	ErrQuarantined = errors.New("file is quarantined")
+	// ErrNotScanned is returned for downloads of files not found clean yet
+	// when downloads wait for the scan.
+	ErrNotScanned = errors.New("file is not scanned yet")
+	// ErrScanningDisabled is returned for rescans without a scanner.
+	ErrScanningDisabled = errors.New("malware scanning is disabled")
+)
+
+// Scanner checks the content of a file. An error means the scan did not
+// happen (scanner unreachable); a broken file is reported with the
+// entities.ScanStatusError status.
+type Scanner interface {
+	Scan(ctx context.Context, content io.Reader) (*entities.ScanResult, error)
+}
+
+// ScanRepository defines the interface for scan status persistence.
+// Statuses belong to stored files: uuid is the stored file of the current
+// content of an upload or of one of its older versions.
+//
+// Methods:
+//
+//   - SetStatus: Records the status of the stored file,
+//     upload.ErrFileNotFound if no upload or version has it
+//   - ListPending: Stored files waiting for a scan, oldest first
+//   - ListQuarantined: Uploads of all users by filter (the status included)
+type ScanRepository interface {
+	SetStatus(ctx context.Context, uuid string, status string, detail string) error
+	ListPending(ctx context.Context, limit int) ([]string, error)
+	ListQuarantined(ctx context.Context, offset, limit int, filter *query.Query) (*entities.Uploads, *dto.Pagination, error)
+}
+
+// BlobSource reads stored files for the scanner.
+type BlobSource interface {
+	Download(ctx context.Context, uuid string) (io.ReadCloser, error)
+}
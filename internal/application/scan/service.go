@@ -0,0 +1,282 @@
+package scan
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/aube/auth/internal/application/dto"
+	appUpload "github.com/aube/auth/internal/application/upload"
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/aube/auth/internal/utils/logger"
+	"github.com/aube/auth/internal/utils/query"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+)
+
+// queueSize limits files waiting for a worker; the rest stay pending
+// and are picked up by the next pass over pending files.
+const queueSize = 100
+
+// QuarantineSchema lists the upload fields admins may filter and sort
+// quarantined files by.
+var QuarantineSchema = query.Schema{
+	"scan_status":  {Type: query.TypeString},
+	"user_id":      {Type: query.TypeInt},
+	"name":         {Type: query.TypeString, Sortable: true},
+	"content_type": {Type: query.TypeString, Sortable: true},
+	"size":         {Type: query.TypeInt, Sortable: true},
+	"created_at":   {Type: query.TypeTime, Sortable: true},
+}
+
+// ScanService queues new contents of uploads for the scanner and
+// decides whether a file may be downloaded.
+// scanner: nil disables scanning, files are stored without a status
+// blockUntilClean: pending and failed files are not downloadable
+type ScanService struct {
+	repo            ScanRepository
+	files           BlobSource
+	scanner         Scanner
+	blockUntilClean bool
+	queue           chan string
+	mu              sync.Mutex
+	queued          map[string]bool
+	log             zerolog.Logger
+}
+
+func NewScanService(repo ScanRepository, files BlobSource, scanner Scanner, blockUntilClean bool) *ScanService {
+	return &ScanService{
+		repo:            repo,
+		files:           files,
+		scanner:         scanner,
+		blockUntilClean: blockUntilClean,
+		queue:           make(chan string, queueSize),
+		queued:          make(map[string]bool),
+		log:             logger.Get().With().Str("scan", "service").Logger(),
+	}
+}
+
+// Enabled reports whether files are scanned.
+func (s *ScanService) Enabled() bool {
+	return s.scanner != nil
+}
+
+// InitialStatus returns the status new contents of uploads are registered
+// with: pending while scanning is enabled, so they are never taken for
+// files stored without a scan.
+func (s *ScanService) InitialStatus() string {
+	if !s.Enabled() {
+		return ""
+	}
+	return entities.ScanStatusPending
+}
+
+// Submit marks the stored file as pending and queues it for a scan.
+// Does nothing while scanning is disabled.
+func (s *ScanService) Submit(ctx context.Context, uuid string) error {
+	if !s.Enabled() {
+		return nil
+	}
+
+	if err := s.repo.SetStatus(ctx, uuid, entities.ScanStatusPending, ""); err != nil {
+		s.log.Debug().Err(err).Msg("Submit")
+		return err
+	}
+	s.enqueue(uuid)
+	return nil
+}
+
+// Run scans queued files with workers and every interval queues files
+// left pending (queue overflow, restarts, unreachable scanner) until ctx is done.
+func (s *ScanService) Run(ctx context.Context, workers int, interval time.Duration) {
+	if !s.Enabled() {
+		return
+	}
+
+	for i := 0; i < workers; i++ {
+		go s.work(ctx)
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		s.requeue(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Scan checks the stored file and records the verdict. When the scanner
+// is unreachable the file stays pending and the error is returned.
+func (s *ScanService) Scan(ctx context.Context, uuid string) error {
+	if !s.Enabled() {
+		return ErrScanningDisabled
+	}
+
+	result, err := s.scan(ctx, uuid)
+	if err != nil {
+		s.log.Error().Err(err).Msg("Scan1")
+		return err
+	}
+
+	if err := s.repo.SetStatus(ctx, uuid, result.Status, result.Detail); err != nil {
+		s.log.Debug().Err(err).Msg("Scan2")
+		return err
+	}
+
+	if result.Status == entities.ScanStatusInfected {
+		s.log.Warn().Str("uuid", uuid).Str("malware", result.Detail).Msg("QUARANTINE file")
+	}
+	return nil
+}
+
+// CheckDownload returns ErrQuarantined for infected files and, when
+// downloads wait for the scan, ErrNotScanned for files not found clean,
+// including files without a status while scanning is enabled.
+func (s *ScanService) CheckDownload(status string) error {
+	switch status {
+	case entities.ScanStatusInfected:
+		return ErrQuarantined
+	case entities.ScanStatusPending, entities.ScanStatusError:
+		if s.blockUntilClean {
+			return ErrNotScanned
+		}
+	case "":
+		if s.blockUntilClean && s.Enabled() {
+			return ErrNotScanned
+		}
+	}
+	return nil
+}
+
+// List returns uploads of all users for review; by default the ones
+// not found clean.
+func (s *ScanService) List(ctx context.Context, offset, limit int, filter *query.Query) (*entities.Uploads, *dto.Pagination, error) {
+	filtered, err := statusFiltered(filter)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	filter = filter.Clone()
+	if !filtered {
+		filter.Add("scan_status", query.OpIn, []any{
+			entities.ScanStatusPending,
+			entities.ScanStatusInfected,
+			entities.ScanStatusError,
+		})
+	}
+	filter.Add("deleted", query.OpEq, false)
+
+	return s.repo.ListQuarantined(ctx, offset, limit, filter)
+}
+
+// Rescan queues the stored file for another scan.
+func (s *ScanService) Rescan(ctx context.Context, id string) error {
+	if !s.Enabled() {
+		return ErrScanningDisabled
+	}
+	if !validUUID(id) {
+		return appUpload.ErrFileNotFound
+	}
+	return s.Submit(ctx, id)
+}
+
+// Release marks the stored file as clean by decision of the admin.
+func (s *ScanService) Release(ctx context.Context, id string, adminID int64) error {
+	if !validUUID(id) {
+		return appUpload.ErrFileNotFound
+	}
+
+	detail := "released by user " + strconv.FormatInt(adminID, 10)
+	if err := s.repo.SetStatus(ctx, id, entities.ScanStatusClean, detail); err != nil {
+		s.log.Debug().Err(err).Msg("Release")
+		return err
+	}
+
+	s.log.Info().Str("uuid", id).Int64("admin", adminID).Msg("RELEASE file")
+	return nil
+}
+
+func validUUID(id string) bool {
+	_, err := uuid.Parse(id)
+	return err == nil
+}
+
+// statusFiltered reports whether the filter picks scan statuses
+// and checks that they are known.
+func statusFiltered(filter *query.Query) (bool, error) {
+	found := false
+	for _, f := range filter.Filters {
+		if f.Field != "scan_status" {
+			continue
+		}
+		found = true
+
+		values, ok := f.Value.([]any)
+		if !ok {
+			values = []any{f.Value}
+		}
+		for _, v := range values {
+			if status, _ := v.(string); !entities.IsValidScanStatus(status) {
+				return false, fmt.Errorf("%w: unknown scan status %v", query.ErrInvalidQuery, v)
+			}
+		}
+	}
+	return found, nil
+}
+
+func (s *ScanService) scan(ctx context.Context, uuid string) (*entities.ScanResult, error) {
+	content, err := s.files.Download(ctx, uuid)
+	if err != nil {
+		// Файл не прочитать — повторная проверка не поможет
+		return &entities.ScanResult{Status: entities.ScanStatusError, Detail: err.Error()}, nil
+	}
+	defer content.Close()
+
+	return s.scanner.Scan(ctx, content)
+}
+
+func (s *ScanService) work(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case uuid := <-s.queue:
+			s.Scan(ctx, uuid)
+			s.mu.Lock()
+			delete(s.queued, uuid)
+			s.mu.Unlock()
+		}
+	}
+}
+
+func (s *ScanService) requeue(ctx context.Context) {
+	pending, err := s.repo.ListPending(ctx, queueSize)
+	if err != nil {
+		s.log.Error().Err(err).Msg("requeue")
+		return
+	}
+	for _, uuid := range pending {
+		s.enqueue(uuid)
+	}
+}
+
+// enqueue queues the file unless it is already queued or the queue is full.
+func (s *ScanService) enqueue(uuid string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.queued[uuid] {
+		return
+	}
+	select {
+	case s.queue <- uuid:
+		s.queued[uuid] = true
+	default:
+	}
+}
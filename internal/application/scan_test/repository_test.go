@@ -0,0 +1,59 @@
+package scan_test
+
+import (
+	"context"
+	"io"
+
+	"github.com/aube/auth/internal/application/dto"
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/aube/auth/internal/utils/query"
+	"github.com/stretchr/testify/mock"
+)
+
+type ScanRepository struct {
+	mock.Mock
+}
+
+func (m *ScanRepository) SetStatus(ctx context.Context, uuid string, status string, detail string) error {
+	return m.Called(ctx, uuid, status, detail).Error(0)
+}
+
+func (m *ScanRepository) ListPending(ctx context.Context, limit int) ([]string, error) {
+	args := m.Called(ctx, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *ScanRepository) ListQuarantined(ctx context.Context, offset, limit int, filter *query.Query) (*entities.Uploads, *dto.Pagination, error) {
+	args := m.Called(ctx, offset, limit, filter)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+	return args.Get(0).(*entities.Uploads), args.Get(1).(*dto.Pagination), args.Error(2)
+}
+
+type BlobSource struct {
+	mock.Mock
+}
+
+func (m *BlobSource) Download(ctx context.Context, uuid string) (io.ReadCloser, error) {
+	args := m.Called(ctx, uuid)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(io.ReadCloser), args.Error(1)
+}
+
+type Scanner struct {
+	mock.Mock
+}
+
+func (m *Scanner) Scan(ctx context.Context, content io.Reader) (*entities.ScanResult, error) {
+	args := m.Called(ctx, content)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.ScanResult), args.Error(1)
+}
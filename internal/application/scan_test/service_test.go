@@ -0,0 +1,167 @@
+package scan_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aube/auth/internal/application/dto"
+	appFile "github.com/aube/auth/internal/application/file"
+	appScan "github.com/aube/auth/internal/application/scan"
+	appUpload "github.com/aube/auth/internal/application/upload"
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/aube/auth/internal/utils/query"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScanService_Scan_Infected(t *testing.T) {
+	repo, files, scanner := new(ScanRepository), new(BlobSource), new(Scanner)
+	service := appScan.NewScanService(repo, files, scanner, false)
+
+	files.On("Download", mock.Anything, "u1").Return(io.NopCloser(strings.NewReader("virus")), nil)
+	scanner.On("Scan", mock.Anything, mock.Anything).Return(&entities.ScanResult{Status: entities.ScanStatusInfected, Detail: "Eicar"}, nil)
+	repo.On("SetStatus", mock.Anything, "u1", entities.ScanStatusInfected, "Eicar").Return(nil)
+
+	require.NoError(t, service.Scan(context.Background(), "u1"))
+	repo.AssertExpectations(t)
+}
+
+func TestScanService_Scan_Unreachable(t *testing.T) {
+	repo, files, scanner := new(ScanRepository), new(BlobSource), new(Scanner)
+	service := appScan.NewScanService(repo, files, scanner, false)
+
+	files.On("Download", mock.Anything, "u1").Return(io.NopCloser(strings.NewReader("data")), nil)
+	scanner.On("Scan", mock.Anything, mock.Anything).Return(nil, errors.New("connection refused"))
+
+	// Файл остаётся в очереди до следующего прохода
+	assert.Error(t, service.Scan(context.Background(), "u1"))
+	repo.AssertNotCalled(t, "SetStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestScanService_Scan_MissingFile(t *testing.T) {
+	repo, files, scanner := new(ScanRepository), new(BlobSource), new(Scanner)
+	service := appScan.NewScanService(repo, files, scanner, false)
+
+	files.On("Download", mock.Anything, "u1").Return(nil, appFile.ErrFileNotFound)
+	repo.On("SetStatus", mock.Anything, "u1", entities.ScanStatusError, appFile.ErrFileNotFound.Error()).Return(nil)
+
+	require.NoError(t, service.Scan(context.Background(), "u1"))
+	scanner.AssertNotCalled(t, "Scan", mock.Anything, mock.Anything)
+}
+
+func TestScanService_Submit_Disabled(t *testing.T) {
+	repo := new(ScanRepository)
+	service := appScan.NewScanService(repo, new(BlobSource), nil, true)
+
+	require.NoError(t, service.Submit(context.Background(), "u1"))
+	repo.AssertNotCalled(t, "SetStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	assert.ErrorIs(t, service.Rescan(context.Background(), "u1"), appScan.ErrScanningDisabled)
+}
+
+func TestScanService_Run(t *testing.T) {
+	repo, files, scanner := new(ScanRepository), new(BlobSource), new(Scanner)
+	service := appScan.NewScanService(repo, files, scanner, false)
+
+	done := make(chan struct{})
+	repo.On("SetStatus", mock.Anything, "new", entities.ScanStatusPending, "").Return(nil)
+	repo.On("ListPending", mock.Anything, mock.Anything).Return([]string{"old"}, nil)
+	files.On("Download", mock.Anything, mock.Anything).Return(io.NopCloser(strings.NewReader("data")), nil)
+	scanner.On("Scan", mock.Anything, mock.Anything).Return(&entities.ScanResult{Status: entities.ScanStatusClean}, nil)
+	repo.On("SetStatus", mock.Anything, "old", entities.ScanStatusClean, "").Return(nil)
+	repo.On("SetStatus", mock.Anything, "new", entities.ScanStatusClean, "").Return(nil).Run(func(mock.Arguments) { close(done) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	require.NoError(t, service.Submit(ctx, "new"))
+	go service.Run(ctx, 1, time.Hour)
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("queued file was not scanned")
+	}
+}
+
+func TestScanService_CheckDownload(t *testing.T) {
+	open := appScan.NewScanService(nil, nil, new(Scanner), false)
+	blocking := appScan.NewScanService(nil, nil, new(Scanner), true)
+
+	tests := []struct {
+		status   string
+		open     error
+		blocking error
+	}{
+		{"", nil, appScan.ErrNotScanned},
+		{entities.ScanStatusClean, nil, nil},
+		{entities.ScanStatusPending, nil, appScan.ErrNotScanned},
+		{entities.ScanStatusError, nil, appScan.ErrNotScanned},
+		{entities.ScanStatusInfected, appScan.ErrQuarantined, appScan.ErrQuarantined},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.open, open.CheckDownload(tt.status), tt.status)
+		assert.Equal(t, tt.blocking, blocking.CheckDownload(tt.status), tt.status)
+	}
+}
+
+func TestScanService_CheckDownload_Disabled(t *testing.T) {
+	// Без сканера файлы сохраняются без статуса и остаются доступны
+	service := appScan.NewScanService(nil, nil, nil, true)
+
+	assert.NoError(t, service.CheckDownload(""))
+	assert.ErrorIs(t, service.CheckDownload(entities.ScanStatusInfected), appScan.ErrQuarantined)
+}
+
+func TestScanService_InitialStatus(t *testing.T) {
+	assert.Equal(t, entities.ScanStatusPending, appScan.NewScanService(nil, nil, new(Scanner), false).InitialStatus())
+	assert.Empty(t, appScan.NewScanService(nil, nil, nil, false).InitialStatus())
+}
+
+func TestScanService_List_DefaultStatuses(t *testing.T) {
+	repo := new(ScanRepository)
+	service := appScan.NewScanService(repo, nil, nil, false)
+
+	repo.On("ListQuarantined", mock.Anything, 0, 10, mock.MatchedBy(func(filter *query.Query) bool {
+		deleted, _ := filter.Value("deleted")
+		for _, f := range filter.Filters {
+			if f.Field == "scan_status" {
+				return f.Op == query.OpIn && len(f.Value.([]any)) == 3 && deleted == false
+			}
+		}
+		return false
+	})).Return(&entities.Uploads{}, &dto.Pagination{}, nil)
+
+	_, _, err := service.List(context.Background(), 0, 10, query.New())
+
+	require.NoError(t, err)
+	repo.AssertExpectations(t)
+}
+
+func TestScanService_List_UnknownStatus(t *testing.T) {
+	repo := new(ScanRepository)
+	service := appScan.NewScanService(repo, nil, nil, false)
+
+	filter := query.New().Add("scan_status", query.OpIn, []any{entities.ScanStatusInfected, "quarantined"})
+	_, _, err := service.List(context.Background(), 0, 10, filter)
+
+	assert.ErrorIs(t, err, query.ErrInvalidQuery)
+	repo.AssertNotCalled(t, "ListQuarantined", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestScanService_Release(t *testing.T) {
+	repo := new(ScanRepository)
+	service := appScan.NewScanService(repo, nil, nil, false)
+
+	id := "0b6d2f8e-3c1a-4f5e-9d7b-2a4c6e8f0a1b"
+	repo.On("SetStatus", mock.Anything, id, entities.ScanStatusClean, "released by user 5").Return(nil)
+
+	require.NoError(t, service.Release(context.Background(), id, 5))
+	repo.AssertExpectations(t)
+
+	// До базы не доходит
+	assert.ErrorIs(t, service.Release(context.Background(), "u1", 5), appUpload.ErrFileNotFound)
+}
@@ -0,0 +1,52 @@
+// Package share manages public links to private uploads.
+package share
+
+import (
+	"context"
+	"errors"
+
+	"github.com/aube/auth/internal/application/dto"
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/aube/auth/internal/utils/query"
+)
+
+var (
+	// ErrShareNotFound is returned for unknown or revoked links, links of
+	// deleted uploads and links of other users.
+	ErrShareNotFound = errors.New("share link not found")
+	ErrShareExpired  = errors.New("share link expired")
+	// ErrShareExhausted is returned when all allowed downloads are used.
+	ErrShareExhausted = errors.New("share link download limit reached")
+	// ErrPasswordRequired is returned for a missing or wrong password.
+	ErrPasswordRequired = errors.New("share link password required")
+)
+
+// ShareRepository defines the interface for share link persistence.
+// Links of deleted uploads are not found.
+//
+// Methods:
+//
+//   - Create: Stores the link and sets its ID
+//   - FindByToken: Link with the shared upload
+//   - ListByUserID: Links of the owner, newest first; filter carries the cursor only
+//   - Delete: Revokes a link of the owner, ErrShareNotFound if there is none
+//   - CountDownload: Counts a download unless the link expired or is exhausted
+//     in the meantime (ErrShareExhausted)
+type ShareRepository interface {
+	Create(ctx context.Context, share *entities.UploadShare) error
+	FindByToken(ctx context.Context, token string) (*entities.UploadShare, error)
+	ListByUserID(ctx context.Context, userID int64, offset, limit int, filter *query.Query) (*entities.UploadShares, *dto.Pagination, error)
+	Delete(ctx context.Context, id int64, userID int64) error
+	CountDownload(ctx context.Context, id int64) error
+}
+
+// DownloadGate decides whether a shared file may be downloaded
+// (malware scan); a refused download is not counted.
+type DownloadGate interface {
+	CheckDownload(status string) error
+}
+
+// UploadFinder finds uploads of the owner.
+type UploadFinder interface {
+	GetByUUID(ctx context.Context, uuid string, userID int64) (*entities.Upload, error)
+}
@@ -0,0 +1,126 @@
+package share
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"strconv"
+	"time"
+
+	"github.com/aube/auth/internal/application/dto"
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/aube/auth/internal/utils/logger"
+	"github.com/aube/auth/internal/utils/query"
+	"github.com/rs/zerolog"
+)
+
+// tokenBytes is the entropy of a link token.
+const tokenBytes = 24
+
+type ShareService struct {
+	repo    ShareRepository
+	uploads UploadFinder
+	gate    DownloadGate
+	log     zerolog.Logger
+}
+
+// NewShareService creates the service; gate may be nil.
+func NewShareService(repo ShareRepository, uploads UploadFinder, gate DownloadGate) *ShareService {
+	return &ShareService{
+		repo:    repo,
+		uploads: uploads,
+		gate:    gate,
+		log:     logger.Get().With().Str("share", "service").Logger(),
+	}
+}
+
+// Create makes a public link to an upload of the user.
+func (s *ShareService) Create(ctx context.Context, userID int64, req dto.UploadShareRequest) (*entities.UploadShare, error) {
+	upload, err := s.uploads.GetByUUID(ctx, req.UUID, userID)
+	if err != nil {
+		s.log.Debug().Err(err).Msg("Create1")
+		return nil, err
+	}
+
+	token, err := newToken()
+	if err != nil {
+		s.log.Debug().Err(err).Msg("Create2")
+		return nil, err
+	}
+
+	now := time.Now()
+	var expiresAt *time.Time
+	if req.TTL > 0 {
+		t := now.Add(time.Duration(req.TTL) * time.Second)
+		expiresAt = &t
+	}
+
+	share, err := entities.NewUploadShare(upload, token, req.Password, expiresAt, req.MaxDownloads, req.Disposition, now)
+	if err != nil {
+		s.log.Debug().Err(err).Msg("Create3")
+		return nil, err
+	}
+
+	if err := s.repo.Create(ctx, share); err != nil {
+		s.log.Debug().Err(err).Msg("Create4")
+		return nil, err
+	}
+
+	s.log.Debug().Msg("SHARE upload: " + upload.UUID + ", link " + strconv.FormatInt(share.ID, 10))
+	return share, nil
+}
+
+func (s *ShareService) List(ctx context.Context, userID int64, offset, limit int, filter *query.Query) (*entities.UploadShares, *dto.Pagination, error) {
+	return s.repo.ListByUserID(ctx, userID, offset, limit, filter)
+}
+
+// Revoke deletes a link of the user.
+func (s *ShareService) Revoke(ctx context.Context, id int64, userID int64) error {
+	if err := s.repo.Delete(ctx, id, userID); err != nil {
+		s.log.Debug().Err(err).Msg("Revoke")
+		return err
+	}
+
+	s.log.Debug().Msg("REVOKE link: " + strconv.FormatInt(id, 10))
+	return nil
+}
+
+// Open checks a link for a download and counts the download.
+func (s *ShareService) Open(ctx context.Context, token string, password string) (*entities.UploadShare, error) {
+	share, err := s.repo.FindByToken(ctx, token)
+	if err != nil {
+		s.log.Debug().Err(err).Msg("Open1")
+		return nil, err
+	}
+
+	if share.Expired(time.Now()) {
+		return nil, ErrShareExpired
+	}
+	if share.Exhausted() {
+		return nil, ErrShareExhausted
+	}
+	if !share.PasswordMatches(password) {
+		return nil, ErrPasswordRequired
+	}
+	if s.gate != nil {
+		if err := s.gate.CheckDownload(share.Upload.ScanStatus); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.repo.CountDownload(ctx, share.ID); err != nil {
+		s.log.Debug().Err(err).Msg("Open2")
+		return nil, err
+	}
+	share.Downloads++
+
+	return share, nil
+}
+
+func newToken() (string, error) {
+	b := make([]byte, tokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,54 @@
+package share_test
+
+import (
+	"context"
+
+	"github.com/aube/auth/internal/application/dto"
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/aube/auth/internal/utils/query"
+	"github.com/stretchr/testify/mock"
+)
+
+type ShareRepository struct {
+	mock.Mock
+}
+
+func (m *ShareRepository) Create(ctx context.Context, share *entities.UploadShare) error {
+	return m.Called(ctx, share).Error(0)
+}
+
+func (m *ShareRepository) FindByToken(ctx context.Context, token string) (*entities.UploadShare, error) {
+	args := m.Called(ctx, token)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.UploadShare), args.Error(1)
+}
+
+func (m *ShareRepository) ListByUserID(ctx context.Context, userID int64, offset, limit int, filter *query.Query) (*entities.UploadShares, *dto.Pagination, error) {
+	args := m.Called(ctx, userID, offset, limit, filter)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+	return args.Get(0).(*entities.UploadShares), args.Get(1).(*dto.Pagination), args.Error(2)
+}
+
+func (m *ShareRepository) Delete(ctx context.Context, id int64, userID int64) error {
+	return m.Called(ctx, id, userID).Error(0)
+}
+
+func (m *ShareRepository) CountDownload(ctx context.Context, id int64) error {
+	return m.Called(ctx, id).Error(0)
+}
+
+type UploadFinder struct {
+	mock.Mock
+}
+
+func (m *UploadFinder) GetByUUID(ctx context.Context, uuid string, userID int64) (*entities.Upload, error) {
+	args := m.Called(ctx, uuid, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Upload), args.Error(1)
+}
@@ -0,0 +1,113 @@
+package share_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aube/auth/internal/application/dto"
+	appScan "github.com/aube/auth/internal/application/scan"
+	appShare "github.com/aube/auth/internal/application/share"
+	appUpload "github.com/aube/auth/internal/application/upload"
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShareService_Create(t *testing.T) {
+	mockRepo, uploads := new(ShareRepository), new(UploadFinder)
+	service := appShare.NewShareService(mockRepo, uploads, nil)
+
+	upload := &entities.Upload{UUID: "u1", UserID: 3, Name: "a.pdf"}
+	uploads.On("GetByUUID", mock.Anything, "u1", int64(3)).Return(upload, nil)
+	mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*entities.UploadShare")).Return(nil)
+
+	share, err := service.Create(context.Background(), 3, dto.UploadShareRequest{
+		UUID:         "u1",
+		TTL:          3600,
+		Password:     "correct horse",
+		MaxDownloads: 5,
+	})
+
+	require.NoError(t, err)
+	assert.Len(t, share.Token, 32)
+	assert.Equal(t, entities.ShareDispositionAttachment, share.Disposition)
+	assert.True(t, share.HasPassword())
+	assert.NotEqual(t, "correct horse", share.Password.String())
+	require.NotNil(t, share.ExpiresAt)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), *share.ExpiresAt, time.Minute)
+}
+
+func TestShareService_Create_Invalid(t *testing.T) {
+	mockRepo, uploads := new(ShareRepository), new(UploadFinder)
+	service := appShare.NewShareService(mockRepo, uploads, nil)
+
+	uploads.On("GetByUUID", mock.Anything, "missing", int64(3)).Return(nil, appUpload.ErrFileNotFound)
+	uploads.On("GetByUUID", mock.Anything, "u1", int64(3)).Return(&entities.Upload{UUID: "u1", UserID: 3}, nil)
+
+	_, err := service.Create(context.Background(), 3, dto.UploadShareRequest{UUID: "missing"})
+	assert.ErrorIs(t, err, appUpload.ErrFileNotFound)
+
+	_, err = service.Create(context.Background(), 3, dto.UploadShareRequest{UUID: "u1", Disposition: "download"})
+	assert.Error(t, err)
+
+	_, err = service.Create(context.Background(), 3, dto.UploadShareRequest{UUID: "u1", Password: "short"})
+	assert.Error(t, err)
+
+	mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestShareService_Open(t *testing.T) {
+	mockRepo, uploads := new(ShareRepository), new(UploadFinder)
+	service := appShare.NewShareService(mockRepo, uploads, nil)
+
+	upload := &entities.Upload{UUID: "u1", UserID: 3}
+	protected, err := entities.NewUploadShare(upload, "tok", "correct horse", nil, 0, "", time.Now())
+	require.NoError(t, err)
+	protected.ID = 1
+
+	past := time.Now().Add(-time.Minute)
+	expired := &entities.UploadShare{ID: 2, Upload: upload, ExpiresAt: &past}
+	exhausted := &entities.UploadShare{ID: 3, Upload: upload, MaxDownloads: 2, Downloads: 2}
+
+	mockRepo.On("FindByToken", mock.Anything, "tok").Return(protected, nil)
+	mockRepo.On("FindByToken", mock.Anything, "expired").Return(expired, nil)
+	mockRepo.On("FindByToken", mock.Anything, "exhausted").Return(exhausted, nil)
+	mockRepo.On("FindByToken", mock.Anything, "unknown").Return(nil, appShare.ErrShareNotFound)
+	mockRepo.On("CountDownload", mock.Anything, int64(1)).Return(nil)
+
+	_, err = service.Open(context.Background(), "tok", "wrong password")
+	assert.ErrorIs(t, err, appShare.ErrPasswordRequired)
+
+	share, err := service.Open(context.Background(), "tok", "correct horse")
+	require.NoError(t, err)
+	assert.Equal(t, 1, share.Downloads)
+
+	_, err = service.Open(context.Background(), "expired", "")
+	assert.ErrorIs(t, err, appShare.ErrShareExpired)
+	_, err = service.Open(context.Background(), "exhausted", "")
+	assert.ErrorIs(t, err, appShare.ErrShareExhausted)
+	_, err = service.Open(context.Background(), "unknown", "")
+	assert.ErrorIs(t, err, appShare.ErrShareNotFound)
+
+	mockRepo.AssertNumberOfCalls(t, "CountDownload", 1)
+}
+
+func TestShareService_Open_Quarantined(t *testing.T) {
+	mockRepo, uploads := new(ShareRepository), new(UploadFinder)
+	service := appShare.NewShareService(mockRepo, uploads, appScan.NewScanService(nil, nil, nil, true))
+
+	infected := &entities.UploadShare{ID: 1, Upload: &entities.Upload{UUID: "u1", ScanStatus: entities.ScanStatusInfected}}
+	pending := &entities.UploadShare{ID: 2, Upload: &entities.Upload{UUID: "u2", ScanStatus: entities.ScanStatusPending}}
+	mockRepo.On("FindByToken", mock.Anything, "infected").Return(infected, nil)
+	mockRepo.On("FindByToken", mock.Anything, "pending").Return(pending, nil)
+
+	_, err := service.Open(context.Background(), "infected", "")
+	assert.ErrorIs(t, err, appScan.ErrQuarantined)
+	_, err = service.Open(context.Background(), "pending", "")
+	assert.ErrorIs(t, err, appScan.ErrNotScanned)
+
+	// Отказ не расходует лимит скачиваний
+	mockRepo.AssertNotCalled(t, "CountDownload", mock.Anything, mock.Anything)
+}
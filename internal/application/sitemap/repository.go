@@ -0,0 +1,17 @@
+package sitemap
+
+import (
+	"context"
+	"errors"
+
+	"github.com/aube/auth/internal/domain/entities"
+)
+
+var ErrSitemapNotFound = errors.New("sitemap not found")
+
+// SitemapRepository lists published, indexable pages.
+// Pages bound to a published node use the node path, others "/<name>".
+type SitemapRepository interface {
+	CountEntries(ctx context.Context) (int, error)
+	ListEntries(ctx context.Context, offset, limit int) (*entities.SitemapEntries, error)
+}
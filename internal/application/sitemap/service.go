@@ -0,0 +1,64 @@
+package sitemap
+
+import (
+	"context"
+
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/aube/auth/internal/utils/logger"
+	"github.com/rs/zerolog"
+)
+
+// MaxURLs is the limit of URLs in one sitemap file set by the sitemaps protocol.
+const MaxURLs = 50000
+
+// SitemapService splits page URLs into sitemap files of at most maxURLs entries.
+type SitemapService struct {
+	repo    SitemapRepository
+	maxURLs int
+	log     zerolog.Logger
+}
+
+func NewSitemapService(repo SitemapRepository, maxURLs int) *SitemapService {
+	if maxURLs <= 0 || maxURLs > MaxURLs {
+		maxURLs = MaxURLs
+	}
+	return &SitemapService{
+		repo:    repo,
+		maxURLs: maxURLs,
+		log:     logger.Get().With().Str("sitemap", "service").Logger(),
+	}
+}
+
+// Files returns the number of sitemap files; with more than one file
+// sitemap.xml becomes a sitemap index.
+func (s *SitemapService) Files(ctx context.Context) (int, error) {
+	total, err := s.repo.CountEntries(ctx)
+	if err != nil {
+		s.log.Debug().Err(err).Msg("Files")
+		return 0, err
+	}
+
+	if total == 0 {
+		return 1, nil
+	}
+	return (total + s.maxURLs - 1) / s.maxURLs, nil
+}
+
+// Entries returns the URLs of sitemap file number file (starting at 1).
+func (s *SitemapService) Entries(ctx context.Context, file int) (*entities.SitemapEntries, error) {
+	files, err := s.Files(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if file < 1 || file > files {
+		return nil, ErrSitemapNotFound
+	}
+
+	entries, err := s.repo.ListEntries(ctx, (file-1)*s.maxURLs, s.maxURLs)
+	if err != nil {
+		s.log.Debug().Err(err).Msg("Entries")
+		return nil, err
+	}
+
+	return entries, nil
+}
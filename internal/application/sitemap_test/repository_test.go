@@ -0,0 +1,25 @@
+package sitemap_test
+
+import (
+	"context"
+
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/stretchr/testify/mock"
+)
+
+type SitemapRepository struct {
+	mock.Mock
+}
+
+func (m *SitemapRepository) CountEntries(ctx context.Context) (int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *SitemapRepository) ListEntries(ctx context.Context, offset, limit int) (*entities.SitemapEntries, error) {
+	args := m.Called(ctx, offset, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.SitemapEntries), args.Error(1)
+}
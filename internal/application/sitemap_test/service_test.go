@@ -0,0 +1,63 @@
+package sitemap_test
+
+import (
+	"context"
+	"testing"
+
+	appSitemap "github.com/aube/auth/internal/application/sitemap"
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSitemapService_Files(t *testing.T) {
+	tests := []struct {
+		total int
+		files int
+	}{
+		{0, 1},
+		{1, 1},
+		{10, 1},
+		{11, 2},
+		{25, 3},
+	}
+
+	for _, tt := range tests {
+		repo := new(SitemapRepository)
+		repo.On("CountEntries", mock.Anything).Return(tt.total, nil)
+		service := appSitemap.NewSitemapService(repo, 10)
+
+		files, err := service.Files(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, tt.files, files, "total %d", tt.total)
+	}
+}
+
+func TestSitemapService_Entries(t *testing.T) {
+	repo := new(SitemapRepository)
+	service := appSitemap.NewSitemapService(repo, 10)
+
+	entries := &entities.SitemapEntries{{Path: "/about"}}
+	repo.On("CountEntries", mock.Anything).Return(25, nil)
+	repo.On("ListEntries", mock.Anything, 20, 10).Return(entries, nil)
+
+	res, err := service.Entries(context.Background(), 3)
+	require.NoError(t, err)
+	assert.Equal(t, entries, res)
+
+	_, err = service.Entries(context.Background(), 4)
+	assert.ErrorIs(t, err, appSitemap.ErrSitemapNotFound)
+
+	_, err = service.Entries(context.Background(), 0)
+	assert.ErrorIs(t, err, appSitemap.ErrSitemapNotFound)
+}
+
+func TestNewSitemapService_LimitsFileSize(t *testing.T) {
+	repo := new(SitemapRepository)
+	repo.On("CountEntries", mock.Anything).Return(appSitemap.MaxURLs+1, nil)
+
+	files, err := appSitemap.NewSitemapService(repo, 0).Files(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, files)
+}
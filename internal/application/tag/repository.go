@@ -0,0 +1,42 @@
+// Package tag manages tags of pages, uploads and images: setting tags
+// of an item, renaming and merging tags and counting facets of lists.
+package tag
+
+import (
+	"context"
+	"errors"
+
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/aube/auth/internal/utils/query"
+)
+
+var (
+	// ErrTagNotFound is returned when none of the tags exist in the namespace.
+	ErrTagNotFound = errors.New("tag not found")
+	// ErrItemNotFound is returned for a missing, deleted or foreign item.
+	ErrItemNotFound = errors.New("tagged item not found")
+	// ErrInvalidKind is returned for an unknown item kind.
+	ErrInvalidKind = errors.New("invalid tag kind")
+	// ErrTooManyTags is returned when an item gets more than MaxItemTags tags.
+	ErrTooManyTags = errors.New("too many tags")
+)
+
+// TagRepository defines the interface for tag persistence.
+// owner is the tag namespace (entities.TagOwner); files are also
+// looked up by owner, so users cannot tag foreign files.
+//
+// Methods:
+//
+//   - List: Tags of a namespace with the number of tagged items, by name
+//   - ItemTags: Tags of an item, by name
+//   - SetItemTags: Replaces tags of an item, creating missing tags
+//   - Merge: Moves items of the from tags to the target tag (created when
+//     missing) and deletes the from tags; renaming is a merge of one tag
+//   - Facets: Item counts per tag and per category of a kind for the filter
+type TagRepository interface {
+	List(ctx context.Context, owner int64) (*entities.Tags, error)
+	ItemTags(ctx context.Context, item *entities.TaggedItem, owner int64) ([]string, error)
+	SetItemTags(ctx context.Context, item *entities.TaggedItem, owner int64, tags []string) error
+	Merge(ctx context.Context, owner int64, from []string, to string) error
+	Facets(ctx context.Context, kind string, filter *query.Query) (*entities.Facets, error)
+}
@@ -0,0 +1,115 @@
+package tag
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/aube/auth/internal/utils/query"
+)
+
+// MaxItemTags limits tags of one item.
+const MaxItemTags = 50
+
+// FilterField is the list filter field holding tags.
+const FilterField = "tags"
+
+type TagService struct {
+	repo TagRepository
+}
+
+func NewTagService(repo TagRepository) *TagService {
+	return &TagService{repo: repo}
+}
+
+// List returns tags available to items of the kind.
+func (s *TagService) List(ctx context.Context, kind string, userID int64) (*entities.Tags, error) {
+	if !entities.IsValidTagKind(kind) {
+		return nil, ErrInvalidKind
+	}
+	return s.repo.List(ctx, entities.TagOwner(kind, userID))
+}
+
+func (s *TagService) ItemTags(ctx context.Context, item *entities.TaggedItem, userID int64) ([]string, error) {
+	return s.repo.ItemTags(ctx, item, entities.TagOwner(item.Kind, userID))
+}
+
+// SetItemTags replaces tags of an item and returns them normalized.
+func (s *TagService) SetItemTags(ctx context.Context, item *entities.TaggedItem, userID int64, names []string) ([]string, error) {
+	tags, err := entities.NormalizeTags(names)
+	if err != nil {
+		return nil, err
+	}
+	if len(tags) > MaxItemTags {
+		return nil, ErrTooManyTags
+	}
+
+	if err := s.repo.SetItemTags(ctx, item, entities.TagOwner(item.Kind, userID), tags); err != nil {
+		return nil, err
+	}
+	slices.Sort(tags)
+	return tags, nil
+}
+
+// Rename renames a tag; renaming to an existing tag merges the two.
+func (s *TagService) Rename(ctx context.Context, kind string, userID int64, from, to string) error {
+	return s.Merge(ctx, kind, userID, []string{from}, to)
+}
+
+// Merge moves items of the from tags to the target tag and removes the from tags.
+func (s *TagService) Merge(ctx context.Context, kind string, userID int64, from []string, to string) error {
+	if !entities.IsValidTagKind(kind) {
+		return ErrInvalidKind
+	}
+	sources, err := entities.NormalizeTags(from)
+	if err != nil {
+		return err
+	}
+	target, err := entities.NormalizeTag(to)
+	if err != nil {
+		return err
+	}
+	sources = slices.DeleteFunc(sources, func(tag string) bool { return tag == target })
+	if len(sources) == 0 {
+		return nil
+	}
+
+	return s.repo.Merge(ctx, entities.TagOwner(kind, userID), sources, target)
+}
+
+// Facets counts items of the kind matching filter per tag and per category.
+// Files are limited to the user's own.
+func (s *TagService) Facets(ctx context.Context, kind string, userID int64, filter *query.Query) (*entities.Facets, error) {
+	if !entities.IsValidTagKind(kind) {
+		return nil, ErrInvalidKind
+	}
+	filter = filter.Clone()
+	if err := NormalizeFilter(filter); err != nil {
+		return nil, err
+	}
+	if kind != entities.TagKindPage {
+		filter.Add("user_id", query.OpEq, userID)
+	}
+	return s.repo.Facets(ctx, kind, filter)
+}
+
+// NormalizeFilter normalizes tags of tag filters, so "Summer Sale"
+// finds items tagged summer-sale.
+func NormalizeFilter(filter *query.Query) error {
+	if filter == nil {
+		return nil
+	}
+	for i, f := range filter.Filters {
+		values, ok := f.Value.([]string)
+		if f.Field != FilterField || !ok {
+			continue
+		}
+		tags, err := entities.NormalizeTags(values)
+		if err != nil {
+			return fmt.Errorf("%w: %s: %v", query.ErrInvalidQuery, f.Field, err)
+		}
+		filter.Filters[i].Value = tags
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package tag_test
+
+import (
+	"context"
+
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/aube/auth/internal/utils/query"
+	"github.com/stretchr/testify/mock"
+)
+
+type TagRepository struct {
+	mock.Mock
+}
+
+func (m *TagRepository) List(ctx context.Context, owner int64) (*entities.Tags, error) {
+	args := m.Called(ctx, owner)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Tags), args.Error(1)
+}
+
+func (m *TagRepository) ItemTags(ctx context.Context, item *entities.TaggedItem, owner int64) ([]string, error) {
+	args := m.Called(ctx, item, owner)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *TagRepository) SetItemTags(ctx context.Context, item *entities.TaggedItem, owner int64, tags []string) error {
+	return m.Called(ctx, item, owner, tags).Error(0)
+}
+
+func (m *TagRepository) Merge(ctx context.Context, owner int64, from []string, to string) error {
+	return m.Called(ctx, owner, from, to).Error(0)
+}
+
+func (m *TagRepository) Facets(ctx context.Context, kind string, filter *query.Query) (*entities.Facets, error) {
+	args := m.Called(ctx, kind, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Facets), args.Error(1)
+}
@@ -0,0 +1,104 @@
+package tag_test
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	appTag "github.com/aube/auth/internal/application/tag"
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/aube/auth/internal/utils/query"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTagService_List_Namespaces(t *testing.T) {
+	mockRepo := new(TagRepository)
+	service := appTag.NewTagService(mockRepo)
+
+	mockRepo.On("List", mock.Anything, int64(0)).Return(&entities.Tags{}, nil)
+	mockRepo.On("List", mock.Anything, int64(7)).Return(&entities.Tags{}, nil)
+
+	_, err := service.List(context.Background(), entities.TagKindPage, 7)
+	require.NoError(t, err)
+	_, err = service.List(context.Background(), entities.TagKindImage, 7)
+	require.NoError(t, err)
+
+	_, err = service.List(context.Background(), "node", 7)
+	assert.ErrorIs(t, err, appTag.ErrInvalidKind)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestTagService_SetItemTags_Normalizes(t *testing.T) {
+	mockRepo := new(TagRepository)
+	service := appTag.NewTagService(mockRepo)
+
+	item := &entities.TaggedItem{Kind: entities.TagKindUpload, UUID: "0b6f5a3e-8d7c-4e1a-9f2b-3c4d5e6f7a8b"}
+	mockRepo.On("SetItemTags", mock.Anything, item, int64(3), []string{"summer-sale", "docs"}).Return(nil)
+
+	tags, err := service.SetItemTags(context.Background(), item, 3, []string{" Summer  Sale ", "DOCS", "summer-sale"})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"docs", "summer-sale"}, tags)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestTagService_SetItemTags_Invalid(t *testing.T) {
+	mockRepo := new(TagRepository)
+	service := appTag.NewTagService(mockRepo)
+	item := &entities.TaggedItem{Kind: entities.TagKindPage, ID: 1}
+
+	_, err := service.SetItemTags(context.Background(), item, 3, []string{"a/b"})
+	assert.ErrorIs(t, err, entities.ErrInvalidTag)
+
+	names := make([]string, appTag.MaxItemTags+1)
+	for i := range names {
+		names[i] = "tag" + strconv.Itoa(i)
+	}
+	_, err = service.SetItemTags(context.Background(), item, 3, names)
+	assert.ErrorIs(t, err, appTag.ErrTooManyTags)
+
+	mockRepo.AssertNotCalled(t, "SetItemTags", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTagService_Merge_DropsTarget(t *testing.T) {
+	mockRepo := new(TagRepository)
+	service := appTag.NewTagService(mockRepo)
+
+	mockRepo.On("Merge", mock.Anything, int64(0), []string{"news"}, "blog").Return(nil)
+
+	require.NoError(t, service.Merge(context.Background(), entities.TagKindPage, 5, []string{"News", "Blog"}, "blog"))
+	require.NoError(t, service.Rename(context.Background(), entities.TagKindPage, 5, "blog", "Blog"))
+
+	mockRepo.AssertNumberOfCalls(t, "Merge", 1)
+}
+
+func TestTagService_Facets_OwnFiles(t *testing.T) {
+	mockRepo := new(TagRepository)
+	service := appTag.NewTagService(mockRepo)
+
+	filter := query.New().Add(appTag.FilterField, query.OpAny, []string{"Summer Sale"})
+	mockRepo.On("Facets", mock.Anything, entities.TagKindImage, mock.MatchedBy(func(q *query.Query) bool {
+		return len(q.Filters) == 2 &&
+			assert.ObjectsAreEqual([]string{"summer-sale"}, q.Filters[0].Value) &&
+			q.Filters[1] == query.Filter{Field: "user_id", Op: query.OpEq, Value: int64(4)}
+	})).Return(&entities.Facets{}, nil)
+	mockRepo.On("Facets", mock.Anything, entities.TagKindPage, mock.MatchedBy(func(q *query.Query) bool {
+		return len(q.Filters) == 1
+	})).Return(&entities.Facets{}, nil)
+
+	_, err := service.Facets(context.Background(), entities.TagKindImage, 4, filter)
+	require.NoError(t, err)
+	_, err = service.Facets(context.Background(), entities.TagKindPage, 4, filter)
+	require.NoError(t, err)
+
+	assert.Len(t, filter.Filters, 1)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestNormalizeFilter_Invalid(t *testing.T) {
+	filter := query.New().Add(appTag.FilterField, query.OpAll, []string{"a/b"})
+
+	assert.ErrorIs(t, appTag.NormalizeFilter(filter), query.ErrInvalidQuery)
+}
@@ -0,0 +1,41 @@
+// Package translation provides locale variants of pages.
+package translation
+
+import (
+	"context"
+	"errors"
+
+	"github.com/aube/auth/internal/domain/entities"
+)
+
+var (
+	ErrTranslationNotFound = errors.New("page translation not found")
+	// ErrTranslationExists is returned when another page uses the name in the locale.
+	ErrTranslationExists = errors.New("page translation with this name already exists")
+	// ErrUnsupportedLocale is returned for locales the site does not serve;
+	// the default locale is edited on the page itself.
+	ErrUnsupportedLocale = errors.New("unsupported translation locale")
+)
+
+// PageTranslationRepository defines the interface for translation persistence.
+//
+// Methods:
+//
+//   - Save: Inserts or replaces the translation of a page in a locale, fills ID and times
+//   - Delete: Removes the translation of a page in a locale
+//   - FindByPage: Translation of a page in a locale
+//   - FindByName: Translation with the name in a locale
+//   - ListByPage: All translations of a page ordered by locale
+type PageTranslationRepository interface {
+	Save(ctx context.Context, translation *entities.PageTranslation) error
+	Delete(ctx context.Context, pageID int64, locale string) error
+	FindByPage(ctx context.Context, pageID int64, locale string) (*entities.PageTranslation, error)
+	FindByName(ctx context.Context, locale string, name string) (*entities.PageTranslation, error)
+	ListByPage(ctx context.Context, pageID int64) (*entities.PageTranslations, error)
+}
+
+// PageSource loads the pages translations belong to.
+type PageSource interface {
+	GetByID(ctx context.Context, id int64) (*entities.PageWithTime, error)
+	GetByName(ctx context.Context, name string) (*entities.PageWithTime, error)
+}
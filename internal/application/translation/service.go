@@ -0,0 +1,228 @@
+package translation
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/aube/auth/internal/application/dto"
+	"github.com/aube/auth/internal/application/render"
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/aube/auth/internal/domain/valueobjects"
+	"github.com/aube/auth/internal/utils/locale"
+	"github.com/aube/auth/internal/utils/logger"
+	"github.com/rs/zerolog"
+)
+
+// maxNameSuffix limits the numeric suffixes tried for a generated name.
+const maxNameSuffix = 100
+
+type TranslationService struct {
+	repo     PageTranslationRepository
+	pages    PageSource
+	locales  *locale.Locales
+	renderer *render.Renderer
+	log      zerolog.Logger
+}
+
+func NewTranslationService(repo PageTranslationRepository, pages PageSource, locales *locale.Locales) *TranslationService {
+	return &TranslationService{
+		repo:     repo,
+		pages:    pages,
+		locales:  locales,
+		renderer: render.NewRenderer(),
+		log:      logger.Get().With().Str("translation", "service").Logger(),
+	}
+}
+
+// Locales returns the locale settings of the site.
+func (s *TranslationService) Locales() *locale.Locales {
+	return s.locales
+}
+
+// List returns the translations of a page.
+func (s *TranslationService) List(ctx context.Context, pageID int64) (*entities.PageTranslations, error) {
+	translations, err := s.repo.ListByPage(ctx, pageID)
+	if err != nil {
+		s.log.Debug().Err(err).Msg("List")
+		return nil, err
+	}
+	return translations, nil
+}
+
+// Save creates or replaces the translation of a page in a locale.
+// Without a name one is generated from the title and made unique in the locale.
+func (s *TranslationService) Save(ctx context.Context, translationDTO dto.PageTranslationRequest) (*entities.PageTranslation, error) {
+	loc := locale.Normalize(translationDTO.Locale)
+	if !s.locales.IsSupported(loc) || loc == s.locales.Default() {
+		return nil, ErrUnsupportedLocale
+	}
+	if _, err := s.pages.GetByID(ctx, translationDTO.PageID); err != nil {
+		s.log.Debug().Err(err).Msg("Save1")
+		return nil, err
+	}
+
+	name, err := s.translationName(ctx, translationDTO.PageID, loc, translationDTO.Name, translationDTO.Title)
+	if err != nil {
+		s.log.Debug().Err(err).Msg("Save2")
+		return nil, err
+	}
+
+	translation, err := entities.NewPageTranslation(
+		translationDTO.PageID,
+		loc,
+		name,
+		translationDTO.Meta,
+		translationDTO.Title,
+		translationDTO.H1,
+		translationDTO.Content,
+		translationDTO.ContentShort,
+	)
+	if err != nil {
+		s.log.Debug().Err(err).Msg("Save3")
+		return nil, err
+	}
+
+	if translationDTO.ContentFormat != "" {
+		translation.ContentFormat = translationDTO.ContentFormat
+	}
+	translation.ContentHTML, err = s.renderer.Render(translation.ContentFormat, translation.Content)
+	if err != nil {
+		s.log.Debug().Err(err).Msg("Save4")
+		return nil, err
+	}
+	if translation.ContentShort == "" {
+		translation.ContentShort = s.renderer.Excerpt(translation.ContentHTML, render.ExcerptLength)
+	}
+
+	if err := s.repo.Save(ctx, translation); err != nil {
+		s.log.Debug().Err(err).Msg("Save5")
+		return nil, err
+	}
+
+	s.log.Debug().Msg("SAVE translation: " + strconv.Itoa(int(translation.PageID)) + ", " + loc)
+	return translation, nil
+}
+
+func (s *TranslationService) Delete(ctx context.Context, pageID int64, loc string) error {
+	if err := s.repo.Delete(ctx, pageID, locale.Normalize(loc)); err != nil {
+		s.log.Debug().Err(err).Msg("Delete")
+		return err
+	}
+	return nil
+}
+
+// GetByName finds the page by its name in a locale: the name of the
+// translation in that locale or the name of the page itself.
+// Returns the page in the default locale; see Localize.
+func (s *TranslationService) GetByName(ctx context.Context, name string, loc string) (*entities.PageWithTime, error) {
+	loc = locale.Normalize(loc)
+	if loc != s.locales.Default() && s.locales.IsSupported(loc) {
+		translation, err := s.repo.FindByName(ctx, loc, name)
+		switch {
+		case err == nil:
+			return s.pages.GetByID(ctx, translation.PageID)
+		case !errors.Is(err, ErrTranslationNotFound):
+			s.log.Debug().Err(err).Msg("GetByName")
+			return nil, err
+		}
+	}
+
+	return s.pages.GetByName(ctx, name)
+}
+
+// Localize returns the page in the first locale of the fallback chain of loc
+// that has content; the page itself is the content of the default locale.
+// The Locale field of the result tells which locale was used.
+func (s *TranslationService) Localize(ctx context.Context, page *entities.PageWithTime, loc string) (*entities.PageWithTime, error) {
+	chain := s.locales.Chain(loc)
+
+	var byLocale map[string]entities.PageTranslation
+	if len(chain) > 1 {
+		translations, err := s.repo.ListByPage(ctx, page.ID)
+		if err != nil {
+			s.log.Debug().Err(err).Msg("Localize")
+			return nil, err
+		}
+		byLocale = make(map[string]entities.PageTranslation, len(*translations))
+		for _, t := range *translations {
+			byLocale[t.Locale] = t
+		}
+	}
+
+	for _, l := range chain {
+		if t, ok := byLocale[l]; ok {
+			return page.Translate(&t), nil
+		}
+		if l == s.locales.Default() {
+			break
+		}
+	}
+
+	res := *page
+	res.Locale = s.locales.Default()
+	return &res, nil
+}
+
+// Alternates lists the locale variants of a page for hreflang links.
+// nodePath: Path of the node the page is shown at, empty for pages
+// addressed by name. Returns nil when the page has no translations.
+func (s *TranslationService) Alternates(ctx context.Context, page *entities.PageWithTime, nodePath string) ([]entities.PageAlternate, error) {
+	translations, err := s.repo.ListByPage(ctx, page.ID)
+	if err != nil {
+		s.log.Debug().Err(err).Msg("Alternates")
+		return nil, err
+	}
+	if len(*translations) == 0 {
+		return nil, nil
+	}
+
+	path := func(loc, name string) string {
+		if nodePath != "" {
+			return entities.LocalePath(loc, s.locales.Default(), nodePath)
+		}
+		if page.Name == entities.HomePageName {
+			name = entities.HomePageName
+		}
+		return entities.LocalePath(loc, s.locales.Default(), entities.PagePath(name))
+	}
+
+	alternates := []entities.PageAlternate{{Locale: s.locales.Default(), Path: path(s.locales.Default(), page.Name)}}
+	for _, t := range *translations {
+		if s.locales.IsSupported(t.Locale) {
+			alternates = append(alternates, entities.PageAlternate{Locale: t.Locale, Path: path(t.Locale, t.Name)})
+		}
+	}
+	return alternates, nil
+}
+
+// translationName returns the normalized name of a translation, generating
+// a unique one from the title when it is empty.
+func (s *TranslationService) translationName(ctx context.Context, pageID int64, loc, name, title string) (string, error) {
+	if name != "" {
+		slug, err := valueobjects.NewSlug(name)
+		if err != nil {
+			return "", err
+		}
+		return slug.String(), nil
+	}
+
+	slug, err := valueobjects.SlugFromTitle(title)
+	if err != nil {
+		return "", errors.New("name cannot be empty")
+	}
+
+	candidate := slug
+	for n := 2; n <= maxNameSuffix; n++ {
+		existing, err := s.repo.FindByName(ctx, loc, candidate.String())
+		if errors.Is(err, ErrTranslationNotFound) || (err == nil && existing.PageID == pageID) {
+			return candidate.String(), nil
+		}
+		if err != nil {
+			return "", err
+		}
+		candidate = slug.WithSuffix(n)
+	}
+
+	return "", errors.New("cannot generate a unique name for " + slug.String())
+}
@@ -0,0 +1,64 @@
+package translation_test
+
+import (
+	"context"
+
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/stretchr/testify/mock"
+)
+
+type PageTranslationRepository struct {
+	mock.Mock
+}
+
+func (m *PageTranslationRepository) Save(ctx context.Context, translation *entities.PageTranslation) error {
+	return m.Called(ctx, translation).Error(0)
+}
+
+func (m *PageTranslationRepository) Delete(ctx context.Context, pageID int64, locale string) error {
+	return m.Called(ctx, pageID, locale).Error(0)
+}
+
+func (m *PageTranslationRepository) FindByPage(ctx context.Context, pageID int64, locale string) (*entities.PageTranslation, error) {
+	args := m.Called(ctx, pageID, locale)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.PageTranslation), args.Error(1)
+}
+
+func (m *PageTranslationRepository) FindByName(ctx context.Context, locale string, name string) (*entities.PageTranslation, error) {
+	args := m.Called(ctx, locale, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.PageTranslation), args.Error(1)
+}
+
+func (m *PageTranslationRepository) ListByPage(ctx context.Context, pageID int64) (*entities.PageTranslations, error) {
+	args := m.Called(ctx, pageID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.PageTranslations), args.Error(1)
+}
+
+type PageSource struct {
+	mock.Mock
+}
+
+func (m *PageSource) GetByID(ctx context.Context, id int64) (*entities.PageWithTime, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.PageWithTime), args.Error(1)
+}
+
+func (m *PageSource) GetByName(ctx context.Context, name string) (*entities.PageWithTime, error) {
+	args := m.Called(ctx, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.PageWithTime), args.Error(1)
+}
@@ -0,0 +1,140 @@
+package translation_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aube/auth/internal/application/dto"
+	appPage "github.com/aube/auth/internal/application/page"
+	appTranslation "github.com/aube/auth/internal/application/translation"
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/aube/auth/internal/utils/locale"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newService(t *testing.T) (*appTranslation.TranslationService, *PageTranslationRepository, *PageSource) {
+	locales, err := locale.New("ru", []string{"ru", "en", "uk"}, map[string]string{"uk": "en"})
+	require.NoError(t, err)
+
+	repo := new(PageTranslationRepository)
+	pages := new(PageSource)
+	return appTranslation.NewTranslationService(repo, pages, locales), repo, pages
+}
+
+func basePage() *entities.PageWithTime {
+	page := &entities.PageWithTime{}
+	page.ID = 7
+	page.Name = "o-nas"
+	page.Title = "О нас"
+	page.Status = entities.PageStatusPublished
+	return page
+}
+
+func TestTranslationService_Save(t *testing.T) {
+	service, repo, pages := newService(t)
+	ctx := context.Background()
+
+	pages.On("GetByID", ctx, int64(7)).Return(basePage(), nil)
+	repo.On("FindByName", ctx, "en", "about-us").Return(nil, appTranslation.ErrTranslationNotFound)
+	repo.On("Save", ctx, mock.Anything).Return(nil)
+
+	translation, err := service.Save(ctx, dto.PageTranslationRequest{
+		PageID:        7,
+		Locale:        "EN",
+		Title:         "About us",
+		Content:       "# About\n\nWho we are",
+		ContentFormat: entities.ContentFormatMarkdown,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "en", translation.Locale)
+	assert.Equal(t, "about-us", translation.Name)
+	assert.Contains(t, translation.ContentHTML, "<h1")
+	assert.Equal(t, "About Who we are", translation.ContentShort)
+}
+
+func TestTranslationService_Save_UnsupportedLocale(t *testing.T) {
+	service, _, _ := newService(t)
+
+	for _, loc := range []string{"ru", "de"} {
+		_, err := service.Save(context.Background(), dto.PageTranslationRequest{PageID: 7, Locale: loc, Name: "x"})
+		assert.ErrorIs(t, err, appTranslation.ErrUnsupportedLocale, loc)
+	}
+}
+
+func TestTranslationService_Save_PageNotFound(t *testing.T) {
+	service, _, pages := newService(t)
+	ctx := context.Background()
+
+	pages.On("GetByID", ctx, int64(9)).Return(nil, appPage.ErrPageNotFound)
+
+	_, err := service.Save(ctx, dto.PageTranslationRequest{PageID: 9, Locale: "en", Name: "x"})
+	assert.ErrorIs(t, err, appPage.ErrPageNotFound)
+}
+
+func TestTranslationService_Localize(t *testing.T) {
+	service, repo, _ := newService(t)
+	ctx := context.Background()
+
+	repo.On("ListByPage", ctx, int64(7)).Return(&entities.PageTranslations{
+		{PageID: 7, Locale: "en", Name: "about-us", Title: "About us"},
+	}, nil)
+
+	// uk -> en по цепочке запасных языков
+	for _, loc := range []string{"en", "uk"} {
+		page, err := service.Localize(ctx, basePage(), loc)
+		require.NoError(t, err)
+		assert.Equal(t, "en", page.Locale, loc)
+		assert.Equal(t, "About us", page.Title, loc)
+		assert.Equal(t, int64(7), page.ID, loc)
+	}
+
+	page, err := service.Localize(ctx, basePage(), "ru")
+	require.NoError(t, err)
+	assert.Equal(t, "ru", page.Locale)
+	assert.Equal(t, "О нас", page.Title)
+}
+
+func TestTranslationService_GetByName(t *testing.T) {
+	service, repo, pages := newService(t)
+	ctx := context.Background()
+
+	repo.On("FindByName", ctx, "en", "about-us").Return(&entities.PageTranslation{PageID: 7, Locale: "en"}, nil)
+	repo.On("FindByName", ctx, "en", "o-nas").Return(nil, appTranslation.ErrTranslationNotFound)
+	pages.On("GetByID", ctx, int64(7)).Return(basePage(), nil)
+	pages.On("GetByName", ctx, "o-nas").Return(basePage(), nil)
+
+	page, err := service.GetByName(ctx, "about-us", "en")
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), page.ID)
+
+	// Имя страницы на языке по умолчанию тоже подходит
+	page, err = service.GetByName(ctx, "o-nas", "en")
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), page.ID)
+}
+
+func TestTranslationService_Alternates(t *testing.T) {
+	service, repo, _ := newService(t)
+	ctx := context.Background()
+
+	repo.On("ListByPage", ctx, int64(7)).Return(&entities.PageTranslations{
+		{PageID: 7, Locale: "en", Name: "about-us"},
+		{PageID: 7, Locale: "de", Name: "uber-uns"},
+	}, nil).Twice()
+
+	alternates, err := service.Alternates(ctx, basePage(), "")
+	require.NoError(t, err)
+	assert.Equal(t, []entities.PageAlternate{
+		{Locale: "ru", Path: "/o-nas"},
+		{Locale: "en", Path: "/en/about-us"},
+	}, alternates)
+
+	alternates, err = service.Alternates(ctx, basePage(), "/company/about")
+	require.NoError(t, err)
+	assert.Equal(t, []entities.PageAlternate{
+		{Locale: "ru", Path: "/company/about"},
+		{Locale: "en", Path: "/en/company/about"},
+	}, alternates)
+}
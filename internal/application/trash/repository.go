@@ -0,0 +1,48 @@
+// Package trash lists, restores and purges soft-deleted pages, uploads
+// and images, and purges them automatically after the retention period.
+package trash
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/aube/auth/internal/application/dto"
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/aube/auth/internal/utils/query"
+)
+
+var (
+	// ErrItemNotFound is returned when the item is not in the trash
+	// or belongs to another user.
+	ErrItemNotFound = errors.New("item not found in trash")
+	// ErrNameTaken is returned when a live item with the same name
+	// appeared after deletion, restoring would make the name ambiguous.
+	ErrNameTaken = errors.New("item with this name already exists")
+	// ErrInvalidType is returned for an unknown item type.
+	ErrInvalidType = errors.New("invalid trash item type")
+)
+
+// TrashRepository defines the interface for deleted item persistence.
+// Uploads and images are scoped by owner; pages are shared by all editors.
+// userID 0 means any owner (retention job).
+//
+// Methods:
+//
+//   - List: Deleted items of a type (all types for ""), newest first;
+//     filter carries the cursor only
+//   - ListExpired: Items of all types deleted before the time, oldest first
+//   - Restore: Clears the deleted flag
+//   - Purge: Hard-deletes the record with its older versions and returns
+//     their stored files; stored files are removed by the service
+type TrashRepository interface {
+	List(ctx context.Context, itemType string, userID int64, offset, limit int, filter *query.Query) (*entities.TrashItems, *dto.Pagination, error)
+	ListExpired(ctx context.Context, before time.Time, limit int) (*entities.TrashItems, error)
+	Restore(ctx context.Context, item *entities.TrashItem, userID int64) error
+	Purge(ctx context.Context, item *entities.TrashItem, userID int64) ([]string, error)
+}
+
+// BlobStore removes stored files of purged uploads or images.
+type BlobStore interface {
+	Delete(ctx context.Context, id string) error
+}
@@ -0,0 +1,176 @@
+package trash
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/aube/auth/internal/application/dto"
+	appFile "github.com/aube/auth/internal/application/file"
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/aube/auth/internal/utils/logger"
+	"github.com/aube/auth/internal/utils/query"
+	"github.com/rs/zerolog"
+)
+
+// purgeBatch limits items purged by one retention query.
+const purgeBatch = 100
+
+// TrashService manages deleted items. Files of deleted uploads and images
+// stay in storage until the item is purged, so restoring brings them back.
+// Items older than retention are purged by RunRetention; 0 keeps them forever.
+type TrashService struct {
+	repo      TrashRepository
+	uploads   BlobStore
+	images    BlobStore
+	retention time.Duration
+	log       zerolog.Logger
+
+	mu        sync.RWMutex
+	listeners []func()
+}
+
+func NewTrashService(repo TrashRepository, uploads BlobStore, images BlobStore, retention time.Duration) *TrashService {
+	return &TrashService{
+		repo:      repo,
+		uploads:   uploads,
+		images:    images,
+		retention: retention,
+		log:       logger.Get().With().Str("trash", "service").Logger(),
+	}
+}
+
+// OnChange registers fn to be called after a page is restored.
+// Used by caches built from pages (menus).
+func (s *TrashService) OnChange(fn func()) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.listeners = append(s.listeners, fn)
+}
+
+func (s *TrashService) notifyChange() {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, fn := range s.listeners {
+		fn()
+	}
+}
+
+// Retention is the time deleted items are kept, 0 for forever.
+func (s *TrashService) Retention() time.Duration {
+	return s.retention
+}
+
+func (s *TrashService) List(ctx context.Context, itemType string, userID int64, offset, limit int, filter *query.Query) (*entities.TrashItems, *dto.Pagination, error) {
+	if itemType != "" && !entities.IsValidTrashType(itemType) {
+		return nil, nil, ErrInvalidType
+	}
+	return s.repo.List(ctx, itemType, userID, offset, limit, filter)
+}
+
+func (s *TrashService) Restore(ctx context.Context, item *entities.TrashItem, userID int64) error {
+	if err := s.repo.Restore(ctx, item, userID); err != nil {
+		s.log.Debug().Err(err).Msg("Restore")
+		return err
+	}
+
+	if item.Type == entities.TrashTypePage {
+		s.notifyChange()
+	}
+	s.log.Debug().Msg("RESTORE " + item.Type + ": " + itemKey(item))
+	return nil
+}
+
+// Purge permanently deletes the item and its stored files,
+// older versions included.
+func (s *TrashService) Purge(ctx context.Context, item *entities.TrashItem, userID int64) error {
+	versions, err := s.repo.Purge(ctx, item, userID)
+	if err != nil {
+		s.log.Debug().Err(err).Msg("Purge1")
+		return err
+	}
+
+	if err := s.deleteBlobs(ctx, item, versions); err != nil {
+		s.log.Debug().Err(err).Msg("Purge2")
+		return err
+	}
+
+	s.log.Debug().Msg("PURGE " + item.Type + ": " + itemKey(item))
+	return nil
+}
+
+// PurgeExpired purges items deleted more than retention ago.
+// Returns the number of purged items.
+func (s *TrashService) PurgeExpired(ctx context.Context, now time.Time) (int, error) {
+	if s.retention <= 0 {
+		return 0, nil
+	}
+
+	purged := 0
+	for {
+		items, err := s.repo.ListExpired(ctx, now.Add(-s.retention), purgeBatch)
+		if err != nil {
+			s.log.Debug().Err(err).Msg("PurgeExpired")
+			return purged, err
+		}
+
+		for i := range *items {
+			item := &(*items)[i]
+			if err := s.Purge(ctx, item, 0); err != nil {
+				return purged, err
+			}
+			purged++
+		}
+
+		if len(*items) < purgeBatch {
+			return purged, nil
+		}
+	}
+}
+
+// RunRetention purges expired items every interval until ctx is done.
+func (s *TrashService) RunRetention(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if _, err := s.PurgeExpired(ctx, now); err != nil {
+				s.log.Error().Err(err).Msg("trash retention")
+			}
+		}
+	}
+}
+
+// deleteBlobs removes the stored file of the item and the files of its
+// older versions; a file already missing is not an error.
+func (s *TrashService) deleteBlobs(ctx context.Context, item *entities.TrashItem, versions []string) error {
+	var store BlobStore
+	switch item.Type {
+	case entities.TrashTypeUpload:
+		store = s.uploads
+	case entities.TrashTypeImage:
+		store = s.images
+	default:
+		return nil
+	}
+
+	for _, id := range append([]string{item.UUID}, versions...) {
+		if err := store.Delete(ctx, id); err != nil && !errors.Is(err, appFile.ErrFileNotFound) {
+			return err
+		}
+	}
+	return nil
+}
+
+func itemKey(item *entities.TrashItem) string {
+	if item.HasBlob() {
+		return item.UUID
+	}
+	return strconv.FormatInt(item.ID, 10)
+}
@@ -0,0 +1,51 @@
+package trash_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/aube/auth/internal/application/dto"
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/aube/auth/internal/utils/query"
+	"github.com/stretchr/testify/mock"
+)
+
+type TrashRepository struct {
+	mock.Mock
+}
+
+func (m *TrashRepository) List(ctx context.Context, itemType string, userID int64, offset, limit int, filter *query.Query) (*entities.TrashItems, *dto.Pagination, error) {
+	args := m.Called(ctx, itemType, userID, offset, limit, filter)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+	return args.Get(0).(*entities.TrashItems), args.Get(1).(*dto.Pagination), args.Error(2)
+}
+
+func (m *TrashRepository) ListExpired(ctx context.Context, before time.Time, limit int) (*entities.TrashItems, error) {
+	args := m.Called(ctx, before, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.TrashItems), args.Error(1)
+}
+
+func (m *TrashRepository) Restore(ctx context.Context, item *entities.TrashItem, userID int64) error {
+	return m.Called(ctx, item, userID).Error(0)
+}
+
+func (m *TrashRepository) Purge(ctx context.Context, item *entities.TrashItem, userID int64) ([]string, error) {
+	args := m.Called(ctx, item, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+type BlobStore struct {
+	mock.Mock
+}
+
+func (m *BlobStore) Delete(ctx context.Context, id string) error {
+	return m.Called(ctx, id).Error(0)
+}
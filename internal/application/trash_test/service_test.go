@@ -0,0 +1,135 @@
+package trash_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	appFile "github.com/aube/auth/internal/application/file"
+	appTrash "github.com/aube/auth/internal/application/trash"
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const fileUUID = "0b6f5a3e-8d7c-4e1a-9f2b-3c4d5e6f7a8b"
+
+func TestTrashService_List_InvalidType(t *testing.T) {
+	mockRepo := new(TrashRepository)
+	service := appTrash.NewTrashService(mockRepo, nil, nil, 0)
+
+	_, _, err := service.List(context.Background(), "node", 1, 0, 10, nil)
+
+	assert.ErrorIs(t, err, appTrash.ErrInvalidType)
+	mockRepo.AssertNotCalled(t, "List", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTrashService_Restore_Page(t *testing.T) {
+	mockRepo := new(TrashRepository)
+	service := appTrash.NewTrashService(mockRepo, nil, nil, 0)
+
+	changed := 0
+	service.OnChange(func() { changed++ })
+
+	item := &entities.TrashItem{Type: entities.TrashTypePage, ID: 5}
+	mockRepo.On("Restore", mock.Anything, item, int64(1)).Return(nil)
+
+	err := service.Restore(context.Background(), item, 1)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, changed)
+}
+
+func TestTrashService_Restore_NameTaken(t *testing.T) {
+	mockRepo := new(TrashRepository)
+	service := appTrash.NewTrashService(mockRepo, nil, nil, 0)
+
+	item := &entities.TrashItem{Type: entities.TrashTypeUpload, UUID: fileUUID}
+	mockRepo.On("Restore", mock.Anything, item, int64(1)).Return(appTrash.ErrNameTaken)
+
+	err := service.Restore(context.Background(), item, 1)
+
+	assert.ErrorIs(t, err, appTrash.ErrNameTaken)
+}
+
+func TestTrashService_Purge_RemovesBlob(t *testing.T) {
+	mockRepo := new(TrashRepository)
+	uploads := new(BlobStore)
+	images := new(BlobStore)
+	service := appTrash.NewTrashService(mockRepo, uploads, images, 0)
+
+	item := &entities.TrashItem{Type: entities.TrashTypeImage, UUID: fileUUID}
+	mockRepo.On("Purge", mock.Anything, item, int64(1)).Return(nil, nil)
+	// Файл, пропавший из хранилища, не мешает очистке
+	images.On("Delete", mock.Anything, fileUUID).Return(appFile.ErrFileNotFound)
+
+	err := service.Purge(context.Background(), item, 1)
+
+	require.NoError(t, err)
+	images.AssertExpectations(t)
+	uploads.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+}
+
+func TestTrashService_Purge_RemovesVersions(t *testing.T) {
+	mockRepo := new(TrashRepository)
+	uploads := new(BlobStore)
+	service := appTrash.NewTrashService(mockRepo, uploads, nil, 0)
+
+	versionUUID := "5e1c2d3b-4a59-4687-9a0b-1c2d3e4f5a6b"
+	item := &entities.TrashItem{Type: entities.TrashTypeUpload, UUID: fileUUID}
+	mockRepo.On("Purge", mock.Anything, item, int64(1)).Return([]string{versionUUID}, nil)
+	uploads.On("Delete", mock.Anything, fileUUID).Return(nil)
+	uploads.On("Delete", mock.Anything, versionUUID).Return(nil)
+
+	err := service.Purge(context.Background(), item, 1)
+
+	require.NoError(t, err)
+	uploads.AssertExpectations(t)
+}
+
+func TestTrashService_Purge_NotFound(t *testing.T) {
+	mockRepo := new(TrashRepository)
+	uploads := new(BlobStore)
+	service := appTrash.NewTrashService(mockRepo, uploads, nil, 0)
+
+	item := &entities.TrashItem{Type: entities.TrashTypeUpload, UUID: fileUUID}
+	mockRepo.On("Purge", mock.Anything, item, int64(2)).Return(nil, appTrash.ErrItemNotFound)
+
+	err := service.Purge(context.Background(), item, 2)
+
+	assert.ErrorIs(t, err, appTrash.ErrItemNotFound)
+	uploads.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+}
+
+func TestTrashService_PurgeExpired(t *testing.T) {
+	mockRepo := new(TrashRepository)
+	uploads := new(BlobStore)
+	service := appTrash.NewTrashService(mockRepo, uploads, nil, 24*time.Hour)
+
+	now := time.Date(2025, 6, 3, 12, 0, 0, 0, time.UTC)
+	page := entities.TrashItem{Type: entities.TrashTypePage, ID: 5}
+	upload := entities.TrashItem{Type: entities.TrashTypeUpload, UUID: fileUUID, UserID: 1}
+	mockRepo.On("ListExpired", mock.Anything, now.Add(-24*time.Hour), mock.Anything).
+		Return(&entities.TrashItems{page, upload}, nil)
+	mockRepo.On("Purge", mock.Anything, mock.Anything, int64(0)).Return(nil, nil)
+	uploads.On("Delete", mock.Anything, fileUUID).Return(nil)
+
+	purged, err := service.PurgeExpired(context.Background(), now)
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, purged)
+	mockRepo.AssertNumberOfCalls(t, "Purge", 2)
+	uploads.AssertExpectations(t)
+}
+
+func TestTrashService_PurgeExpired_Disabled(t *testing.T) {
+	mockRepo := new(TrashRepository)
+	service := appTrash.NewTrashService(mockRepo, nil, nil, 0)
+
+	purged, err := service.PurgeExpired(context.Background(), time.Now())
+
+	require.NoError(t, err)
+	assert.Zero(t, purged)
+	mockRepo.AssertNotCalled(t, "ListExpired", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -7,21 +7,26 @@ import (
 
 	"github.com/aube/auth/internal/application/dto"
 	"github.com/aube/auth/internal/domain/entities"
+	"github.com/aube/auth/internal/utils/query"
 )
 
-// ErrFileNotFound is returned when requested upload metadata cannot be found.
-var ErrFileNotFound = errors.New("file not found")
+var (
+	// ErrFileNotFound is returned when requested upload metadata cannot be found.
+	ErrFileNotFound = errors.New("file not found")
+	// ErrNameTaken is returned when the folder already has a file with the name.
+	ErrNameTaken = errors.New("file with this name already exists in the folder")
+)
 
 // UploadRepository defines the interface for upload metadata persistence operations.
 // Implementations should handle database operations for upload records.
 //
 // Methods:
 //
-//   - Create: Stores new upload metadata
+//   - Create: Stores new upload metadata in upload.FolderID
 //     ctx: Context for cancellation/timeout
 //     userID: Owner of the upload
 //     upload: Upload metadata entity
-//     Returns: error on failure
+//     Returns: error on failure, appFolder.ErrFolderNotFound for a missing folder
 //
 //   - ListByUserID: Retrieves paginated uploads for a user
 //     ctx: Context for cancellation/timeout
@@ -36,12 +41,20 @@ var ErrFileNotFound = errors.New("file not found")
 //     userID: Owner verification
 //     Returns: (*entities.Upload, error)
 //
-//   - GetByName: Retrieves upload by filename with owner check
+//   - GetByName: Retrieves upload by filename in a folder with owner check
 //     ctx: Context for cancellation/timeout
 //     name: Filename
+//     folderID: Folder (0 for the root)
 //     userID: Owner verification
 //     Returns: (*entities.Upload, error)
 //
+//   - NamesWithPrefix: Names of the folder's files starting with prefix
+//     Used to pick a free name on auto-rename
+//
+//   - Move: Moves upload to a folder under a name
+//     Returns ErrNameTaken when the name is used there,
+//     appFolder.ErrFolderNotFound for a missing folder
+//
 //   - Delete: Soft-deletes upload record (standard deletion)
 //     ctx: Context for cancellation/timeout
 //     uuid: Upload identifier
@@ -55,9 +68,11 @@ var ErrFileNotFound = errors.New("file not found")
 //     Returns: error on failure
 type UploadRepository interface {
 	Create(ctx context.Context, userID int64, upload *entities.Upload) error
-	ListByUserID(ctx context.Context, userID int64, offset, limit int, params map[string]any) (*entities.Uploads, *dto.Pagination, error)
+	ListByUserID(ctx context.Context, userID int64, offset, limit int, filter *query.Query) (*entities.Uploads, *dto.Pagination, error)
 	GetByUUID(ctx context.Context, uuid string, userID int64) (*entities.Upload, error)
-	GetByName(ctx context.Context, name string, userID int64) (*entities.Upload, error)
+	GetByName(ctx context.Context, name string, folderID int64, userID int64) (*entities.Upload, error)
+	NamesWithPrefix(ctx context.Context, userID int64, folderID int64, prefix string) ([]string, error)
+	Move(ctx context.Context, uuid string, userID int64, folderID int64, name string) error
 	Delete(ctx context.Context, uuid string, userID int64) error
 	DeleteForce(ctx context.Context, uuid string, userID int64) error
 }
@@ -3,11 +3,14 @@ package upload
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/aube/auth/internal/application/dto"
+	appTag "github.com/aube/auth/internal/application/tag"
 	"github.com/aube/auth/internal/domain/entities"
 	"github.com/aube/auth/internal/utils/logger"
+	"github.com/aube/auth/internal/utils/query"
 	"github.com/rs/zerolog"
 )
 
@@ -39,6 +42,7 @@ func NewUploadService(repo UploadRepository) *UploadService {
 //
 // ctx: Context for cancellation/timeout
 // userID: Upload owner
+// folderID: Folder of the upload (0 for the root)
 // file: Associated file entity
 // name: Original filename
 // category: User classification
@@ -48,6 +52,7 @@ func NewUploadService(repo UploadRepository) *UploadService {
 func (s *UploadService) RegisterUploadedFile(
 	ctx context.Context,
 	userID int64,
+	folderID int64,
 	file *entities.File,
 	name,
 	category,
@@ -56,6 +61,7 @@ func (s *UploadService) RegisterUploadedFile(
 ) (*entities.Upload, error) {
 
 	upload := entities.NewUpload(file, 0, userID, name, category, contentType, description, time.Now())
+	upload.FolderID = folderID
 
 	err := s.repo.Create(ctx, userID, upload)
 	if err != nil {
@@ -66,6 +72,20 @@ func (s *UploadService) RegisterUploadedFile(
 	return upload, nil
 }
 
+// ListSchema lists the upload fields clients may filter and sort by.
+var ListSchema = query.Schema{
+	"name":         {Type: query.TypeString, Sortable: true},
+	"folder_id":    {Type: query.TypeInt},
+	"category":     {Type: query.TypeString, Sortable: true},
+	"content_type": {Type: query.TypeString, Sortable: true},
+	"size":         {Type: query.TypeInt, Sortable: true},
+	"description":  {Type: query.TypeString},
+	"created_at":   {Type: query.TypeTime, Sortable: true},
+	"updated_at":   {Type: query.TypeTime, Sortable: true},
+	"tags":         {Type: query.TypeString, List: true},
+	"scan_status":  {Type: query.TypeString},
+}
+
 // ListByUserID retrieves paginated uploads for a user:
 // Proxies to repository with pagination parameters
 //
@@ -74,8 +94,11 @@ func (s *UploadService) RegisterUploadedFile(
 // offset: Pagination start
 // limit: Maximum results
 // Returns: (*entities.Uploads, *dto.Pagination, error)
-func (s *UploadService) ListByUserID(ctx context.Context, userID int64, offset, limit int, params map[string]any) (*entities.Uploads, *dto.Pagination, error) {
-	return s.repo.ListByUserID(ctx, userID, offset, limit, params)
+func (s *UploadService) ListByUserID(ctx context.Context, userID int64, offset, limit int, filter *query.Query) (*entities.Uploads, *dto.Pagination, error) {
+	if err := appTag.NormalizeFilter(filter); err != nil {
+		return nil, nil, err
+	}
+	return s.repo.ListByUserID(ctx, userID, offset, limit, filter)
 }
 
 // GetByUUID retrieves upload by identifier with ownership check
@@ -87,13 +110,78 @@ func (s *UploadService) GetByUUID(ctx context.Context, uuid string, userID int64
 	return s.repo.GetByUUID(ctx, uuid, userID)
 }
 
-// GetByName retrieves upload by filename with ownership check
+// GetByName retrieves upload by filename in a folder with ownership check
 // ctx: Context for cancellation/timeout
 // name: Original filename
+// folderID: Folder (0 for the root)
 // userID: Owner verification
 // Returns: (*entities.Upload, error)
-func (s *UploadService) GetByName(ctx context.Context, name string, userID int64) (*entities.Upload, error) {
-	return s.repo.GetByName(ctx, name, userID)
+func (s *UploadService) GetByName(ctx context.Context, name string, folderID int64, userID int64) (*entities.Upload, error) {
+	return s.repo.GetByName(ctx, name, folderID, userID)
+}
+
+// ResolveName applies the conflict policy to a name in the folder.
+// Returns the name to store the file under and, for overwrite,
+// the existing upload: a new file becomes its next version.
+// ErrNameTaken is returned by the reject policy.
+func (s *UploadService) ResolveName(ctx context.Context, userID int64, folderID int64, name string, policy string) (string, *entities.Upload, error) {
+	if !entities.IsValidConflictPolicy(policy) {
+		return "", nil, entities.ErrInvalidConflictPolicy
+	}
+
+	existing, err := s.repo.GetByName(ctx, name, folderID, userID)
+	if errors.Is(err, ErrFileNotFound) {
+		return name, nil, nil
+	}
+	if err != nil {
+		s.log.Debug().Err(err).Msg("ResolveName1")
+		return "", nil, err
+	}
+
+	switch policy {
+	case entities.ConflictOverwrite:
+		return name, existing, nil
+	case entities.ConflictRename:
+		names, err := s.repo.NamesWithPrefix(ctx, userID, folderID, entities.NameStem(name))
+		if err != nil {
+			s.log.Debug().Err(err).Msg("ResolveName2")
+			return "", nil, err
+		}
+		return entities.FreeName(name, names), nil, nil
+	}
+	return "", nil, ErrNameTaken
+}
+
+// Move moves an upload to another folder (0 for the root).
+// A file replaced by the overwrite policy goes to the trash.
+func (s *UploadService) Move(ctx context.Context, uuid string, userID int64, folderID int64, policy string) (*entities.Upload, error) {
+	upload, err := s.repo.GetByUUID(ctx, uuid, userID)
+	if err != nil {
+		return nil, err
+	}
+	if upload.FolderID == folderID {
+		return upload, nil
+	}
+
+	name, existing, err := s.ResolveName(ctx, userID, folderID, upload.Name, policy)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		if err := s.repo.Delete(ctx, existing.UUID, userID); err != nil {
+			s.log.Debug().Err(err).Msg("Move1")
+			return nil, err
+		}
+	}
+
+	if err := s.repo.Move(ctx, uuid, userID, folderID, name); err != nil {
+		s.log.Debug().Err(err).Msg("Move2")
+		return nil, err
+	}
+
+	upload.FolderID = folderID
+	upload.Name = name
+	return upload, nil
 }
 
 // Delete removes upload metadata (standard operation)
@@ -5,6 +5,7 @@ import (
 
 	"github.com/aube/auth/internal/application/dto"
 	"github.com/aube/auth/internal/domain/entities"
+	"github.com/aube/auth/internal/utils/query"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -17,8 +18,8 @@ func (m *UploadRepository) Create(ctx context.Context, userID int64, upload *ent
 	return args.Error(0)
 }
 
-func (m *UploadRepository) ListByUserID(ctx context.Context, userID int64, offset, limit int, params map[string]any) (*entities.Uploads, *dto.Pagination, error) {
-	args := m.Called(ctx, userID, offset, limit, params)
+func (m *UploadRepository) ListByUserID(ctx context.Context, userID int64, offset, limit int, filter *query.Query) (*entities.Uploads, *dto.Pagination, error) {
+	args := m.Called(ctx, userID, offset, limit, filter)
 	return args.Get(0).(*entities.Uploads), args.Get(1).(*dto.Pagination), args.Error(2)
 }
 
@@ -30,14 +31,26 @@ func (m *UploadRepository) GetByUUID(ctx context.Context, uuid string, userID in
 	return args.Get(0).(*entities.Upload), args.Error(1)
 }
 
-func (m *UploadRepository) GetByName(ctx context.Context, name string, userID int64) (*entities.Upload, error) {
-	args := m.Called(ctx, name, userID)
+func (m *UploadRepository) GetByName(ctx context.Context, name string, folderID int64, userID int64) (*entities.Upload, error) {
+	args := m.Called(ctx, name, folderID, userID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*entities.Upload), args.Error(1)
 }
 
+func (m *UploadRepository) NamesWithPrefix(ctx context.Context, userID int64, folderID int64, prefix string) ([]string, error) {
+	args := m.Called(ctx, userID, folderID, prefix)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *UploadRepository) Move(ctx context.Context, uuid string, userID int64, folderID int64, name string) error {
+	return m.Called(ctx, uuid, userID, folderID, name).Error(0)
+}
+
 func (m *UploadRepository) Delete(ctx context.Context, uuid string, userID int64) error {
 	return m.Called(ctx, uuid, userID).Error(0)
 }
@@ -7,6 +7,7 @@ import (
 	"github.com/aube/auth/internal/application/dto"
 	appUpload "github.com/aube/auth/internal/application/upload"
 	"github.com/aube/auth/internal/domain/entities"
+	"github.com/aube/auth/internal/utils/query"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -29,6 +30,7 @@ func TestUploadService_RegisterUploadedFile_Success(t *testing.T) {
 			uploadArg := args.Get(2).(*entities.Upload)
 			// assert.Equal(t, file.Name, uploadArg.File.Name)
 			assert.Equal(t, userID, uploadArg.UserID)
+			assert.Equal(t, int64(4), uploadArg.FolderID)
 			assert.Equal(t, "test.txt", uploadArg.Name)
 			assert.Equal(t, "docs", uploadArg.Category)
 			assert.Equal(t, "text/plain", uploadArg.ContentType)
@@ -40,6 +42,7 @@ func TestUploadService_RegisterUploadedFile_Success(t *testing.T) {
 	result, err := service.RegisterUploadedFile(
 		context.Background(),
 		userID,
+		4,
 		file,
 		"test.txt",
 		"docs",
@@ -63,7 +66,7 @@ func TestUploadService_ListByUserID_Success(t *testing.T) {
 	userID := int64(1)
 	offset := 0
 	limit := 10
-	params := make(map[string]any)
+	filter := query.New().Add("deleted", query.OpEq, false)
 	expectedUploads := &entities.Uploads{
 		entities.Upload{UUID: "uuid1"},
 		entities.Upload{UUID: "uuid2"},
@@ -71,11 +74,11 @@ func TestUploadService_ListByUserID_Success(t *testing.T) {
 	expectedPagination := &dto.Pagination{Total: 2, Page: 1, Size: 10}
 
 	// Mock expectations
-	mockRepo.On("ListByUserID", mock.Anything, userID, offset, limit, params).
+	mockRepo.On("ListByUserID", mock.Anything, userID, offset, limit, filter).
 		Return(expectedUploads, expectedPagination, nil)
 
 	// Execute
-	uploads, pagination, err := service.ListByUserID(context.Background(), userID, offset, limit, params)
+	uploads, pagination, err := service.ListByUserID(context.Background(), userID, offset, limit, filter)
 
 	// Assert
 	require.NoError(t, err)
@@ -191,3 +194,56 @@ func TestUploadService_CanBeDeleted_NotFound(t *testing.T) {
 	assert.Equal(t, appUpload.ErrFileNotFound, err)
 	mockRepo.AssertExpectations(t)
 }
+
+func TestUploadService_ResolveName(t *testing.T) {
+	mockRepo := new(UploadRepository)
+	service := appUpload.NewUploadService(mockRepo)
+	ctx := context.Background()
+
+	existing := &entities.Upload{UUID: "old", Name: "a.pdf", FolderID: 4}
+	mockRepo.On("GetByName", ctx, "a.pdf", int64(4), int64(1)).Return(existing, nil)
+	mockRepo.On("GetByName", ctx, "b.pdf", int64(4), int64(1)).Return(nil, appUpload.ErrFileNotFound)
+	mockRepo.On("NamesWithPrefix", ctx, int64(1), int64(4), "a").Return([]string{"a.pdf", "a (1).pdf", "ab.pdf"}, nil)
+
+	name, replaced, err := service.ResolveName(ctx, 1, 4, "b.pdf", entities.ConflictReject)
+	require.NoError(t, err)
+	assert.Equal(t, "b.pdf", name)
+	assert.Nil(t, replaced)
+
+	_, _, err = service.ResolveName(ctx, 1, 4, "a.pdf", entities.ConflictReject)
+	assert.ErrorIs(t, err, appUpload.ErrNameTaken)
+
+	name, replaced, err = service.ResolveName(ctx, 1, 4, "a.pdf", entities.ConflictOverwrite)
+	require.NoError(t, err)
+	assert.Equal(t, "a.pdf", name)
+	assert.Equal(t, existing, replaced)
+
+	name, replaced, err = service.ResolveName(ctx, 1, 4, "a.pdf", entities.ConflictRename)
+	require.NoError(t, err)
+	assert.Equal(t, "a (2).pdf", name)
+	assert.Nil(t, replaced)
+
+	_, _, err = service.ResolveName(ctx, 1, 4, "a.pdf", "skip")
+	assert.ErrorIs(t, err, entities.ErrInvalidConflictPolicy)
+}
+
+func TestUploadService_Move(t *testing.T) {
+	mockRepo := new(UploadRepository)
+	service := appUpload.NewUploadService(mockRepo)
+	ctx := context.Background()
+
+	mockRepo.On("GetByUUID", ctx, "u1", int64(1)).Return(&entities.Upload{UUID: "u1", Name: "a.pdf"}, nil)
+	mockRepo.On("GetByName", ctx, "a.pdf", int64(4), int64(1)).Return(&entities.Upload{UUID: "old", Name: "a.pdf", FolderID: 4}, nil)
+	mockRepo.On("Delete", ctx, "old", int64(1)).Return(nil)
+	mockRepo.On("Move", ctx, "u1", int64(1), int64(4), "a.pdf").Return(nil)
+
+	_, err := service.Move(ctx, "u1", 1, 4, entities.ConflictReject)
+	assert.ErrorIs(t, err, appUpload.ErrNameTaken)
+	mockRepo.AssertNotCalled(t, "Move", ctx, "u1", int64(1), int64(4), "a.pdf")
+
+	// Заменённый файл уходит в корзину
+	upload, err := service.Move(ctx, "u1", 1, 4, entities.ConflictOverwrite)
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), upload.FolderID)
+	mockRepo.AssertCalled(t, "Delete", ctx, "old", int64(1))
+}
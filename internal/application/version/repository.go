@@ -0,0 +1,44 @@
+// Package version keeps older contents of uploads and images: a file
+// uploaded under an existing name becomes a new version of that file,
+// older versions can be downloaded or made current until pruned.
+package version
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/aube/auth/internal/domain/entities"
+)
+
+var (
+	// ErrVersionNotFound is returned for a missing or pruned version.
+	ErrVersionNotFound = errors.New("version not found")
+	// ErrInvalidKind is returned for a kind of files without versions.
+	ErrInvalidKind = errors.New("invalid version kind")
+)
+
+// VersionRepository defines the interface for file version persistence.
+// kind is entities.VersionKindUpload or entities.VersionKindImage;
+// files are changed only by the owner and while not deleted; List takes
+// the ID of a file already found for the owner.
+//
+// Methods:
+//
+//   - AddVersion: Makes the stored file the current content of the file
+//     and keeps the previous content as an older version; returns the new number
+//   - List: Versions of the file, the current one included, newest first
+//   - Promote: Swaps the current content with the older version
+//   - Prune: Removes older versions beyond keepLast or created before
+//     olderThan (zero for no age limit); returns their stored files
+type VersionRepository interface {
+	AddVersion(ctx context.Context, kind string, fileID int64, userID int64, file *entities.File, contentType string) (int, error)
+	List(ctx context.Context, kind string, fileID int64) (*entities.FileVersions, error)
+	Promote(ctx context.Context, kind string, fileID int64, userID int64, version int) error
+	Prune(ctx context.Context, kind string, fileID int64, keepLast int, olderThan time.Time) ([]string, error)
+}
+
+// BlobStore removes stored files of pruned versions.
+type BlobStore interface {
+	Delete(ctx context.Context, id string) error
+}
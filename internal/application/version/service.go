@@ -0,0 +1,148 @@
+package version
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	appFile "github.com/aube/auth/internal/application/file"
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/aube/auth/internal/utils/logger"
+	"github.com/rs/zerolog"
+)
+
+// Retention limits how many older versions of a file are kept.
+// KeepLast: number of newest older versions to keep (0 for unlimited)
+// KeepDays: age in days after which older versions are removed (0 for unlimited)
+// The current version of a file is never removed.
+type Retention struct {
+	KeepLast int
+	KeepDays int
+}
+
+// VersionService manages versions of one kind of files; blobs
+// is the storage of that kind, pruned versions are removed from it.
+type VersionService struct {
+	repo      VersionRepository
+	kind      string
+	blobs     BlobStore
+	retention Retention
+	log       zerolog.Logger
+}
+
+func NewVersionService(repo VersionRepository, kind string, blobs BlobStore, retention Retention) *VersionService {
+	return &VersionService{
+		repo:      repo,
+		kind:      kind,
+		blobs:     blobs,
+		retention: retention,
+		log:       logger.Get().With().Str("version", kind).Logger(),
+	}
+}
+
+// AddVersion makes the stored file the current content of the file
+// and applies the retention policy. Returns the new version number.
+func (s *VersionService) AddVersion(ctx context.Context, fileID int64, userID int64, file *entities.File, contentType string) (int, error) {
+	version, err := s.repo.AddVersion(ctx, s.kind, fileID, userID, file, contentType)
+	if err != nil {
+		s.log.Debug().Err(err).Msg("AddVersion")
+		return 0, err
+	}
+
+	if err := s.prune(ctx, fileID); err != nil {
+		// Не удалось почистить — не повод отменять загрузку
+		s.log.Error().Err(err).Msg("AddVersion")
+	}
+
+	return version, nil
+}
+
+// AddUploadVersion makes the file the current content of the existing
+// upload. Returns the upload as it is stored now.
+func (s *VersionService) AddUploadVersion(ctx context.Context, existing *entities.Upload, userID int64, file *entities.File, contentType string) (*entities.Upload, error) {
+	version, err := s.AddVersion(ctx, existing.ID, userID, file, contentType)
+	if err != nil {
+		return nil, err
+	}
+
+	upload := *existing
+	upload.UUID = file.Name
+	upload.Size = file.Size
+	upload.ContentType = contentType
+	upload.DeclaredType = file.DeclaredType
+	upload.Version = version
+	// Проверка относилась к прежнему содержимому
+	upload.ScanStatus = file.ScanStatus
+	upload.ScanDetail = ""
+	return &upload, nil
+}
+
+// AddImageVersion makes the file the current content of the existing
+// image. Returns the image as it is stored now.
+func (s *VersionService) AddImageVersion(ctx context.Context, existing *entities.Image, userID int64, file *entities.File, contentType string) (*entities.Image, error) {
+	version, err := s.AddVersion(ctx, existing.ID, userID, file, contentType)
+	if err != nil {
+		return nil, err
+	}
+
+	image := *existing
+	image.UUID = file.Name
+	image.Size = file.Size
+	image.ContentType = contentType
+	image.DeclaredType = file.DeclaredType
+	image.Version = version
+	return &image, nil
+}
+
+// List returns versions of the file, newest first.
+func (s *VersionService) List(ctx context.Context, fileID int64) (*entities.FileVersions, error) {
+	return s.repo.List(ctx, s.kind, fileID)
+}
+
+// Get returns the version of the file.
+func (s *VersionService) Get(ctx context.Context, fileID int64, version int) (*entities.FileVersion, error) {
+	versions, err := s.repo.List(ctx, s.kind, fileID)
+	if err != nil {
+		return nil, err
+	}
+
+	found := versions.Find(version)
+	if found == nil {
+		return nil, ErrVersionNotFound
+	}
+	return found, nil
+}
+
+// Promote makes the older version current; the current content becomes
+// an older version and keeps its number.
+func (s *VersionService) Promote(ctx context.Context, fileID int64, userID int64, version int) error {
+	if err := s.repo.Promote(ctx, s.kind, fileID, userID, version); err != nil {
+		s.log.Debug().Err(err).Msg("Promote")
+		return err
+	}
+	return nil
+}
+
+// prune removes older versions beyond the retention and their stored files.
+func (s *VersionService) prune(ctx context.Context, fileID int64) error {
+	if s.retention.KeepLast <= 0 && s.retention.KeepDays <= 0 {
+		return nil
+	}
+
+	var olderThan time.Time
+	if s.retention.KeepDays > 0 {
+		olderThan = time.Now().AddDate(0, 0, -s.retention.KeepDays)
+	}
+
+	pruned, err := s.repo.Prune(ctx, s.kind, fileID, s.retention.KeepLast, olderThan)
+	if err != nil {
+		return err
+	}
+
+	for _, id := range pruned {
+		if err := s.blobs.Delete(ctx, id); err != nil && !errors.Is(err, appFile.ErrFileNotFound) {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package version_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/stretchr/testify/mock"
+)
+
+type VersionRepository struct {
+	mock.Mock
+}
+
+func (m *VersionRepository) AddVersion(ctx context.Context, kind string, fileID int64, userID int64, file *entities.File, contentType string) (int, error) {
+	args := m.Called(ctx, kind, fileID, userID, file, contentType)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *VersionRepository) List(ctx context.Context, kind string, fileID int64) (*entities.FileVersions, error) {
+	args := m.Called(ctx, kind, fileID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.FileVersions), args.Error(1)
+}
+
+func (m *VersionRepository) Promote(ctx context.Context, kind string, fileID int64, userID int64, version int) error {
+	return m.Called(ctx, kind, fileID, userID, version).Error(0)
+}
+
+func (m *VersionRepository) Prune(ctx context.Context, kind string, fileID int64, keepLast int, olderThan time.Time) ([]string, error) {
+	args := m.Called(ctx, kind, fileID, keepLast, olderThan)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+type BlobStore struct {
+	mock.Mock
+}
+
+func (m *BlobStore) Delete(ctx context.Context, id string) error {
+	return m.Called(ctx, id).Error(0)
+}
@@ -0,0 +1,126 @@
+package version_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	appFile "github.com/aube/auth/internal/application/file"
+	appVersion "github.com/aube/auth/internal/application/version"
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVersionService_AddVersion_Prunes(t *testing.T) {
+	repo, blobs := new(VersionRepository), new(BlobStore)
+	service := appVersion.NewVersionService(repo, entities.VersionKindUpload, blobs, appVersion.Retention{KeepLast: 2})
+
+	file := &entities.File{Name: "v3", Size: 10}
+	repo.On("AddVersion", mock.Anything, entities.VersionKindUpload, int64(7), int64(1), file, "text/plain").Return(3, nil)
+	repo.On("Prune", mock.Anything, entities.VersionKindUpload, int64(7), 2, time.Time{}).Return([]string{"v0", "gone"}, nil)
+	blobs.On("Delete", mock.Anything, "v0").Return(nil)
+	// Файл, пропавший из хранилища, не мешает очистке
+	blobs.On("Delete", mock.Anything, "gone").Return(appFile.ErrFileNotFound)
+
+	version, err := service.AddVersion(context.Background(), 7, 1, file, "text/plain")
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, version)
+	blobs.AssertExpectations(t)
+}
+
+func TestVersionService_AddVersion_KeepDays(t *testing.T) {
+	repo := new(VersionRepository)
+	service := appVersion.NewVersionService(repo, entities.VersionKindImage, new(BlobStore), appVersion.Retention{KeepDays: 30})
+
+	file := &entities.File{Name: "v2"}
+	repo.On("AddVersion", mock.Anything, entities.VersionKindImage, int64(7), int64(1), file, "image/png").Return(2, nil)
+	repo.On("Prune", mock.Anything, entities.VersionKindImage, int64(7), 0, mock.MatchedBy(func(olderThan time.Time) bool {
+		return olderThan.Before(time.Now().AddDate(0, 0, -29)) && olderThan.After(time.Now().AddDate(0, 0, -31))
+	})).Return([]string{}, nil)
+
+	_, err := service.AddVersion(context.Background(), 7, 1, file, "image/png")
+
+	require.NoError(t, err)
+	repo.AssertExpectations(t)
+}
+
+func TestVersionService_AddVersion_PruneFailureIgnored(t *testing.T) {
+	repo := new(VersionRepository)
+	service := appVersion.NewVersionService(repo, entities.VersionKindUpload, new(BlobStore), appVersion.Retention{KeepLast: 1})
+
+	file := &entities.File{Name: "v2"}
+	repo.On("AddVersion", mock.Anything, entities.VersionKindUpload, int64(7), int64(1), file, "").Return(2, nil)
+	repo.On("Prune", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("db down"))
+
+	version, err := service.AddVersion(context.Background(), 7, 1, file, "")
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, version)
+}
+
+func TestVersionService_AddVersion_Unlimited(t *testing.T) {
+	repo := new(VersionRepository)
+	service := appVersion.NewVersionService(repo, entities.VersionKindUpload, nil, appVersion.Retention{})
+
+	file := &entities.File{Name: "v2"}
+	repo.On("AddVersion", mock.Anything, entities.VersionKindUpload, int64(7), int64(1), file, "").Return(2, nil)
+
+	_, err := service.AddVersion(context.Background(), 7, 1, file, "")
+
+	require.NoError(t, err)
+	repo.AssertNotCalled(t, "Prune", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestVersionService_AddUploadVersion(t *testing.T) {
+	repo := new(VersionRepository)
+	service := appVersion.NewVersionService(repo, entities.VersionKindUpload, nil, appVersion.Retention{})
+
+	existing := &entities.Upload{ID: 7, UUID: "v1", Name: "notes.txt", Category: "docs", Version: 1, ScanStatus: entities.ScanStatusClean}
+	file := &entities.File{Name: "v2", Size: 12, DeclaredType: "text/markdown", ScanStatus: entities.ScanStatusPending}
+	repo.On("AddVersion", mock.Anything, entities.VersionKindUpload, int64(7), int64(1), file, "text/plain").Return(2, nil)
+
+	upload, err := service.AddUploadVersion(context.Background(), existing, 1, file, "text/plain")
+
+	require.NoError(t, err)
+	assert.Equal(t, "v2", upload.UUID)
+	assert.Equal(t, int64(12), upload.Size)
+	assert.Equal(t, "text/plain", upload.ContentType)
+	assert.Equal(t, "text/markdown", upload.DeclaredType)
+	assert.Equal(t, 2, upload.Version)
+	assert.Equal(t, "docs", upload.Category)
+	assert.Equal(t, entities.ScanStatusPending, upload.ScanStatus)
+	assert.Equal(t, "v1", existing.UUID)
+}
+
+func TestVersionService_AddImageVersion_Failure(t *testing.T) {
+	repo := new(VersionRepository)
+	service := appVersion.NewVersionService(repo, entities.VersionKindImage, nil, appVersion.Retention{})
+
+	file := &entities.File{Name: "v2"}
+	repo.On("AddVersion", mock.Anything, entities.VersionKindImage, int64(7), int64(1), file, "image/png").Return(0, errors.New("db down"))
+
+	_, err := service.AddImageVersion(context.Background(), &entities.Image{ID: 7}, 1, file, "image/png")
+
+	require.Error(t, err)
+}
+
+func TestVersionService_Get(t *testing.T) {
+	repo := new(VersionRepository)
+	service := appVersion.NewVersionService(repo, entities.VersionKindUpload, nil, appVersion.Retention{})
+
+	repo.On("List", mock.Anything, entities.VersionKindUpload, int64(7)).Return(&entities.FileVersions{
+		{FileID: 7, Version: 2, UUID: "v2", Current: true},
+		{FileID: 7, Version: 1, UUID: "v1"},
+	}, nil)
+
+	version, err := service.Get(context.Background(), 7, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "v1", version.UUID)
+
+	_, err = service.Get(context.Background(), 7, 5)
+	assert.ErrorIs(t, err, appVersion.ErrVersionNotFound)
+}
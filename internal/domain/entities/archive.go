@@ -0,0 +1,60 @@
+package entities
+
+import (
+	"path"
+	"strconv"
+	"strings"
+)
+
+// Results of an entry of an imported archive.
+const (
+	ArchiveEntryOK = "ok"
+	// ArchiveEntryRejected: unsafe path, too large or too compressed entry
+	ArchiveEntryRejected = "rejected"
+	// ArchiveEntryFailed: the entry could not be read or stored
+	ArchiveEntryFailed = "failed"
+)
+
+// ArchiveEntry is the outcome for one file of an imported archive.
+// Name is the path inside the archive, Upload is set for stored entries.
+type ArchiveEntry struct {
+	Name   string
+	Status string
+	Reason string
+	Upload *Upload
+}
+
+type ArchiveEntries []ArchiveEntry
+
+// ArchiveEntryName returns the file name of a ZIP entry path. Absolute
+// paths, ".." elements, backslashes and drive letters are rejected
+// (zip slip), as well as directories.
+func ArchiveEntryName(name string) (string, bool) {
+	if name == "" || strings.ContainsAny(name, "\\\x00") || strings.HasPrefix(name, "/") ||
+		strings.HasSuffix(name, "/") || (len(name) > 1 && name[1] == ':') {
+		return "", false
+	}
+	for _, part := range strings.Split(name, "/") {
+		if part == ".." {
+			return "", false
+		}
+	}
+	base := path.Base(name)
+	if base == "." || base == "/" {
+		return "", false
+	}
+	return base, true
+}
+
+// UniqueName returns name, or "name (N).ext" with the lowest N not in taken,
+// and marks the result as taken.
+func UniqueName(name string, taken map[string]bool) string {
+	unique := name
+	ext := path.Ext(name)
+	stem := strings.TrimSuffix(name, ext)
+	for i := 1; taken[unique]; i++ {
+		unique = stem + " (" + strconv.Itoa(i) + ")" + ext
+	}
+	taken[unique] = true
+	return unique
+}
@@ -0,0 +1,66 @@
+package entities
+
+import "errors"
+
+// Kinds of items batch operations apply to.
+const (
+	BatchTypePage   = "page"
+	BatchTypeUpload = "upload"
+	BatchTypeImage  = "image"
+)
+
+// Batch actions. Uploads and images can be deleted, moved to a category,
+// tagged and restored from the trash; pages can be published, unpublished
+// and deleted.
+const (
+	BatchActionDelete    = "delete"
+	BatchActionMove      = "move"
+	BatchActionTag       = "tag"
+	BatchActionRestore   = "restore"
+	BatchActionPublish   = "publish"
+	BatchActionUnpublish = "unpublish"
+)
+
+// Results of an item of a batch.
+const (
+	BatchStatusOK = "ok"
+	// BatchStatusNotFound: no such item of the user in the required state
+	// (e.g. already deleted or not in the trash)
+	BatchStatusNotFound = "not_found"
+	// BatchStatusConflict: a live item with the same name exists (restore)
+	BatchStatusConflict = "conflict"
+	BatchStatusInvalid  = "invalid"
+)
+
+var batchActions = map[string][]string{
+	BatchTypePage:   {BatchActionPublish, BatchActionUnpublish, BatchActionDelete},
+	BatchTypeUpload: {BatchActionDelete, BatchActionMove, BatchActionTag, BatchActionRestore},
+	BatchTypeImage:  {BatchActionDelete, BatchActionMove, BatchActionTag, BatchActionRestore},
+}
+
+var ErrInvalidBatchAction = errors.New("invalid batch action")
+
+// IsValidBatchAction reports whether the action applies to items of the type.
+func IsValidBatchAction(itemType, action string) bool {
+	for _, a := range batchActions[itemType] {
+		if a == action {
+			return true
+		}
+	}
+	return false
+}
+
+// BatchResult is the outcome for one requested item: pages are
+// identified by ID, uploads and images by UUID.
+type BatchResult struct {
+	ID     int64
+	UUID   string
+	Status string
+}
+
+type BatchResults []BatchResult
+
+// OK reports whether the action was applied to the item.
+func (r *BatchResult) OK() bool {
+	return r.Status == BatchStatusOK
+}
@@ -0,0 +1,169 @@
+package entities
+
+import (
+	"errors"
+	"time"
+
+	"github.com/aube/auth/internal/domain/valueobjects"
+)
+
+// Bundle format: a ZIP archive with manifest.json and image files under blobs/.
+// The version is raised on incompatible changes of the manifest.
+const (
+	BundleFormat  = "aube-pages"
+	BundleVersion = 1
+)
+
+// Import conflict strategies for pages whose name is taken on the target site:
+// skip keeps the existing page, overwrite replaces it (keeping its id, so
+// nodes stay bound to it), rename imports the page under a free name.
+const (
+	ImportSkip      = "skip"
+	ImportOverwrite = "overwrite"
+	ImportRename    = "rename"
+)
+
+// Import actions reported per page.
+const (
+	ImportActionCreate    = "create"
+	ImportActionOverwrite = "overwrite"
+	ImportActionRename    = "rename"
+	ImportActionSkip      = "skip"
+	ImportActionFail      = "fail"
+)
+
+// BundleManifest describes the content of a bundle.
+type BundleManifest struct {
+	Format    string       `json:"format"`
+	Version   int          `json:"version"`
+	CreatedAt time.Time    `json:"created_at"`
+	Pages     []BundlePage `json:"pages"`
+}
+
+// BundlePage is a page with everything that belongs to it.
+// Ids are not exported: they are assigned by the target site.
+// ContentHTML is informational: imports render the content again.
+type BundlePage struct {
+	Name          string              `json:"name"`
+	Meta          PageMeta            `json:"meta"`
+	Title         string              `json:"title"`
+	Category      string              `json:"category"`
+	Template      string              `json:"template"`
+	H1            string              `json:"h1"`
+	Content       string              `json:"content"`
+	ContentShort  string              `json:"content_short"`
+	ContentFormat string              `json:"content_format"`
+	ContentHTML   string              `json:"content_html"`
+	ContentType   string              `json:"content_type,omitempty"`
+	Fields        PageFields          `json:"fields,omitempty"`
+	Status        string              `json:"status"`
+	PublishAt     *time.Time          `json:"publish_at,omitempty"`
+	UnpublishAt   *time.Time          `json:"unpublish_at,omitempty"`
+	PublishedAt   *time.Time          `json:"published_at,omitempty"`
+	CreatedAt     time.Time           `json:"created_at"`
+	UpdatedAt     time.Time           `json:"updated_at"`
+	Revisions     []BundleRevision    `json:"revisions"`
+	Translations  []BundleTranslation `json:"translations"`
+	Images        []BundleImage       `json:"images"`
+}
+
+// BundleRevision is a page snapshot; authors are not exported.
+type BundleRevision struct {
+	Revision      int        `json:"revision"`
+	Name          string     `json:"name"`
+	Meta          PageMeta   `json:"meta"`
+	Title         string     `json:"title"`
+	Category      string     `json:"category"`
+	Template      string     `json:"template"`
+	H1            string     `json:"h1"`
+	Content       string     `json:"content"`
+	ContentShort  string     `json:"content_short"`
+	ContentFormat string     `json:"content_format,omitempty"`
+	ContentType   string     `json:"content_type,omitempty"`
+	Fields        PageFields `json:"fields,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+type BundleTranslation struct {
+	Locale        string   `json:"locale"`
+	Name          string   `json:"name"`
+	Meta          PageMeta `json:"meta"`
+	Title         string   `json:"title"`
+	H1            string   `json:"h1"`
+	Content       string   `json:"content"`
+	ContentShort  string   `json:"content_short"`
+	ContentFormat string   `json:"content_format"`
+	ContentHTML   string   `json:"content_html"`
+}
+
+// BundleImage is an image attached to a page; its file is stored
+// in the archive as blobs/<uuid>.
+type BundleImage struct {
+	UUID        string `json:"uuid"`
+	Name        string `json:"name"`
+	Category    string `json:"category"`
+	ContentType string `json:"content_type"`
+	Description string `json:"description"`
+	Size        int64  `json:"size"`
+	SHA256      string `json:"sha256"`
+	Sort        int    `json:"sort"`
+	Pinned      bool   `json:"pinned"`
+}
+
+// IsValidImportStrategy reports whether s is a known conflict strategy.
+func IsValidImportStrategy(s string) bool {
+	return s == ImportSkip || s == ImportOverwrite || s == ImportRename
+}
+
+// Validate checks the data a page needs to be stored.
+func (p *BundlePage) Validate() error {
+	if _, err := valueobjects.NewSlug(p.Name); err != nil {
+		return err
+	}
+	if !IsValidPageStatus(p.Status) {
+		return errors.New("invalid page status: " + p.Status)
+	}
+	if p.ContentFormat != ContentFormatHTML && p.ContentFormat != ContentFormatMarkdown {
+		return errors.New("invalid content format: " + p.ContentFormat)
+	}
+	if err := p.Meta.Validate(); err != nil {
+		return err
+	}
+	if p.ContentType == "" && len(p.Fields) > 0 {
+		return errors.New("fields without content type in page " + p.Name)
+	}
+	for _, t := range p.Translations {
+		if t.ContentFormat != "" && t.ContentFormat != ContentFormatHTML && t.ContentFormat != ContentFormatMarkdown {
+			return errors.New("invalid content format: " + t.ContentFormat)
+		}
+		if _, err := NewPageTranslation(1, t.Locale, t.Name, t.Meta, t.Title, t.H1, t.Content, t.ContentShort); err != nil {
+			return err
+		}
+	}
+	for _, img := range p.Images {
+		if img.UUID == "" || img.SHA256 == "" {
+			return errors.New("image without file in page " + p.Name)
+		}
+	}
+	return nil
+}
+
+// ImportResult is the outcome for one page of a bundle.
+// Name: Name in the bundle
+// TargetName: Name on the site (differs after rename)
+type ImportResult struct {
+	Name       string `json:"name"`
+	Action     string `json:"action"`
+	TargetName string `json:"target_name,omitempty"`
+	PageID     int64  `json:"page_id,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+// ImportReport summarizes an import; with DryRun nothing was written.
+type ImportReport struct {
+	DryRun  bool           `json:"dry_run"`
+	Version int            `json:"version"`
+	Pages   []ImportResult `json:"pages"`
+	Images  int            `json:"images"`
+	Failed  int            `json:"failed"`
+}
@@ -0,0 +1,325 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+	"unicode/utf8"
+
+	"github.com/aube/auth/internal/domain/valueobjects"
+	"github.com/google/uuid"
+)
+
+// Content field types.
+// Image fields hold an image UUID, page fields hold a page ID,
+// group fields hold a list of objects described by nested fields.
+const (
+	FieldTypeText     = "text"
+	FieldTypeRichText = "richtext"
+	FieldTypeNumber   = "number"
+	FieldTypeDate     = "date"
+	FieldTypeBoolean  = "boolean"
+	FieldTypeImage    = "image"
+	FieldTypePage     = "page"
+	FieldTypeGroup    = "group"
+)
+
+// FieldDateLayout is the format of date field values.
+const FieldDateLayout = "2006-01-02"
+
+var fieldNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,62}$`)
+
+// ContentType describes the structured fields of a kind of page
+// (product, news, landing). Fields are stored as a JSON document.
+type ContentType struct {
+	ID        int64
+	Name      string
+	Title     string
+	Fields    ContentFields
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type ContentTypes []ContentType
+
+// ContentField is a field definition.
+// MaxLength applies to text fields, Min/Max to numbers,
+// MinItems/MaxItems and Fields to groups.
+type ContentField struct {
+	Name      string        `json:"name"`
+	Label     string        `json:"label,omitempty"`
+	Type      string        `json:"type"`
+	Required  bool          `json:"required,omitempty"`
+	MaxLength int           `json:"max_length,omitempty"`
+	Min       *float64      `json:"min,omitempty"`
+	Max       *float64      `json:"max,omitempty"`
+	MinItems  int           `json:"min_items,omitempty"`
+	MaxItems  int           `json:"max_items,omitempty"`
+	Fields    ContentFields `json:"fields,omitempty"`
+}
+
+type ContentFields []ContentField
+
+// PageFields are field values of a typed page keyed by field name.
+type PageFields map[string]any
+
+// FieldRefs lists the images and pages referenced by field values.
+type FieldRefs struct {
+	Images []string
+	Pages  []int64
+}
+
+func NewContentType(id int64, name string, title string, fields ContentFields) (*ContentType, error) {
+	if name == "" {
+		return nil, errors.New("name cannot be empty")
+	}
+	slug, err := valueobjects.NewSlug(name)
+	if err != nil {
+		return nil, err
+	}
+	if len(fields) == 0 {
+		return nil, errors.New("content type must have fields")
+	}
+	if err := fields.validate(""); err != nil {
+		return nil, err
+	}
+	if title == "" {
+		title = slug.String()
+	}
+
+	return &ContentType{
+		ID:     id,
+		Name:   slug.String(),
+		Title:  title,
+		Fields: fields,
+	}, nil
+}
+
+func IsValidFieldType(fieldType string) bool {
+	switch fieldType {
+	case FieldTypeText, FieldTypeRichText, FieldTypeNumber, FieldTypeDate,
+		FieldTypeBoolean, FieldTypeImage, FieldTypePage, FieldTypeGroup:
+		return true
+	}
+	return false
+}
+
+// Field returns the top-level field definition by name.
+func (t *ContentType) Field(name string) (*ContentField, bool) {
+	for i := range t.Fields {
+		if t.Fields[i].Name == name {
+			return &t.Fields[i], true
+		}
+	}
+	return nil, false
+}
+
+// ValidateFields checks values against the definition and returns them
+// normalized (page IDs as integers) with the references they contain.
+// richText converts rich text values (e.g. sanitizes HTML); nil keeps them as is.
+func (t *ContentType) ValidateFields(values PageFields, richText func(string) (string, error)) (PageFields, *FieldRefs, error) {
+	v := fieldValidator{richText: richText, refs: &FieldRefs{}}
+	res, err := v.object(t.Fields, values, "")
+	if err != nil {
+		return nil, nil, err
+	}
+	return res, v.refs, nil
+}
+
+// ParseFilter converts a query string value into a field value
+// comparable with stored values. Only scalar fields can be filtered.
+func (f *ContentField) ParseFilter(value string) (any, error) {
+	switch f.Type {
+	case FieldTypeText, FieldTypeDate, FieldTypeImage:
+		return value, nil
+	case FieldTypeNumber:
+		return strconv.ParseFloat(value, 64)
+	case FieldTypePage:
+		return strconv.ParseInt(value, 10, 64)
+	case FieldTypeBoolean:
+		return strconv.ParseBool(value)
+	}
+	return nil, errors.New("field " + f.Name + " cannot be filtered")
+}
+
+func (fields ContentFields) validate(prefix string) error {
+	seen := make(map[string]bool, len(fields))
+	for _, f := range fields {
+		path := prefix + f.Name
+		if !fieldNamePattern.MatchString(f.Name) {
+			return errors.New("invalid field name: " + path)
+		}
+		if seen[f.Name] {
+			return errors.New("duplicate field: " + path)
+		}
+		seen[f.Name] = true
+
+		if !IsValidFieldType(f.Type) {
+			return fmt.Errorf("field %s: invalid type %q", path, f.Type)
+		}
+		if f.MaxLength < 0 || f.MinItems < 0 || f.MaxItems < 0 {
+			return errors.New("field " + path + ": limits cannot be negative")
+		}
+		if f.Min != nil && f.Max != nil && *f.Min > *f.Max {
+			return errors.New("field " + path + ": min is greater than max")
+		}
+		if f.MaxItems > 0 && f.MinItems > f.MaxItems {
+			return errors.New("field " + path + ": min_items is greater than max_items")
+		}
+
+		if f.Type == FieldTypeGroup {
+			if len(f.Fields) == 0 {
+				return errors.New("group " + path + " must have fields")
+			}
+			if err := f.Fields.validate(path + "."); err != nil {
+				return err
+			}
+		} else if len(f.Fields) > 0 {
+			return errors.New("field " + path + ": only groups have nested fields")
+		}
+	}
+	return nil
+}
+
+type fieldValidator struct {
+	richText func(string) (string, error)
+	refs     *FieldRefs
+}
+
+func (v *fieldValidator) object(fields ContentFields, values map[string]any, prefix string) (PageFields, error) {
+	res := make(PageFields, len(values))
+	for name := range values {
+		found := false
+		for _, f := range fields {
+			if f.Name == name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, errors.New("unknown field: " + prefix + name)
+		}
+	}
+
+	for i := range fields {
+		f := &fields[i]
+		path := prefix + f.Name
+		value, ok := values[f.Name]
+		if !ok || value == nil {
+			if f.Required {
+				return nil, errors.New("field " + path + " is required")
+			}
+			continue
+		}
+
+		normalized, err := v.value(f, value, path)
+		if err != nil {
+			return nil, err
+		}
+		res[f.Name] = normalized
+	}
+	return res, nil
+}
+
+func (v *fieldValidator) value(f *ContentField, value any, path string) (any, error) {
+	switch f.Type {
+	case FieldTypeText, FieldTypeRichText:
+		s, ok := value.(string)
+		if !ok {
+			return nil, errors.New("field " + path + " must be a string")
+		}
+		if f.Required && s == "" {
+			return nil, errors.New("field " + path + " is required")
+		}
+		if f.MaxLength > 0 && utf8.RuneCountInString(s) > f.MaxLength {
+			return nil, fmt.Errorf("field %s is longer than %d characters", path, f.MaxLength)
+		}
+		if f.Type == FieldTypeRichText && v.richText != nil {
+			return v.richText(s)
+		}
+		return s, nil
+
+	case FieldTypeNumber:
+		n, ok := value.(float64)
+		if !ok {
+			return nil, errors.New("field " + path + " must be a number")
+		}
+		if f.Min != nil && n < *f.Min {
+			return nil, fmt.Errorf("field %s must be at least %v", path, *f.Min)
+		}
+		if f.Max != nil && n > *f.Max {
+			return nil, fmt.Errorf("field %s must be at most %v", path, *f.Max)
+		}
+		return n, nil
+
+	case FieldTypeDate:
+		s, ok := value.(string)
+		if !ok {
+			return nil, errors.New("field " + path + " must be a date")
+		}
+		if _, err := time.Parse(FieldDateLayout, s); err != nil {
+			return nil, errors.New("field " + path + " must be a date in YYYY-MM-DD format")
+		}
+		return s, nil
+
+	case FieldTypeBoolean:
+		b, ok := value.(bool)
+		if !ok {
+			return nil, errors.New("field " + path + " must be a boolean")
+		}
+		return b, nil
+
+	case FieldTypeImage:
+		s, ok := value.(string)
+		if !ok {
+			return nil, errors.New("field " + path + " must be an image UUID")
+		}
+		id, err := uuid.Parse(s)
+		if err != nil {
+			return nil, errors.New("field " + path + " must be an image UUID")
+		}
+		v.refs.Images = append(v.refs.Images, id.String())
+		return id.String(), nil
+
+	case FieldTypePage:
+		n, ok := value.(float64)
+		if !ok || n <= 0 || n != float64(int64(n)) {
+			return nil, errors.New("field " + path + " must be a page ID")
+		}
+		v.refs.Pages = append(v.refs.Pages, int64(n))
+		return int64(n), nil
+
+	case FieldTypeGroup:
+		items, ok := value.([]any)
+		if !ok {
+			return nil, errors.New("field " + path + " must be a list")
+		}
+		if f.Required && len(items) == 0 {
+			return nil, errors.New("field " + path + " is required")
+		}
+		if len(items) < f.MinItems {
+			return nil, fmt.Errorf("field %s must have at least %d items", path, f.MinItems)
+		}
+		if f.MaxItems > 0 && len(items) > f.MaxItems {
+			return nil, fmt.Errorf("field %s must have at most %d items", path, f.MaxItems)
+		}
+
+		res := make([]any, len(items))
+		for i, item := range items {
+			obj, ok := item.(map[string]any)
+			if !ok {
+				return nil, fmt.Errorf("field %s[%d] must be an object", path, i)
+			}
+			normalized, err := v.object(f.Fields, obj, fmt.Sprintf("%s[%d].", path, i))
+			if err != nil {
+				return nil, err
+			}
+			res[i] = map[string]any(normalized)
+		}
+		return res, nil
+	}
+
+	return nil, errors.New("field " + path + ": unsupported type")
+}
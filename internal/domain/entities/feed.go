@@ -0,0 +1,29 @@
+package entities
+
+import "time"
+
+// FeedItem is a published page listed in an RSS/Atom feed.
+// Path: Site-relative path of the page
+// Summary: ContentShort of the page
+// ContentHTML: Rendered content, included in full-content feeds
+// PublishedAt: Publication time, items are ordered by it
+type FeedItem struct {
+	PageID      int64
+	Title       string
+	Category    string
+	Path        string
+	Summary     string
+	ContentHTML string
+	PublishedAt time.Time
+	UpdatedAt   time.Time
+}
+
+type FeedItems []FeedItem
+
+// Feed is a list of the latest pages of a category; an empty Category
+// means all categories. Updated is the latest change of its items.
+type Feed struct {
+	Category string
+	Items    FeedItems
+	Updated  time.Time
+}
@@ -6,12 +6,18 @@ package entities
 //   - Name: Unique identifier for the file (typically a UUID)
 //   - Size: File size in bytes
 //   - Path: Storage location path (filesystem, S3, etc.)
+//   - ContentType: Type detected from the content on upload
+//   - DeclaredType: Type claimed by the client
+//   - ScanStatus: Malware scan status the content is registered with
 // JSON tags support serialization for API responses.
 
 type File struct {
-	Name string `json:"name"`
-	Size int64  `json:"size"`
-	Path string `json:"path"`
+	Name         string `json:"name"`
+	Size         int64  `json:"size"`
+	Path         string `json:"path"`
+	ContentType  string `json:"content_type"`
+	DeclaredType string `json:"declared_content_type"`
+	ScanStatus   string `json:"scan_status,omitempty"`
 }
 
 // Files is a collection type for multiple File entities.
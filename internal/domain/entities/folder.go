@@ -0,0 +1,109 @@
+package entities
+
+import (
+	"errors"
+	"path"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// MaxFolderNameLength limits a folder name in characters.
+const MaxFolderNameLength = 255
+
+// Policies for a name already taken in the target folder.
+const (
+	ConflictReject = "reject"
+	// ConflictOverwrite replaces the existing file (files only): an upload
+	// becomes a new version of it, a moved file sends it to the trash
+	ConflictOverwrite = "overwrite"
+	// ConflictRename stores under "name (N).ext"
+	ConflictRename = "rename"
+)
+
+// Kinds of folder entries.
+const (
+	FolderEntryFolder = "folder"
+	FolderEntryFile   = "file"
+)
+
+var (
+	ErrInvalidConflictPolicy = errors.New("invalid conflict policy")
+	ErrInvalidFolderName     = errors.New("invalid folder name")
+)
+
+// UploadFolder is a virtual folder of a user's uploads.
+// ParentID 0 means the folder is in the root.
+type UploadFolder struct {
+	ID        int64
+	UserID    int64
+	ParentID  int64
+	Name      string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type UploadFolders []UploadFolder
+
+// FolderEntry is a child of a folder: a subfolder (by ID) or an upload (by UUID).
+type FolderEntry struct {
+	Type        string
+	ID          int64
+	UUID        string
+	Name        string
+	Size        int64
+	ContentType string
+	UpdatedAt   time.Time
+}
+
+type FolderEntries []FolderEntry
+
+// NewUploadFolder creates a folder of the user under parentID.
+func NewUploadFolder(userID int64, parentID int64, name string, now time.Time) (*UploadFolder, error) {
+	name, err := ValidateFolderName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return &UploadFolder{
+		UserID:    userID,
+		ParentID:  parentID,
+		Name:      name,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}, nil
+}
+
+// ValidateFolderName returns the trimmed name; path separators
+// and the "." and ".." names are not allowed.
+func ValidateFolderName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || name == "." || name == ".." || strings.ContainsAny(name, "/\\\x00") ||
+		utf8.RuneCountInString(name) > MaxFolderNameLength {
+		return "", ErrInvalidFolderName
+	}
+	return name, nil
+}
+
+// IsValidConflictPolicy reports whether the policy is known.
+func IsValidConflictPolicy(policy string) bool {
+	switch policy {
+	case ConflictReject, ConflictOverwrite, ConflictRename:
+		return true
+	}
+	return false
+}
+
+// FreeName returns name, or the first "name (N).ext" not among the used names.
+func FreeName(name string, used []string) string {
+	taken := make(map[string]bool, len(used))
+	for _, n := range used {
+		taken[n] = true
+	}
+	return UniqueName(name, taken)
+}
+
+// NameStem is the name without its extension; every FreeName candidate starts with it.
+func NameStem(name string) string {
+	return strings.TrimSuffix(name, path.Ext(name))
+}
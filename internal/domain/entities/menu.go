@@ -0,0 +1,102 @@
+package entities
+
+import (
+	"errors"
+	"regexp"
+)
+
+var menuNameRe = regexp.MustCompile(`^[a-z0-9_-]{1,64}$`)
+
+// Menu is a named navigation menu built from a subtree of the site tree.
+// Fields:
+//   - ID: Database primary key
+//   - Name: Menu key used in URLs (main, footer, sidebar, ...)
+//   - RootID: Node whose children form the top level (0 for the whole tree)
+//   - Depth: Number of levels to include (0 for unlimited)
+type Menu struct {
+	ID     int64
+	Name   string
+	RootID int64
+	Depth  int
+}
+
+// Menus is a collection type for multiple Menu entities.
+type Menus []Menu
+
+// MenuItem is a rendered menu entry.
+// Active marks every item on the trail to the current page,
+// Current marks the item of the current page itself.
+type MenuItem struct {
+	NodeID  int64
+	Title   string
+	URL     string
+	Active  bool
+	Current bool
+	Items   []*MenuItem
+}
+
+// NewMenu creates a validated Menu instance.
+// Validation:
+//   - Name must match [a-z0-9_-], up to 64 characters
+//   - RootID and Depth cannot be negative
+func NewMenu(id int64, name string, rootID int64, depth int) (*Menu, error) {
+	if !menuNameRe.MatchString(name) {
+		return nil, errors.New("menu name must contain only a-z, 0-9, '_' or '-'")
+	}
+	if rootID < 0 || depth < 0 {
+		return nil, errors.New("root_id and depth cannot be negative")
+	}
+
+	return &Menu{
+		ID:     id,
+		Name:   name,
+		RootID: rootID,
+		Depth:  depth,
+	}, nil
+}
+
+// BuildMenuItems converts a node tree into menu items.
+// Unpublished nodes are skipped together with their subtrees.
+// MenuTitle is used as the title, falling back to the node name.
+func BuildMenuItems(tree []*NodeTree) []*MenuItem {
+	items := make([]*MenuItem, 0, len(tree))
+	for _, node := range tree {
+		if !node.Published {
+			continue
+		}
+		title := node.MenuTitle
+		if title == "" {
+			title = node.Name
+		}
+		items = append(items, &MenuItem{
+			NodeID: node.ID,
+			Title:  title,
+			URL:    node.Path,
+			Items:  BuildMenuItems(node.Items),
+		})
+	}
+	return items
+}
+
+// WithActiveTrail returns a copy of items with Active/Current set
+// along the path to the item whose URL equals currentPath.
+// The source items are not modified, so they can be shared from a cache.
+func WithActiveTrail(items []*MenuItem, currentPath string) []*MenuItem {
+	res, _ := markActiveTrail(items, currentPath)
+	return res
+}
+
+func markActiveTrail(items []*MenuItem, currentPath string) ([]*MenuItem, bool) {
+	res := make([]*MenuItem, len(items))
+	found := false
+	for i, item := range items {
+		copied := *item
+		var inTrail bool
+		copied.Items, inTrail = markActiveTrail(item.Items, currentPath)
+		copied.Current = currentPath != "" && item.URL == currentPath
+		copied.Active = copied.Current || inTrail
+		found = found || copied.Active
+		res[i] = &copied
+	}
+	return res, found
+}
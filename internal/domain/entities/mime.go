@@ -0,0 +1,108 @@
+package entities
+
+import (
+	"mime"
+	"strings"
+)
+
+// Generic types content sniffing returns when it recognizes nothing specific.
+const (
+	TypeOctetStream = "application/octet-stream"
+	TypeTextPlain   = "text/plain"
+)
+
+// riskyTypes are rendered by browsers as active content (scripts run in
+// the origin of the site); they are served only as attachments.
+var riskyTypes = map[string]bool{
+	"text/html":                true,
+	"application/xhtml+xml":    true,
+	"image/svg+xml":            true,
+	"text/xml":                 true,
+	"application/xml":          true,
+	"text/javascript":          true,
+	"application/javascript":   true,
+	"application/x-javascript": true,
+	"application/ecmascript":   true,
+	"text/xsl":                 true,
+}
+
+// MediaType returns the lower-cased type without parameters,
+// "" if the value is not a media type.
+func MediaType(contentType string) string {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil || !strings.Contains(mediaType, "/") {
+		return ""
+	}
+	return mediaType
+}
+
+// IsRiskyType reports whether browsers may run scripts from content of
+// the type; unknown values are risky.
+func IsRiskyType(contentType string) bool {
+	mediaType := MediaType(contentType)
+	return mediaType == "" || riskyTypes[mediaType] || strings.HasSuffix(mediaType, "+xml")
+}
+
+// ResolveContentType picks the type a file is stored and served with:
+// the detected one, or the declared one when detection found only a
+// generic type and the declared type is harmless (text/csv, JSON, office
+// documents). The result is only what the file is served with: type
+// policies must also be checked against the detected type.
+func ResolveContentType(declared, detected string) string {
+	switch MediaType(detected) {
+	case TypeOctetStream, TypeTextPlain:
+		if MediaType(declared) != "" && !IsRiskyType(declared) {
+			return declared
+		}
+	}
+	return detected
+}
+
+// TypePolicy limits types of stored files. Entries are media types or
+// "type/*" patterns; an empty Allow list allows every type not denied.
+type TypePolicy struct {
+	Allow []string
+	Deny  []string
+}
+
+// NewTypePolicy builds a policy from comma-separated lists.
+func NewTypePolicy(allow, deny string) TypePolicy {
+	return TypePolicy{
+		Allow: splitTypes(allow),
+		Deny:  splitTypes(deny),
+	}
+}
+
+// Allows reports whether files of the type may be stored.
+func (p TypePolicy) Allows(contentType string) bool {
+	mediaType := MediaType(contentType)
+	if mediaType == "" {
+		return false
+	}
+	if matchesType(p.Deny, mediaType) {
+		return false
+	}
+	return len(p.Allow) == 0 || matchesType(p.Allow, mediaType)
+}
+
+func matchesType(patterns []string, mediaType string) bool {
+	for _, pattern := range patterns {
+		if pattern == mediaType || pattern == "*/*" {
+			return true
+		}
+		if prefix, ok := strings.CutSuffix(pattern, "/*"); ok && strings.HasPrefix(mediaType, prefix+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+func splitTypes(value string) []string {
+	var types []string
+	for _, t := range strings.Split(value, ",") {
+		if t = strings.ToLower(strings.TrimSpace(t)); t != "" {
+			types = append(types, t)
+		}
+	}
+	return types
+}
@@ -0,0 +1,22 @@
+package entities
+
+import (
+	"strings"
+
+	"github.com/aube/auth/internal/domain/valueobjects"
+)
+
+// CheckName returns the name of a page or node normalized to a slug.
+// stored: Current name of an existing page or node, "" for a new one.
+// An unchanged stored name is accepted as is: names saved before slugs
+// were required stay editable without a forced rename.
+func CheckName(name, stored string) (string, error) {
+	if stored != "" && strings.TrimSpace(name) == stored {
+		return stored, nil
+	}
+	slug, err := valueobjects.NewSlug(name)
+	if err != nil {
+		return "", err
+	}
+	return slug.String(), nil
+}
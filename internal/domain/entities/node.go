@@ -0,0 +1,148 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Node represents a position of a page in the site tree.
+// Fields:
+//   - ID: Database primary key
+//   - PageID: Page rendered at this position
+//   - Name: URL segment of the node
+//   - MenuTitle: Title used in navigation
+//   - Path: Full URL path built from ancestor names
+//   - Parent: Parent node ID (0 for top level)
+//   - Sort: Position among siblings
+//   - Level: Depth in the tree (0 for top level)
+//   - Children: Number of direct children
+//   - Published: Visibility flag
+type Node struct {
+	ID        int64
+	PageID    int64
+	Name      string
+	MenuTitle string
+	Path      string
+	Parent    int64
+	Sort      int
+	Level     int
+	Children  int
+	Published bool
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// ErrInvalidNode is wrapped by validation errors of nodes.
+var ErrInvalidNode = errors.New("invalid node")
+
+// NodeSortLast places a new node after its siblings.
+const NodeSortLast = -1
+
+// Nodes is a collection type for multiple Node entities.
+type Nodes []Node
+
+// NodeTree is a node with its nested children.
+type NodeTree struct {
+	Node
+	Items []*NodeTree
+}
+
+// NodePage links an additional page to a node (node_page table).
+type NodePage struct {
+	ID     int64
+	NodeID int64
+	PageID int64
+	Sort   int
+	Pinned bool
+}
+
+// NodePages is a collection type for multiple NodePage entities.
+type NodePages []NodePage
+
+// NewNode creates a validated Node instance.
+// Validation:
+//   - Rejects empty name
+//   - Rejects names containing "/" (slugs are checked by CheckName)
+//   - Rejects a node being its own parent
+//   - Negative sort becomes NodeSortLast
+func NewNode(
+	id int64,
+	pageID int64,
+	name string,
+	menuTitle string,
+	parent int64,
+	sort int,
+	published bool,
+) (*Node, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, fmt.Errorf("%w: name cannot be empty", ErrInvalidNode)
+	}
+	if strings.Contains(name, "/") {
+		return nil, fmt.Errorf("%w: name cannot contain '/'", ErrInvalidNode)
+	}
+	if id > 0 && id == parent {
+		return nil, fmt.Errorf("%w: node cannot be its own parent", ErrInvalidNode)
+	}
+	if sort < 0 {
+		sort = NodeSortLast
+	}
+
+	return &Node{
+		ID:        id,
+		PageID:    pageID,
+		Name:      name,
+		MenuTitle: menuTitle,
+		Parent:    parent,
+		Sort:      sort,
+		Published: published,
+	}, nil
+}
+
+// BuildNodePath joins the parent path and the node name into a URL path.
+// Top level nodes get "/" + name.
+func BuildNodePath(parentPath, name string) string {
+	return strings.TrimSuffix(parentPath, "/") + "/" + name
+}
+
+// NormalizeNodePath brings an incoming URL path to the stored form:
+// leading slash, no trailing slash, no empty segments.
+func NormalizeNodePath(path string) string {
+	segments := strings.Split(path, "/")
+	parts := make([]string, 0, len(segments))
+	for _, s := range segments {
+		if s != "" {
+			parts = append(parts, s)
+		}
+	}
+	return "/" + strings.Join(parts, "/")
+}
+
+// BuildNodeTree nests a flat list of nodes under rootID.
+// nodes: Flat list, expected to be ordered by sort
+// rootID: Parent of the returned top level (0 for the whole site)
+// Returns: Top level nodes with their children attached
+func BuildNodeTree(nodes Nodes, rootID int64) []*NodeTree {
+	byParent := make(map[int64][]*NodeTree)
+	for i := range nodes {
+		item := &NodeTree{Node: nodes[i]}
+		byParent[item.Parent] = append(byParent[item.Parent], item)
+	}
+
+	var attach func(parent int64, seen map[int64]bool) []*NodeTree
+	attach = func(parent int64, seen map[int64]bool) []*NodeTree {
+		items := byParent[parent]
+		for _, item := range items {
+			if seen[item.ID] {
+				continue
+			}
+			seen[item.ID] = true
+			item.Items = attach(item.ID, seen)
+		}
+		return items
+	}
+
+	return attach(rootID, make(map[int64]bool))
+}
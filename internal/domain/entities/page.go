@@ -2,33 +2,84 @@ package entities
 
 import (
 	"errors"
+	"strings"
 	"time"
 )
 
+// Page statuses: draft pages are visible only to editors,
+// review marks a draft waiting for approval, published pages are public.
+const (
+	PageStatusDraft     = "draft"
+	PageStatusReview    = "review"
+	PageStatusPublished = "published"
+)
+
+// HomePageName is the page served at "/" when no node is bound to the root path.
+const HomePageName = "index"
+
+// PagePath returns the public path of a page outside the site tree:
+// "/" for the home page, "/<name>" for others.
+func PagePath(name string) string {
+	if name == HomePageName {
+		return "/"
+	}
+	return "/" + name
+}
+
+// Page content formats; rendered HTML is stored in ContentHTML.
+const (
+	ContentFormatHTML     = "html"
+	ContentFormatMarkdown = "markdown"
+)
+
 type Page struct {
-	ID           int64
-	Name         string
-	Meta         string
-	Title        string
-	Category     string
-	Template     string
-	H1           string
-	Content      string
-	ContentShort string
+	ID            int64
+	Name          string
+	Meta          PageMeta
+	Title         string
+	Category      string
+	Template      string
+	H1            string
+	Content       string
+	ContentShort  string
+	ContentFormat string
+	ContentHTML   string
+	Status        string
+	PublishAt     *time.Time
+	UnpublishAt   *time.Time
+	// ContentType is the name of the page content type, empty for untyped pages
+	ContentType string
+	Fields      PageFields
+	// Version expected by an update, 0 to overwrite any version
+	Version int
 }
 
 type PageWithTime struct {
-	ID           int64
-	Name         string
-	Meta         string
-	Title        string
-	Category     string
-	Template     string
-	H1           string
-	Content      string
-	ContentShort string
-	CreatedAt    time.Time
-	UpdatedAt    time.Time
+	ID            int64
+	Name          string
+	Meta          PageMeta
+	Title         string
+	Category      string
+	Template      string
+	H1            string
+	Content       string
+	ContentShort  string
+	ContentFormat string
+	ContentHTML   string
+	Status        string
+	PublishAt     *time.Time
+	UnpublishAt   *time.Time
+	PublishedAt   *time.Time
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	ContentType   string
+	Fields        PageFields
+	// Version is incremented on every save
+	Version int
+	// Locale of the localized fields; empty for the page in the default locale
+	Locale string
+	// Tags are filled by lists
+	Tags []string
 }
 
 type Pages []Page
@@ -37,7 +88,7 @@ type PagesWithTimes []PageWithTime
 func NewPage(
 	id int64,
 	name string,
-	meta string,
+	meta PageMeta,
 	title string,
 	category string,
 	template string,
@@ -46,27 +97,89 @@ func NewPage(
 	contentShort string,
 ) (*Page, error) {
 
-	if name == "" {
+	// Имя приводится к slug заранее, см. CheckName
+	if strings.TrimSpace(name) == "" {
 		return nil, errors.New("name cannot be empty")
 	}
+	if strings.Contains(name, "/") {
+		return nil, errors.New("name cannot contain '/'")
+	}
+	if err := meta.Validate(); err != nil {
+		return nil, err
+	}
 
 	return &Page{
-		ID:           id,
-		Name:         name,
-		Meta:         meta,
-		Title:        title,
-		Category:     category,
-		Template:     template,
-		H1:           h1,
-		Content:      content,
-		ContentShort: contentShort,
+		ID:            id,
+		Name:          strings.TrimSpace(name),
+		Meta:          meta,
+		Title:         title,
+		Category:      category,
+		Template:      template,
+		H1:            h1,
+		Content:       content,
+		ContentShort:  contentShort,
+		ContentFormat: ContentFormatHTML,
+		Status:        PageStatusDraft,
 	}, nil
 }
 
+// SetStatus changes the publication status.
+// status: draft, review or published
+// publishAt/unpublishAt: Optional schedule, unpublishAt must be after publishAt
+func (p *Page) SetStatus(status string, publishAt, unpublishAt *time.Time) error {
+	if !IsValidPageStatus(status) {
+		return errors.New("invalid page status: " + status)
+	}
+	if publishAt != nil && unpublishAt != nil && !unpublishAt.After(*publishAt) {
+		return errors.New("unpublish_at must be after publish_at")
+	}
+
+	p.Status = status
+	p.PublishAt = publishAt
+	p.UnpublishAt = unpublishAt
+	return nil
+}
+
+// IsPublished reports whether the page is visible to the public.
+func (p *PageWithTime) IsPublished() bool {
+	return p.Status == PageStatusPublished
+}
+
+// ToPage returns the editable part of a stored page.
+func (p *PageWithTime) ToPage() *Page {
+	return &Page{
+		ID:            p.ID,
+		Name:          p.Name,
+		Meta:          p.Meta,
+		Title:         p.Title,
+		Category:      p.Category,
+		Template:      p.Template,
+		H1:            p.H1,
+		Content:       p.Content,
+		ContentShort:  p.ContentShort,
+		ContentFormat: p.ContentFormat,
+		ContentHTML:   p.ContentHTML,
+		Status:        p.Status,
+		PublishAt:     p.PublishAt,
+		UnpublishAt:   p.UnpublishAt,
+		ContentType:   p.ContentType,
+		Fields:        p.Fields,
+		Version:       p.Version,
+	}
+}
+
+func IsValidPageStatus(status string) bool {
+	switch status {
+	case PageStatusDraft, PageStatusReview, PageStatusPublished:
+		return true
+	}
+	return false
+}
+
 func NewPageWithTime(
 	id int64,
 	name string,
-	meta string,
+	meta PageMeta,
 	title string,
 	category string,
 	template string,
@@ -82,16 +195,18 @@ func NewPageWithTime(
 	}
 
 	return &PageWithTime{
-		ID:           id,
-		Name:         name,
-		Meta:         meta,
-		Title:        title,
-		Category:     category,
-		Template:     template,
-		H1:           h1,
-		Content:      content,
-		ContentShort: contentShort,
-		CreatedAt:    createdAt,
-		UpdatedAt:    updatedAt,
+		ID:            id,
+		Name:          name,
+		Meta:          meta,
+		Title:         title,
+		Category:      category,
+		Template:      template,
+		H1:            h1,
+		Content:       content,
+		ContentShort:  contentShort,
+		ContentFormat: ContentFormatHTML,
+		Status:        PageStatusDraft,
+		CreatedAt:     createdAt,
+		UpdatedAt:     updatedAt,
 	}, nil
 }
@@ -0,0 +1,13 @@
+package entities
+
+import "time"
+
+// PageLock is an advisory edit lock: it tells other editors who is editing
+// the page but does not block saving. Locks expire unless renewed.
+type PageLock struct {
+	PageID     int64
+	UserID     int64
+	Username   string
+	AcquiredAt time.Time
+	ExpiresAt  time.Time
+}
@@ -0,0 +1,125 @@
+package entities
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+	"unicode/utf8"
+)
+
+// PageMeta is the SEO metadata of a page, stored as a JSON document.
+// Canonical and image URLs are absolute (http/https) or site-relative ("/...").
+type PageMeta struct {
+	Description string        `json:"description,omitempty"`
+	Keywords    string        `json:"keywords,omitempty"`
+	Canonical   string        `json:"canonical,omitempty"`
+	Robots      string        `json:"robots,omitempty"`
+	OpenGraph   OpenGraphMeta `json:"og"`
+	Twitter     TwitterMeta   `json:"twitter"`
+}
+
+// OpenGraphMeta holds og:* properties; empty fields fall back to the page values.
+type OpenGraphMeta struct {
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+	Image       string `json:"image,omitempty"`
+	Type        string `json:"type,omitempty"`
+}
+
+// TwitterMeta holds twitter:* card properties.
+type TwitterMeta struct {
+	Card        string `json:"card,omitempty"`
+	Site        string `json:"site,omitempty"`
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+	Image       string `json:"image,omitempty"`
+}
+
+const (
+	maxMetaDescription = 320
+	maxMetaTitle       = 256
+)
+
+var (
+	ogTypes      = map[string]bool{"website": true, "article": true, "profile": true, "book": true}
+	twitterCards = map[string]bool{"summary": true, "summary_large_image": true, "app": true, "player": true}
+	robotsTokens = map[string]bool{
+		"all": true, "none": true, "index": true, "noindex": true, "follow": true, "nofollow": true,
+		"noarchive": true, "nosnippet": true, "noimageindex": true, "notranslate": true,
+	}
+)
+
+// Validate checks lengths, URLs and enumerated values.
+func (m PageMeta) Validate() error {
+	for _, d := range []string{m.Description, m.OpenGraph.Description, m.Twitter.Description} {
+		if utf8.RuneCountInString(d) > maxMetaDescription {
+			return errors.New("meta description is too long")
+		}
+	}
+	for _, t := range []string{m.OpenGraph.Title, m.Twitter.Title} {
+		if utf8.RuneCountInString(t) > maxMetaTitle {
+			return errors.New("meta title is too long")
+		}
+	}
+
+	for _, u := range []string{m.Canonical, m.OpenGraph.Image, m.Twitter.Image} {
+		if !isValidMetaURL(u) {
+			return errors.New("invalid meta url: " + u)
+		}
+	}
+
+	if m.OpenGraph.Type != "" && !ogTypes[m.OpenGraph.Type] {
+		return errors.New("invalid og type: " + m.OpenGraph.Type)
+	}
+	if m.Twitter.Card != "" && !twitterCards[m.Twitter.Card] {
+		return errors.New("invalid twitter card: " + m.Twitter.Card)
+	}
+	if m.Twitter.Site != "" && !strings.HasPrefix(m.Twitter.Site, "@") {
+		return errors.New("twitter site must start with @")
+	}
+
+	if m.Robots != "" {
+		for _, token := range strings.Split(m.Robots, ",") {
+			if !robotsTokens[strings.TrimSpace(token)] {
+				return errors.New("invalid robots directive: " + token)
+			}
+		}
+	}
+
+	return nil
+}
+
+// NoIndex reports whether the page asks search engines not to index it.
+func (m PageMeta) NoIndex() bool {
+	for _, token := range strings.Split(m.Robots, ",") {
+		switch strings.TrimSpace(token) {
+		case "noindex", "none":
+			return true
+		}
+	}
+	return false
+}
+
+func isValidMetaURL(s string) bool {
+	if s == "" {
+		return true
+	}
+	if strings.HasPrefix(s, "/") && !strings.HasPrefix(s, "//") {
+		return true
+	}
+	u, err := url.Parse(s)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// CanonicalURL returns the canonical URL of a page served at path.
+// baseURL: Public site URL without trailing slash (e.g. https://example.com)
+// An explicit Canonical wins; a site-relative one is joined with baseURL.
+func (m PageMeta) CanonicalURL(baseURL string, path string) string {
+	switch {
+	case m.Canonical == "":
+		return baseURL + path
+	case strings.HasPrefix(m.Canonical, "/"):
+		return baseURL + m.Canonical
+	}
+	return m.Canonical
+}
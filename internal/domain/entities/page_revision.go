@@ -0,0 +1,45 @@
+package entities
+
+import "time"
+
+// PageRevision is an immutable snapshot of a page saved on every change.
+type PageRevision struct {
+	ID            int64
+	PageID        int64
+	Revision      int
+	Name          string
+	Meta          PageMeta
+	Title         string
+	Category      string
+	Template      string
+	H1            string
+	Content       string
+	ContentShort  string
+	ContentFormat string
+	ContentType   string
+	Fields        PageFields
+	AuthorID      int64
+	CreatedAt     time.Time
+}
+
+type PageRevisions []PageRevision
+
+// NewPageRevision snapshots the page being saved.
+// The revision number is assigned by the repository.
+func NewPageRevision(page *Page, authorID int64) *PageRevision {
+	return &PageRevision{
+		PageID:        page.ID,
+		Name:          page.Name,
+		Meta:          page.Meta,
+		Title:         page.Title,
+		Category:      page.Category,
+		Template:      page.Template,
+		H1:            page.H1,
+		Content:       page.Content,
+		ContentShort:  page.ContentShort,
+		ContentFormat: page.ContentFormat,
+		ContentType:   page.ContentType,
+		Fields:        page.Fields,
+		AuthorID:      authorID,
+	}
+}
@@ -0,0 +1,13 @@
+package entities
+
+// PageSearchResult is a page found by full-text search.
+// Rank: Relevance, higher is better
+// TitleHighlight/Snippet: HTML-escaped plain text, matched words wrapped in <mark></mark>
+type PageSearchResult struct {
+	PageWithTime
+	Rank           float64
+	TitleHighlight string
+	Snippet        string
+}
+
+type PageSearchResults []PageSearchResult
@@ -0,0 +1,111 @@
+package entities
+
+import (
+	"errors"
+	"time"
+
+	"github.com/aube/auth/internal/domain/valueobjects"
+	"github.com/aube/auth/internal/utils/locale"
+)
+
+// PageTranslation is a page in another locale. The page row itself holds
+// the content in the default locale of the site; a translation replaces
+// its localized fields, everything else (status, template, category,
+// position in the tree) is shared with the page.
+type PageTranslation struct {
+	ID            int64
+	PageID        int64
+	Locale        string
+	Name          string
+	Meta          PageMeta
+	Title         string
+	H1            string
+	Content       string
+	ContentShort  string
+	ContentFormat string
+	ContentHTML   string
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+type PageTranslations []PageTranslation
+
+// PageAlternate is a locale variant of a page, used for hreflang links.
+// Path: Site-relative path of the variant
+type PageAlternate struct {
+	Locale string `json:"locale"`
+	Path   string `json:"path"`
+}
+
+// NewPageTranslation creates a validated PageTranslation instance.
+// Validation:
+//   - Requires a page and a well-formed locale (normalized to lowercase)
+//   - Requires the name to be a slug
+//   - Validates meta
+func NewPageTranslation(
+	pageID int64,
+	loc string,
+	name string,
+	meta PageMeta,
+	title string,
+	h1 string,
+	content string,
+	contentShort string,
+) (*PageTranslation, error) {
+	if pageID <= 0 {
+		return nil, errors.New("page id is required")
+	}
+	loc = locale.Normalize(loc)
+	if !locale.IsValid(loc) {
+		return nil, errors.New("invalid locale: " + loc)
+	}
+	slug, err := valueobjects.NewSlug(name)
+	if err != nil {
+		return nil, err
+	}
+	if err := meta.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &PageTranslation{
+		PageID:        pageID,
+		Locale:        loc,
+		Name:          slug.String(),
+		Meta:          meta,
+		Title:         title,
+		H1:            h1,
+		Content:       content,
+		ContentShort:  contentShort,
+		ContentFormat: ContentFormatHTML,
+	}, nil
+}
+
+// Translate returns a copy of the page with the localized fields of t.
+func (p *PageWithTime) Translate(t *PageTranslation) *PageWithTime {
+	res := *p
+	res.Locale = t.Locale
+	res.Name = t.Name
+	res.Meta = t.Meta
+	res.Title = t.Title
+	res.H1 = t.H1
+	res.Content = t.Content
+	res.ContentShort = t.ContentShort
+	res.ContentFormat = t.ContentFormat
+	res.ContentHTML = t.ContentHTML
+	if t.UpdatedAt.After(res.UpdatedAt) {
+		res.UpdatedAt = t.UpdatedAt
+	}
+	return &res
+}
+
+// LocalePath prefixes a site path with a locale ("/en/about");
+// paths in the default locale have no prefix.
+func LocalePath(loc string, defaultLocale string, path string) string {
+	if loc == "" || loc == defaultLocale {
+		return path
+	}
+	if path == "/" {
+		return "/" + loc
+	}
+	return "/" + loc + path
+}
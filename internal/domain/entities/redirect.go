@@ -0,0 +1,130 @@
+package entities
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Redirect rule kinds.
+//   - exact: Source is a path matched as is (after normalization)
+//   - wildcard: "*" in Source matches any characters, each "*" in Target
+//     is replaced with the text matched by the corresponding "*" of Source
+//   - regex: Source is a regular expression matched against the whole path,
+//     Target may reference groups as $1 or ${name}
+const (
+	RedirectExact    = "exact"
+	RedirectWildcard = "wildcard"
+	RedirectRegex    = "regex"
+)
+
+// Redirect sends requests for Source to Target with an HTTP redirect.
+// Fields:
+//   - Code: 301, 302, 307 or 308
+//   - Auto: Created automatically on a page rename or node move;
+//     auto rules are removed when their source path is taken again
+type Redirect struct {
+	ID        int64
+	Source    string
+	Target    string
+	Kind      string
+	Code      int
+	Auto      bool
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type Redirects []Redirect
+
+// NewRedirect creates a validated Redirect instance.
+// Validation:
+//   - Source starts with "/" (exact and wildcard) or compiles (regex)
+//   - Target is a site path or an absolute http(s) URL
+//   - Exact rules cannot redirect a path to itself
+//   - Code defaults to 301
+func NewRedirect(id int64, source string, target string, kind string, code int, auto bool) (*Redirect, error) {
+	source = strings.TrimSpace(source)
+	target = strings.TrimSpace(target)
+	if kind == "" {
+		kind = RedirectExact
+	}
+	if code == 0 {
+		code = http.StatusMovedPermanently
+	}
+
+	switch code {
+	case http.StatusMovedPermanently, http.StatusFound, http.StatusTemporaryRedirect, http.StatusPermanentRedirect:
+	default:
+		return nil, errors.New("invalid redirect code")
+	}
+
+	if source == "" {
+		return nil, errors.New("source cannot be empty")
+	}
+	switch kind {
+	case RedirectExact:
+		if !strings.HasPrefix(source, "/") {
+			return nil, errors.New("source must start with /")
+		}
+		source = NormalizeNodePath(source)
+	case RedirectWildcard:
+		if !strings.HasPrefix(source, "/") {
+			return nil, errors.New("source must start with /")
+		}
+		if !strings.Contains(source, "*") {
+			return nil, errors.New("wildcard source must contain *")
+		}
+	case RedirectRegex:
+	default:
+		return nil, errors.New("invalid redirect kind: " + kind)
+	}
+
+	if !isValidRedirectTarget(target) {
+		return nil, errors.New("invalid redirect target: " + target)
+	}
+
+	r := &Redirect{
+		ID:     id,
+		Source: source,
+		Target: target,
+		Kind:   kind,
+		Code:   code,
+		Auto:   auto,
+	}
+	if _, err := r.Pattern(); err != nil {
+		return nil, errors.New("invalid redirect source: " + err.Error())
+	}
+	if kind == RedirectExact && r.IsInternal() && NormalizeNodePath(target) == source {
+		return nil, errors.New("redirect target equals source")
+	}
+
+	return r, nil
+}
+
+// Pattern returns the compiled source of wildcard and regex rules
+// (nil for exact ones), anchored to match the whole path.
+func (r *Redirect) Pattern() (*regexp.Regexp, error) {
+	switch r.Kind {
+	case RedirectWildcard:
+		return regexp.Compile("^" + strings.ReplaceAll(regexp.QuoteMeta(r.Source), `\*`, "(.*)") + "$")
+	case RedirectRegex:
+		return regexp.Compile("^(?:" + r.Source + ")$")
+	}
+	return nil, nil
+}
+
+// IsInternal reports whether Target is a path of this site.
+func (r *Redirect) IsInternal() bool {
+	return strings.HasPrefix(r.Target, "/") && !strings.HasPrefix(r.Target, "//")
+}
+
+func isValidRedirectTarget(s string) bool {
+	if strings.HasPrefix(s, "/") {
+		return !strings.HasPrefix(s, "//")
+	}
+	u, err := url.Parse(s)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
@@ -0,0 +1,26 @@
+package entities
+
+// Malware scan statuses of uploads; an empty status means the file
+// was stored while scanning was disabled.
+const (
+	ScanStatusPending  = "pending"
+	ScanStatusClean    = "clean"
+	ScanStatusInfected = "infected"
+	ScanStatusError    = "error"
+)
+
+// ScanResult is the verdict of a scanner on a file.
+// Detail is the name of the found malware for infected files.
+type ScanResult struct {
+	Status string
+	Detail string
+}
+
+// IsValidScanStatus reports whether the status is a known scan status.
+func IsValidScanStatus(status string) bool {
+	switch status {
+	case ScanStatusPending, ScanStatusClean, ScanStatusInfected, ScanStatusError:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,96 @@
+package entities
+
+import (
+	"errors"
+	"time"
+
+	"github.com/aube/auth/internal/domain/valueobjects"
+)
+
+// How a shared file is served by the browser.
+const (
+	ShareDispositionAttachment = "attachment"
+	ShareDispositionInline     = "inline"
+)
+
+// UploadShare is a public link to an upload.
+// Password is nil for links without a password, ExpiresAt for links
+// without expiry, MaxDownloads is 0 for unlimited downloads.
+// Upload holds the UUID, name, content type and size of the shared file.
+type UploadShare struct {
+	ID           int64
+	Token        string
+	UserID       int64
+	Upload       *Upload
+	Password     *valueobjects.Password
+	ExpiresAt    *time.Time
+	MaxDownloads int
+	Downloads    int
+	Disposition  string
+	CreatedAt    time.Time
+}
+
+type UploadShares []UploadShare
+
+// NewUploadShare creates a share link of the upload; an empty
+// disposition means attachment, an empty password none.
+func NewUploadShare(upload *Upload, token string, password string, expiresAt *time.Time, maxDownloads int, disposition string, now time.Time) (*UploadShare, error) {
+	if disposition == "" {
+		disposition = ShareDispositionAttachment
+	}
+	if !IsValidShareDisposition(disposition) {
+		return nil, errors.New("invalid disposition: " + disposition)
+	}
+	if maxDownloads < 0 {
+		return nil, errors.New("max downloads cannot be negative")
+	}
+	if expiresAt != nil && !expiresAt.After(now) {
+		return nil, errors.New("expiry must be in the future")
+	}
+
+	share := &UploadShare{
+		Token:        token,
+		UserID:       upload.UserID,
+		Upload:       upload,
+		ExpiresAt:    expiresAt,
+		MaxDownloads: maxDownloads,
+		Disposition:  disposition,
+		CreatedAt:    now,
+	}
+	if password != "" {
+		pwd, err := valueobjects.NewPassword(password)
+		if err != nil {
+			return nil, err
+		}
+		if err := pwd.Hash(); err != nil {
+			return nil, err
+		}
+		share.Password = pwd
+	}
+
+	return share, nil
+}
+
+func IsValidShareDisposition(disposition string) bool {
+	return disposition == ShareDispositionAttachment || disposition == ShareDispositionInline
+}
+
+// Expired reports whether the link expired at now.
+func (s *UploadShare) Expired(now time.Time) bool {
+	return s.ExpiresAt != nil && !now.Before(*s.ExpiresAt)
+}
+
+// Exhausted reports whether all allowed downloads are used.
+func (s *UploadShare) Exhausted() bool {
+	return s.MaxDownloads > 0 && s.Downloads >= s.MaxDownloads
+}
+
+func (s *UploadShare) HasPassword() bool {
+	return s.Password != nil
+}
+
+// PasswordMatches checks the password of a protected link;
+// links without a password accept any.
+func (s *UploadShare) PasswordMatches(password string) bool {
+	return s.Password == nil || s.Password.Matches(password)
+}
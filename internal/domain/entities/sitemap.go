@@ -0,0 +1,16 @@
+package entities
+
+import "time"
+
+// SitemapEntry is a public page URL listed in sitemap.xml.
+// Path: Site-relative path of the page
+// LastMod: Last modification of the page
+// Alternates: All locale variants of the page, including this one;
+// empty when the page has no translations
+type SitemapEntry struct {
+	Path       string
+	LastMod    time.Time
+	Alternates []PageAlternate
+}
+
+type SitemapEntries []SitemapEntry
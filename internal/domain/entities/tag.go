@@ -0,0 +1,115 @@
+package entities
+
+import (
+	"errors"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// Kinds of tagged items. File tags belong to the owner of the files,
+// page tags are shared by all editors.
+const (
+	TagKindPage   = "page"
+	TagKindUpload = "upload"
+	TagKindImage  = "image"
+)
+
+// MaxTagLength is the maximum tag length in characters.
+const MaxTagLength = 64
+
+var ErrInvalidTag = errors.New("invalid tag")
+
+// Tag is a label of a namespace: UserID is the owner of file tags, 0 for page tags.
+// Count is the number of items tagged with it.
+type Tag struct {
+	ID     int64
+	UserID int64
+	Name   string
+	Count  int
+}
+
+type Tags []Tag
+
+// TaggedItem references a page by ID or an upload or image by UUID.
+type TaggedItem struct {
+	Kind string
+	ID   int64
+	UUID string
+}
+
+// NewTaggedItemRef validates a reference to a tagged item.
+func NewTaggedItemRef(kind string, id int64, uuidStr string) (*TaggedItem, error) {
+	switch kind {
+	case TagKindPage:
+		if id <= 0 {
+			return nil, errors.New("page ID is required")
+		}
+		return &TaggedItem{Kind: kind, ID: id}, nil
+	case TagKindUpload, TagKindImage:
+		parsed, err := uuid.Parse(uuidStr)
+		if err != nil {
+			return nil, errors.New("invalid file UUID")
+		}
+		return &TaggedItem{Kind: kind, UUID: parsed.String()}, nil
+	}
+	return nil, errors.New("invalid tag kind: " + kind)
+}
+
+func IsValidTagKind(kind string) bool {
+	return kind == TagKindPage || kind == TagKindUpload || kind == TagKindImage
+}
+
+// TagOwner returns the namespace of tags of a kind for the user.
+func TagOwner(kind string, userID int64) int64 {
+	if kind == TagKindPage {
+		return 0
+	}
+	return userID
+}
+
+// NormalizeTag lowercases a tag and joins its words with "-":
+// " Summer  Sale " becomes "summer-sale". Letters, digits, "-", "_" and "." are allowed.
+func NormalizeTag(name string) (string, error) {
+	tag := strings.ToLower(strings.Join(strings.Fields(name), "-"))
+	if tag == "" || utf8.RuneCountInString(tag) > MaxTagLength {
+		return "", ErrInvalidTag
+	}
+	for _, r := range tag {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '-' && r != '_' && r != '.' {
+			return "", ErrInvalidTag
+		}
+	}
+	return tag, nil
+}
+
+// NormalizeTags normalizes tags and drops duplicates keeping the order.
+func NormalizeTags(names []string) ([]string, error) {
+	tags := make([]string, 0, len(names))
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		tag, err := NormalizeTag(name)
+		if err != nil {
+			return nil, err
+		}
+		if !seen[tag] {
+			seen[tag] = true
+			tags = append(tags, tag)
+		}
+	}
+	return tags, nil
+}
+
+// Facet is the number of items with a value of a field.
+type Facet struct {
+	Value string
+	Count int
+}
+
+// Facets counts items of a filtered list per tag and per category.
+type Facets struct {
+	Tags       []Facet
+	Categories []Facet
+}
@@ -0,0 +1,58 @@
+package entities
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Kinds of deleted items kept in the trash.
+const (
+	TrashTypePage   = "page"
+	TrashTypeUpload = "upload"
+	TrashTypeImage  = "image"
+)
+
+// TrashItem is a soft-deleted page, upload or image.
+// Pages are identified by ID, uploads and images by UUID;
+// UserID and Size are empty for pages, Title for files.
+type TrashItem struct {
+	Type      string
+	ID        int64
+	UUID      string
+	Name      string
+	Title     string
+	UserID    int64
+	Size      int64
+	DeletedAt time.Time
+}
+
+type TrashItems []TrashItem
+
+// NewTrashItemRef validates a reference to a deleted item.
+func NewTrashItemRef(itemType string, id int64, uuidStr string) (*TrashItem, error) {
+	switch itemType {
+	case TrashTypePage:
+		if id <= 0 {
+			return nil, errors.New("page ID is required")
+		}
+		return &TrashItem{Type: itemType, ID: id}, nil
+	case TrashTypeUpload, TrashTypeImage:
+		parsed, err := uuid.Parse(uuidStr)
+		if err != nil {
+			return nil, errors.New("invalid file UUID")
+		}
+		return &TrashItem{Type: itemType, UUID: parsed.String()}, nil
+	}
+	return nil, errors.New("invalid trash item type: " + itemType)
+}
+
+func IsValidTrashType(itemType string) bool {
+	return itemType == TrashTypePage || itemType == TrashTypeUpload || itemType == TrashTypeImage
+}
+
+// HasBlob reports whether purging the item must remove a stored file.
+func (i *TrashItem) HasBlob() bool {
+	return i.Type == TrashTypeUpload || i.Type == TrashTypeImage
+}
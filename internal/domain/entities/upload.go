@@ -7,27 +7,39 @@ import "time"
 // Fields:
 //   - ID: Database primary key
 //   - UserID: Owner of the upload
+//   - FolderID: Folder of the upload (0 for the root)
 //   - UUID: Server-side file identifier
 //   - Name: Original client filename
 //   - Category: User-defined classification
 //   - Size: File size in bytes
-//   - ContentType: MIME type
+//   - ContentType: MIME type detected from the content, served on download
+//   - DeclaredType: MIME type claimed by the client
+//   - Version: Number of the current version of the content
 //   - Path: Physical storage location
 //   - UploadedAt: Creation timestamp
 //   - Description: User-provided description
+//   - Tags: Tags of the owner's namespace (filled by lists)
+//   - ScanStatus: Malware scan status of the content ("" if not scanned)
+//   - ScanDetail: Found malware or scanner error
 //
 // JSON tags support serialization for API responses.
 type Upload struct {
-	ID          int64     `json:"id"`
-	UserID      int64     `json:"user_id"`
-	UUID        string    `json:"uuid"`
-	Name        string    `json:"name"`
-	Category    string    `json:"category"`
-	Size        int64     `json:"size"`
-	ContentType string    `json:"content_type"`
-	Path        string    `json:"path"`
-	UploadedAt  time.Time `json:"uploaded_at"`
-	Description string    `json:"description"`
+	ID           int64     `json:"id"`
+	UserID       int64     `json:"user_id"`
+	FolderID     int64     `json:"folder_id"`
+	UUID         string    `json:"uuid"`
+	Name         string    `json:"name"`
+	Category     string    `json:"category"`
+	Size         int64     `json:"size"`
+	ContentType  string    `json:"content_type"`
+	DeclaredType string    `json:"declared_content_type"`
+	Version      int       `json:"version"`
+	Path         string    `json:"path"`
+	UploadedAt   time.Time `json:"uploaded_at"`
+	Description  string    `json:"description"`
+	Tags         []string  `json:"tags"`
+	ScanStatus   string    `json:"scan_status"`
+	ScanDetail   string    `json:"scan_detail"`
 }
 
 // Uploads is a collection type for multiple Upload entities.
@@ -46,15 +58,18 @@ type Uploads []Upload
 // Note: Distinguishes between server UUID (File.Name) and original name
 func NewUpload(file *File, id int64, userID int64, name string, category string, contentType string, description string, createdAt time.Time) *Upload {
 	return &Upload{
-		ID:          id,
-		UserID:      userID,
-		UUID:        file.Name, // is UUID on server filesysten
-		Name:        name,      // is original name in database
-		Category:    category,
-		Size:        file.Size,
-		ContentType: contentType,
-		Path:        file.Path,
-		UploadedAt:  createdAt,
-		Description: description,
+		ID:           id,
+		UserID:       userID,
+		UUID:         file.Name, // is UUID on server filesysten
+		Name:         name,      // is original name in database
+		Category:     category,
+		Size:         file.Size,
+		ContentType:  contentType,
+		DeclaredType: file.DeclaredType,
+		ScanStatus:   file.ScanStatus,
+		Version:      1,
+		Path:         file.Path,
+		UploadedAt:   createdAt,
+		Description:  description,
 	}
 }
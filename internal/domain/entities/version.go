@@ -0,0 +1,40 @@
+package entities
+
+import "time"
+
+// Kinds of files that keep versions.
+const (
+	VersionKindUpload = "upload"
+	VersionKindImage  = "image"
+)
+
+// FileVersion is a stored content of an upload or image. The current
+// version is the file record itself, older ones are kept until pruned.
+// ScanStatus is empty for images and unscanned uploads.
+type FileVersion struct {
+	FileID      int64
+	Version     int
+	UUID        string
+	Size        int64
+	ContentType string
+	ScanStatus  string
+	Current     bool
+	CreatedAt   time.Time
+}
+
+type FileVersions []FileVersion
+
+// IsValidVersionKind reports whether files of the kind keep versions.
+func IsValidVersionKind(kind string) bool {
+	return kind == VersionKindUpload || kind == VersionKindImage
+}
+
+// Find returns the version with the number, nil when there is none.
+func (v FileVersions) Find(version int) *FileVersion {
+	for i := range v {
+		if v[i].Version == version {
+			return &v[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+package entities_test
+
+import (
+	"testing"
+
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArchiveEntryName(t *testing.T) {
+	valid := map[string]string{
+		"report.pdf":        "report.pdf",
+		"docs/2024/a b.txt": "a b.txt",
+		"./x.txt":           "x.txt",
+	}
+	for name, want := range valid {
+		got, ok := entities.ArchiveEntryName(name)
+		assert.True(t, ok, name)
+		assert.Equal(t, want, got)
+	}
+
+	for _, name := range []string{"", "/etc/passwd", "../x.txt", "a/../../x.txt", "a\\..\\x.txt", "C:/x.txt", "dir/"} {
+		_, ok := entities.ArchiveEntryName(name)
+		assert.False(t, ok, name)
+	}
+}
+
+func TestUniqueName(t *testing.T) {
+	taken := map[string]bool{}
+
+	assert.Equal(t, "a.txt", entities.UniqueName("a.txt", taken))
+	assert.Equal(t, "a (1).txt", entities.UniqueName("a.txt", taken))
+	assert.Equal(t, "a (2).txt", entities.UniqueName("a.txt", taken))
+	assert.Equal(t, "README", entities.UniqueName("README", taken))
+	assert.Equal(t, "README (1)", entities.UniqueName("README", taken))
+}
@@ -0,0 +1,145 @@
+package entities_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func productType(t *testing.T) *entities.ContentType {
+	minPrice := 0.0
+	contentType, err := entities.NewContentType(0, "product", "Product", entities.ContentFields{
+		{Name: "sku", Type: entities.FieldTypeText, Required: true, MaxLength: 8},
+		{Name: "description", Type: entities.FieldTypeRichText},
+		{Name: "price", Type: entities.FieldTypeNumber, Min: &minPrice},
+		{Name: "released", Type: entities.FieldTypeDate},
+		{Name: "in_stock", Type: entities.FieldTypeBoolean},
+		{Name: "photo", Type: entities.FieldTypeImage},
+		{Name: "related", Type: entities.FieldTypePage},
+		{Name: "specs", Type: entities.FieldTypeGroup, MaxItems: 2, Fields: entities.ContentFields{
+			{Name: "key", Type: entities.FieldTypeText, Required: true},
+			{Name: "value", Type: entities.FieldTypeText},
+		}},
+	})
+	require.NoError(t, err)
+	return contentType
+}
+
+// fields декодирует значения так же, как они приходят в запросе
+func fields(t *testing.T, src string) entities.PageFields {
+	var values entities.PageFields
+	require.NoError(t, json.Unmarshal([]byte(src), &values))
+	return values
+}
+
+func TestNewContentType(t *testing.T) {
+	text := entities.ContentField{Name: "title", Type: entities.FieldTypeText}
+	tests := []struct {
+		name    string
+		typName string
+		fields  entities.ContentFields
+		wantErr bool
+	}{
+		{"valid", "news", entities.ContentFields{text}, false},
+		{"empty name", "", entities.ContentFields{text}, true},
+		{"no fields", "news", nil, true},
+		{"bad field name", "news", entities.ContentFields{{Name: "Title", Type: entities.FieldTypeText}}, true},
+		{"duplicate field", "news", entities.ContentFields{text, text}, true},
+		{"unknown type", "news", entities.ContentFields{{Name: "title", Type: "color"}}, true},
+		{"empty group", "news", entities.ContentFields{{Name: "items", Type: entities.FieldTypeGroup}}, true},
+		{"nested fields outside group", "news", entities.ContentFields{{Name: "title", Type: entities.FieldTypeText, Fields: entities.ContentFields{text}}}, true},
+		{"bad nested field", "news", entities.ContentFields{{Name: "items", Type: entities.FieldTypeGroup, Fields: entities.ContentFields{{Name: "x", Type: "?"}}}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			contentType, err := entities.NewContentType(0, tt.typName, "", tt.fields)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.typName, contentType.Title)
+		})
+	}
+}
+
+func TestContentType_ValidateFields(t *testing.T) {
+	contentType := productType(t)
+
+	values, refs, err := contentType.ValidateFields(fields(t, `{
+		"sku": "A-1",
+		"description": "<p>Hi</p><script>x</script>",
+		"price": 10.5,
+		"released": "2025-05-01",
+		"in_stock": true,
+		"photo": "0B6F5A3E-8D7C-4E1A-9F2B-3C4D5E6F7A8B",
+		"related": 7,
+		"specs": [{"key": "color", "value": "red"}]
+	}`), func(s string) (string, error) {
+		return strings.ReplaceAll(s, "<script>x</script>", ""), nil
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "<p>Hi</p>", values["description"])
+	assert.Equal(t, "0b6f5a3e-8d7c-4e1a-9f2b-3c4d5e6f7a8b", values["photo"])
+	assert.Equal(t, int64(7), values["related"])
+	assert.Equal(t, []string{"0b6f5a3e-8d7c-4e1a-9f2b-3c4d5e6f7a8b"}, refs.Images)
+	assert.Equal(t, []int64{7}, refs.Pages)
+}
+
+func TestContentType_ValidateFields_Errors(t *testing.T) {
+	contentType := productType(t)
+
+	tests := []struct {
+		name   string
+		values string
+		errMsg string
+	}{
+		{"missing required", `{}`, "sku is required"},
+		{"unknown field", `{"sku": "A", "color": "red"}`, "unknown field: color"},
+		{"too long", `{"sku": "ABCDEFGHI"}`, "longer than 8"},
+		{"not a number", `{"sku": "A", "price": "10"}`, "price must be a number"},
+		{"below min", `{"sku": "A", "price": -1}`, "at least 0"},
+		{"bad date", `{"sku": "A", "released": "01.05.2025"}`, "YYYY-MM-DD"},
+		{"not a boolean", `{"sku": "A", "in_stock": "yes"}`, "boolean"},
+		{"bad image", `{"sku": "A", "photo": "cover.jpg"}`, "image UUID"},
+		{"bad page", `{"sku": "A", "related": 1.5}`, "page ID"},
+		{"not a list", `{"sku": "A", "specs": {"key": "a"}}`, "must be a list"},
+		{"too many items", `{"sku": "A", "specs": [{"key": "a"}, {"key": "b"}, {"key": "c"}]}`, "at most 2"},
+		{"nested required", `{"sku": "A", "specs": [{"value": "a"}]}`, "specs[0].key is required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := contentType.ValidateFields(fields(t, tt.values), nil)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+		})
+	}
+}
+
+func TestContentField_ParseFilter(t *testing.T) {
+	contentType := productType(t)
+
+	price, _ := contentType.Field("price")
+	v, err := price.ParseFilter("10.5")
+	require.NoError(t, err)
+	assert.Equal(t, 10.5, v)
+
+	inStock, _ := contentType.Field("in_stock")
+	v, err = inStock.ParseFilter("true")
+	require.NoError(t, err)
+	assert.Equal(t, true, v)
+
+	_, err = price.ParseFilter("cheap")
+	assert.Error(t, err)
+
+	specs, _ := contentType.Field("specs")
+	_, err = specs.ParseFilter("a")
+	assert.Error(t, err)
+}
@@ -0,0 +1,37 @@
+package entities_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewUploadFolder(t *testing.T) {
+	folder, err := entities.NewUploadFolder(1, 3, "  Отчёты 2024 ", time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, "Отчёты 2024", folder.Name)
+	assert.Equal(t, int64(3), folder.ParentID)
+
+	for _, name := range []string{"", "  ", ".", "..", "a/b", "a\\b", strings.Repeat("я", entities.MaxFolderNameLength+1)} {
+		_, err := entities.NewUploadFolder(1, 0, name, time.Now())
+		assert.ErrorIs(t, err, entities.ErrInvalidFolderName, name)
+	}
+}
+
+func TestFreeName(t *testing.T) {
+	assert.Equal(t, "a.pdf", entities.FreeName("a.pdf", nil))
+	assert.Equal(t, "a (2).pdf", entities.FreeName("a.pdf", []string{"a.pdf", "a (1).pdf"}))
+	assert.Equal(t, "docs (1)", entities.FreeName("docs", []string{"docs", "docs.old"}))
+	assert.Equal(t, "report", entities.NameStem("report.tar"))
+}
+
+func TestIsValidConflictPolicy(t *testing.T) {
+	assert.True(t, entities.IsValidConflictPolicy(entities.ConflictReject))
+	assert.True(t, entities.IsValidConflictPolicy(entities.ConflictOverwrite))
+	assert.True(t, entities.IsValidConflictPolicy(entities.ConflictRename))
+	assert.False(t, entities.IsValidConflictPolicy(""))
+}
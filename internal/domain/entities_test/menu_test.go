@@ -0,0 +1,58 @@
+package entities_test
+
+import (
+	"testing"
+
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewMenu(t *testing.T) {
+	_, err := entities.NewMenu(0, "main", 0, 2)
+	assert.NoError(t, err)
+
+	_, err = entities.NewMenu(0, "Main Menu", 0, 2)
+	assert.Error(t, err)
+
+	_, err = entities.NewMenu(0, "footer", -1, 0)
+	assert.Error(t, err)
+}
+
+func TestBuildMenuItems_SkipsUnpublished(t *testing.T) {
+	tree := entities.BuildNodeTree(entities.Nodes{
+		{ID: 1, Name: "about", MenuTitle: "About us", Path: "/about", Published: true},
+		{ID: 2, Name: "draft", Path: "/draft", Published: false},
+		{ID: 3, Name: "team", Path: "/about/team", Parent: 1, Published: true},
+		{ID: 4, Name: "hidden", Path: "/draft/hidden", Parent: 2, Published: true},
+	}, 0)
+
+	items := entities.BuildMenuItems(tree)
+
+	require.Len(t, items, 1)
+	assert.Equal(t, "About us", items[0].Title)
+	assert.Equal(t, "/about", items[0].URL)
+	require.Len(t, items[0].Items, 1)
+	assert.Equal(t, "team", items[0].Items[0].Title)
+}
+
+func TestWithActiveTrail(t *testing.T) {
+	items := []*entities.MenuItem{
+		{NodeID: 1, URL: "/about", Items: []*entities.MenuItem{
+			{NodeID: 3, URL: "/about/team"},
+		}},
+		{NodeID: 2, URL: "/news"},
+	}
+
+	marked := entities.WithActiveTrail(items, "/about/team")
+
+	assert.True(t, marked[0].Active)
+	assert.False(t, marked[0].Current)
+	assert.True(t, marked[0].Items[0].Active)
+	assert.True(t, marked[0].Items[0].Current)
+	assert.False(t, marked[1].Active)
+
+	// Исходные элементы не изменяются
+	assert.False(t, items[0].Active)
+	assert.False(t, items[0].Items[0].Current)
+}
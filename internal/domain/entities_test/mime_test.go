@@ -0,0 +1,43 @@
+package entities_test
+
+import (
+	"testing"
+
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsRiskyType(t *testing.T) {
+	assert.True(t, entities.IsRiskyType("text/html; charset=utf-8"))
+	assert.True(t, entities.IsRiskyType("IMAGE/SVG+XML"))
+	assert.True(t, entities.IsRiskyType("application/rss+xml"))
+	// Непонятный тип браузер может угадать как HTML
+	assert.True(t, entities.IsRiskyType(""))
+	assert.True(t, entities.IsRiskyType("html"))
+	assert.False(t, entities.IsRiskyType("image/png"))
+	assert.False(t, entities.IsRiskyType("text/plain; charset=utf-8"))
+}
+
+func TestResolveContentType(t *testing.T) {
+	// Содержимое важнее заявленного типа
+	assert.Equal(t, "text/html; charset=utf-8", entities.ResolveContentType("image/png", "text/html; charset=utf-8"))
+	// Уточнение общего типа, но не на опасный
+	assert.Equal(t, "text/csv", entities.ResolveContentType("text/csv", "text/plain; charset=utf-8"))
+	assert.Equal(t, "application/octet-stream", entities.ResolveContentType("text/html", "application/octet-stream"))
+	assert.Equal(t, "application/octet-stream", entities.ResolveContentType("", "application/octet-stream"))
+}
+
+func TestTypePolicy_Allows(t *testing.T) {
+	assert.True(t, entities.TypePolicy{}.Allows("text/html"))
+	assert.False(t, entities.TypePolicy{}.Allows("not a type"))
+
+	policy := entities.NewTypePolicy(" image/* , application/pdf", "image/svg+xml")
+	assert.True(t, policy.Allows("image/png"))
+	assert.True(t, policy.Allows("application/pdf"))
+	assert.False(t, policy.Allows("image/svg+xml"))
+	assert.False(t, policy.Allows("text/plain; charset=utf-8"))
+
+	policy = entities.NewTypePolicy("", "text/*")
+	assert.False(t, policy.Allows("text/html"))
+	assert.True(t, policy.Allows("application/zip"))
+}
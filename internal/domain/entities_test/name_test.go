@@ -0,0 +1,26 @@
+package entities_test
+
+import (
+	"testing"
+
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckName(t *testing.T) {
+	name, err := entities.CheckName(" About ", "")
+	require.NoError(t, err)
+	assert.Equal(t, "about", name)
+
+	// Старое имя, сохранённое до появления slug, остаётся как есть
+	name, err = entities.CheckName("о компании.html", "о компании.html")
+	require.NoError(t, err)
+	assert.Equal(t, "о компании.html", name)
+
+	// Но новое имя должно быть slug
+	_, err = entities.CheckName("о компании", "о компании.html")
+	assert.Error(t, err)
+	_, err = entities.CheckName("", "")
+	assert.Error(t, err)
+}
@@ -0,0 +1,74 @@
+package entities_test
+
+import (
+	"testing"
+
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewNode(t *testing.T) {
+	tests := []struct {
+		name    string
+		id      int64
+		node    string
+		parent  int64
+		wantErr bool
+	}{
+		{"valid node", 0, "about", 0, false},
+		{"trimmed name", 0, " news ", 1, false},
+		{"empty name", 0, " ", 0, true},
+		{"slash in name", 0, "a/b", 0, true},
+		{"own parent", 5, "loop", 5, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node, err := entities.NewNode(tt.id, 1, tt.node, "", tt.parent, 0, true)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, entities.ErrInvalidNode)
+				assert.Nil(t, node)
+				return
+			}
+			require.NoError(t, err)
+			assert.NotContains(t, node.Name, " ")
+			assert.Equal(t, tt.parent, node.Parent)
+		})
+	}
+}
+
+func TestBuildNodePath(t *testing.T) {
+	assert.Equal(t, "/about", entities.BuildNodePath("", "about"))
+	assert.Equal(t, "/about/team", entities.BuildNodePath("/about", "team"))
+	assert.Equal(t, "/about/team", entities.BuildNodePath("/about/", "team"))
+}
+
+func TestNormalizeNodePath(t *testing.T) {
+	assert.Equal(t, "/", entities.NormalizeNodePath(""))
+	assert.Equal(t, "/about/team", entities.NormalizeNodePath("about//team/"))
+	assert.Equal(t, "/about", entities.NormalizeNodePath("/about"))
+}
+
+func TestBuildNodeTree(t *testing.T) {
+	nodes := entities.Nodes{
+		{ID: 1, Name: "about", Parent: 0},
+		{ID: 2, Name: "news", Parent: 0},
+		{ID: 3, Name: "team", Parent: 1},
+		{ID: 4, Name: "history", Parent: 1},
+		{ID: 5, Name: "people", Parent: 3},
+	}
+
+	tree := entities.BuildNodeTree(nodes, 0)
+
+	require.Len(t, tree, 2)
+	assert.Equal(t, "about", tree[0].Name)
+	require.Len(t, tree[0].Items, 2)
+	assert.Equal(t, "team", tree[0].Items[0].Name)
+	require.Len(t, tree[0].Items[0].Items, 1)
+	assert.Equal(t, "people", tree[0].Items[0].Items[0].Name)
+	assert.Empty(t, tree[1].Items)
+
+	subtree := entities.BuildNodeTree(nodes, 1)
+	assert.Len(t, subtree, 2)
+}
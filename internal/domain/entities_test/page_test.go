@@ -0,0 +1,69 @@
+package entities_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPage_DefaultsToDraft(t *testing.T) {
+	page, err := entities.NewPage(0, "about", entities.PageMeta{}, "", "", "", "", "", "")
+	require.NoError(t, err)
+	assert.Equal(t, entities.PageStatusDraft, page.Status)
+}
+
+func TestPage_SetStatus(t *testing.T) {
+	page, err := entities.NewPage(0, "about", entities.PageMeta{}, "", "", "", "", "", "")
+	require.NoError(t, err)
+
+	publishAt := time.Date(2025, 6, 1, 10, 0, 0, 0, time.UTC)
+	unpublishAt := publishAt.Add(24 * time.Hour)
+
+	assert.NoError(t, page.SetStatus(entities.PageStatusReview, &publishAt, &unpublishAt))
+	assert.Equal(t, entities.PageStatusReview, page.Status)
+	assert.Equal(t, &publishAt, page.PublishAt)
+
+	assert.Error(t, page.SetStatus("archived", nil, nil))
+	assert.Error(t, page.SetStatus(entities.PageStatusPublished, &unpublishAt, &publishAt))
+	assert.Equal(t, entities.PageStatusReview, page.Status)
+}
+
+func TestPageMeta_Validate(t *testing.T) {
+	valid := entities.PageMeta{
+		Description: "About us",
+		Canonical:   "https://example.com/about",
+		Robots:      "noindex, follow",
+		OpenGraph:   entities.OpenGraphMeta{Image: "/img/about.jpg", Type: "article"},
+		Twitter:     entities.TwitterMeta{Card: "summary_large_image", Site: "@example"},
+	}
+	assert.NoError(t, valid.Validate())
+	assert.True(t, valid.NoIndex())
+
+	invalid := []entities.PageMeta{
+		{Canonical: "javascript:alert(1)"},
+		{Canonical: "//evil.example.com"},
+		{Robots: "index, sometimes"},
+		{OpenGraph: entities.OpenGraphMeta{Type: "video"}},
+		{Twitter: entities.TwitterMeta{Card: "large"}},
+		{Twitter: entities.TwitterMeta{Site: "example"}},
+		{Description: strings.Repeat("a", 321)},
+	}
+	for _, meta := range invalid {
+		assert.Error(t, meta.Validate(), "%+v", meta)
+	}
+
+	_, err := entities.NewPage(0, "about", entities.PageMeta{Robots: "bogus"}, "", "", "", "", "", "")
+	assert.Error(t, err)
+}
+
+func TestPageMeta_CanonicalURL(t *testing.T) {
+	base := "https://example.com"
+
+	assert.Equal(t, "https://example.com/about", entities.PageMeta{}.CanonicalURL(base, "/about"))
+	assert.Equal(t, "https://example.com/company", entities.PageMeta{Canonical: "/company"}.CanonicalURL(base, "/about"))
+	assert.Equal(t, "https://other.example.com/", entities.PageMeta{Canonical: "https://other.example.com/"}.CanonicalURL(base, "/about"))
+}
@@ -0,0 +1,67 @@
+package entities_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPageTranslation(t *testing.T) {
+	tests := []struct {
+		name    string
+		pageID  int64
+		locale  string
+		slug    string
+		wantErr bool
+	}{
+		{"valid", 1, "en", "about-us", false},
+		{"region", 1, "en-GB", "about-us", false},
+		{"no page", 0, "en", "about-us", true},
+		{"invalid locale", 1, "english!", "about-us", true},
+		{"invalid name", 1, "en", "about us", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			translation, err := entities.NewPageTranslation(tt.pageID, tt.locale, tt.slug, entities.PageMeta{}, "About", "", "", "")
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, entities.ContentFormatHTML, translation.ContentFormat)
+		})
+	}
+}
+
+func TestPageWithTime_Translate(t *testing.T) {
+	page := &entities.PageWithTime{UpdatedAt: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}
+	page.ID = 3
+	page.Name = "o-nas"
+	page.Title = "О нас"
+	page.Category = "company"
+
+	translated := page.Translate(&entities.PageTranslation{
+		Locale:    "en",
+		Name:      "about-us",
+		Title:     "About us",
+		UpdatedAt: time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC),
+	})
+
+	assert.Equal(t, "en", translated.Locale)
+	assert.Equal(t, "about-us", translated.Name)
+	assert.Equal(t, "About us", translated.Title)
+	assert.Equal(t, "company", translated.Category)
+	assert.Equal(t, 2, int(translated.UpdatedAt.Month()))
+	assert.Equal(t, "О нас", page.Title)
+}
+
+func TestLocalePath(t *testing.T) {
+	assert.Equal(t, "/about", entities.LocalePath("ru", "ru", "/about"))
+	assert.Equal(t, "/about", entities.LocalePath("", "ru", "/about"))
+	assert.Equal(t, "/en/about", entities.LocalePath("en", "ru", "/about"))
+	assert.Equal(t, "/en", entities.LocalePath("en", "ru", "/"))
+}
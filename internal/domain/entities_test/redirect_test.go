@@ -0,0 +1,69 @@
+package entities_test
+
+import (
+	"testing"
+
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRedirect(t *testing.T) {
+	tests := []struct {
+		name    string
+		source  string
+		target  string
+		kind    string
+		code    int
+		wantErr bool
+	}{
+		{"exact", "/old/", "/new", "", 0, false},
+		{"external target", "/docs", "https://docs.example.com/", entities.RedirectExact, 302, false},
+		{"wildcard", "/blog/*", "/news/*", entities.RedirectWildcard, 308, false},
+		{"regex", `/p/(\d+)`, "/page?id=$1", entities.RedirectRegex, 0, false},
+		{"relative source", "old", "/new", "", 0, true},
+		{"same path", "/old", "/old/", "", 0, true},
+		{"protocol-relative target", "/old", "//evil.example.com", "", 0, true},
+		{"script target", "/old", "javascript:alert(1)", "", 0, true},
+		{"wildcard without star", "/blog", "/news", entities.RedirectWildcard, 0, true},
+		{"broken regex", "/p/(", "/p", entities.RedirectRegex, 0, true},
+		{"unknown kind", "/old", "/new", "glob", 0, true},
+		{"invalid code", "/old", "/new", "", 200, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			redirect, err := entities.NewRedirect(0, tt.source, tt.target, tt.kind, tt.code, false)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.NotZero(t, redirect.Code)
+			assert.NotEmpty(t, redirect.Kind)
+		})
+	}
+}
+
+func TestRedirect_Pattern(t *testing.T) {
+	exact, err := entities.NewRedirect(0, "/old/", "/new", "", 0, false)
+	require.NoError(t, err)
+	assert.Equal(t, "/old", exact.Source)
+	pattern, err := exact.Pattern()
+	require.NoError(t, err)
+	assert.Nil(t, pattern)
+
+	wildcard, err := entities.NewRedirect(0, "/a.b/*", "/c/*", entities.RedirectWildcard, 0, false)
+	require.NoError(t, err)
+	pattern, err = wildcard.Pattern()
+	require.NoError(t, err)
+	assert.True(t, pattern.MatchString("/a.b/x/y"))
+	assert.False(t, pattern.MatchString("/aXb/x"))
+
+	regex, err := entities.NewRedirect(0, `/p/\d+`, "/p", entities.RedirectRegex, 0, false)
+	require.NoError(t, err)
+	pattern, err = regex.Pattern()
+	require.NoError(t, err)
+	assert.True(t, pattern.MatchString("/p/12"))
+	assert.False(t, pattern.MatchString("/p/12/edit"))
+}
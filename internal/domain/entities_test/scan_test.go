@@ -0,0 +1,16 @@
+package entities_test
+
+import (
+	"testing"
+
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsValidScanStatus(t *testing.T) {
+	assert.True(t, entities.IsValidScanStatus(entities.ScanStatusInfected))
+	assert.True(t, entities.IsValidScanStatus(entities.ScanStatusPending))
+	// Пустой статус — файл не проверялся, но это не статус проверки
+	assert.False(t, entities.IsValidScanStatus(""))
+	assert.False(t, entities.IsValidScanStatus("quarantined"))
+}
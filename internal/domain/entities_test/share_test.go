@@ -0,0 +1,49 @@
+package entities_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewUploadShare(t *testing.T) {
+	now := time.Now()
+	upload := &entities.Upload{UUID: "u1", UserID: 5}
+	past := now.Add(-time.Second)
+
+	_, err := entities.NewUploadShare(upload, "tok", "", nil, 0, "preview", now)
+	assert.Error(t, err)
+	_, err = entities.NewUploadShare(upload, "tok", "", nil, -1, "", now)
+	assert.Error(t, err)
+	_, err = entities.NewUploadShare(upload, "tok", "", &past, 0, "", now)
+	assert.Error(t, err)
+
+	share, err := entities.NewUploadShare(upload, "tok", "", nil, 0, "", now)
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), share.UserID)
+	assert.Equal(t, entities.ShareDispositionAttachment, share.Disposition)
+	assert.False(t, share.HasPassword())
+	assert.True(t, share.PasswordMatches(""))
+	assert.False(t, share.Expired(now.Add(24*time.Hour)))
+	assert.False(t, share.Exhausted())
+}
+
+func TestUploadShare_Limits(t *testing.T) {
+	now := time.Now()
+	expires := now.Add(time.Hour)
+	share, err := entities.NewUploadShare(&entities.Upload{UUID: "u1"}, "tok", "correct horse", &expires, 2, entities.ShareDispositionInline, now)
+	require.NoError(t, err)
+
+	assert.True(t, share.HasPassword())
+	assert.True(t, share.PasswordMatches("correct horse"))
+	assert.False(t, share.PasswordMatches("wrong horse"))
+
+	assert.False(t, share.Expired(now))
+	assert.True(t, share.Expired(expires))
+
+	share.Downloads = 2
+	assert.True(t, share.Exhausted())
+}
@@ -0,0 +1,50 @@
+package entities_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeTag(t *testing.T) {
+	valid := map[string]string{
+		" Summer  Sale ": "summer-sale",
+		"Ёлка":           "ёлка",
+		"v1.2_beta":      "v1.2_beta",
+	}
+	for name, want := range valid {
+		tag, err := entities.NormalizeTag(name)
+		require.NoError(t, err, name)
+		assert.Equal(t, want, tag)
+	}
+
+	for _, name := range []string{"", "   ", "a/b", "#tag", strings.Repeat("x", entities.MaxTagLength+1)} {
+		_, err := entities.NormalizeTag(name)
+		assert.ErrorIs(t, err, entities.ErrInvalidTag, name)
+	}
+}
+
+func TestNormalizeTags_Dedup(t *testing.T) {
+	tags, err := entities.NormalizeTags([]string{"Docs", "news", "DOCS"})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"docs", "news"}, tags)
+}
+
+func TestNewTaggedItemRef(t *testing.T) {
+	page, err := entities.NewTaggedItemRef(entities.TagKindPage, 5, "")
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), page.ID)
+
+	image, err := entities.NewTaggedItemRef(entities.TagKindImage, 0, "0B6F5A3E-8D7C-4E1A-9F2B-3C4D5E6F7A8B")
+	require.NoError(t, err)
+	assert.Equal(t, "0b6f5a3e-8d7c-4e1a-9f2b-3c4d5e6f7a8b", image.UUID)
+
+	_, err = entities.NewTaggedItemRef(entities.TagKindUpload, 5, "")
+	assert.Error(t, err)
+	_, err = entities.NewTaggedItemRef("node", 5, "")
+	assert.Error(t, err)
+}
@@ -0,0 +1,35 @@
+package entities_test
+
+import (
+	"testing"
+
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTrashItemRef(t *testing.T) {
+	page, err := entities.NewTrashItemRef(entities.TrashTypePage, 5, "")
+	require.NoError(t, err)
+	assert.False(t, page.HasBlob())
+
+	image, err := entities.NewTrashItemRef(entities.TrashTypeImage, 0, "0B6F5A3E-8D7C-4E1A-9F2B-3C4D5E6F7A8B")
+	require.NoError(t, err)
+	assert.True(t, image.HasBlob())
+	assert.Equal(t, "0b6f5a3e-8d7c-4e1a-9f2b-3c4d5e6f7a8b", image.UUID)
+
+	invalid := []struct {
+		itemType string
+		id       int64
+		uuid     string
+	}{
+		{entities.TrashTypePage, 0, ""},
+		{entities.TrashTypeUpload, 5, ""},
+		{entities.TrashTypeUpload, 0, "not-a-uuid"},
+		{"node", 5, ""},
+	}
+	for _, tt := range invalid {
+		_, err := entities.NewTrashItemRef(tt.itemType, tt.id, tt.uuid)
+		assert.Error(t, err, tt.itemType)
+	}
+}
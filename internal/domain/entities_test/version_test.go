@@ -0,0 +1,26 @@
+package entities_test
+
+import (
+	"testing"
+
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileVersions_Find(t *testing.T) {
+	versions := entities.FileVersions{
+		{Version: 3, UUID: "c", Current: true},
+		{Version: 1, UUID: "a"},
+	}
+
+	found := versions.Find(1)
+	require.NotNil(t, found)
+	assert.Equal(t, "a", found.UUID)
+	assert.Nil(t, versions.Find(2))
+}
+
+func TestIsValidVersionKind(t *testing.T) {
+	assert.True(t, entities.IsValidVersionKind(entities.VersionKindImage))
+	assert.False(t, entities.IsValidVersionKind("page"))
+}
@@ -0,0 +1,117 @@
+package valueobjects
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// MaxSlugLength limits the length of a URL segment.
+const MaxSlugLength = 200
+
+var slugRegex = regexp.MustCompile(`^[a-z0-9]+(?:[-_][a-z0-9]+)*$`)
+
+// translit maps Cyrillic letters (Russian, Ukrainian, Belarusian) to Latin.
+var translit = map[rune]string{
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "e", 'ж': "zh",
+	'з': "z", 'и': "i", 'й': "y", 'к': "k", 'л': "l", 'м': "m", 'н': "n", 'о': "o",
+	'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u", 'ф': "f", 'х': "kh", 'ц': "ts",
+	'ч': "ch", 'ш': "sh", 'щ': "shch", 'ъ': "", 'ы': "y", 'ь': "", 'э': "e", 'ю': "yu",
+	'я': "ya", 'є': "ye", 'і': "i", 'ї': "yi", 'ґ': "g", 'ў': "u",
+}
+
+// Slug represents a validated URL segment value object.
+type Slug struct {
+	value string
+}
+
+// NewSlug creates a validated Slug instance.
+// value: Candidate slug
+// Returns: (Slug, error)
+// Validation:
+//   - Trims whitespace and normalizes to lowercase
+//   - Latin letters and digits separated by single "-" or "_"
+//   - At most MaxSlugLength characters
+func NewSlug(value string) (Slug, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if value == "" {
+		return Slug{}, errors.New("slug cannot be empty")
+	}
+	if len(value) > MaxSlugLength {
+		return Slug{}, errors.New("slug is too long")
+	}
+	if !slugRegex.MatchString(value) {
+		return Slug{}, errors.New("invalid slug: " + value)
+	}
+
+	return Slug{value: value}, nil
+}
+
+// SlugFromTitle builds a slug from arbitrary text.
+// Cyrillic is transliterated, other characters outside [a-z0-9] become
+// separators; the result is cut at a word boundary to MaxSlugLength.
+// Returns an error when nothing usable is left.
+func SlugFromTitle(title string) (Slug, error) {
+	var b strings.Builder
+	sep := false
+	for _, r := range strings.ToLower(title) {
+		switch {
+		case r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)):
+			if sep && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			b.WriteRune(r)
+			sep = false
+		case translit[r] != "":
+			if sep && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			b.WriteString(translit[r])
+			sep = false
+		case r == 'ъ' || r == 'ь':
+			// Знаки не дают звука и не разделяют слова
+		default:
+			sep = true
+		}
+	}
+
+	value := b.String()
+	if len(value) > MaxSlugLength {
+		value = value[:MaxSlugLength]
+		if i := strings.LastIndexByte(value, '-'); i > 0 {
+			value = value[:i]
+		}
+		value = strings.TrimRight(value, "-")
+	}
+
+	return NewSlug(value)
+}
+
+// WithSuffix returns the slug with a numeric suffix ("about-2"),
+// used to make a generated slug unique.
+func (s Slug) WithSuffix(n int) Slug {
+	suffix := "-" + strconv.Itoa(n)
+	value := s.value
+	if len(value)+len(suffix) > MaxSlugLength {
+		value = strings.TrimRight(value[:MaxSlugLength-len(suffix)], "-_")
+	}
+	return Slug{value: value + suffix}
+}
+
+// String returns the slug value.
+// Implements fmt.Stringer interface.
+func (s Slug) String() string {
+	return s.value
+}
+
+// Value returns the slug value.
+func (s Slug) Value() string {
+	return s.value
+}
+
+// Equals compares two Slug objects for value equality.
+func (s Slug) Equals(other Slug) bool {
+	return s.value == other.value
+}
@@ -0,0 +1,85 @@
+package valueobjects_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aube/auth/internal/domain/valueobjects"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSlug(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{"simple", "about", "about", false},
+		{"hyphens", "about-us", "about-us", false},
+		{"underscore", "about_us", "about_us", false},
+		{"uppercase", " About-US ", "about-us", false},
+		{"digits", "2025-news", "2025-news", false},
+		{"empty", "", "", true},
+		{"spaces", "about us", "", true},
+		{"slash", "about/us", "", true},
+		{"leading hyphen", "-about", "", true},
+		{"double hyphen", "about--us", "", true},
+		{"cyrillic", "страница", "", true},
+		{"too long", strings.Repeat("a", valueobjects.MaxSlugLength+1), "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			slug, err := valueobjects.NewSlug(tt.value)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, slug.String())
+		})
+	}
+}
+
+func TestSlugFromTitle(t *testing.T) {
+	tests := []struct {
+		title string
+		want  string
+	}{
+		{"About Us", "about-us"},
+		{"  Hello, World!  ", "hello-world"},
+		{"О компании", "o-kompanii"},
+		{"Щедрый подъезд", "shchedryy-podezd"},
+		{"Ёлка и Юла", "elka-i-yula"},
+		{"Їжак і ґанок", "yizhak-i-ganok"},
+		{"Café 2025", "caf-2025"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			slug, err := valueobjects.SlugFromTitle(tt.title)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, slug.String())
+		})
+	}
+
+	_, err := valueobjects.SlugFromTitle("!!! ???")
+	assert.Error(t, err)
+
+	long, err := valueobjects.SlugFromTitle(strings.Repeat("word ", 100))
+	require.NoError(t, err)
+	assert.LessOrEqual(t, len(long.String()), valueobjects.MaxSlugLength)
+	assert.False(t, strings.HasSuffix(long.String(), "-"))
+}
+
+func TestSlug_WithSuffix(t *testing.T) {
+	slug, err := valueobjects.NewSlug("about")
+	require.NoError(t, err)
+	assert.Equal(t, "about-2", slug.WithSuffix(2).String())
+
+	long, err := valueobjects.NewSlug(strings.Repeat("a", valueobjects.MaxSlugLength))
+	require.NoError(t, err)
+	assert.Len(t, long.WithSuffix(10).String(), valueobjects.MaxSlugLength)
+}
@@ -0,0 +1,133 @@
+// Package clamd implements scan.Scanner with the ClamAV daemon INSTREAM command.
+package clamd
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/aube/auth/internal/domain/entities"
+)
+
+// chunkSize is the size of INSTREAM chunks; clamd limits the whole
+// stream with StreamMaxLength.
+const chunkSize = 64 * 1024
+
+// Client scans streams with clamd over TCP or a Unix socket,
+// one connection per scan.
+type Client struct {
+	network string
+	address string
+	timeout time.Duration
+}
+
+// New creates a client for the address: tcp://host:port, unix:///path
+// or host:port. timeout limits a whole scan, 0 for no limit.
+func New(address string, timeout time.Duration) (*Client, error) {
+	network, addr := "tcp", address
+	switch {
+	case strings.HasPrefix(address, "tcp://"):
+		addr = strings.TrimPrefix(address, "tcp://")
+	case strings.HasPrefix(address, "unix://"):
+		network, addr = "unix", strings.TrimPrefix(address, "unix://")
+	}
+	if addr == "" {
+		return nil, fmt.Errorf("invalid clamd address %q", address)
+	}
+
+	return &Client{
+		network: network,
+		address: addr,
+		timeout: timeout,
+	}, nil
+}
+
+// Scan sends the content to clamd. Files clamd refuses to scan (size limit)
+// are reported with the error status; failures to talk to clamd are errors.
+func (c *Client) Scan(ctx context.Context, content io.Reader) (*entities.ScanResult, error) {
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, c.network, c.address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to clamd: %w", err)
+	}
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+	stop := context.AfterFunc(ctx, func() { conn.SetDeadline(time.Now()) })
+	defer stop()
+
+	sendErr := send(conn, content)
+
+	// Превысив StreamMaxLength, clamd отвечает ошибкой и закрывает соединение
+	reply, err := bufio.NewReader(conn).ReadBytes(0)
+	if err != nil {
+		if sendErr != nil {
+			return nil, fmt.Errorf("failed to send file to clamd: %w", sendErr)
+		}
+		return nil, fmt.Errorf("failed to read clamd reply: %w", err)
+	}
+
+	return parseReply(string(bytes.TrimRight(reply, "\x00")))
+}
+
+// send writes the INSTREAM command, the content in length-prefixed chunks
+// and the zero-length terminator.
+func send(conn net.Conn, content io.Reader) error {
+	if _, err := conn.Write([]byte("zINSTREAM\x00")); err != nil {
+		return err
+	}
+
+	buf := make([]byte, 4+chunkSize)
+	for {
+		n, err := content.Read(buf[4:])
+		if n > 0 {
+			binary.BigEndian.PutUint32(buf, uint32(n))
+			if _, err := conn.Write(buf[:4+n]); err != nil {
+				return err
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err := conn.Write([]byte{0, 0, 0, 0})
+	return err
+}
+
+// parseReply reads replies like "stream: OK", "stream: Eicar-Signature FOUND"
+// and "INSTREAM size limit exceeded. ERROR".
+func parseReply(reply string) (*entities.ScanResult, error) {
+	reply = strings.TrimSpace(reply)
+	switch {
+	case strings.HasSuffix(reply, " FOUND"):
+		signature := strings.TrimSuffix(reply, " FOUND")
+		if i := strings.Index(signature, ": "); i >= 0 {
+			signature = signature[i+2:]
+		}
+		return &entities.ScanResult{Status: entities.ScanStatusInfected, Detail: signature}, nil
+	case strings.HasSuffix(reply, " ERROR"):
+		return &entities.ScanResult{Status: entities.ScanStatusError, Detail: strings.TrimSuffix(reply, " ERROR")}, nil
+	case strings.HasSuffix(reply, ": OK"):
+		return &entities.ScanResult{Status: entities.ScanStatusClean}, nil
+	}
+	return nil, fmt.Errorf("unexpected clamd reply %q", reply)
+}
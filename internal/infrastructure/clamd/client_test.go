@@ -0,0 +1,126 @@
+package clamd
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"io"
+	"net"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const eicar = `X5O!P%@AP[4\PZX54(P^)7CC)7}$EICAR-STANDARD-ANTIVIRUS-TEST-FILE!H+H*`
+
+// fakeClamd answers INSTREAM like clamd: EICAR is found, streams
+// longer than maxLength are refused.
+func fakeClamd(t *testing.T, network, address string, maxLength int) string {
+	listener, err := net.Listen(network, address)
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go serveClamd(conn, maxLength)
+		}
+	}()
+
+	return listener.Addr().String()
+}
+
+func serveClamd(conn net.Conn, maxLength int) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+
+	command, err := r.ReadString(0)
+	if err != nil || command != "zINSTREAM\x00" {
+		conn.Write([]byte("UNKNOWN COMMAND\x00"))
+		return
+	}
+
+	var stream bytes.Buffer
+	for {
+		var size uint32
+		if err := binary.Read(r, binary.BigEndian, &size); err != nil {
+			return
+		}
+		if size == 0 {
+			break
+		}
+		if stream.Len()+int(size) > maxLength {
+			conn.Write([]byte("INSTREAM size limit exceeded. ERROR\x00"))
+			return
+		}
+		if _, err := io.CopyN(&stream, r, int64(size)); err != nil {
+			return
+		}
+	}
+
+	if strings.Contains(stream.String(), "EICAR-STANDARD-ANTIVIRUS-TEST-FILE") {
+		conn.Write([]byte("stream: Eicar-Test-Signature FOUND\x00"))
+		return
+	}
+	conn.Write([]byte("stream: OK\x00"))
+}
+
+func TestClient_Scan_TCP(t *testing.T) {
+	address := fakeClamd(t, "tcp", "127.0.0.1:0", 1<<20)
+	client, err := New("tcp://"+address, 5*time.Second)
+	require.NoError(t, err)
+
+	result, err := client.Scan(context.Background(), strings.NewReader(eicar))
+	require.NoError(t, err)
+	assert.Equal(t, entities.ScanStatusInfected, result.Status)
+	assert.Equal(t, "Eicar-Test-Signature", result.Detail)
+
+	// Несколько блоков подряд
+	result, err = client.Scan(context.Background(), bytes.NewReader(bytes.Repeat([]byte("a"), 3*chunkSize+1)))
+	require.NoError(t, err)
+	assert.Equal(t, entities.ScanStatusClean, result.Status)
+}
+
+func TestClient_Scan_Unix(t *testing.T) {
+	socket := filepath.Join(t.TempDir(), "clamd.sock")
+	fakeClamd(t, "unix", socket, 1<<20)
+	client, err := New("unix://"+socket, 5*time.Second)
+	require.NoError(t, err)
+
+	result, err := client.Scan(context.Background(), strings.NewReader("hello"))
+	require.NoError(t, err)
+	assert.Equal(t, entities.ScanStatusClean, result.Status)
+}
+
+func TestClient_Scan_SizeLimit(t *testing.T) {
+	address := fakeClamd(t, "tcp", "127.0.0.1:0", 1024)
+	client, err := New(address, 5*time.Second)
+	require.NoError(t, err)
+
+	result, err := client.Scan(context.Background(), bytes.NewReader(make([]byte, 4*chunkSize)))
+	require.NoError(t, err)
+	assert.Equal(t, entities.ScanStatusError, result.Status)
+	assert.Equal(t, "INSTREAM size limit exceeded.", result.Detail)
+}
+
+func TestClient_Scan_Unreachable(t *testing.T) {
+	client, err := New("unix://"+filepath.Join(t.TempDir(), "missing.sock"), time.Second)
+	require.NoError(t, err)
+
+	_, err = client.Scan(context.Background(), strings.NewReader("hello"))
+	assert.Error(t, err)
+}
+
+func TestNew_InvalidAddress(t *testing.T) {
+	_, err := New("unix://", time.Second)
+	assert.Error(t, err)
+}
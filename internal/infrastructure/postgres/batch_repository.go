@@ -0,0 +1,215 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/aube/auth/internal/utils/logger"
+	"github.com/rs/zerolog"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Запросы к файлам: %[1]s — таблица uploads или images
+const (
+	queryBatchFilesDelete string = `UPDATE %[1]s SET deleted = true, deleted_at = now()
+		WHERE uuid = ANY($1::uuid[]) and user_id = $2 and deleted = false RETURNING uuid::text`
+	queryBatchFilesMove string = `UPDATE %[1]s SET category = $3
+		WHERE uuid = ANY($1::uuid[]) and user_id = $2 and deleted = false RETURNING uuid::text`
+	queryBatchFilesLock string = `SELECT id, uuid::text FROM %[1]s
+		WHERE uuid = ANY($1::uuid[]) and user_id = $2 and deleted = false FOR UPDATE`
+	queryBatchFilesTag string = `INSERT INTO %[1]s (%[2]s, tag_id)
+		SELECT f.id, t.id FROM unnest($1::bigint[]) f(id) CROSS JOIN tags t WHERE t.user_id = $2 and t.name = ANY($3)
+		ON CONFLICT DO NOTHING`
+	// Из нескольких удалённых файлов с одним именем восстанавливается удалённый последним;
+	// %[2]s — столбец, внутри которого имя уникально у владельца
+	queryBatchFilesRestore string = `UPDATE %[1]s SET deleted = false, deleted_at = NULL
+		WHERE id IN (SELECT DISTINCT ON (f.%[2]s, name) id FROM %[1]s f
+			WHERE uuid = ANY($1::uuid[]) and user_id = $2 and deleted = true
+				and not exists (SELECT 1 FROM %[1]s l WHERE l.name = f.name and l.user_id = f.user_id
+					and l.%[2]s IS NOT DISTINCT FROM f.%[2]s and l.deleted = false)
+			ORDER BY f.%[2]s, name, deleted_at DESC NULLS LAST)
+		RETURNING uuid::text`
+	queryBatchFilesInTrash string = "SELECT uuid::text FROM %[1]s WHERE uuid = ANY($1::uuid[]) and user_id = $2 and deleted = true"
+
+	queryBatchPagesPublish string = `UPDATE pages SET status = 'published', published = true, published_at = coalesce(published_at, now()),
+		publish_at = NULL, unpublish_at = NULL, version = version + 1
+		WHERE id = ANY($1) and deleted = false RETURNING id`
+	queryBatchPagesUnpublish string = `UPDATE pages SET status = 'draft', published = false,
+		publish_at = NULL, unpublish_at = NULL, version = version + 1
+		WHERE id = ANY($1) and deleted = false RETURNING id`
+	queryBatchPagesDelete string = "UPDATE pages SET deleted = true, deleted_at = now() WHERE id = ANY($1) and deleted = false RETURNING id"
+)
+
+type BatchRepository struct {
+	db  *pgxpool.Pool
+	log zerolog.Logger
+}
+
+func NewBatchRepository(db *pgxpool.Pool) *BatchRepository {
+	return &BatchRepository{
+		db:  db,
+		log: logger.Get().With().Str("postgres", "batch_repository").Logger(),
+	}
+}
+
+func (r *BatchRepository) DeleteFiles(ctx context.Context, fileType string, userID int64, uuids []string) ([]string, error) {
+	target, err := fileTarget(fileType)
+	if err != nil {
+		return nil, err
+	}
+
+	deleted, err := collectKeys[string](ctx, r.db, fmt.Sprintf(queryBatchFilesDelete, target.table), uuids, userID)
+	if err != nil {
+		r.log.Debug().Err(err).Msg("DeleteFiles")
+		return nil, fmt.Errorf("failed to delete %ss: %w", fileType, err)
+	}
+
+	return deleted, nil
+}
+
+func (r *BatchRepository) MoveFiles(ctx context.Context, fileType string, userID int64, uuids []string, category string) ([]string, error) {
+	target, err := fileTarget(fileType)
+	if err != nil {
+		return nil, err
+	}
+
+	moved, err := collectKeys[string](ctx, r.db, fmt.Sprintf(queryBatchFilesMove, target.table), uuids, userID, category)
+	if err != nil {
+		r.log.Debug().Err(err).Msg("MoveFiles")
+		return nil, fmt.Errorf("failed to move %ss: %w", fileType, err)
+	}
+
+	return moved, nil
+}
+
+func (r *BatchRepository) TagFiles(ctx context.Context, fileType string, userID int64, uuids []string, tags []string) ([]string, error) {
+	target, err := fileTarget(fileType)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		r.log.Debug().Err(err).Msg("TagFiles1")
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	rows, err := tx.Query(ctx, fmt.Sprintf(queryBatchFilesLock, target.table), uuids, userID)
+	if err != nil {
+		r.log.Debug().Err(err).Msg("TagFiles2")
+		return nil, fmt.Errorf("failed to find %ss: %w", fileType, err)
+	}
+	var (
+		ids    []int64
+		tagged []string
+		id     int64
+		uuid   string
+	)
+	_, err = pgx.ForEachRow(rows, []any{&id, &uuid}, func() error {
+		ids = append(ids, id)
+		tagged = append(tagged, uuid)
+		return nil
+	})
+	if err != nil {
+		r.log.Debug().Err(err).Msg("TagFiles2")
+		return nil, fmt.Errorf("failed to find %ss: %w", fileType, err)
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	if _, err := tx.Exec(ctx, queryTagsInsert, userID, tags); err != nil {
+		r.log.Debug().Err(err).Msg("TagFiles3")
+		return nil, fmt.Errorf("failed to create tags: %w", err)
+	}
+	if _, err := tx.Exec(ctx, fmt.Sprintf(queryBatchFilesTag, target.link, target.item), ids, userID, tags); err != nil {
+		r.log.Debug().Err(err).Msg("TagFiles4")
+		return nil, fmt.Errorf("failed to tag %ss: %w", fileType, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		r.log.Debug().Err(err).Msg("TagFiles5")
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return tagged, nil
+}
+
+func (r *BatchRepository) RestoreFiles(ctx context.Context, fileType string, userID int64, uuids []string) ([]string, []string, error) {
+	target, err := fileTarget(fileType)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		r.log.Debug().Err(err).Msg("RestoreFiles1")
+		return nil, nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	restored, err := collectKeys[string](ctx, tx, fmt.Sprintf(queryBatchFilesRestore, target.table, target.nameScope), uuids, userID)
+	if err != nil {
+		r.log.Debug().Err(err).Msg("RestoreFiles2")
+		return nil, nil, fmt.Errorf("failed to restore %ss: %w", fileType, err)
+	}
+
+	// Оставшиеся в корзине не восстановлены из-за занятого имени
+	conflicts, err := collectKeys[string](ctx, tx, fmt.Sprintf(queryBatchFilesInTrash, target.table), uuids, userID)
+	if err != nil {
+		r.log.Debug().Err(err).Msg("RestoreFiles3")
+		return nil, nil, fmt.Errorf("failed to restore %ss: %w", fileType, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		r.log.Debug().Err(err).Msg("RestoreFiles4")
+		return nil, nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return restored, conflicts, nil
+}
+
+func (r *BatchRepository) SetPagesPublished(ctx context.Context, ids []int64, published bool) ([]int64, error) {
+	sql := queryBatchPagesUnpublish
+	if published {
+		sql = queryBatchPagesPublish
+	}
+
+	changed, err := collectKeys[int64](ctx, r.db, sql, ids)
+	if err != nil {
+		r.log.Debug().Err(err).Msg("SetPagesPublished")
+		return nil, fmt.Errorf("failed to change page status: %w", err)
+	}
+
+	return changed, nil
+}
+
+func (r *BatchRepository) DeletePages(ctx context.Context, ids []int64) ([]int64, error) {
+	deleted, err := collectKeys[int64](ctx, r.db, queryBatchPagesDelete, ids)
+	if err != nil {
+		r.log.Debug().Err(err).Msg("DeletePages")
+		return nil, fmt.Errorf("failed to delete pages: %w", err)
+	}
+
+	return deleted, nil
+}
+
+func fileTarget(fileType string) (tagTarget, error) {
+	if fileType != entities.BatchTypeUpload && fileType != entities.BatchTypeImage {
+		return tagTarget{}, entities.ErrInvalidBatchAction
+	}
+	return tagTargets[fileType], nil
+}
+
+// collectKeys runs a query returning one key column.
+func collectKeys[T any](ctx context.Context, db queryer, sql string, args ...any) ([]T, error) {
+	rows, err := db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, pgx.RowTo[T])
+}
@@ -0,0 +1,316 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	appPage "github.com/aube/auth/internal/application/page"
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/aube/auth/internal/utils/logger"
+	"github.com/rs/zerolog"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	// Страницы узла и его потомков: привязанные к узлам и прикреплённые через node_page
+	queryBundleSubtreePages string = `WITH RECURSIVE sub AS (
+			SELECT id, page_id, level, sort, 0 AS depth FROM nodes WHERE id = $1 and deleted = false
+			UNION
+			SELECT n.id, n.page_id, n.level, n.sort, sub.depth + 1 FROM nodes n JOIN sub ON n.parent = sub.id
+			WHERE n.deleted = false and sub.depth < 256
+		)
+		SELECT p.page_id FROM (
+			SELECT page_id, level, sort, id, 0 AS k FROM sub WHERE page_id > 0
+			UNION ALL
+			SELECT np.page_id, sub.level, sub.sort, sub.id, 1 FROM sub JOIN node_page np ON np.node_id = sub.id
+		) p JOIN pages ON pages.id = p.page_id and pages.deleted = false
+		ORDER BY p.level, p.sort, p.id, p.k`
+
+	queryBundlePage string = `SELECT name, meta, title, category, template, h1, content, content_short, content_format, content_html,
+		status, publish_at, unpublish_at, published_at, coalesce(created_at, now()), coalesce(updated_at, now()),
+		coalesce(content_type, ''), fields
+		FROM pages WHERE id = $1 and deleted = false`
+	queryBundleRevisions string = `SELECT revision, name, meta, title, category, template, h1, content, content_short,
+		content_format, content_type, fields, coalesce(created_at, now())
+		FROM page_revisions WHERE page_id = $1 ORDER BY revision`
+	queryBundleTranslations string = `SELECT locale, name, meta, title, h1, content, content_short, content_format, content_html
+		FROM page_translations WHERE page_id = $1 ORDER BY locale`
+	queryBundleImages string = `SELECT i.uuid::text, i.name, coalesce(i.category, ''), i.content_type, coalesce(i.description, ''),
+		coalesce(i.size, 0), pi.sort, pi.pinned <> 0
+		FROM page_image pi JOIN images i ON i.id = pi.image_id
+		WHERE pi.page_id = $1 and i.deleted = false
+		ORDER BY pi.pinned DESC, pi.sort, pi.id`
+
+	queryBundleFindPage        string = "SELECT id FROM pages WHERE name = $1 ORDER BY deleted, id LIMIT 1"
+	queryBundleTranslationUsed string = "SELECT count(*) FROM page_translations WHERE locale = $1 and name = $2 and page_id <> $3"
+	queryBundleImageExists     string = "SELECT count(*) FROM images WHERE uuid = $1::uuid"
+	queryBundleContentType     string = "SELECT count(*) FROM content_types WHERE name = $1"
+
+	queryBundlePageInsert string = `INSERT INTO pages (name, meta, title, category, template, h1, content, content_short,
+			content_format, content_html, status, publish_at, unpublish_at, published_at, published, search_config, created_at, updated_at,
+			content_type, fields)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $11::varchar = 'published', $15::regconfig, $16, $17,
+			nullif($18::varchar, ''), $19)
+		RETURNING id`
+	queryBundlePageUpdate string = `UPDATE pages SET name = $1, meta = $2, title = $3, category = $4, template = $5, h1 = $6,
+			content = $7, content_short = $8, content_format = $9, content_html = $10, status = $11,
+			publish_at = $12, unpublish_at = $13, published_at = $14, published = ($11::varchar = 'published'),
+			search_config = $15::regconfig, content_type = nullif($17::varchar, ''), fields = $18, deleted = false,
+			version = version + 1
+		WHERE id = $16`
+	queryBundleDeleteRevisions    string = "DELETE FROM page_revisions WHERE page_id = $1"
+	queryBundleDeleteTranslations string = "DELETE FROM page_translations WHERE page_id = $1"
+	queryBundleDeleteImages       string = "DELETE FROM page_image WHERE page_id = $1"
+	queryBundleRevisionInsert     string = `INSERT INTO page_revisions (page_id, revision, name, meta, title, category, template, h1, content, content_short,
+			content_format, content_type, fields, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)`
+	queryBundleTranslationInsert string = `INSERT INTO page_translations (page_id, locale, name, meta, title, h1, content, content_short, content_format, content_html)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`
+	// Изображение регистрируется, только если его ещё нет на сайте
+	queryBundleImageInsert string = `INSERT INTO images (user_id, uuid, size, name, category, content_type, description)
+		SELECT $1, $2::uuid, $3, $4, $5, $6, $7 WHERE NOT EXISTS (SELECT 1 FROM images WHERE uuid = $2::uuid)`
+	queryBundlePageImageInsert string = `INSERT INTO page_image (page_id, image_id, sort, pinned)
+		SELECT $1, id, $3, $4 FROM images WHERE uuid = $2::uuid ORDER BY deleted, id LIMIT 1
+		ON CONFLICT (page_id, image_id) DO NOTHING`
+)
+
+// BundleRepository reads and writes pages for bundle export and import.
+// searchConfig is the text search configuration of imported pages.
+type BundleRepository struct {
+	db           *pgxpool.Pool
+	searchConfig string
+	log          zerolog.Logger
+}
+
+func NewBundleRepository(db *pgxpool.Pool, searchConfig string) *BundleRepository {
+	return &BundleRepository{
+		db:           db,
+		searchConfig: searchConfig,
+		log:          logger.Get().With().Str("postgres", "bundle_repository").Logger(),
+	}
+}
+
+func (r *BundleRepository) SubtreePageIDs(ctx context.Context, nodeID int64) ([]int64, error) {
+	rows, err := r.db.Query(ctx, queryBundleSubtreePages, nodeID)
+	if err != nil {
+		r.log.Debug().Err(err).Msg("SubtreePageIDs")
+		return nil, fmt.Errorf("failed to list subtree pages: %w", err)
+	}
+
+	ids, err := pgx.CollectRows(rows, pgx.RowTo[int64])
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan subtree pages: %w", err)
+	}
+	return ids, nil
+}
+
+func (r *BundleRepository) ExportPage(ctx context.Context, id int64) (*entities.BundlePage, error) {
+	var page entities.BundlePage
+	err := r.db.QueryRow(ctx, queryBundlePage, id).Scan(
+		&page.Name,
+		&page.Meta,
+		&page.Title,
+		&page.Category,
+		&page.Template,
+		&page.H1,
+		&page.Content,
+		&page.ContentShort,
+		&page.ContentFormat,
+		&page.ContentHTML,
+		&page.Status,
+		&page.PublishAt,
+		&page.UnpublishAt,
+		&page.PublishedAt,
+		&page.CreatedAt,
+		&page.UpdatedAt,
+		&page.ContentType,
+		&page.Fields,
+	)
+	if err != nil {
+		r.log.Debug().Err(err).Msg("ExportPage1")
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, appPage.ErrPageNotFound
+		}
+		return nil, fmt.Errorf("failed to export page: %w", err)
+	}
+	page.Fields = bundleFields(page.Fields)
+
+	rows, err := r.db.Query(ctx, queryBundleRevisions, id)
+	if err != nil {
+		r.log.Debug().Err(err).Msg("ExportPage2")
+		return nil, fmt.Errorf("failed to export page revisions: %w", err)
+	}
+	page.Revisions, err = pgx.CollectRows(rows, func(row pgx.CollectableRow) (entities.BundleRevision, error) {
+		var rev entities.BundleRevision
+		err := row.Scan(&rev.Revision, &rev.Name, &rev.Meta, &rev.Title, &rev.Category, &rev.Template,
+			&rev.H1, &rev.Content, &rev.ContentShort, &rev.ContentFormat, &rev.ContentType, &rev.Fields, &rev.CreatedAt)
+		rev.Fields = bundleFields(rev.Fields)
+		return rev, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan page revision: %w", err)
+	}
+
+	rows, err = r.db.Query(ctx, queryBundleTranslations, id)
+	if err != nil {
+		r.log.Debug().Err(err).Msg("ExportPage3")
+		return nil, fmt.Errorf("failed to export page translations: %w", err)
+	}
+	page.Translations, err = pgx.CollectRows(rows, func(row pgx.CollectableRow) (entities.BundleTranslation, error) {
+		var t entities.BundleTranslation
+		err := row.Scan(&t.Locale, &t.Name, &t.Meta, &t.Title, &t.H1, &t.Content, &t.ContentShort,
+			&t.ContentFormat, &t.ContentHTML)
+		return t, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan page translation: %w", err)
+	}
+
+	rows, err = r.db.Query(ctx, queryBundleImages, id)
+	if err != nil {
+		r.log.Debug().Err(err).Msg("ExportPage4")
+		return nil, fmt.Errorf("failed to export page images: %w", err)
+	}
+	page.Images, err = pgx.CollectRows(rows, func(row pgx.CollectableRow) (entities.BundleImage, error) {
+		var img entities.BundleImage
+		err := row.Scan(&img.UUID, &img.Name, &img.Category, &img.ContentType, &img.Description,
+			&img.Size, &img.Sort, &img.Pinned)
+		return img, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan page image: %w", err)
+	}
+
+	return &page, nil
+}
+
+func (r *BundleRepository) FindPageID(ctx context.Context, name string) (int64, error) {
+	var id int64
+	err := r.db.QueryRow(ctx, queryBundleFindPage, name).Scan(&id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, nil
+		}
+		r.log.Debug().Err(err).Msg("FindPageID")
+		return 0, fmt.Errorf("failed to find page: %w", err)
+	}
+
+	return id, nil
+}
+
+func (r *BundleRepository) TranslationNameTaken(ctx context.Context, locale string, name string, pageID int64) (bool, error) {
+	var count int
+	if err := r.db.QueryRow(ctx, queryBundleTranslationUsed, locale, name, pageID).Scan(&count); err != nil {
+		r.log.Debug().Err(err).Msg("TranslationNameTaken")
+		return false, fmt.Errorf("failed to check translation name: %w", err)
+	}
+
+	return count > 0, nil
+}
+
+func (r *BundleRepository) ImageExists(ctx context.Context, uuid string) (bool, error) {
+	var count int
+	if err := r.db.QueryRow(ctx, queryBundleImageExists, uuid).Scan(&count); err != nil {
+		r.log.Debug().Err(err).Msg("ImageExists")
+		return false, fmt.Errorf("failed to check image: %w", err)
+	}
+
+	return count > 0, nil
+}
+
+func (r *BundleRepository) ContentTypeExists(ctx context.Context, name string) (bool, error) {
+	var count int
+	if err := r.db.QueryRow(ctx, queryBundleContentType, name).Scan(&count); err != nil {
+		r.log.Debug().Err(err).Msg("ContentTypeExists")
+		return false, fmt.Errorf("failed to check content type: %w", err)
+	}
+
+	return count > 0, nil
+}
+
+func (r *BundleRepository) ImportPage(ctx context.Context, page *entities.BundlePage, targetID int64, userID int64) (int64, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		r.log.Debug().Err(err).Msg("ImportPage1")
+		return 0, fmt.Errorf("failed to import page: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	id := targetID
+	if id == 0 {
+		err = tx.QueryRow(ctx, queryBundlePageInsert,
+			page.Name, page.Meta, page.Title, page.Category, page.Template, page.H1, page.Content, page.ContentShort,
+			page.ContentFormat, page.ContentHTML, page.Status, page.PublishAt, page.UnpublishAt, page.PublishedAt,
+			r.searchConfig, page.CreatedAt, page.UpdatedAt, page.ContentType, pageFields(page.Fields),
+		).Scan(&id)
+	} else {
+		err = r.clearPage(ctx, tx, page, id)
+	}
+	if err != nil {
+		r.log.Debug().Err(err).Msg("ImportPage2")
+		return 0, fmt.Errorf("failed to import page %s: %w", page.Name, err)
+	}
+
+	batch := &pgx.Batch{}
+	for _, rev := range page.Revisions {
+		batch.Queue(queryBundleRevisionInsert, id, rev.Revision, rev.Name, rev.Meta, rev.Title, rev.Category,
+			rev.Template, rev.H1, rev.Content, rev.ContentShort, rev.ContentFormat, rev.ContentType, pageFields(rev.Fields), rev.CreatedAt)
+	}
+	for _, t := range page.Translations {
+		batch.Queue(queryBundleTranslationInsert, id, t.Locale, t.Name, t.Meta, t.Title, t.H1, t.Content,
+			t.ContentShort, t.ContentFormat, t.ContentHTML)
+	}
+	for _, img := range page.Images {
+		batch.Queue(queryBundleImageInsert, userID, img.UUID, img.Size, img.Name, img.Category, img.ContentType, img.Description)
+		pinned := 0
+		if img.Pinned {
+			pinned = 1
+		}
+		batch.Queue(queryBundlePageImageInsert, id, img.UUID, img.Sort, pinned)
+	}
+	if err := tx.SendBatch(ctx, batch).Close(); err != nil {
+		r.log.Debug().Err(err).Msg("ImportPage3")
+		return 0, wrapPageTranslationError("failed to import page "+page.Name, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		r.log.Debug().Err(err).Msg("ImportPage4")
+		return 0, fmt.Errorf("failed to import page %s: %w", page.Name, err)
+	}
+
+	return id, nil
+}
+
+// clearPage overwrites the page row and removes its revisions,
+// translations and image links.
+func (r *BundleRepository) clearPage(ctx context.Context, tx pgx.Tx, page *entities.BundlePage, id int64) error {
+	tag, err := tx.Exec(ctx, queryBundlePageUpdate,
+		page.Name, page.Meta, page.Title, page.Category, page.Template, page.H1, page.Content, page.ContentShort,
+		page.ContentFormat, page.ContentHTML, page.Status, page.PublishAt, page.UnpublishAt, page.PublishedAt,
+		r.searchConfig, id, page.ContentType, pageFields(page.Fields),
+	)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return appPage.ErrPageNotFound
+	}
+
+	for _, query := range []string{queryBundleDeleteRevisions, queryBundleDeleteTranslations, queryBundleDeleteImages} {
+		if _, err := tx.Exec(ctx, query, id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// bundleFields leaves the fields of untyped pages out of the manifest.
+func bundleFields(fields entities.PageFields) entities.PageFields {
+	if len(fields) == 0 {
+		return nil
+	}
+	return fields
+}
@@ -0,0 +1,115 @@
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/aube/auth/internal/infrastructure/postgres"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testPool подключается к базе из TEST_DB_* и пропускает тест без неё
+func testPool(t *testing.T) *pgxpool.Pool {
+	host := os.Getenv("TEST_DB_HOST")
+	if host == "" {
+		t.Skip("TEST_DB_HOST is not set")
+	}
+
+	pool, err := postgres.NewPool(context.Background(), postgres.Config{
+		Host:     host,
+		Port:     os.Getenv("TEST_DB_PORT"),
+		User:     os.Getenv("TEST_DB_USER"),
+		Password: os.Getenv("TEST_DB_PASSWORD"),
+		DBName:   os.Getenv("TEST_DB_NAME"),
+		SSLMode:  "disable",
+	})
+	require.NoError(t, err)
+	t.Cleanup(pool.Close)
+	return pool
+}
+
+func TestBundleRepository_RoundTrip(t *testing.T) {
+	pool := testPool(t)
+	repo := postgres.NewBundleRepository(pool, "russian")
+	ctx := context.Background()
+
+	suffix := fmt.Sprint(time.Now().UnixNano())
+	created := time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC)
+	published := created.Add(time.Hour)
+
+	page := &entities.BundlePage{
+		Name:          "bundle-" + suffix,
+		Meta:          entities.PageMeta{Description: "Bundle test"},
+		Title:         "Bundle",
+		Category:      "test",
+		H1:            "Bundle",
+		Content:       "# Bundle",
+		ContentShort:  "Bundle",
+		ContentFormat: entities.ContentFormatMarkdown,
+		ContentHTML:   "<h1>Bundle</h1>",
+		Status:        entities.PageStatusPublished,
+		PublishedAt:   &published,
+		CreatedAt:     created,
+		UpdatedAt:     published,
+		Revisions: []entities.BundleRevision{
+			{Revision: 1, Name: "bundle-" + suffix, Title: "Draft", CreatedAt: created},
+			{Revision: 2, Name: "bundle-" + suffix, Title: "Bundle", CreatedAt: published},
+		},
+		Translations: []entities.BundleTranslation{
+			{Locale: "en", Name: "bundle-en-" + suffix, Title: "Bundle", ContentFormat: entities.ContentFormatHTML},
+		},
+		Images: []entities.BundleImage{
+			{UUID: uuid.NewString(), Name: "cover.jpg", ContentType: "image/jpeg", Size: 5, Sort: 1, Pinned: true},
+		},
+	}
+
+	id, err := repo.ImportPage(ctx, page, 0, 1)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		pool.Exec(ctx, "DELETE FROM page_translations WHERE page_id = $1", id)
+		pool.Exec(ctx, "DELETE FROM page_revisions WHERE page_id = $1", id)
+		pool.Exec(ctx, "DELETE FROM page_image WHERE page_id = $1", id)
+		pool.Exec(ctx, "DELETE FROM images WHERE uuid = $1", page.Images[0].UUID)
+		pool.Exec(ctx, "DELETE FROM pages WHERE id = $1", id)
+	})
+
+	exported, err := repo.ExportPage(ctx, id)
+	require.NoError(t, err)
+	exported.CreatedAt = exported.CreatedAt.UTC()
+	exported.UpdatedAt = exported.UpdatedAt.UTC()
+	for i := range exported.Revisions {
+		exported.Revisions[i].CreatedAt = exported.Revisions[i].CreatedAt.UTC()
+	}
+	if exported.PublishedAt != nil {
+		p := exported.PublishedAt.UTC()
+		exported.PublishedAt = &p
+	}
+	// SHA256 хранится только в манифесте
+	assert.Equal(t, page, exported)
+
+	found, err := repo.FindPageID(ctx, page.Name)
+	require.NoError(t, err)
+	assert.Equal(t, id, found)
+
+	// Перезапись заменяет ревизии, переводы и изображения
+	page.Title = "Overwritten"
+	page.Revisions = page.Revisions[:1]
+	page.Translations = nil
+	overwritten, err := repo.ImportPage(ctx, page, id, 1)
+	require.NoError(t, err)
+	assert.Equal(t, id, overwritten)
+
+	exported, err = repo.ExportPage(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, "Overwritten", exported.Title)
+	assert.Len(t, exported.Revisions, 1)
+	assert.Empty(t, exported.Translations)
+	assert.Len(t, exported.Images, 1)
+}
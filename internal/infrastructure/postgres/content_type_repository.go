@@ -0,0 +1,183 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	appContentType "github.com/aube/auth/internal/application/contenttype"
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/aube/auth/internal/utils/logger"
+	"github.com/rs/zerolog"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	contentTypeColumns string = "id, name, title, fields, created_at, updated_at"
+
+	queryContentTypeInsert        string = "INSERT INTO content_types (name, title, fields) VALUES ($1, $2, $3) RETURNING id"
+	queryContentTypeUpdate        string = "UPDATE content_types SET name = $2, title = $3, fields = $4 WHERE id = $1"
+	queryContentTypeDelete        string = "DELETE FROM content_types WHERE id = $1"
+	queryContentTypeSelectByID    string = "SELECT " + contentTypeColumns + " FROM content_types WHERE id = $1"
+	queryContentTypeSelectByName  string = "SELECT " + contentTypeColumns + " FROM content_types WHERE name = $1"
+	queryContentTypeSelectAll     string = "SELECT " + contentTypeColumns + " FROM content_types ORDER BY name"
+	queryContentTypeMissingImages string = `SELECT u FROM unnest($1::text[]) u
+		WHERE NOT EXISTS (SELECT 1 FROM images WHERE uuid = u::uuid and deleted = false)`
+)
+
+type ContentTypeRepository struct {
+	db  *pgxpool.Pool
+	log zerolog.Logger
+}
+
+func NewContentTypeRepository(db *pgxpool.Pool) *ContentTypeRepository {
+	return &ContentTypeRepository{
+		db:  db,
+		log: logger.Get().With().Str("postgres", "content_type_repository").Logger(),
+	}
+}
+
+func (r *ContentTypeRepository) Create(ctx context.Context, contentType *entities.ContentType) error {
+	err := r.db.QueryRow(ctx, queryContentTypeInsert,
+		contentType.Name,
+		contentType.Title,
+		contentType.Fields,
+	).Scan(&contentType.ID)
+	if err != nil {
+		r.log.Debug().Err(err).Msg("Create")
+		return wrapContentTypeError("failed to create content type", err)
+	}
+
+	return nil
+}
+
+func (r *ContentTypeRepository) Update(ctx context.Context, contentType *entities.ContentType) error {
+	tag, err := r.db.Exec(ctx, queryContentTypeUpdate,
+		contentType.ID,
+		contentType.Name,
+		contentType.Title,
+		contentType.Fields,
+	)
+	if err != nil {
+		r.log.Debug().Err(err).Msg("Update")
+		return wrapContentTypeError("failed to update content type", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return appContentType.ErrContentTypeNotFound
+	}
+
+	return nil
+}
+
+func (r *ContentTypeRepository) Delete(ctx context.Context, id int64) error {
+	tag, err := r.db.Exec(ctx, queryContentTypeDelete, id)
+	if err != nil {
+		r.log.Debug().Err(err).Msg("Delete")
+		return wrapContentTypeError("failed to delete content type", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return appContentType.ErrContentTypeNotFound
+	}
+
+	return nil
+}
+
+func (r *ContentTypeRepository) FindByID(ctx context.Context, id int64) (*entities.ContentType, error) {
+	contentType, err := scanContentType(r.db.QueryRow(ctx, queryContentTypeSelectByID, id))
+	if err != nil {
+		r.log.Debug().Err(err).Msg("FindByID")
+		return nil, wrapContentTypeError("failed to find content type", err)
+	}
+
+	return contentType, nil
+}
+
+func (r *ContentTypeRepository) FindByName(ctx context.Context, name string) (*entities.ContentType, error) {
+	contentType, err := scanContentType(r.db.QueryRow(ctx, queryContentTypeSelectByName, name))
+	if err != nil {
+		r.log.Debug().Err(err).Msg("FindByName")
+		return nil, wrapContentTypeError("failed to find content type", err)
+	}
+
+	return contentType, nil
+}
+
+func (r *ContentTypeRepository) List(ctx context.Context) (*entities.ContentTypes, error) {
+	rows, err := r.db.Query(ctx, queryContentTypeSelectAll)
+	if err != nil {
+		r.log.Debug().Err(err).Msg("List1")
+		return nil, fmt.Errorf("failed to list content types: %w", err)
+	}
+	defer rows.Close()
+
+	contentTypes := entities.ContentTypes{}
+	for rows.Next() {
+		contentType, err := scanContentType(rows)
+		if err != nil {
+			r.log.Debug().Err(err).Msg("List2")
+			return nil, fmt.Errorf("failed to scan content type row: %w", err)
+		}
+		contentTypes = append(contentTypes, *contentType)
+	}
+
+	if err = rows.Err(); err != nil {
+		r.log.Debug().Err(err).Msg("List3")
+		return nil, fmt.Errorf("error after iterating content type rows: %w", err)
+	}
+
+	return &contentTypes, nil
+}
+
+func (r *ContentTypeRepository) MissingImages(ctx context.Context, uuids []string) ([]string, error) {
+	if len(uuids) == 0 {
+		return nil, nil
+	}
+
+	rows, err := r.db.Query(ctx, queryContentTypeMissingImages, uuids)
+	if err != nil {
+		r.log.Debug().Err(err).Msg("MissingImages")
+		return nil, fmt.Errorf("failed to check images: %w", err)
+	}
+
+	missing, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, fmt.Errorf("failed to check images: %w", err)
+	}
+
+	return missing, nil
+}
+
+func scanContentType(row pgx.Row) (*entities.ContentType, error) {
+	var contentType entities.ContentType
+	err := row.Scan(
+		&contentType.ID,
+		&contentType.Name,
+		&contentType.Title,
+		&contentType.Fields,
+		&contentType.CreatedAt,
+		&contentType.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &contentType, nil
+}
+
+// wrapContentTypeError maps a duplicate name to ErrContentTypeExists and
+// a delete blocked by pages (foreign key violation) to ErrContentTypeInUse.
+func wrapContentTypeError(msg string, err error) error {
+	var pgErr *pgconn.PgError
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		return appContentType.ErrContentTypeNotFound
+	case errors.As(err, &pgErr) && pgErr.Code == "23505":
+		return appContentType.ErrContentTypeExists
+	case errors.As(err, &pgErr) && pgErr.Code == "23503":
+		return appContentType.ErrContentTypeInUse
+	}
+	return fmt.Errorf("%s: %w", msg, err)
+}
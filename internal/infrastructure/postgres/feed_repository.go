@@ -0,0 +1,70 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/aube/auth/internal/utils/logger"
+	"github.com/rs/zerolog"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	// $1 - категория (пустая - все категории), $2 - лимит, $3 - имя главной страницы
+	queryFeedItems string = `SELECT p.id, coalesce(p.title, ''), coalesce(p.category, ''), ` + publicPathExpr + `,
+			coalesce(p.content_short, ''), coalesce(p.content_html, ''),
+			coalesce(p.published_at, p.created_at, now()), coalesce(p.updated_at, p.created_at, now())
+		FROM pages p ` + publicPathJoin + `
+		WHERE p.deleted = false and p.status = 'published' and ($1 = '' or p.category = $1)
+		ORDER BY coalesce(p.published_at, p.created_at) DESC, p.id DESC
+		LIMIT $2`
+)
+
+type FeedRepository struct {
+	db  *pgxpool.Pool
+	log zerolog.Logger
+}
+
+func NewFeedRepository(db *pgxpool.Pool) *FeedRepository {
+	return &FeedRepository{
+		db:  db,
+		log: logger.Get().With().Str("postgres", "feed_repository").Logger(),
+	}
+}
+
+func (r *FeedRepository) ListItems(ctx context.Context, category string, limit int) (*entities.FeedItems, error) {
+	rows, err := r.db.Query(ctx, queryFeedItems, category, limit, entities.HomePageName)
+	if err != nil {
+		r.log.Debug().Err(err).Msg("ListItems1")
+		return nil, fmt.Errorf("failed to list feed items: %w", err)
+	}
+	defer rows.Close()
+
+	items := entities.FeedItems{}
+	for rows.Next() {
+		var item entities.FeedItem
+		if err := rows.Scan(
+			&item.PageID,
+			&item.Title,
+			&item.Category,
+			&item.Path,
+			&item.Summary,
+			&item.ContentHTML,
+			&item.PublishedAt,
+			&item.UpdatedAt,
+		); err != nil {
+			r.log.Debug().Err(err).Msg("ListItems2")
+			return nil, fmt.Errorf("failed to scan feed item: %w", err)
+		}
+		items = append(items, item)
+	}
+
+	if err = rows.Err(); err != nil {
+		r.log.Debug().Err(err).Msg("ListItems3")
+		return nil, fmt.Errorf("error after iterating feed items: %w", err)
+	}
+
+	return &items, nil
+}
@@ -0,0 +1,292 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aube/auth/internal/application/dto"
+	appFolder "github.com/aube/auth/internal/application/folder"
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/aube/auth/internal/utils/logger"
+	"github.com/aube/auth/internal/utils/query"
+	"github.com/rs/zerolog"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	folderFields string = "id, user_id, coalesce(parent_id, 0), name, created_at, updated_at"
+
+	// Изменения дерева папок пользователя выполняются по очереди
+	queryFolderLock   string = "SELECT pg_advisory_xact_lock(hashtextextended('upload_folders:' || $1::text, 0))"
+	queryFolderInsert string = `INSERT INTO upload_folders (user_id, parent_id, name, created_at, updated_at)
+		SELECT $1, nullif($2::integer, 0), $3, $4, $4
+		WHERE $2 = 0 or exists (SELECT 1 FROM upload_folders WHERE id = $2 and user_id = $1)
+		RETURNING id`
+	queryFolderUpdate string = `UPDATE upload_folders SET name = $3, parent_id = nullif($4::integer, 0)
+		WHERE id = $1 and user_id = $2 RETURNING updated_at`
+	queryFolderSelectByID   string = "SELECT " + folderFields + " FROM upload_folders WHERE id = $1 and user_id = $2"
+	queryFolderNamesPrefix  string = "SELECT name FROM upload_folders WHERE user_id = $1 and coalesce(parent_id, 0) = $2 and starts_with(name, $3)"
+	queryFolderDelete       string = "DELETE FROM upload_folders WHERE id = $1 and user_id = $2"
+	queryFolderTrashUploads string = `WITH RECURSIVE sub AS (
+			SELECT id, 0 AS depth FROM upload_folders WHERE id = $1 and user_id = $2
+			UNION
+			SELECT f.id, sub.depth + 1 FROM upload_folders f JOIN sub ON f.parent_id = sub.id WHERE sub.depth < 256
+		)
+		UPDATE uploads SET deleted = true, deleted_at = now()
+		WHERE folder_id IN (SELECT id FROM sub) and user_id = $2 and deleted = false`
+
+	// Обход ограничен 256 уровнями, чтобы испорченные данные не зациклили запрос
+	queryFolderSelectAncestors string = `WITH RECURSIVE anc AS (
+			SELECT id, user_id, parent_id, name, created_at, updated_at, 0 AS depth
+			FROM upload_folders WHERE id = $1 and user_id = $2
+			UNION
+			SELECT f.id, f.user_id, f.parent_id, f.name, f.created_at, f.updated_at, anc.depth + 1
+			FROM upload_folders f JOIN anc ON f.id = anc.parent_id WHERE anc.depth < 256
+		)
+		SELECT ` + folderFields + ` FROM anc ORDER BY depth DESC`
+
+	folderEntriesSelect string = `SELECT 'folder' AS type, id, '' AS uuid, name, 0::bigint AS size, '' AS content_type,
+			user_id, coalesce(parent_id, 0) AS folder_id, updated_at
+			FROM upload_folders
+		UNION ALL SELECT 'file', id, uuid::text, name, coalesce(size, 0), content_type,
+			user_id, coalesce(folder_id, 0), updated_at
+			FROM uploads WHERE deleted = false`
+)
+
+// id уникален только внутри типа
+var folderEntryListSpec = listSpec{
+	fields: "type, id, uuid, name, size, content_type, updated_at",
+	from:   "(" + folderEntriesSelect + ") e",
+	where:  "user_id = $1 and folder_id = $2",
+	columns: query.Columns{
+		"type":       "type",
+		"name":       "name",
+		"size":       "size",
+		"updated_at": "updated_at",
+	},
+	tieBreaker: "type || ':' || id",
+}
+
+// FolderRepository provides PostgreSQL storage for folders of uploads.
+// Moves run in a transaction holding an advisory lock of the owner,
+// so concurrent moves cannot create cycles.
+type FolderRepository struct {
+	db  *pgxpool.Pool
+	log zerolog.Logger
+}
+
+func NewFolderRepository(db *pgxpool.Pool) *FolderRepository {
+	return &FolderRepository{
+		db:  db,
+		log: logger.Get().With().Str("postgres", "folder_repository").Logger(),
+	}
+}
+
+func (r *FolderRepository) Create(ctx context.Context, folder *entities.UploadFolder) error {
+	err := r.db.QueryRow(ctx, queryFolderInsert,
+		folder.UserID,
+		folder.ParentID,
+		folder.Name,
+		folder.CreatedAt,
+	).Scan(&folder.ID)
+	if err != nil {
+		r.log.Debug().Err(err).Msg("Create")
+		return wrapFolderError("failed to create folder", err)
+	}
+
+	return nil
+}
+
+func (r *FolderRepository) Update(ctx context.Context, folder *entities.UploadFolder) error {
+	err := r.inFolderTx(ctx, folder.UserID, func(tx pgx.Tx) error {
+		if folder.ParentID > 0 {
+			ancestors, err := r.listAncestors(ctx, tx, folder.ParentID, folder.UserID)
+			if err != nil {
+				return err
+			}
+			if len(ancestors) == 0 {
+				return appFolder.ErrFolderNotFound
+			}
+			for _, ancestor := range ancestors {
+				if ancestor.ID == folder.ID {
+					return appFolder.ErrFolderCycle
+				}
+			}
+		}
+
+		return tx.QueryRow(ctx, queryFolderUpdate,
+			folder.ID,
+			folder.UserID,
+			folder.Name,
+			folder.ParentID,
+		).Scan(&folder.UpdatedAt)
+	})
+
+	if err != nil {
+		r.log.Debug().Err(err).Msg("Update")
+		return wrapFolderError("failed to update folder", err)
+	}
+
+	return nil
+}
+
+func (r *FolderRepository) Delete(ctx context.Context, id int64, userID int64) (int64, error) {
+	var trashed int64
+	err := r.inFolderTx(ctx, userID, func(tx pgx.Tx) error {
+		// Файлы уходят в корзину, при восстановлении они попадут в корень
+		tag, err := tx.Exec(ctx, queryFolderTrashUploads, id, userID)
+		if err != nil {
+			return err
+		}
+		trashed = tag.RowsAffected()
+
+		tag, err = tx.Exec(ctx, queryFolderDelete, id, userID)
+		if err != nil {
+			return err
+		}
+		if tag.RowsAffected() == 0 {
+			return appFolder.ErrFolderNotFound
+		}
+		return nil
+	})
+
+	if err != nil {
+		r.log.Debug().Err(err).Msg("Delete")
+		return 0, wrapFolderError("failed to delete folder", err)
+	}
+
+	return trashed, nil
+}
+
+func (r *FolderRepository) FindByID(ctx context.Context, id int64, userID int64) (*entities.UploadFolder, error) {
+	folder, err := scanFolder(r.db.QueryRow(ctx, queryFolderSelectByID, id, userID))
+	if err != nil {
+		r.log.Debug().Err(err).Msg("FindByID")
+		return nil, wrapFolderError("failed to find folder", err)
+	}
+
+	return folder, nil
+}
+
+func (r *FolderRepository) ListAncestors(ctx context.Context, id int64, userID int64) (*entities.UploadFolders, error) {
+	folders, err := r.listAncestors(ctx, r.db, id, userID)
+	if err != nil {
+		r.log.Debug().Err(err).Msg("ListAncestors")
+		return nil, fmt.Errorf("failed to list folders: %w", err)
+	}
+	if len(folders) == 0 {
+		return nil, appFolder.ErrFolderNotFound
+	}
+
+	return &folders, nil
+}
+
+func (r *FolderRepository) NamesWithPrefix(ctx context.Context, userID int64, parentID int64, prefix string) ([]string, error) {
+	names, err := collectKeys[string](ctx, r.db, queryFolderNamesPrefix, userID, parentID, prefix)
+	if err != nil {
+		r.log.Debug().Err(err).Msg("NamesWithPrefix")
+		return nil, fmt.Errorf("failed to list folder names: %w", err)
+	}
+	return names, nil
+}
+
+func (r *FolderRepository) ListChildren(ctx context.Context, userID int64, folderID int64, offset, limit int, filter *query.Query) (*entities.FolderEntries, *dto.Pagination, error) {
+	spec := folderEntryListSpec
+	spec.args = []any{userID, folderID}
+
+	entries, pagination, err := listPage(ctx, r.db, spec, offset, limit, filter, scanFolderEntry)
+	if err != nil {
+		r.log.Debug().Err(err).Msg("ListChildren")
+		return nil, nil, fmt.Errorf("failed to list folder: %w", err)
+	}
+
+	result := entities.FolderEntries(entries)
+	return &result, pagination, nil
+}
+
+func (r *FolderRepository) listAncestors(ctx context.Context, q queryer, id int64, userID int64) (entities.UploadFolders, error) {
+	rows, err := q.Query(ctx, queryFolderSelectAncestors, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	folders := entities.UploadFolders{}
+	for rows.Next() {
+		folder, err := scanFolder(rows)
+		if err != nil {
+			return nil, err
+		}
+		folders = append(folders, *folder)
+	}
+	return folders, rows.Err()
+}
+
+func (r *FolderRepository) inFolderTx(ctx context.Context, userID int64, fn func(tx pgx.Tx) error) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, queryFolderLock, userID); err != nil {
+		return err
+	}
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func scanFolder(row pgx.Row) (*entities.UploadFolder, error) {
+	var folder entities.UploadFolder
+	err := row.Scan(
+		&folder.ID,
+		&folder.UserID,
+		&folder.ParentID,
+		&folder.Name,
+		&folder.CreatedAt,
+		&folder.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &folder, nil
+}
+
+func scanFolderEntry(row pgx.Row) (*entities.FolderEntry, error) {
+	var entry entities.FolderEntry
+	err := row.Scan(
+		&entry.Type,
+		&entry.ID,
+		&entry.UUID,
+		&entry.Name,
+		&entry.Size,
+		&entry.ContentType,
+		&entry.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+func wrapFolderError(msg string, err error) error {
+	var pgErr *pgconn.PgError
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		return appFolder.ErrFolderNotFound
+	case errors.As(err, &pgErr) && pgErr.Code == "23505":
+		return appFolder.ErrFolderExists
+	case errors.Is(err, appFolder.ErrFolderNotFound),
+		errors.Is(err, appFolder.ErrFolderCycle):
+		return err
+	}
+	return fmt.Errorf("%s: %w", msg, err)
+}
@@ -4,15 +4,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"math"
-	"strings"
 	"time"
 
 	"github.com/aube/auth/internal/application/dto"
 	appUpload "github.com/aube/auth/internal/application/upload"
 	"github.com/aube/auth/internal/domain/entities"
 	"github.com/aube/auth/internal/utils/logger"
-	"github.com/aube/auth/internal/utils/sql"
+	"github.com/aube/auth/internal/utils/query"
 	"github.com/rs/zerolog"
 
 	"github.com/jackc/pgx/v5"
@@ -20,15 +18,20 @@ import (
 )
 
 const (
-	queryImageInsert              string = "INSERT INTO images (user_id, uuid, size, name, category, content_type, description) VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id"
-	queryImageSelectByUserID      string = "SELECT id, user_id, uuid, size, name, category, content_type, description, created_at FROM images %WHERE% OFFSET $1 LIMIT $2"
-	queryImageSelectByUserIDTotal string = "SELECT count(*) total FROM images %WHERE%"
-	queryImageGetByUUID           string = "SELECT id, user_id, size, name, category, content_type, description, created_at FROM images WHERE uuid = $1 and user_id=$2 and deleted=false"
-	queryImageGetByName           string = "SELECT id, user_id, uuid, size, category, content_type, description, created_at FROM images WHERE name = $1 and user_id=$2 and deleted=false"
-	queryImageDelete              string = "UPDATE images SET deleted=true WHERE uuid = $1 and user_id=$2"
-	queryImageDeleteForce         string = "DELETE images WHERE uuid = $1 and user_id=$2"
+	queryImageInsert      string = "INSERT INTO images (user_id, uuid, size, name, category, content_type, description, declared_content_type) VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING id"
+	queryImageGetByUUID   string = "SELECT id, user_id, size, name, category, content_type, version, description, created_at, declared_content_type FROM images WHERE uuid = $1 and user_id=$2 and deleted=false"
+	queryImageGetByName   string = "SELECT id, user_id, uuid, size, category, content_type, version, description, created_at, declared_content_type FROM images WHERE name = $1 and user_id=$2 and deleted=false"
+	queryImageDelete      string = "UPDATE images SET deleted=true, deleted_at=now() WHERE uuid = $1 and user_id=$2 and deleted=false"
+	queryImageDeleteForce string = "DELETE FROM images WHERE uuid = $1 and user_id=$2"
 )
 
+var imageListSpec = listSpec{
+	fields:     "id, user_id, uuid, size, name, category, content_type, version, description, created_at, declared_content_type, " + imageTagsColumn,
+	from:       "images",
+	columns:    fileColumns.With("tags", imageTagsColumn),
+	tieBreaker: "id",
+}
+
 type ImageRepository struct {
 	db  *pgxpool.Pool
 	log zerolog.Logger
@@ -60,6 +63,7 @@ func (r *ImageRepository) Create(
 		image.Category,
 		image.ContentType,
 		image.Description,
+		image.DeclaredType,
 	).Scan(&id)
 
 	if err != nil {
@@ -72,91 +76,18 @@ func (r *ImageRepository) Create(
 
 // List returns all URL mappings for the current user from the database.
 // Returns an unauthorized error if no user ID is present in context.
-func (r *ImageRepository) ListByUserID(ctx context.Context, userID int64, offset, limit int, params map[string]any) (*entities.Images, *dto.Pagination, error) {
-
-	whereClause, whereParams := sql.BuildWhere(params, "AND", 3)
-	allParams := []any{offset, limit}
-	allParams = append(allParams, whereParams...)
-
-	query := strings.Replace(queryImageSelectByUserID, "%WHERE%", "WHERE "+whereClause, 1)
-
-	rows, err := r.db.Query(ctx, query, allParams...)
-	if err != nil {
-		r.log.Debug().Err(err).Msg("ListByUserID1")
-		return nil, nil, err
-	}
-	defer rows.Close()
-
-	var images entities.Images
-
-	for rows.Next() {
-		var (
-			id          int64
-			userId      int64
-			uuid        string
-			size        int64
-			name        string
-			category    string
-			contentType string
-			description string
-			createdAt   time.Time
-		)
-
-		err := rows.Scan(
-			&id,
-			&userId,
-			&uuid,
-			&size,
-			&name,
-			&category,
-			&contentType,
-			&description,
-			&createdAt,
-		)
-		if err != nil {
-			r.log.Error().Err(err).Msg("Failed to scan image row")
-			return nil, nil, fmt.Errorf("failed to scan image row: %w", err)
-		}
-
-		file := entities.NewFile(uuid, "", size)
-		image := entities.NewImage(
-			file,
-			id,
-			userId,
-			name,
-			category,
-			contentType,
-			description,
-			createdAt,
-		)
-		images = append(images, *image)
-	}
-
-	if err = rows.Err(); err != nil {
-		r.log.Debug().Err(err).Msg("ListByUserID2")
-		return nil, nil, fmt.Errorf("error after iterating image rows: %w", err)
-	}
-
-	// Totals
-	whereClause, whereParams = sql.BuildWhere(params, "AND", 1)
-	query = strings.Replace(queryImageSelectByUserIDTotal, "%WHERE%", "WHERE "+whereClause, 1)
-
-	var total int
-	err = r.db.QueryRow(ctx, query, whereParams...).Scan(&total)
+func (r *ImageRepository) ListByUserID(ctx context.Context, userID int64, offset, limit int, filter *query.Query) (*entities.Images, *dto.Pagination, error) {
 
+	// Пользователь видит только свои файлы
+	filter = filter.Clone().Add("user_id", query.OpEq, userID)
+	images, pagination, err := listPage(ctx, r.db, imageListSpec, offset, limit, filter, scanImage)
 	if err != nil {
-		r.log.Debug().Err(err).Msg("GetTotals")
-		return nil, nil, fmt.Errorf("failed to get totals: %w", err)
+		r.log.Debug().Err(err).Msg("ListByUserID")
+		return nil, nil, fmt.Errorf("failed to list images: %w", err)
 	}
 
-	page := float64(offset) / float64(limit)
-	pagination := dto.Pagination{
-		Total: total,
-		Page:  int(math.Round(page)) + 1,
-		Size:  limit,
-	}
-
-	return &images, &pagination, nil
+	result := entities.Images(images)
+	return &result, pagination, nil
 }
 
 func (r *ImageRepository) GetByUUID(ctx context.Context, uuid string, userID int64) (*entities.Image, error) {
@@ -167,11 +98,13 @@ func (r *ImageRepository) GetByUUID(ctx context.Context, uuid string, userID int
 		category    string
 		size        int64
 		contentType string
+		version     int
 		description string
 		createdAt   time.Time
+		declared    string
 	)
 
-	err := r.db.QueryRow(ctx, queryImageGetByUUID, uuid, userID).Scan(&id, &user_id, &size, &name, &category, &contentType, &description, &createdAt)
+	err := r.db.QueryRow(ctx, queryImageGetByUUID, uuid, userID).Scan(&id, &user_id, &size, &name, &category, &contentType, &version, &description, &createdAt, &declared)
 
 	if err != nil {
 		r.log.Debug().Err(err).Msg("GetByUUID")
@@ -183,7 +116,7 @@ func (r *ImageRepository) GetByUUID(ctx context.Context, uuid string, userID int
 
 	file := entities.NewFile(uuid, "", size)
 
-	return entities.NewImage(
+	image := entities.NewImage(
 		file,
 		id,
 		user_id,
@@ -192,7 +125,10 @@ func (r *ImageRepository) GetByUUID(ctx context.Context, uuid string, userID int
 		contentType,
 		description,
 		createdAt,
-	), nil
+	)
+	image.Version = version
+	image.DeclaredType = declared
+	return image, nil
 }
 
 func (r *ImageRepository) GetByName(ctx context.Context, name string, userID int64) (*entities.Image, error) {
@@ -203,11 +139,13 @@ func (r *ImageRepository) GetByName(ctx context.Context, name string, userID int
 		size        int64
 		category    string
 		contentType string
+		version     int
 		description string
 		createdAt   time.Time
+		declared    string
 	)
 
-	err := r.db.QueryRow(ctx, queryImageGetByName, name, userID).Scan(&id, &user_id, &uuid, &size, &category, &contentType, &description, &createdAt)
+	err := r.db.QueryRow(ctx, queryImageGetByName, name, userID).Scan(&id, &user_id, &uuid, &size, &category, &contentType, &version, &description, &createdAt, &declared)
 
 	if err != nil {
 		r.log.Debug().Err(err).Msg("GetByName")
@@ -219,7 +157,7 @@ func (r *ImageRepository) GetByName(ctx context.Context, name string, userID int
 
 	file := entities.NewFile(uuid, "", size)
 
-	return entities.NewImage(
+	image := entities.NewImage(
 		file,
 		id,
 		user_id,
@@ -228,33 +166,85 @@ func (r *ImageRepository) GetByName(ctx context.Context, name string, userID int
 		contentType,
 		description,
 		createdAt,
-	), nil
+	)
+	image.Version = version
+	image.DeclaredType = declared
+	return image, nil
 }
 
 func (r *ImageRepository) Delete(ctx context.Context, uuid string, userID int64) error {
-	_, err := r.db.Query(ctx, queryImageDelete, uuid, userID)
-
+	tag, err := r.db.Exec(ctx, queryImageDelete, uuid, userID)
 	if err != nil {
 		r.log.Debug().Err(err).Msg("Delete")
-		if errors.Is(err, pgx.ErrNoRows) {
-			return appUpload.ErrFileNotFound
-		}
 		return fmt.Errorf("failed to delete image: %w", err)
 	}
+	if tag.RowsAffected() == 0 {
+		return appUpload.ErrFileNotFound
+	}
 
 	return nil
 }
 
 func (r *ImageRepository) DeleteForce(ctx context.Context, uuid string, userID int64) error {
-	_, err := r.db.Query(ctx, queryImageDeleteForce, uuid, userID)
-
+	tag, err := r.db.Exec(ctx, queryImageDeleteForce, uuid, userID)
 	if err != nil {
-		r.log.Debug().Err(err).Msg("Delete")
-		if errors.Is(err, pgx.ErrNoRows) {
-			return appUpload.ErrFileNotFound
-		}
+		r.log.Debug().Err(err).Msg("DeleteForce")
 		return fmt.Errorf("failed to delete image: %w", err)
 	}
+	if tag.RowsAffected() == 0 {
+		return appUpload.ErrFileNotFound
+	}
 
 	return nil
 }
+
+func scanImage(row pgx.Row) (*entities.Image, error) {
+	var (
+		id          int64
+		userId      int64
+		uuid        string
+		size        int64
+		name        string
+		category    string
+		contentType string
+		version     int
+		description string
+		createdAt   time.Time
+		declared    string
+		tags        []string
+	)
+
+	err := row.Scan(
+		&id,
+		&userId,
+		&uuid,
+		&size,
+		&name,
+		&category,
+		&contentType,
+		&version,
+		&description,
+		&createdAt,
+		&declared,
+		&tags,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	file := entities.NewFile(uuid, "", size)
+	image := entities.NewImage(
+		file,
+		id,
+		userId,
+		name,
+		category,
+		contentType,
+		description,
+		createdAt,
+	)
+	image.Version = version
+	image.DeclaredType = declared
+	image.Tags = tags
+	return image, nil
+}
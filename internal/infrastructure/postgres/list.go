@@ -0,0 +1,157 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/aube/auth/internal/application/dto"
+	"github.com/aube/auth/internal/utils/query"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// listSpec describes a list query shared by the offset and cursor modes.
+// where holds fixed conditions numbered from $1 with args.
+type listSpec struct {
+	fields     string
+	from       string
+	where      string
+	args       []any
+	columns    query.Columns
+	tieBreaker string
+}
+
+// listPage runs a list query. Without a cursor rows are skipped by offset;
+// with one the page continues from the cursor row and offset is ignored.
+// Next/Prev cursors are set for adjacent pages, the total is skipped by filter.SkipTotal.
+func listPage[T any](ctx context.Context, db *pgxpool.Pool, spec listSpec, offset, limit int, filter *query.Query, scan func(pgx.Row) (*T, error)) ([]T, *dto.Pagination, error) {
+	var cursor *query.Cursor
+	if filter != nil {
+		cursor = filter.Cursor
+	}
+	if cursor != nil {
+		offset = 0
+	}
+
+	conditions, args, err := spec.conditions(filter)
+	if err != nil {
+		return nil, nil, err
+	}
+	keyset, keyArgs, err := filter.Keyset(spec.columns, spec.tieBreaker, len(args)+1)
+	if err != nil {
+		return nil, nil, err
+	}
+	orderClause, err := filter.OrderBy(spec.columns, spec.tieBreaker)
+	if err != nil {
+		return nil, nil, err
+	}
+	keyColumns, err := filter.KeyColumns(spec.columns, spec.tieBreaker)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	where := conditions
+	if keyset != "" {
+		where = append(slices.Clone(where), keyset)
+	}
+	pageArgs := append(slices.Clone(args), keyArgs...)
+	pageArgs = append(pageArgs, offset, limit+1)
+	sql := fmt.Sprintf("SELECT %s, %s FROM %s %s %s OFFSET $%d LIMIT $%d",
+		spec.fields, strings.Join(keyColumns, ", "), spec.from, whereClause(where), orderClause, len(pageArgs)-1, len(pageArgs))
+
+	rows, err := db.Query(ctx, sql, pageArgs...)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	var (
+		items []T
+		keys  [][]any
+	)
+	for rows.Next() {
+		row := keyRow{row: rows, key: make([]any, len(keyColumns))}
+		item, err := scan(row)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		items = append(items, *item)
+		keys = append(keys, row.key)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("error after iterating rows: %w", err)
+	}
+
+	// Лишняя строка показывает, что за страницей есть ещё
+	more := len(items) > limit
+	if more {
+		items, keys = items[:limit], keys[:limit]
+	}
+	backward := cursor != nil && cursor.Backward
+	if backward {
+		slices.Reverse(items)
+		slices.Reverse(keys)
+	}
+
+	pagination := dto.Pagination{Size: limit, Total: -1}
+	if cursor == nil {
+		page := float64(offset) / float64(limit)
+		pagination.Page = int(math.Round(page)) + 1
+	}
+	if len(items) > 0 {
+		if more || backward {
+			pagination.Next = filter.CursorAt(keys[len(keys)-1], false)
+		}
+		if (more && backward) || (!backward && (cursor != nil || offset > 0)) {
+			pagination.Prev = filter.CursorAt(keys[0], true)
+		}
+	}
+
+	if filter == nil || !filter.SkipTotal {
+		sql = fmt.Sprintf("SELECT count(*) FROM %s %s", spec.from, whereClause(conditions))
+		if err := db.QueryRow(ctx, sql, args...).Scan(&pagination.Total); err != nil {
+			return nil, nil, fmt.Errorf("failed to get totals: %w", err)
+		}
+	}
+
+	return items, &pagination, nil
+}
+
+func (s listSpec) conditions(filter *query.Query) ([]string, []any, error) {
+	var where []string
+	if s.where != "" {
+		where = append(where, s.where)
+	}
+	conditions, args, err := filter.Conditions(s.columns, len(s.args)+1)
+	if err != nil {
+		return nil, nil, err
+	}
+	if conditions != "" {
+		where = append(where, conditions)
+	}
+	return where, append(slices.Clone(s.args), args...), nil
+}
+
+func whereClause(conditions []string) string {
+	if len(conditions) == 0 {
+		return ""
+	}
+	return "WHERE " + strings.Join(conditions, " AND ")
+}
+
+// keyRow reads the sort key columns selected after the row fields.
+type keyRow struct {
+	row pgx.Row
+	key []any
+}
+
+func (k keyRow) Scan(dest ...any) error {
+	for i := range k.key {
+		dest = append(dest, &k.key[i])
+	}
+	return k.row.Scan(dest...)
+}
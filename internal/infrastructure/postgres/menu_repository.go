@@ -0,0 +1,98 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	appMenu "github.com/aube/auth/internal/application/menu"
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/aube/auth/internal/utils/logger"
+	"github.com/rs/zerolog"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	queryMenuSelectByName string = "SELECT id, name, root_id, depth FROM menus WHERE name = $1"
+	queryMenuSelect       string = "SELECT id, name, root_id, depth FROM menus ORDER BY name"
+	queryMenuUpsert       string = `INSERT INTO menus (name, root_id, depth) VALUES ($1, $2, $3)
+		ON CONFLICT (name) DO UPDATE SET root_id = EXCLUDED.root_id, depth = EXCLUDED.depth RETURNING id`
+	queryMenuDelete string = "DELETE FROM menus WHERE name = $1"
+)
+
+type MenuRepository struct {
+	db  *pgxpool.Pool
+	log zerolog.Logger
+}
+
+func NewMenuRepository(db *pgxpool.Pool) *MenuRepository {
+	return &MenuRepository{
+		db:  db,
+		log: logger.Get().With().Str("postgres", "menu_repository").Logger(),
+	}
+}
+
+func (r *MenuRepository) FindByName(ctx context.Context, name string) (*entities.Menu, error) {
+	var menu entities.Menu
+	err := r.db.QueryRow(ctx, queryMenuSelectByName, name).Scan(&menu.ID, &menu.Name, &menu.RootID, &menu.Depth)
+	if err != nil {
+		r.log.Debug().Err(err).Msg("FindByName")
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, appMenu.ErrMenuNotFound
+		}
+		return nil, fmt.Errorf("failed to find menu: %w", err)
+	}
+
+	return &menu, nil
+}
+
+func (r *MenuRepository) List(ctx context.Context) (*entities.Menus, error) {
+	rows, err := r.db.Query(ctx, queryMenuSelect)
+	if err != nil {
+		r.log.Debug().Err(err).Msg("List1")
+		return nil, fmt.Errorf("failed to list menus: %w", err)
+	}
+	defer rows.Close()
+
+	menus := entities.Menus{}
+	for rows.Next() {
+		var menu entities.Menu
+		if err := rows.Scan(&menu.ID, &menu.Name, &menu.RootID, &menu.Depth); err != nil {
+			r.log.Debug().Err(err).Msg("List2")
+			return nil, fmt.Errorf("failed to scan menu row: %w", err)
+		}
+		menus = append(menus, menu)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.log.Debug().Err(err).Msg("List3")
+		return nil, fmt.Errorf("error after iterating menu rows: %w", err)
+	}
+
+	return &menus, nil
+}
+
+func (r *MenuRepository) Save(ctx context.Context, menu *entities.Menu) error {
+	err := r.db.QueryRow(ctx, queryMenuUpsert, menu.Name, menu.RootID, menu.Depth).Scan(&menu.ID)
+	if err != nil {
+		r.log.Debug().Err(err).Msg("Save")
+		return fmt.Errorf("failed to save menu: %w", err)
+	}
+
+	return nil
+}
+
+func (r *MenuRepository) Delete(ctx context.Context, name string) error {
+	tag, err := r.db.Exec(ctx, queryMenuDelete, name)
+	if err != nil {
+		r.log.Debug().Err(err).Msg("Delete")
+		return fmt.Errorf("failed to delete menu: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return appMenu.ErrMenuNotFound
+	}
+
+	return nil
+}
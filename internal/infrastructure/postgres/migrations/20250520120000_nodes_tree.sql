@@ -0,0 +1,46 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE node_page ALTER COLUMN node_id TYPE integer;
+ALTER TABLE node_page ALTER COLUMN page_id TYPE integer;
+
+CREATE INDEX nodes_parent on nodes (parent, sort);
+
+-- Прежде путь не вычислялся и у всех узлов равен '/'.
+-- Одноимённые соседи получили бы один путь: к имени добавляется id
+UPDATE nodes SET name = name || '-' || id
+WHERE deleted = false and EXISTS (
+    SELECT 1 FROM nodes s WHERE s.parent = nodes.parent and s.name = nodes.name and s.id < nodes.id and s.deleted = false
+);
+
+-- Пути и уровни строятся от корня так же, как при переносе узла
+WITH RECURSIVE tree AS (
+    SELECT id, ('/' || name)::varchar AS path, 0 AS level FROM nodes WHERE parent = 0 and deleted = false
+    UNION
+    SELECT n.id, (tree.path || '/' || n.name)::varchar, tree.level + 1 FROM nodes n JOIN tree ON n.parent = tree.id
+    WHERE n.deleted = false and tree.level < 256
+)
+UPDATE nodes SET path = tree.path, level = tree.level FROM tree WHERE nodes.id = tree.id;
+
+-- Узлы вне дерева (родитель удалён) сохраняют старый путь; делаем его уникальным
+UPDATE nodes SET path = path || '-' || id
+WHERE deleted = false and EXISTS (
+    SELECT 1 FROM nodes s WHERE s.path = nodes.path and s.id < nodes.id and s.deleted = false
+);
+
+CREATE UNIQUE INDEX nodes_path on nodes (path) WHERE deleted = false;
+CREATE UNIQUE INDEX node_page_node_id_page_id on node_page (node_id, page_id);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX node_page_node_id_page_id;
+DROP INDEX nodes_path;
+DROP INDEX nodes_parent;
+
+ALTER TABLE node_page ALTER COLUMN page_id TYPE smallint;
+ALTER TABLE node_page ALTER COLUMN node_id TYPE smallint;
+
+-- +goose StatementEnd
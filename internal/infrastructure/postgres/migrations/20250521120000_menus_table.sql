@@ -0,0 +1,32 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE menus (
+    id SERIAL not null primary key,
+    name varchar(64) NOT NULL unique,
+    root_id INTEGER NOT NULL DEFAULT '0',
+    depth smallint NOT NULL DEFAULT '0',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TRIGGER menus_updated_at_trigger
+BEFORE UPDATE ON menus
+FOR EACH ROW
+EXECUTE FUNCTION update_updated_at();
+
+INSERT INTO menus (name, root_id, depth) VALUES
+    ('main', 0, 2),
+    ('footer', 0, 1),
+    ('sidebar', 0, 0);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TRIGGER menus_updated_at_trigger ON menus;
+
+DROP TABLE menus;
+
+-- +goose StatementEnd
@@ -0,0 +1,33 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE page_revisions (
+    id SERIAL not null primary key,
+    page_id INTEGER NOT NULL REFERENCES pages (id) ON DELETE CASCADE,
+    revision INTEGER NOT NULL,
+
+    name varchar(256) NOT NULL,
+    meta text NOT NULL default '',
+    title varchar(1024) NOT NULL default '',
+    category varchar NOT NULL default '',
+    template varchar NOT NULL default '',
+    h1 varchar(1024) NOT NULL default '',
+    content text NOT NULL default '',
+    content_short varchar(4096) NOT NULL default '',
+
+    author_id bigint NOT NULL default '0',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX page_revisions_page_id_revision on page_revisions (page_id, revision);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX page_revisions_page_id_revision;
+
+DROP TABLE page_revisions;
+
+-- +goose StatementEnd
@@ -0,0 +1,30 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE pages ADD COLUMN status varchar(16) NOT NULL default 'draft';
+ALTER TABLE pages ADD COLUMN publish_at TIMESTAMP;
+ALTER TABLE pages ADD COLUMN unpublish_at TIMESTAMP;
+ALTER TABLE pages ADD COLUMN published_at TIMESTAMP;
+
+-- До появления статусов все страницы были доступны публично
+UPDATE pages SET status = 'published', published = true, published_at = updated_at WHERE deleted = false;
+
+CREATE INDEX pages_status on pages (status) WHERE deleted = false;
+CREATE INDEX pages_publish_at on pages (publish_at) WHERE publish_at IS NOT NULL;
+CREATE INDEX pages_unpublish_at on pages (unpublish_at) WHERE unpublish_at IS NOT NULL;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX pages_unpublish_at;
+DROP INDEX pages_publish_at;
+DROP INDEX pages_status;
+
+ALTER TABLE pages DROP COLUMN published_at;
+ALTER TABLE pages DROP COLUMN unpublish_at;
+ALTER TABLE pages DROP COLUMN publish_at;
+ALTER TABLE pages DROP COLUMN status;
+
+-- +goose StatementEnd
@@ -0,0 +1,43 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE pages ADD COLUMN search_config regconfig NOT NULL default 'simple';
+ALTER TABLE pages ADD COLUMN search_vector tsvector;
+
+CREATE OR REPLACE FUNCTION pages_search_vector_update()
+RETURNS TRIGGER AS $$
+BEGIN
+    NEW.search_vector =
+        setweight(to_tsvector(NEW.search_config, coalesce(NEW.title, '')), 'A') ||
+        setweight(to_tsvector(NEW.search_config, coalesce(NEW.h1, '')), 'A') ||
+        setweight(to_tsvector(NEW.search_config, coalesce(NEW.content_short, '')), 'B') ||
+        setweight(to_tsvector(NEW.search_config, coalesce(NEW.content, '')), 'C');
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER pages_search_vector_trigger
+BEFORE INSERT OR UPDATE OF title, h1, content, content_short, search_config ON pages
+FOR EACH ROW
+EXECUTE FUNCTION pages_search_vector_update();
+
+-- Индексируем существующие страницы, не трогая updated_at
+ALTER TABLE pages DISABLE TRIGGER pages_updated_at_trigger;
+UPDATE pages SET search_config = search_config;
+ALTER TABLE pages ENABLE TRIGGER pages_updated_at_trigger;
+
+CREATE INDEX pages_search_vector on pages USING gin (search_vector);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX pages_search_vector;
+DROP TRIGGER pages_search_vector_trigger ON pages;
+DROP FUNCTION pages_search_vector_update();
+
+ALTER TABLE pages DROP COLUMN search_vector;
+ALTER TABLE pages DROP COLUMN search_config;
+
+-- +goose StatementEnd
@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE pages ADD COLUMN content_format varchar(16) NOT NULL default 'html';
+-- Пустое значение означает, что страница ещё не отрендерена (заполняется при старте сервиса)
+ALTER TABLE pages ADD COLUMN content_html text NOT NULL default '';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE pages DROP COLUMN content_html;
+ALTER TABLE pages DROP COLUMN content_format;
+
+-- +goose StatementEnd
@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Свободный текст meta становится описанием страницы
+ALTER TABLE pages ALTER COLUMN meta DROP DEFAULT;
+ALTER TABLE pages ALTER COLUMN meta TYPE jsonb
+    USING CASE WHEN meta = '' THEN '{}'::jsonb ELSE jsonb_build_object('description', meta) END;
+ALTER TABLE pages ALTER COLUMN meta SET DEFAULT '{}';
+
+ALTER TABLE page_revisions ALTER COLUMN meta DROP DEFAULT;
+ALTER TABLE page_revisions ALTER COLUMN meta TYPE jsonb
+    USING CASE WHEN meta = '' THEN '{}'::jsonb ELSE jsonb_build_object('description', meta) END;
+ALTER TABLE page_revisions ALTER COLUMN meta SET DEFAULT '{}';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE page_revisions ALTER COLUMN meta DROP DEFAULT;
+ALTER TABLE page_revisions ALTER COLUMN meta TYPE text USING coalesce(meta->>'description', '');
+ALTER TABLE page_revisions ALTER COLUMN meta SET DEFAULT '';
+
+ALTER TABLE pages ALTER COLUMN meta DROP DEFAULT;
+ALTER TABLE pages ALTER COLUMN meta TYPE text USING coalesce(meta->>'description', '');
+ALTER TABLE pages ALTER COLUMN meta SET DEFAULT '';
+
+-- +goose StatementEnd
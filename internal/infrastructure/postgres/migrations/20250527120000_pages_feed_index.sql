@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Ленты RSS/Atom: последние опубликованные страницы категории
+CREATE INDEX pages_feed on pages (category, coalesce(published_at, created_at) DESC)
+    WHERE deleted = false and status = 'published';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX pages_feed;
+
+-- +goose StatementEnd
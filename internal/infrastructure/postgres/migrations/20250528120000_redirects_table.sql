@@ -0,0 +1,32 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE redirects (
+    id SERIAL not null primary key,
+    source varchar(1024) NOT NULL,
+    target varchar(2048) NOT NULL,
+    kind varchar(16) NOT NULL default 'exact',
+    code smallint NOT NULL default 301,
+    auto boolean NOT NULL default false,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (kind, source)
+);
+
+CREATE INDEX redirects_target on redirects (target) WHERE auto = true;
+
+CREATE TRIGGER redirects_updated_at_trigger
+BEFORE UPDATE ON redirects
+FOR EACH ROW
+EXECUTE FUNCTION update_updated_at();
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TRIGGER redirects_updated_at_trigger ON redirects;
+
+DROP TABLE redirects;
+
+-- +goose StatementEnd
@@ -0,0 +1,36 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE page_translations (
+    id SERIAL not null primary key,
+    page_id INTEGER NOT NULL REFERENCES pages (id) ON DELETE CASCADE,
+    locale varchar(16) NOT NULL,
+    name varchar(256) NOT NULL,
+    meta jsonb NOT NULL default '{}',
+    title varchar(1024) NOT NULL default '',
+    h1 varchar(1024) NOT NULL default '',
+    content text NOT NULL default '',
+    content_short varchar(4096) NOT NULL default '',
+    content_format varchar(16) NOT NULL default 'html',
+    content_html text NOT NULL default '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (page_id, locale),
+    UNIQUE (locale, name)
+);
+
+CREATE TRIGGER page_translations_updated_at_trigger
+BEFORE UPDATE ON page_translations
+FOR EACH ROW
+EXECUTE FUNCTION update_updated_at();
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TRIGGER page_translations_updated_at_trigger ON page_translations;
+
+DROP TABLE page_translations;
+
+-- +goose StatementEnd
@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Привязка изображения к странице: до этого в page_image не было ссылки на изображение
+ALTER TABLE page_image ADD COLUMN image_id integer REFERENCES images (id) ON DELETE CASCADE;
+ALTER TABLE page_image ALTER COLUMN page_id TYPE integer;
+ALTER TABLE page_image ALTER COLUMN node_id TYPE integer;
+ALTER TABLE page_image ALTER COLUMN node_id SET DEFAULT 0;
+
+CREATE UNIQUE INDEX page_image_page_image on page_image (page_id, image_id);
+CREATE INDEX images_uuid on images (uuid);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX images_uuid;
+DROP INDEX page_image_page_image;
+
+ALTER TABLE page_image ALTER COLUMN node_id DROP DEFAULT;
+ALTER TABLE page_image DROP COLUMN image_id;
+
+-- +goose StatementEnd
@@ -0,0 +1,50 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE content_types (
+    id SERIAL not null primary key,
+    name varchar(256) NOT NULL UNIQUE,
+    title varchar(1024) NOT NULL default '',
+    fields jsonb NOT NULL default '[]',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TRIGGER content_types_updated_at_trigger
+BEFORE UPDATE ON content_types
+FOR EACH ROW
+EXECUTE FUNCTION update_updated_at();
+
+-- Тип удаляется только без страниц, переименование переносится на страницы
+ALTER TABLE pages
+    ADD COLUMN content_type varchar(256) REFERENCES content_types (name) ON UPDATE CASCADE ON DELETE RESTRICT,
+    ADD COLUMN fields jsonb NOT NULL default '{}';
+
+CREATE INDEX pages_content_type ON pages (content_type);
+CREATE INDEX pages_fields ON pages USING gin (fields jsonb_path_ops);
+
+ALTER TABLE page_revisions
+    ADD COLUMN content_type varchar(256) NOT NULL default '',
+    ADD COLUMN fields jsonb NOT NULL default '{}';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE page_revisions
+    DROP COLUMN fields,
+    DROP COLUMN content_type;
+
+DROP INDEX pages_fields;
+DROP INDEX pages_content_type;
+
+ALTER TABLE pages
+    DROP COLUMN fields,
+    DROP COLUMN content_type;
+
+DROP TRIGGER content_types_updated_at_trigger ON content_types;
+
+DROP TABLE content_types;
+
+-- +goose StatementEnd
@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE pages ADD COLUMN version integer NOT NULL default 1;
+
+-- Рекомендательные блокировки редактирования, истекают без продления
+CREATE TABLE page_locks (
+    page_id INTEGER NOT NULL primary key REFERENCES pages (id) ON DELETE CASCADE,
+    user_id bigint NOT NULL,
+    acquired_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMP NOT NULL
+);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE page_locks;
+
+ALTER TABLE pages DROP COLUMN version;
+
+-- +goose StatementEnd
@@ -0,0 +1,30 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Время удаления отсчитывает срок хранения в корзине
+ALTER TABLE pages ADD COLUMN deleted_at TIMESTAMP;
+ALTER TABLE uploads ADD COLUMN deleted_at TIMESTAMP;
+ALTER TABLE images ADD COLUMN deleted_at TIMESTAMP;
+
+UPDATE pages SET deleted_at = updated_at WHERE deleted = true;
+UPDATE uploads SET deleted_at = updated_at WHERE deleted = true;
+UPDATE images SET deleted_at = updated_at WHERE deleted = true;
+
+CREATE INDEX pages_deleted_at ON pages (deleted_at) WHERE deleted = true;
+CREATE INDEX uploads_deleted_at ON uploads (deleted_at) WHERE deleted = true;
+CREATE INDEX images_deleted_at ON images (deleted_at) WHERE deleted = true;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX images_deleted_at;
+DROP INDEX uploads_deleted_at;
+DROP INDEX pages_deleted_at;
+
+ALTER TABLE images DROP COLUMN deleted_at;
+ALTER TABLE uploads DROP COLUMN deleted_at;
+ALTER TABLE pages DROP COLUMN deleted_at;
+
+-- +goose StatementEnd
@@ -0,0 +1,45 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Теги файлов принадлежат владельцу, теги страниц общие (user_id = 0)
+CREATE TABLE tags (
+    id serial not null primary key,
+    user_id bigint NOT NULL default 0,
+    name varchar(64) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (user_id, name)
+);
+
+CREATE TABLE upload_tag (
+    upload_id integer NOT NULL REFERENCES uploads (id) ON DELETE CASCADE,
+    tag_id integer NOT NULL REFERENCES tags (id) ON DELETE CASCADE,
+    PRIMARY KEY (upload_id, tag_id)
+);
+
+CREATE TABLE image_tag (
+    image_id integer NOT NULL REFERENCES images (id) ON DELETE CASCADE,
+    tag_id integer NOT NULL REFERENCES tags (id) ON DELETE CASCADE,
+    PRIMARY KEY (image_id, tag_id)
+);
+
+CREATE TABLE page_tag (
+    page_id integer NOT NULL REFERENCES pages (id) ON DELETE CASCADE,
+    tag_id integer NOT NULL REFERENCES tags (id) ON DELETE CASCADE,
+    PRIMARY KEY (page_id, tag_id)
+);
+
+CREATE INDEX upload_tag_tag_id ON upload_tag (tag_id);
+CREATE INDEX image_tag_tag_id ON image_tag (tag_id);
+CREATE INDEX page_tag_tag_id ON page_tag (tag_id);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE page_tag;
+DROP TABLE image_tag;
+DROP TABLE upload_tag;
+DROP TABLE tags;
+
+-- +goose StatementEnd
@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Публичные ссылки на загрузки; max_downloads = 0 - без ограничения
+CREATE TABLE upload_shares (
+    id serial not null primary key,
+    token varchar(64) NOT NULL UNIQUE,
+    upload_id integer NOT NULL REFERENCES uploads (id) ON DELETE CASCADE,
+    user_id bigint NOT NULL,
+    password varchar(60) NOT NULL default '',
+    expires_at TIMESTAMP,
+    max_downloads integer NOT NULL default 0,
+    downloads integer NOT NULL default 0,
+    disposition varchar(16) NOT NULL default 'attachment',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX upload_shares_user_id ON upload_shares (user_id);
+CREATE INDEX upload_shares_upload_id ON upload_shares (upload_id);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE upload_shares;
+
+-- +goose StatementEnd
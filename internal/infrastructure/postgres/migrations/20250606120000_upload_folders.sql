@@ -0,0 +1,46 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE upload_folders (
+    id serial not null primary key,
+    user_id bigint not null,
+    parent_id integer REFERENCES upload_folders (id) ON DELETE CASCADE,
+    name varchar(255) not null,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Корень пользователя — parent_id IS NULL
+CREATE UNIQUE INDEX upload_folders_name ON upload_folders (user_id, coalesce(parent_id, 0), name);
+CREATE INDEX upload_folders_parent_id ON upload_folders (parent_id);
+
+CREATE TRIGGER upload_folders_updated_at_trigger
+BEFORE UPDATE ON upload_folders
+FOR EACH ROW
+EXECUTE FUNCTION update_updated_at();
+
+-- Файлы удалённой папки лежат в корзине и восстанавливаются в корень
+ALTER TABLE uploads ADD COLUMN folder_id integer REFERENCES upload_folders (id) ON DELETE SET NULL;
+
+CREATE INDEX uploads_folder_id ON uploads (folder_id);
+-- Имя файла уникально в папке, а не у пользователя в целом
+CREATE UNIQUE INDEX uploads_folder_name ON uploads (user_id, coalesce(folder_id, 0), name) WHERE deleted = false;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX uploads_folder_name;
+DROP INDEX uploads_folder_id;
+
+ALTER TABLE uploads DROP COLUMN folder_id;
+
+DROP TRIGGER upload_folders_updated_at_trigger ON upload_folders;
+
+DROP INDEX upload_folders_parent_id;
+DROP INDEX upload_folders_name;
+
+DROP TABLE upload_folders;
+
+-- +goose StatementEnd
@@ -0,0 +1,54 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Текущая версия файла — сама запись uploads/images, прежние лежат в *_versions
+ALTER TABLE uploads ADD COLUMN version integer not null DEFAULT 1;
+ALTER TABLE uploads ADD COLUMN version_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP;
+UPDATE uploads SET version_at = created_at;
+
+ALTER TABLE images ADD COLUMN version integer not null DEFAULT 1;
+ALTER TABLE images ADD COLUMN version_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP;
+UPDATE images SET version_at = created_at;
+
+CREATE TABLE upload_versions (
+    id serial not null primary key,
+    upload_id integer not null REFERENCES uploads (id) ON DELETE CASCADE,
+    version integer not null,
+    uuid uuid not null,
+    size bigint default 0,
+    content_type varchar not null,
+    created_at TIMESTAMP not null
+);
+
+CREATE UNIQUE INDEX upload_versions_version ON upload_versions (upload_id, version);
+
+CREATE TABLE image_versions (
+    id serial not null primary key,
+    image_id integer not null REFERENCES images (id) ON DELETE CASCADE,
+    version integer not null,
+    uuid uuid not null,
+    size bigint default 0,
+    content_type varchar not null,
+    created_at TIMESTAMP not null
+);
+
+CREATE UNIQUE INDEX image_versions_version ON image_versions (image_id, version);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX image_versions_version;
+DROP TABLE image_versions;
+
+DROP INDEX upload_versions_version;
+DROP TABLE upload_versions;
+
+ALTER TABLE images DROP COLUMN version_at;
+ALTER TABLE images DROP COLUMN version;
+
+ALTER TABLE uploads DROP COLUMN version_at;
+ALTER TABLE uploads DROP COLUMN version;
+
+-- +goose StatementEnd
@@ -0,0 +1,32 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Проверка на вредоносное ПО: pending, clean, infected, error; пустой — не проверялся
+ALTER TABLE uploads ADD COLUMN scan_status varchar not null DEFAULT '';
+ALTER TABLE uploads ADD COLUMN scan_detail varchar not null DEFAULT '';
+
+-- Статус относится к содержимому и переходит вместе с ним в версии и обратно
+ALTER TABLE upload_versions ADD COLUMN scan_status varchar not null DEFAULT '';
+ALTER TABLE upload_versions ADD COLUMN scan_detail varchar not null DEFAULT '';
+
+-- Результат проверки записывается по UUID файла в хранилище
+CREATE INDEX uploads_uuid ON uploads (uuid);
+CREATE INDEX upload_versions_uuid ON upload_versions (uuid);
+CREATE INDEX uploads_scan_status ON uploads (scan_status) WHERE scan_status NOT IN ('', 'clean');
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX uploads_scan_status;
+DROP INDEX upload_versions_uuid;
+DROP INDEX uploads_uuid;
+
+ALTER TABLE upload_versions DROP COLUMN scan_detail;
+ALTER TABLE upload_versions DROP COLUMN scan_status;
+
+ALTER TABLE uploads DROP COLUMN scan_detail;
+ALTER TABLE uploads DROP COLUMN scan_status;
+
+-- +goose StatementEnd
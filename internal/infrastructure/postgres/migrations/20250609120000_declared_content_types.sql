@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Тип, заявленный клиентом; content_type теперь определяется по содержимому
+ALTER TABLE uploads ADD COLUMN declared_content_type varchar not null DEFAULT '';
+ALTER TABLE images ADD COLUMN declared_content_type varchar not null DEFAULT '';
+ALTER TABLE upload_versions ADD COLUMN declared_content_type varchar not null DEFAULT '';
+ALTER TABLE image_versions ADD COLUMN declared_content_type varchar not null DEFAULT '';
+
+-- Для загруженного ранее известен только тип от клиента
+UPDATE uploads SET declared_content_type = content_type;
+UPDATE images SET declared_content_type = content_type;
+UPDATE upload_versions SET declared_content_type = content_type;
+UPDATE image_versions SET declared_content_type = content_type;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE image_versions DROP COLUMN declared_content_type;
+ALTER TABLE upload_versions DROP COLUMN declared_content_type;
+ALTER TABLE images DROP COLUMN declared_content_type;
+ALTER TABLE uploads DROP COLUMN declared_content_type;
+
+-- +goose StatementEnd
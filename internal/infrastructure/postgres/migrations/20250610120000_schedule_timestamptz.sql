@@ -0,0 +1,43 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Время, которое сравнивается с моментом из приложения, хранится с часовым поясом:
+-- pgx отбрасывает пояс у значений для TIMESTAMP, и расписание сдвигалось на смещение клиента.
+-- Прежние значения считаются записанными в поясе сессии.
+DROP INDEX pages_feed;
+
+ALTER TABLE pages
+    ALTER COLUMN publish_at TYPE timestamptz,
+    ALTER COLUMN unpublish_at TYPE timestamptz,
+    ALTER COLUMN published_at TYPE timestamptz,
+    ALTER COLUMN created_at TYPE timestamptz,
+    ALTER COLUMN deleted_at TYPE timestamptz;
+ALTER TABLE uploads ALTER COLUMN deleted_at TYPE timestamptz;
+ALTER TABLE images ALTER COLUMN deleted_at TYPE timestamptz;
+ALTER TABLE upload_shares ALTER COLUMN expires_at TYPE timestamptz;
+
+-- created_at переведена вместе с published_at: в индексе нельзя смешивать типы
+CREATE INDEX pages_feed on pages (category, coalesce(published_at, created_at) DESC)
+    WHERE deleted = false and status = 'published';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX pages_feed;
+
+ALTER TABLE upload_shares ALTER COLUMN expires_at TYPE timestamp;
+ALTER TABLE images ALTER COLUMN deleted_at TYPE timestamp;
+ALTER TABLE uploads ALTER COLUMN deleted_at TYPE timestamp;
+ALTER TABLE pages
+    ALTER COLUMN deleted_at TYPE timestamp,
+    ALTER COLUMN created_at TYPE timestamp,
+    ALTER COLUMN published_at TYPE timestamp,
+    ALTER COLUMN unpublish_at TYPE timestamp,
+    ALTER COLUMN publish_at TYPE timestamp;
+
+CREATE INDEX pages_feed on pages (category, coalesce(published_at, created_at) DESC)
+    WHERE deleted = false and status = 'published';
+
+-- +goose StatementEnd
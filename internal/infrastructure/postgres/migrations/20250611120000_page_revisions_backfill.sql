@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Первая ревизия для страниц, сохранённых до появления истории:
+-- иначе первое редактирование теряет исходное содержимое
+INSERT INTO page_revisions (page_id, revision, name, meta, title, category, template, h1, content, content_short, content_type, fields, author_id, created_at)
+SELECT p.id, 1, p.name, p.meta, p.title, p.category, p.template, p.h1, p.content, p.content_short,
+    coalesce(p.content_type, ''), p.fields, 0, coalesce(p.updated_at, p.created_at, now())
+FROM pages p
+WHERE NOT EXISTS (SELECT 1 FROM page_revisions r WHERE r.page_id = p.id);
+
+-- +goose StatementEnd
+
+-- +goose Down
+
+-- Восстановленные ревизии не откатываются: их не отличить от сохранённых редактором
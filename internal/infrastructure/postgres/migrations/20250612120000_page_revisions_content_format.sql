@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Формат нужен для восстановления: без него markdown-ревизия рендерится как HTML
+ALTER TABLE page_revisions ADD COLUMN content_format varchar(16) NOT NULL default '';
+
+-- Для сохранённых ранее ревизий известен только текущий формат страницы
+UPDATE page_revisions r SET content_format = p.content_format FROM pages p WHERE p.id = r.page_id;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE page_revisions DROP COLUMN content_format;
+
+-- +goose StatementEnd
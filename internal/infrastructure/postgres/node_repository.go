@@ -0,0 +1,511 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	appNode "github.com/aube/auth/internal/application/node"
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/aube/auth/internal/utils/logger"
+	"github.com/rs/zerolog"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// nodeTreeLockKey is the advisory lock serializing all site tree mutations.
+const nodeTreeLockKey int64 = 0x6e6f646573 // "nodes"
+
+// maxNodeSort is the largest position the smallint sort column can hold.
+const maxNodeSort = 32767
+
+const (
+	nodeFieldsSelect  string = "id, page_id, name, menu_title, path, parent, sort, level, children, published, created_at, updated_at"
+	nodeFieldsSelectN string = "n.id, n.page_id, n.name, n.menu_title, n.path, n.parent, n.sort, n.level, n.children, n.published, n.created_at, n.updated_at"
+
+	queryNodeLock           string = "SELECT pg_advisory_xact_lock($1)"
+	queryNodeInsert         string = "INSERT INTO nodes (page_id, name, menu_title, path, parent, sort, level, published) VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING id"
+	queryNodeUpdate         string = "UPDATE nodes SET page_id=$1, name=$2, menu_title=$3, published=$4 WHERE id=$5 and deleted = false"
+	queryNodeSetParent      string = "UPDATE nodes SET parent=$1, sort=$2 WHERE id=$3"
+	queryNodeSelectByID     string = "SELECT " + nodeFieldsSelect + " FROM nodes WHERE id = $1 and deleted = false"
+	queryNodeSelectByPath   string = "SELECT " + nodeFieldsSelect + " FROM nodes WHERE path = $1 and deleted = false"
+	queryNodeSelectByPageID string = "SELECT " + nodeFieldsSelect + " FROM nodes WHERE page_id = $1 and deleted = false ORDER BY level, sort, id LIMIT 1"
+	queryNodePathTaken      string = "SELECT count(*) FROM nodes WHERE path = $1 and id <> $2 and deleted = false"
+	queryNodeMaxSort        string = "SELECT coalesce(max(sort) + 1, 0) FROM nodes WHERE parent = $1 and deleted = false"
+	queryNodeShiftSiblings  string = "UPDATE nodes SET sort = sort + 1 WHERE parent = $1 and sort >= $2 and id <> $3 and deleted = false"
+	queryNodeCloseGap       string = "UPDATE nodes SET sort = sort - 1 WHERE parent = $1 and sort > $2 and id <> $3 and deleted = false"
+	queryNodeNormalizeSort  string = `UPDATE nodes SET sort = s.rn
+		FROM (SELECT id, row_number() OVER (ORDER BY sort, id) - 1 rn FROM nodes WHERE parent = $1 and deleted = false) s
+		WHERE nodes.id = s.id and nodes.sort <> s.rn`
+	queryNodeRecountChildren string = `UPDATE nodes SET children = (SELECT count(*) FROM nodes c WHERE c.parent = nodes.id and c.deleted = false)
+		WHERE id = ANY($1)`
+
+	// Subtree walks are bounded by 256 levels so corrupted data cannot loop forever.
+	queryNodeRebuildSubtree string = `WITH RECURSIVE sub AS (
+			SELECT id, $2::varchar AS path, $3::int AS level FROM nodes WHERE id = $1
+			UNION
+			SELECT n.id, (sub.path || '/' || n.name)::varchar, sub.level + 1 FROM nodes n JOIN sub ON n.parent = sub.id
+			WHERE n.deleted = false and sub.level < 256
+		)
+		UPDATE nodes SET path = sub.path, level = sub.level FROM sub WHERE nodes.id = sub.id`
+	queryNodeDeleteSubtree string = `WITH RECURSIVE sub AS (
+			SELECT id FROM nodes WHERE id = $1 and deleted = false
+			UNION
+			SELECT n.id FROM nodes n JOIN sub ON n.parent = sub.id WHERE n.deleted = false
+		)
+		UPDATE nodes SET deleted = true FROM sub WHERE nodes.id = sub.id`
+	queryNodeSelectSubtree string = `WITH RECURSIVE sub AS (
+			SELECT ` + nodeFieldsSelect + `, 0 AS depth FROM nodes WHERE id = $1 and deleted = false
+			UNION
+			SELECT ` + nodeFieldsSelectN + `, sub.depth + 1 FROM nodes n JOIN sub ON n.parent = sub.id
+			WHERE n.deleted = false and ($2 = 0 or sub.depth < $2) and sub.depth < 256
+		)
+		SELECT ` + nodeFieldsSelect + ` FROM sub ORDER BY level, sort, id`
+	queryNodeSelectTree      string = "SELECT " + nodeFieldsSelect + " FROM nodes WHERE deleted = false and ($1 = 0 or level < $1) ORDER BY level, sort, id"
+	queryNodeSelectAncestors string = `WITH RECURSIVE anc AS (
+			SELECT ` + nodeFieldsSelect + `, 0 AS depth FROM nodes WHERE id = $1 and deleted = false
+			UNION
+			SELECT ` + nodeFieldsSelectN + `, anc.depth + 1 FROM nodes n JOIN anc ON n.id = anc.parent
+			WHERE n.deleted = false and anc.depth < 256
+		)
+		SELECT ` + nodeFieldsSelect + ` FROM anc ORDER BY depth DESC`
+
+	queryNodePagesSelect string = "SELECT id, node_id, page_id, sort, pinned FROM node_page WHERE node_id = $1 ORDER BY pinned DESC, sort, id"
+	queryNodePageUpsert  string = `INSERT INTO node_page (node_id, page_id, sort, pinned) VALUES ($1, $2, $3, $4)
+		ON CONFLICT (node_id, page_id) DO UPDATE SET sort = EXCLUDED.sort, pinned = EXCLUDED.pinned RETURNING id`
+	queryNodePageDelete string = "DELETE FROM node_page WHERE node_id = $1 and page_id = $2"
+)
+
+// queryer is satisfied by both the pool and a transaction.
+type queryer interface {
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// NodeRepository provides PostgreSQL storage for the site tree.
+// Every mutation runs in a transaction holding a tree-wide advisory lock,
+// so concurrent moves cannot interleave and create cycles or stale paths.
+type NodeRepository struct {
+	db  *pgxpool.Pool
+	log zerolog.Logger
+}
+
+func NewNodeRepository(db *pgxpool.Pool) *NodeRepository {
+	return &NodeRepository{
+		db:  db,
+		log: logger.Get().With().Str("postgres", "node_repository").Logger(),
+	}
+}
+
+func (r *NodeRepository) Create(ctx context.Context, node *entities.Node) error {
+	err := r.inTreeTx(ctx, func(tx pgx.Tx) error {
+		parentPath, parentLevel, err := r.parentPosition(ctx, tx, node.Parent)
+		if err != nil {
+			return err
+		}
+
+		node.Path = entities.BuildNodePath(parentPath, node.Name)
+		node.Level = parentLevel + 1
+		if err := r.checkPathFree(ctx, tx, node.Path, 0); err != nil {
+			return err
+		}
+
+		// Без явной позиции узел добавляется в конец списка соседей
+		if node.Sort == entities.NodeSortLast {
+			if err := tx.QueryRow(ctx, queryNodeMaxSort, node.Parent).Scan(&node.Sort); err != nil {
+				return err
+			}
+		} else {
+			node.Sort = min(node.Sort, maxNodeSort)
+			if _, err := tx.Exec(ctx, queryNodeShiftSiblings, node.Parent, node.Sort, 0); err != nil {
+				return err
+			}
+		}
+
+		err = tx.QueryRow(
+			ctx,
+			queryNodeInsert,
+			node.PageID,
+			node.Name,
+			node.MenuTitle,
+			node.Path,
+			node.Parent,
+			node.Sort,
+			node.Level,
+			node.Published,
+		).Scan(&node.ID)
+		if err != nil {
+			return err
+		}
+
+		return r.fixSiblings(ctx, tx, node.Parent)
+	})
+
+	if err != nil {
+		r.log.Debug().Err(err).Msg("Create")
+		return wrapNodeError("failed to create node", err)
+	}
+
+	return nil
+}
+
+func (r *NodeRepository) Update(ctx context.Context, node *entities.Node) error {
+	err := r.inTreeTx(ctx, func(tx pgx.Tx) error {
+		current, err := scanNode(tx.QueryRow(ctx, queryNodeSelectByID, node.ID))
+		if err != nil {
+			return err
+		}
+
+		tag, err := tx.Exec(ctx, queryNodeUpdate, node.PageID, node.Name, node.MenuTitle, node.Published, node.ID)
+		if err != nil {
+			return err
+		}
+		if tag.RowsAffected() == 0 {
+			return appNode.ErrNodeNotFound
+		}
+
+		if current.Name == node.Name {
+			return nil
+		}
+
+		// Переименование меняет path всего поддерева
+		parentPath, _, err := r.parentPosition(ctx, tx, current.Parent)
+		if err != nil {
+			return err
+		}
+		newPath := entities.BuildNodePath(parentPath, node.Name)
+		if err := r.checkPathFree(ctx, tx, newPath, node.ID); err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(ctx, queryNodeRebuildSubtree, node.ID, newPath, current.Level)
+		return err
+	})
+
+	if err != nil {
+		r.log.Debug().Err(err).Msg("Update")
+		return wrapNodeError("failed to update node", err)
+	}
+
+	return nil
+}
+
+func (r *NodeRepository) Move(ctx context.Context, id int64, parent int64, sort int) error {
+	err := r.inTreeTx(ctx, func(tx pgx.Tx) error {
+		node, err := scanNode(tx.QueryRow(ctx, queryNodeSelectByID, id))
+		if err != nil {
+			return err
+		}
+
+		if parent > 0 {
+			ancestors, err := r.listNodes(ctx, tx, queryNodeSelectAncestors, parent)
+			if err != nil {
+				return err
+			}
+			if len(ancestors) == 0 {
+				return appNode.ErrNodeNotFound
+			}
+			for _, ancestor := range ancestors {
+				if ancestor.ID == id {
+					return appNode.ErrNodeCycle
+				}
+			}
+		}
+
+		parentPath, parentLevel, err := r.parentPosition(ctx, tx, parent)
+		if err != nil {
+			return err
+		}
+		newPath := entities.BuildNodePath(parentPath, node.Name)
+		if err := r.checkPathFree(ctx, tx, newPath, id); err != nil {
+			return err
+		}
+
+		sort = max(0, min(sort, maxNodeSort))
+		// Сначала узел уходит со старого места, иначе при переносе вниз
+		// внутри того же родителя он встаёт на позицию раньше
+		if _, err := tx.Exec(ctx, queryNodeCloseGap, node.Parent, node.Sort, id); err != nil {
+			return err
+		}
+		if _, err := tx.Exec(ctx, queryNodeShiftSiblings, parent, sort, id); err != nil {
+			return err
+		}
+		if _, err := tx.Exec(ctx, queryNodeSetParent, parent, sort, id); err != nil {
+			return err
+		}
+		if _, err := tx.Exec(ctx, queryNodeRebuildSubtree, id, newPath, parentLevel+1); err != nil {
+			return err
+		}
+
+		if err := r.fixSiblings(ctx, tx, parent); err != nil {
+			return err
+		}
+		if node.Parent != parent {
+			return r.fixSiblings(ctx, tx, node.Parent)
+		}
+		return nil
+	})
+
+	if err != nil {
+		r.log.Debug().Err(err).Msg("Move")
+		return wrapNodeError("failed to move node", err)
+	}
+
+	return nil
+}
+
+func (r *NodeRepository) Delete(ctx context.Context, id int64) error {
+	err := r.inTreeTx(ctx, func(tx pgx.Tx) error {
+		node, err := scanNode(tx.QueryRow(ctx, queryNodeSelectByID, id))
+		if err != nil {
+			return err
+		}
+
+		if _, err := tx.Exec(ctx, queryNodeDeleteSubtree, id); err != nil {
+			return err
+		}
+
+		return r.fixSiblings(ctx, tx, node.Parent)
+	})
+
+	if err != nil {
+		r.log.Debug().Err(err).Msg("Delete")
+		return wrapNodeError("failed to delete node", err)
+	}
+
+	return nil
+}
+
+func (r *NodeRepository) FindByID(ctx context.Context, id int64) (*entities.Node, error) {
+	node, err := scanNode(r.db.QueryRow(ctx, queryNodeSelectByID, id))
+	if err != nil {
+		r.log.Debug().Err(err).Msg("FindByID")
+		return nil, wrapNodeError("failed to find node", err)
+	}
+	return node, nil
+}
+
+func (r *NodeRepository) FindByPath(ctx context.Context, path string) (*entities.Node, error) {
+	node, err := scanNode(r.db.QueryRow(ctx, queryNodeSelectByPath, path))
+	if err != nil {
+		r.log.Debug().Err(err).Msg("FindByPath")
+		return nil, wrapNodeError("failed to find node", err)
+	}
+	return node, nil
+}
+
+func (r *NodeRepository) FindByPageID(ctx context.Context, pageID int64) (*entities.Node, error) {
+	node, err := scanNode(r.db.QueryRow(ctx, queryNodeSelectByPageID, pageID))
+	if err != nil {
+		r.log.Debug().Err(err).Msg("FindByPageID")
+		return nil, wrapNodeError("failed to find node", err)
+	}
+	return node, nil
+}
+
+func (r *NodeRepository) ListSubtree(ctx context.Context, rootID int64, depth int) (*entities.Nodes, error) {
+	var (
+		nodes entities.Nodes
+		err   error
+	)
+	if rootID == 0 {
+		nodes, err = r.listNodes(ctx, r.db, queryNodeSelectTree, depth)
+	} else {
+		nodes, err = r.listNodes(ctx, r.db, queryNodeSelectSubtree, rootID, depth)
+	}
+	if err != nil {
+		r.log.Debug().Err(err).Msg("ListSubtree")
+		return nil, fmt.Errorf("failed to list nodes: %w", err)
+	}
+	if rootID > 0 && len(nodes) == 0 {
+		return nil, appNode.ErrNodeNotFound
+	}
+
+	return &nodes, nil
+}
+
+func (r *NodeRepository) ListAncestors(ctx context.Context, id int64) (*entities.Nodes, error) {
+	nodes, err := r.listNodes(ctx, r.db, queryNodeSelectAncestors, id)
+	if err != nil {
+		r.log.Debug().Err(err).Msg("ListAncestors")
+		return nil, fmt.Errorf("failed to list ancestors: %w", err)
+	}
+	if len(nodes) == 0 {
+		return nil, appNode.ErrNodeNotFound
+	}
+
+	return &nodes, nil
+}
+
+func (r *NodeRepository) ListNodePages(ctx context.Context, nodeID int64) (*entities.NodePages, error) {
+	rows, err := r.db.Query(ctx, queryNodePagesSelect, nodeID)
+	if err != nil {
+		r.log.Debug().Err(err).Msg("ListNodePages1")
+		return nil, fmt.Errorf("failed to list node pages: %w", err)
+	}
+	defer rows.Close()
+
+	links := entities.NodePages{}
+	for rows.Next() {
+		var (
+			link   entities.NodePage
+			pinned int
+		)
+		if err := rows.Scan(&link.ID, &link.NodeID, &link.PageID, &link.Sort, &pinned); err != nil {
+			r.log.Debug().Err(err).Msg("ListNodePages2")
+			return nil, fmt.Errorf("failed to scan node page row: %w", err)
+		}
+		link.Pinned = pinned != 0
+		links = append(links, link)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.log.Debug().Err(err).Msg("ListNodePages3")
+		return nil, fmt.Errorf("error after iterating node page rows: %w", err)
+	}
+
+	return &links, nil
+}
+
+func (r *NodeRepository) AttachPage(ctx context.Context, link *entities.NodePage) error {
+	pinned := 0
+	if link.Pinned {
+		pinned = 1
+	}
+
+	err := r.db.QueryRow(ctx, queryNodePageUpsert, link.NodeID, link.PageID, link.Sort, pinned).Scan(&link.ID)
+	if err != nil {
+		r.log.Debug().Err(err).Msg("AttachPage")
+		return fmt.Errorf("failed to attach page: %w", err)
+	}
+
+	return nil
+}
+
+func (r *NodeRepository) DetachPage(ctx context.Context, nodeID int64, pageID int64) error {
+	tag, err := r.db.Exec(ctx, queryNodePageDelete, nodeID, pageID)
+	if err != nil {
+		r.log.Debug().Err(err).Msg("DetachPage")
+		return fmt.Errorf("failed to detach page: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return appNode.ErrNodeNotFound
+	}
+
+	return nil
+}
+
+// inTreeTx runs fn in a transaction holding the site tree advisory lock.
+func (r *NodeRepository) inTreeTx(ctx context.Context, fn func(tx pgx.Tx) error) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, queryNodeLock, nodeTreeLockKey); err != nil {
+		return err
+	}
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// parentPosition returns the path and level a child of parent is built from.
+func (r *NodeRepository) parentPosition(ctx context.Context, q queryer, parent int64) (string, int, error) {
+	if parent == 0 {
+		return "", -1, nil
+	}
+
+	node, err := scanNode(q.QueryRow(ctx, queryNodeSelectByID, parent))
+	if err != nil {
+		return "", 0, err
+	}
+
+	return node.Path, node.Level, nil
+}
+
+func (r *NodeRepository) checkPathFree(ctx context.Context, q queryer, path string, id int64) error {
+	var count int
+	if err := q.QueryRow(ctx, queryNodePathTaken, path, id).Scan(&count); err != nil {
+		return err
+	}
+	if count > 0 {
+		return appNode.ErrNodeExists
+	}
+	return nil
+}
+
+// fixSiblings renumbers sort among the children of parent and refreshes its children counter.
+func (r *NodeRepository) fixSiblings(ctx context.Context, tx pgx.Tx, parent int64) error {
+	if _, err := tx.Exec(ctx, queryNodeNormalizeSort, parent); err != nil {
+		return err
+	}
+	if parent == 0 {
+		return nil
+	}
+	_, err := tx.Exec(ctx, queryNodeRecountChildren, []int64{parent})
+	return err
+}
+
+func (r *NodeRepository) listNodes(ctx context.Context, q queryer, query string, args ...any) (entities.Nodes, error) {
+	rows, err := q.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	nodes := entities.Nodes{}
+	for rows.Next() {
+		node, err := scanNode(rows)
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, *node)
+	}
+
+	return nodes, rows.Err()
+}
+
+func scanNode(row pgx.Row) (*entities.Node, error) {
+	var node entities.Node
+	err := row.Scan(
+		&node.ID,
+		&node.PageID,
+		&node.Name,
+		&node.MenuTitle,
+		&node.Path,
+		&node.Parent,
+		&node.Sort,
+		&node.Level,
+		&node.Children,
+		&node.Published,
+		&node.CreatedAt,
+		&node.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &node, nil
+}
+
+// wrapNodeError maps driver errors to application errors of the node package.
+func wrapNodeError(msg string, err error) error {
+	var pgErr *pgconn.PgError
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		return appNode.ErrNodeNotFound
+	case errors.As(err, &pgErr) && pgErr.Code == "23505":
+		return appNode.ErrNodeExists
+	case errors.Is(err, appNode.ErrNodeNotFound),
+		errors.Is(err, appNode.ErrNodeExists),
+		errors.Is(err, appNode.ErrNodeCycle):
+		return err
+	}
+	return fmt.Errorf("%s: %w", msg, err)
+}
@@ -0,0 +1,59 @@
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/aube/auth/internal/infrastructure/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNodeRepository_MoveWithinParent(t *testing.T) {
+	pool := testPool(t)
+	repo := postgres.NewNodeRepository(pool)
+	ctx := context.Background()
+
+	suffix := fmt.Sprint(time.Now().UnixNano())
+	root, err := entities.NewNode(0, 0, "nodes-"+suffix, "", 0, entities.NodeSortLast, false)
+	require.NoError(t, err)
+	require.NoError(t, repo.Create(ctx, root))
+	t.Cleanup(func() {
+		pool.Exec(ctx, "DELETE FROM nodes WHERE id = $1 or parent = $1", root.ID)
+	})
+
+	ids := make(map[string]int64)
+	for _, name := range []string{"a", "b", "c", "d"} {
+		node, err := entities.NewNode(0, 0, name, "", root.ID, entities.NodeSortLast, false)
+		require.NoError(t, err)
+		require.NoError(t, repo.Create(ctx, node))
+		ids[name] = node.ID
+	}
+
+	order := func() []string {
+		nodes, err := repo.ListSubtree(ctx, root.ID, 1)
+		require.NoError(t, err)
+		var names []string
+		for _, node := range *nodes {
+			if node.ID != root.ID {
+				names = append(names, node.Name)
+			}
+		}
+		return names
+	}
+
+	// Перенос вниз: a встаёт на позицию 2
+	require.NoError(t, repo.Move(ctx, ids["a"], root.ID, 2))
+	assert.Equal(t, []string{"b", "c", "a", "d"}, order())
+
+	// Перенос вверх
+	require.NoError(t, repo.Move(ctx, ids["d"], root.ID, 0))
+	assert.Equal(t, []string{"d", "b", "c", "a"}, order())
+
+	// Позиция за пределами smallint ставит узел в конец
+	require.NoError(t, repo.Move(ctx, ids["d"], root.ID, 1<<20))
+	assert.Equal(t, []string{"b", "c", "a", "d"}, order())
+}
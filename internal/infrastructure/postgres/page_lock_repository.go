@@ -0,0 +1,103 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	appPage "github.com/aube/auth/internal/application/page"
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/aube/auth/internal/utils/logger"
+	"github.com/rs/zerolog"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	// Блокировку продлевает её владелец или перехватывает кто угодно после истечения
+	queryPageLockAcquire string = `INSERT INTO page_locks AS l (page_id, user_id, acquired_at, expires_at)
+		VALUES ($1, $2, now(), now() + $3 * interval '1 millisecond')
+		ON CONFLICT (page_id) DO UPDATE SET
+			user_id = EXCLUDED.user_id,
+			acquired_at = CASE WHEN l.user_id = EXCLUDED.user_id and l.expires_at > now() THEN l.acquired_at ELSE now() END,
+			expires_at = EXCLUDED.expires_at
+		WHERE l.user_id = EXCLUDED.user_id or l.expires_at <= now()
+		RETURNING page_id`
+	queryPageLockSelect string = `SELECT l.page_id, l.user_id, coalesce(u.username, ''), l.acquired_at, l.expires_at
+		FROM page_locks l LEFT JOIN users u ON u.id = l.user_id
+		WHERE l.page_id = $1 and l.expires_at > now()`
+	queryPageLockRelease string = "DELETE FROM page_locks WHERE page_id = $1 and user_id = $2"
+)
+
+type PageLockRepository struct {
+	db  *pgxpool.Pool
+	log zerolog.Logger
+}
+
+func NewPageLockRepository(db *pgxpool.Pool) *PageLockRepository {
+	return &PageLockRepository{
+		db:  db,
+		log: logger.Get().With().Str("postgres", "page_lock_repository").Logger(),
+	}
+}
+
+func (r *PageLockRepository) Acquire(ctx context.Context, pageID int64, userID int64, ttl time.Duration) (*entities.PageLock, error) {
+	var id int64
+	err := r.db.QueryRow(ctx, queryPageLockAcquire, pageID, userID, ttl.Milliseconds()).Scan(&id)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		r.log.Debug().Err(err).Msg("Acquire1")
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return nil, appPage.ErrPageNotFound
+		}
+		return nil, fmt.Errorf("failed to lock page: %w", err)
+	}
+
+	lock, findErr := r.Find(ctx, pageID)
+	if findErr != nil {
+		r.log.Debug().Err(findErr).Msg("Acquire2")
+		return nil, findErr
+	}
+	// Строка не обновлена: действующая блокировка принадлежит другому
+	if err != nil {
+		return lock, appPage.ErrPageLocked
+	}
+
+	return lock, nil
+}
+
+func (r *PageLockRepository) Find(ctx context.Context, pageID int64) (*entities.PageLock, error) {
+	var lock entities.PageLock
+	err := r.db.QueryRow(ctx, queryPageLockSelect, pageID).Scan(
+		&lock.PageID,
+		&lock.UserID,
+		&lock.Username,
+		&lock.AcquiredAt,
+		&lock.ExpiresAt,
+	)
+	if err != nil {
+		r.log.Debug().Err(err).Msg("Find")
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, appPage.ErrLockNotFound
+		}
+		return nil, fmt.Errorf("failed to find page lock: %w", err)
+	}
+
+	return &lock, nil
+}
+
+func (r *PageLockRepository) Release(ctx context.Context, pageID int64, userID int64) error {
+	tag, err := r.db.Exec(ctx, queryPageLockRelease, pageID, userID)
+	if err != nil {
+		r.log.Debug().Err(err).Msg("Release")
+		return fmt.Errorf("failed to unlock page: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return appPage.ErrLockNotFound
+	}
+
+	return nil
+}
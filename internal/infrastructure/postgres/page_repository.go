@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"html"
 	"math"
 	"strings"
 	"time"
@@ -12,42 +13,111 @@ import (
 	appPage "github.com/aube/auth/internal/application/page"
 	"github.com/aube/auth/internal/domain/entities"
 	"github.com/aube/auth/internal/utils/logger"
-	"github.com/aube/auth/internal/utils/sql"
+	"github.com/aube/auth/internal/utils/query"
 	"github.com/rs/zerolog"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 const (
-	pageFieldsInsert string = "name, meta, title, category, template, h1, content, content_short"
-	pageFieldsSelect string = "name, meta, title, category, template, h1, content, content_short, created_at, updated_at"
-
-	queryPageInsert       string = "INSERT INTO pages (" + pageFieldsInsert + ") VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING id"
-	queryPageUpdate       string = "UPDATE pages SET name=$1, meta=$2, title=$3, category=$4, template=$5, h1=$6, content=$7, content_short=$8 WHERE id=$9"
-	queryPageSelectByName string = "SELECT id, " + pageFieldsSelect + " FROM pages WHERE name = $1 and deleted = false"
-	queryPageSelectByID   string = "SELECT id, " + pageFieldsSelect + " FROM pages WHERE id = $1 and deleted = false"
-	queryPageGetIDByName  string = "SELECT id FROM pages WHERE name = $1"
-	queryPageDelete       string = "UPDATE pages SET deleted=true WHERE id = $1"
-	queryPageDeleteForce  string = "DELETE FROM pages WHERE id = $1"
-	queryPagesSelect      string = "SELECT id, " + pageFieldsSelect + " FROM pages %WHERE% OFFSET $1 LIMIT $2"
-	queryPagesSelectTotal string = "SELECT count(*) total FROM pages %WHERE%"
+	pageFieldsInsert string = "name, meta, title, category, template, h1, content, content_short, content_format, content_html, status, publish_at, unpublish_at, search_config, published, published_at, content_type, fields"
+	pageFieldsSelect string = "name, meta, title, category, template, h1, content, content_short, content_format, content_html, status, publish_at, unpublish_at, published_at, created_at, updated_at, coalesce(content_type, ''), fields, version"
+
+	queryPageInsert string = "INSERT INTO pages (" + pageFieldsInsert + `) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $13, $14, $9, $10, $11, $12::regconfig,
+		$9::varchar = 'published', CASE WHEN $9::varchar = 'published' THEN now() END, nullif($15::varchar, ''), $16) RETURNING id`
+	queryPageUpdate string = `UPDATE pages SET name=$1, meta=$2, title=$3, category=$4, template=$5, h1=$6, content=$7, content_short=$8, content_format=$14, content_html=$15,
+		status=$9, publish_at=$10, unpublish_at=$11, search_config=$13::regconfig, content_type=nullif($16::varchar, ''), fields=$17,
+		published = ($9::varchar = 'published'),
+		published_at = CASE WHEN $9::varchar = 'published' THEN coalesce(published_at, now()) ELSE published_at END,
+		version = version + 1
+		WHERE id=$12 and deleted = false and ($18::int = 0 or version = $18::int)`
+	// Плановая публикация: publish_at/unpublish_at сбрасываются после срабатывания
+	queryPagePublishDue string = `UPDATE pages SET status='published', published=true, published_at=coalesce(published_at, $1), publish_at=NULL,
+		version = version + 1
+		WHERE deleted = false and status <> 'published' and publish_at <= $1 and (unpublish_at IS NULL or unpublish_at > $1)`
+	queryPageUnpublishDue string = `UPDATE pages SET status='draft', published=false, unpublish_at=NULL,
+		version = version + 1
+		WHERE deleted = false and unpublish_at <= $1 and (status = 'published' or publish_at IS NOT NULL)`
+	queryPageSelectByName      string = "SELECT id, " + pageFieldsSelect + " FROM pages WHERE name = $1 and deleted = false"
+	queryPageSelectByID        string = "SELECT id, " + pageFieldsSelect + " FROM pages WHERE id = $1 and deleted = false"
+	queryPageGetIDByName       string = "SELECT id FROM pages WHERE name = $1"
+	queryPageUpdateRendered    string = "UPDATE pages SET content_html=$2, content_short=$3, version = version + 1 WHERE id=$1"
+	queryPagesSelectUnrendered string = "SELECT id, " + pageFieldsSelect + " FROM pages WHERE content_html = '' and content <> '' and id > $1 ORDER BY id LIMIT $2"
+	queryPageDelete            string = "UPDATE pages SET deleted=true, deleted_at=now() WHERE id = $1 and deleted=false"
+	queryPageDeleteForce       string = "DELETE FROM pages WHERE id = $1"
+	// Полнотекстовый поиск: $1 offset, $2 limit, $3 конфигурация, $4 tsquery.
+	// Фрагменты строятся по тексту без разметки, совпадения отмечаются
+	// символами highlightStart/highlightStop, которые заменяются на <mark> после экранирования
+	queryPagesSearch string = "SELECT id, " + pageFieldsSelect + `,
+		ts_rank_cd(search_vector, q) rank,
+		ts_headline($3::regconfig, title, q, 'StartSel="` + highlightStart + `", StopSel="` + highlightStop + `", HighlightAll=true') title_highlight,
+		ts_headline($3::regconfig, content_short || ' ' || regexp_replace(content_html, '<[^>]*>', ' ', 'g'), q,
+			'StartSel="` + highlightStart + `", StopSel="` + highlightStop + `", MaxFragments=2, MaxWords=30, MinWords=10') snippet
+		FROM pages, to_tsquery($3::regconfig, $4) q
+		WHERE search_vector @@ q %WHERE%
+		ORDER BY rank DESC, id
+		OFFSET $1 LIMIT $2`
+	queryPagesSearchTotal string = "SELECT count(*) total FROM pages, to_tsquery($1::regconfig, $2) q WHERE search_vector @@ q %WHERE%"
+	queryPagesReindex     string = "UPDATE pages SET search_config = $1::regconfig WHERE search_config <> $1::regconfig"
 )
 
+// pageColumns lists the columns list filters may use: client fields
+// (appPage.ListSchema) and the ones set by the application.
+var pageColumns = query.Columns{
+	"id":           "id",
+	"name":         "name",
+	"title":        "title",
+	"category":     "category",
+	"template":     "template",
+	"status":       "status",
+	"content_type": "content_type",
+	"created_at":   "created_at",
+	"updated_at":   "updated_at",
+	"published_at": "published_at",
+	"publish_at":   "publish_at",
+	"unpublish_at": "unpublish_at",
+	"deleted":      "deleted",
+	"fields":       "fields",
+	"tags":         pageTagsColumn,
+}
+
+var pageListSpec = listSpec{
+	fields:     "id, " + pageFieldsSelect + ", " + pageTagsColumn,
+	from:       "pages",
+	columns:    pageColumns,
+	tieBreaker: "id",
+}
+
+// PageRepository provides PostgreSQL storage for pages.
+// searchConfig is the text search configuration used to index page content
+// and as the default for search queries.
 type PageRepository struct {
-	db  *pgxpool.Pool
-	log zerolog.Logger
+	db           *pgxpool.Pool
+	searchConfig string
+	log          zerolog.Logger
 }
 
-func NewPageRepository(db *pgxpool.Pool) *PageRepository {
+func NewPageRepository(db *pgxpool.Pool, searchConfig string) *PageRepository {
 	return &PageRepository{
-		db:  db,
-		log: logger.Get().With().Str("postgres", "page_repository").Logger(),
+		db:           db,
+		searchConfig: searchConfig,
+		log:          logger.Get().With().Str("postgres", "page_repository").Logger(),
 	}
 }
 
-func (r *PageRepository) Create(ctx context.Context, page *entities.Page) error {
-	err := r.db.QueryRow(
+// Create stores the page and, when revision is set, its first revision
+// in one transaction.
+func (r *PageRepository) Create(ctx context.Context, page *entities.Page, revision *entities.PageRevision) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		r.log.Debug().Err(err).Msg("Create1")
+		return fmt.Errorf("failed to create page: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	err = tx.QueryRow(
 		ctx,
 		queryPageInsert,
 		page.Name,
@@ -58,19 +128,47 @@ func (r *PageRepository) Create(ctx context.Context, page *entities.Page) error
 		page.H1,
 		page.Content,
 		page.ContentShort,
+		page.Status,
+		page.PublishAt,
+		page.UnpublishAt,
+		r.searchConfig,
+		page.ContentFormat,
+		page.ContentHTML,
+		page.ContentType,
+		pageFields(page.Fields),
 	).Scan(&page.ID)
 
 	if err != nil {
 		r.log.Debug().Err(err).Msg(page.Name)
-		r.log.Debug().Err(err).Msg("Create")
+		r.log.Debug().Err(err).Msg("Create2")
+		return fmt.Errorf("failed to create page: %w", err)
+	}
+
+	if err := r.saveRevision(ctx, tx, page, revision); err != nil {
+		r.log.Debug().Err(err).Msg("Create3")
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		r.log.Debug().Err(err).Msg("Create4")
 		return fmt.Errorf("failed to create page: %w", err)
 	}
 
 	return nil
 }
 
-func (r *PageRepository) Update(ctx context.Context, page *entities.Page) error {
-	_, err := r.db.Exec(
+// Update saves the page and, when revision is set, the revision in one
+// transaction; with page.Version set only that version is overwritten,
+// otherwise ErrVersionConflict is returned.
+func (r *PageRepository) Update(ctx context.Context, page *entities.Page, revision *entities.PageRevision) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		r.log.Debug().Err(err).Msg("Update1")
+		return fmt.Errorf("failed to update page: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	tag, err := tx.Exec(
 		ctx,
 		queryPageUpdate,
 		page.Name,
@@ -81,49 +179,57 @@ func (r *PageRepository) Update(ctx context.Context, page *entities.Page) error
 		page.H1,
 		page.Content,
 		page.ContentShort,
+		page.Status,
+		page.PublishAt,
+		page.UnpublishAt,
 		page.ID,
+		r.searchConfig,
+		page.ContentFormat,
+		page.ContentHTML,
+		page.ContentType,
+		pageFields(page.Fields),
+		page.Version,
 	)
 
 	if err != nil {
 		r.log.Debug().Err(err).Msg(page.Name)
-		r.log.Debug().Err(err).Msg("Update")
+		r.log.Debug().Err(err).Msg("Update2")
+		return fmt.Errorf("failed to update page: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		if page.Version > 0 {
+			return appPage.ErrVersionConflict
+		}
+		return appPage.ErrPageNotFound
+	}
+
+	if err := r.saveRevision(ctx, tx, page, revision); err != nil {
+		r.log.Debug().Err(err).Msg("Update3")
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		r.log.Debug().Err(err).Msg("Update4")
 		return fmt.Errorf("failed to update page: %w", err)
 	}
 
 	return nil
 }
 
+// saveRevision stores the revision of the saved page, if there is one.
+func (r *PageRepository) saveRevision(ctx context.Context, tx pgx.Tx, page *entities.Page, revision *entities.PageRevision) error {
+	if revision == nil {
+		return nil
+	}
+	revision.PageID = page.ID
+	if err := insertRevision(ctx, tx, revision); err != nil {
+		return fmt.Errorf("failed to create page revision: %w", err)
+	}
+	return nil
+}
+
 func (r *PageRepository) FindByName(ctx context.Context, param string) (*entities.PageWithTime, error) {
-	var (
-		id           int64
-		name         string
-		meta         string
-		title        string
-		category     string
-		template     string
-		h1           string
-		content      string
-		contentShort string
-		createdAt    time.Time
-		updatedAt    time.Time
-	)
-	err := r.db.QueryRow(
-		ctx,
-		queryPageSelectByName,
-		param,
-	).Scan(
-		&id,
-		&name,
-		&meta,
-		&title,
-		&category,
-		&template,
-		&h1,
-		&content,
-		&contentShort,
-		&createdAt,
-		&updatedAt,
-	)
+	page, err := scanPage(r.db.QueryRow(ctx, queryPageSelectByName, param))
 	if err != nil {
 		r.log.Debug().Err(err).Msg("FindByName")
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -132,73 +238,20 @@ func (r *PageRepository) FindByName(ctx context.Context, param string) (*entitie
 		return nil, fmt.Errorf("failed to find page: %w", err)
 	}
 
-	return entities.NewPageWithTime(
-		id,
-		name,
-		meta,
-		title,
-		category,
-		template,
-		h1,
-		content,
-		contentShort,
-		createdAt,
-		updatedAt,
-	)
+	return page, nil
 }
 
 func (r *PageRepository) FindByID(ctx context.Context, param int64) (*entities.PageWithTime, error) {
-	var (
-		id           int64
-		name         string
-		meta         string
-		title        string
-		category     string
-		template     string
-		h1           string
-		content      string
-		contentShort string
-		createdAt    time.Time
-		updatedAt    time.Time
-	)
-	err := r.db.QueryRow(
-		ctx,
-		queryPageSelectByID,
-		param,
-	).Scan(
-		&id,
-		&name,
-		&meta,
-		&title,
-		&category,
-		&template,
-		&h1,
-		&content,
-		&contentShort,
-		&createdAt,
-		&updatedAt,
-	)
+	page, err := scanPage(r.db.QueryRow(ctx, queryPageSelectByID, param))
 	if err != nil {
-		r.log.Debug().Err(err).Msg("FindByName")
+		r.log.Debug().Err(err).Msg("FindByID")
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, appPage.ErrPageNotFound
 		}
 		return nil, fmt.Errorf("failed to find page: %w", err)
 	}
 
-	return entities.NewPageWithTime(
-		id,
-		name,
-		meta,
-		title,
-		category,
-		template,
-		h1,
-		content,
-		contentShort,
-		createdAt,
-		updatedAt,
-	)
+	return page, nil
 }
 
 func (r *PageRepository) GetIDByName(ctx context.Context, name string) (int64, error) {
@@ -218,108 +271,151 @@ func (r *PageRepository) GetIDByName(ctx context.Context, name string) (int64, e
 }
 
 func (r *PageRepository) Delete(ctx context.Context, pageID int64) error {
-
-	_, err := r.db.Query(ctx, queryPageDelete, pageID)
-
+	tag, err := r.db.Exec(ctx, queryPageDelete, pageID)
 	if err != nil {
 		r.log.Debug().Err(err).Msg("Delete")
 		return fmt.Errorf("failed to delete page: %w", err)
 	}
+	if tag.RowsAffected() == 0 {
+		return appPage.ErrPageNotFound
+	}
 
 	return nil
 }
 
 func (r *PageRepository) DeleteForce(ctx context.Context, pageID int64) error {
-
-	_, err := r.db.Query(ctx, queryPageDeleteForce, pageID)
-
+	tag, err := r.db.Exec(ctx, queryPageDeleteForce, pageID)
 	if err != nil {
-		r.log.Debug().Err(err).Msg("Delete")
+		r.log.Debug().Err(err).Msg("DeleteForce")
 		return fmt.Errorf("failed to delete page: %w", err)
 	}
+	if tag.RowsAffected() == 0 {
+		return appPage.ErrPageNotFound
+	}
 
 	return nil
 }
 
 // List returns all Pages from the database.
-func (r *PageRepository) ListPages(ctx context.Context, offset, limit int, params map[string]any) (*entities.PagesWithTimes, *dto.Pagination, error) {
+func (r *PageRepository) ListPages(ctx context.Context, offset, limit int, filter *query.Query) (*entities.PagesWithTimes, *dto.Pagination, error) {
+	pages, pagination, err := listPage(ctx, r.db, pageListSpec, offset, limit, filter, scanTaggedPage)
+	if err != nil {
+		r.log.Debug().Err(err).Msg("ListPages")
+		return nil, nil, fmt.Errorf("failed to list pages: %w", err)
+	}
+
+	result := entities.PagesWithTimes(pages)
+	return &result, pagination, nil
+}
 
-	whereClause, whereParams := sql.BuildWhere(params, "AND", 3)
-	allParams := []any{offset, limit}
+// PublishDue publishes pages whose publish_at has passed.
+func (r *PageRepository) PublishDue(ctx context.Context, now time.Time) (int64, error) {
+	tag, err := r.db.Exec(ctx, queryPagePublishDue, now)
+	if err != nil {
+		r.log.Debug().Err(err).Msg("PublishDue")
+		return 0, fmt.Errorf("failed to publish scheduled pages: %w", err)
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+// UnpublishDue moves pages whose unpublish_at has passed back to draft.
+func (r *PageRepository) UnpublishDue(ctx context.Context, now time.Time) (int64, error) {
+	tag, err := r.db.Exec(ctx, queryPageUnpublishDue, now)
+	if err != nil {
+		r.log.Debug().Err(err).Msg("UnpublishDue")
+		return 0, fmt.Errorf("failed to unpublish scheduled pages: %w", err)
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+func (r *PageRepository) ListUnrendered(ctx context.Context, afterID int64, limit int) (*entities.PagesWithTimes, error) {
+	rows, err := r.db.Query(ctx, queryPagesSelectUnrendered, afterID, limit)
+	if err != nil {
+		r.log.Debug().Err(err).Msg("ListUnrendered1")
+		return nil, fmt.Errorf("failed to list unrendered pages: %w", err)
+	}
+	defer rows.Close()
+
+	pages := entities.PagesWithTimes{}
+	for rows.Next() {
+		page, err := scanPage(rows)
+		if err != nil {
+			r.log.Debug().Err(err).Msg("ListUnrendered2")
+			return nil, fmt.Errorf("failed to scan page row: %w", err)
+		}
+		pages = append(pages, *page)
+	}
+
+	if err = rows.Err(); err != nil {
+		r.log.Debug().Err(err).Msg("ListUnrendered3")
+		return nil, fmt.Errorf("error after iterating page rows: %w", err)
+	}
+
+	return &pages, nil
+}
+
+func (r *PageRepository) UpdateRendered(ctx context.Context, id int64, contentHTML string, contentShort string) error {
+	if _, err := r.db.Exec(ctx, queryPageUpdateRendered, id, contentHTML, contentShort); err != nil {
+		r.log.Debug().Err(err).Msg("UpdateRendered")
+		return fmt.Errorf("failed to update rendered page: %w", err)
+	}
+
+	return nil
+}
+
+// SearchPages runs a full-text search; params are additional filters as in ListPages.
+func (r *PageRepository) SearchPages(ctx context.Context, tsQuery string, lang string, offset, limit int, filter *query.Query) (*entities.PageSearchResults, *dto.Pagination, error) {
+	if lang == "" {
+		lang = r.searchConfig
+	}
+
+	whereClause, whereParams, err := filter.Conditions(pageColumns, 5)
+	if err != nil {
+		return nil, nil, err
+	}
+	if whereClause != "" {
+		whereClause = "and " + whereClause
+	}
+	allParams := []any{offset, limit, lang, tsQuery}
 	allParams = append(allParams, whereParams...)
 
-	query := strings.Replace(queryPagesSelect, "%WHERE%", "WHERE "+whereClause, 1)
+	query := strings.Replace(queryPagesSearch, "%WHERE%", whereClause, 1)
 
 	rows, err := r.db.Query(ctx, query, allParams...)
 	if err != nil {
-		r.log.Debug().Err(err).Msg("ListPages1")
-		return nil, nil, err
+		r.log.Debug().Err(err).Msg("SearchPages1")
+		return nil, nil, wrapSearchError(err)
 	}
 	defer rows.Close()
 
-	var pages entities.PagesWithTimes
-
+	results := entities.PageSearchResults{}
 	for rows.Next() {
-		var (
-			id           int64
-			name         string
-			meta         string
-			title        string
-			category     string
-			template     string
-			h1           string
-			content      string
-			contentShort string
-			createdAt    time.Time
-			updatedAt    time.Time
-		)
-
-		err := rows.Scan(
-			&id,
-			&name,
-			&meta,
-			&title,
-			&category,
-			&template,
-			&h1,
-			&content,
-			&contentShort,
-			&createdAt,
-			&updatedAt,
-		)
+		var result entities.PageSearchResult
+		page, err := scanPage(searchRow{row: rows, result: &result})
 		if err != nil {
-			r.log.Error().Err(err).Msg("Failed to scan upload row")
-			return nil, nil, fmt.Errorf("failed to scan upload row: %w", err)
+			r.log.Debug().Err(err).Msg("SearchPages2")
+			return nil, nil, wrapSearchError(err)
 		}
-
-		page, _ := entities.NewPageWithTime(
-			id,
-			name,
-			meta,
-			title,
-			category,
-			template,
-			h1,
-			content,
-			contentShort,
-			createdAt,
-			updatedAt,
-		)
-		pages = append(pages, *page)
+		result.PageWithTime = *page
+		results = append(results, result)
 	}
 
 	if err = rows.Err(); err != nil {
-		r.log.Debug().Err(err).Msg("ListPages2")
-		return nil, nil, fmt.Errorf("error after iterating upload rows: %w", err)
+		r.log.Debug().Err(err).Msg("SearchPages3")
+		return nil, nil, wrapSearchError(err)
 	}
 
 	// Totals
-	whereClause, whereParams = sql.BuildWhere(params, "AND", 1)
-	query = strings.Replace(queryPagesSelectTotal, "%WHERE%", "WHERE "+whereClause, 1)
+	whereClause, whereParams, _ = filter.Conditions(pageColumns, 3)
+	if whereClause != "" {
+		whereClause = "and " + whereClause
+	}
+	query = strings.Replace(queryPagesSearchTotal, "%WHERE%", whereClause, 1)
 
 	var total int
-	err = r.db.QueryRow(ctx, query, whereParams...).Scan(&total)
-
+	err = r.db.QueryRow(ctx, query, append([]any{lang, tsQuery}, whereParams...)...).Scan(&total)
 	if err != nil {
 		r.log.Debug().Err(err).Msg("GetTotals")
 		return nil, nil, fmt.Errorf("failed to get totals: %w", err)
@@ -332,5 +428,158 @@ func (r *PageRepository) ListPages(ctx context.Context, offset, limit int, param
 		Size:  limit,
 	}
 
-	return &pages, &pagination, nil
+	return &results, &pagination, nil
+}
+
+// ReindexSearch re-indexes pages stored with another text search configuration,
+// e.g. after the configured search language has changed.
+func (r *PageRepository) ReindexSearch(ctx context.Context) (int64, error) {
+	tag, err := r.db.Exec(ctx, queryPagesReindex, r.searchConfig)
+	if err != nil {
+		r.log.Debug().Err(err).Msg("ReindexSearch")
+		return 0, fmt.Errorf("failed to reindex pages: %w", err)
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+// Markers of matched words in ts_headline output (Unicode private use area).
+const (
+	highlightStart = "\ue000"
+	highlightStop  = "\ue001"
+)
+
+var highlightReplacer = strings.NewReplacer(highlightStart, "<mark>", highlightStop, "</mark>")
+
+// searchRow appends the rank and highlight columns of a search query
+// to the regular page columns read by scanPage.
+type searchRow struct {
+	row    pgx.Row
+	result *entities.PageSearchResult
+}
+
+func (s searchRow) Scan(dest ...any) error {
+	err := s.row.Scan(append(dest, &s.result.Rank, &s.result.TitleHighlight, &s.result.Snippet)...)
+	if err != nil {
+		return err
+	}
+	s.result.TitleHighlight = markHighlight(s.result.TitleHighlight, false)
+	s.result.Snippet = markHighlight(s.result.Snippet, true)
+	return nil
+}
+
+// markHighlight escapes a ts_headline fragment and turns the markers
+// into <mark> tags, so the result is safe to insert as HTML.
+// fromHTML: the text was stripped from HTML and still holds its entities.
+func markHighlight(text string, fromHTML bool) string {
+	if fromHTML {
+		text = html.UnescapeString(text)
+	}
+	return highlightReplacer.Replace(html.EscapeString(text))
+}
+
+// wrapSearchError reports an unknown text search configuration as ErrInvalidSearchLanguage.
+func wrapSearchError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "42704" {
+		return appPage.ErrInvalidSearchLanguage
+	}
+	return fmt.Errorf("failed to search pages: %w", err)
+}
+
+// scanTaggedPage reads a page followed by its tags.
+func scanTaggedPage(row pgx.Row) (*entities.PageWithTime, error) {
+	var tags []string
+	page, err := scanPage(tagsRow{row: row, tags: &tags})
+	if err != nil {
+		return nil, err
+	}
+	page.Tags = tags
+	return page, nil
+}
+
+func scanPage(row pgx.Row) (*entities.PageWithTime, error) {
+	var (
+		id           int64
+		name         string
+		meta         entities.PageMeta
+		title        string
+		category     string
+		template     string
+		h1           string
+		content      string
+		contentShort string
+		format       string
+		contentHTML  string
+		status       string
+		publishAt    *time.Time
+		unpublishAt  *time.Time
+		publishedAt  *time.Time
+		createdAt    time.Time
+		updatedAt    time.Time
+		contentType  string
+		fields       entities.PageFields
+		version      int
+	)
+	err := row.Scan(
+		&id,
+		&name,
+		&meta,
+		&title,
+		&category,
+		&template,
+		&h1,
+		&content,
+		&contentShort,
+		&format,
+		&contentHTML,
+		&status,
+		&publishAt,
+		&unpublishAt,
+		&publishedAt,
+		&createdAt,
+		&updatedAt,
+		&contentType,
+		&fields,
+		&version,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	page, err := entities.NewPageWithTime(
+		id,
+		name,
+		meta,
+		title,
+		category,
+		template,
+		h1,
+		content,
+		contentShort,
+		createdAt,
+		updatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	page.ContentFormat = format
+	page.ContentHTML = contentHTML
+	page.Status = status
+	page.PublishAt = publishAt
+	page.UnpublishAt = unpublishAt
+	page.PublishedAt = publishedAt
+	page.ContentType = contentType
+	page.Fields = fields
+	page.Version = version
+
+	return page, nil
+}
+
+// pageFields stores pages without field values as an empty object.
+func pageFields(fields entities.PageFields) entities.PageFields {
+	if fields == nil {
+		return entities.PageFields{}
+	}
+	return fields
 }
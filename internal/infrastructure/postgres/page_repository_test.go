@@ -0,0 +1,18 @@
+package postgres
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarkHighlight(t *testing.T) {
+	// Разметка из исходника страницы не должна попасть в ответ как HTML
+	assert.Equal(t,
+		"&lt;img src=x onerror=alert(1)&gt; <mark>cats</mark>",
+		markHighlight("<img src=x onerror=alert(1)> "+highlightStart+"cats"+highlightStop, false))
+	// Текст из content_html уже содержит сущности
+	assert.Equal(t,
+		"Tom &amp; <mark>Jerry</mark> &lt;b&gt;",
+		markHighlight("Tom &amp; "+highlightStart+"Jerry"+highlightStop+" &lt;b&gt;", true))
+}
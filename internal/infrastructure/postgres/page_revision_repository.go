@@ -0,0 +1,151 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aube/auth/internal/application/dto"
+	appPage "github.com/aube/auth/internal/application/page"
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/aube/auth/internal/utils/logger"
+	"github.com/aube/auth/internal/utils/query"
+	"github.com/rs/zerolog"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	pageRevisionFieldsSelect string = "id, page_id, revision, name, meta, title, category, template, h1, content, content_short, content_format, content_type, fields, author_id, created_at"
+
+	queryPageRevisionInsert string = `INSERT INTO page_revisions (page_id, revision, name, meta, title, category, template, h1, content, content_short, content_format, content_type, fields, author_id)
+		SELECT $1, coalesce(max(revision), 0) + 1, $2, $3, $4, $5, $6, $7, $8, $9, $13, $11, $12, $10 FROM page_revisions WHERE page_id = $1
+		RETURNING id, revision, created_at`
+	queryPageRevisionSelect string = "SELECT " + pageRevisionFieldsSelect + " FROM page_revisions WHERE page_id = $1 and revision = $2"
+	queryPageRevisionsPrune string = `DELETE FROM page_revisions WHERE page_id = $1
+		and revision < (SELECT max(revision) FROM page_revisions WHERE page_id = $1)
+		and (
+			($2 > 0 and revision <= (SELECT max(revision) FROM page_revisions WHERE page_id = $1) - $2)
+			or ($3::timestamp IS NOT NULL and created_at < $3::timestamp)
+		)`
+)
+
+// Список без тяжёлых полей: содержимое отдаётся только для одной ревизии
+var pageRevisionListSpec = listSpec{
+	fields:     "id, page_id, revision, name, title, author_id, created_at",
+	from:       "page_revisions",
+	where:      "page_id = $1",
+	columns:    query.Columns{"id": "id", "revision": "revision"},
+	tieBreaker: "id",
+}
+
+// PageRevisionRepository provides PostgreSQL storage for page revisions.
+// Revisions are written by PageRepository together with the page.
+type PageRevisionRepository struct {
+	db  *pgxpool.Pool
+	log zerolog.Logger
+}
+
+func NewPageRevisionRepository(db *pgxpool.Pool) *PageRevisionRepository {
+	return &PageRevisionRepository{
+		db:  db,
+		log: logger.Get().With().Str("postgres", "page_revision_repository").Logger(),
+	}
+}
+
+// insertRevision stores the snapshot in the transaction that saves the page;
+// the page row is locked by that save, so revision numbers cannot collide.
+func insertRevision(ctx context.Context, tx pgx.Tx, revision *entities.PageRevision) error {
+	return tx.QueryRow(
+		ctx,
+		queryPageRevisionInsert,
+		revision.PageID,
+		revision.Name,
+		revision.Meta,
+		revision.Title,
+		revision.Category,
+		revision.Template,
+		revision.H1,
+		revision.Content,
+		revision.ContentShort,
+		revision.AuthorID,
+		revision.ContentType,
+		pageFields(revision.Fields),
+		revision.ContentFormat,
+	).Scan(&revision.ID, &revision.Revision, &revision.CreatedAt)
+}
+
+func (r *PageRevisionRepository) ListRevisions(ctx context.Context, pageID int64, offset, limit int, filter *query.Query) (*entities.PageRevisions, *dto.Pagination, error) {
+	spec := pageRevisionListSpec
+	spec.args = []any{pageID}
+
+	// Порядок фиксирован: новые ревизии первыми
+	filter = filter.Clone()
+	filter.Sort = []query.Sort{{Field: "revision", Desc: true}}
+
+	revisions, pagination, err := listPage(ctx, r.db, spec, offset, limit, filter, scanPageRevisionItem)
+	if err != nil {
+		r.log.Debug().Err(err).Msg("ListRevisions")
+		return nil, nil, fmt.Errorf("failed to list page revisions: %w", err)
+	}
+
+	result := entities.PageRevisions(revisions)
+	return &result, pagination, nil
+}
+
+func (r *PageRevisionRepository) FindRevision(ctx context.Context, pageID int64, revision int) (*entities.PageRevision, error) {
+	var rev entities.PageRevision
+	err := r.db.QueryRow(ctx, queryPageRevisionSelect, pageID, revision).Scan(
+		&rev.ID,
+		&rev.PageID,
+		&rev.Revision,
+		&rev.Name,
+		&rev.Meta,
+		&rev.Title,
+		&rev.Category,
+		&rev.Template,
+		&rev.H1,
+		&rev.Content,
+		&rev.ContentShort,
+		&rev.ContentFormat,
+		&rev.ContentType,
+		&rev.Fields,
+		&rev.AuthorID,
+		&rev.CreatedAt,
+	)
+	if err != nil {
+		r.log.Debug().Err(err).Msg("FindRevision")
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, appPage.ErrRevisionNotFound
+		}
+		return nil, fmt.Errorf("failed to find page revision: %w", err)
+	}
+
+	return &rev, nil
+}
+
+func (r *PageRevisionRepository) PruneRevisions(ctx context.Context, pageID int64, keepLast int, olderThan time.Time) (int64, error) {
+	var before any
+	if !olderThan.IsZero() {
+		before = olderThan
+	}
+
+	tag, err := r.db.Exec(ctx, queryPageRevisionsPrune, pageID, keepLast, before)
+	if err != nil {
+		r.log.Debug().Err(err).Msg("PruneRevisions")
+		return 0, fmt.Errorf("failed to prune page revisions: %w", err)
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+func scanPageRevisionItem(row pgx.Row) (*entities.PageRevision, error) {
+	var rev entities.PageRevision
+	err := row.Scan(&rev.ID, &rev.PageID, &rev.Revision, &rev.Name, &rev.Title, &rev.AuthorID, &rev.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &rev, nil
+}
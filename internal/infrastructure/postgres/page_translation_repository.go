@@ -0,0 +1,159 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	appTranslation "github.com/aube/auth/internal/application/translation"
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/aube/auth/internal/utils/logger"
+	"github.com/rs/zerolog"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	pageTranslationColumns string = `id, page_id, locale, name, meta, title, h1, content, content_short,
+		content_format, content_html, created_at, updated_at`
+
+	queryPageTranslationSave string = `INSERT INTO page_translations
+		(page_id, locale, name, meta, title, h1, content, content_short, content_format, content_html)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		ON CONFLICT (page_id, locale) DO UPDATE SET name = EXCLUDED.name, meta = EXCLUDED.meta,
+			title = EXCLUDED.title, h1 = EXCLUDED.h1, content = EXCLUDED.content,
+			content_short = EXCLUDED.content_short, content_format = EXCLUDED.content_format,
+			content_html = EXCLUDED.content_html
+		RETURNING id, created_at, updated_at`
+	queryPageTranslationDelete       string = "DELETE FROM page_translations WHERE page_id = $1 and locale = $2"
+	queryPageTranslationSelectByPage string = "SELECT " + pageTranslationColumns + " FROM page_translations WHERE page_id = $1 and locale = $2"
+	queryPageTranslationSelectByName string = "SELECT " + pageTranslationColumns + " FROM page_translations WHERE locale = $1 and name = $2"
+	queryPageTranslationSelectAll    string = "SELECT " + pageTranslationColumns + " FROM page_translations WHERE page_id = $1 ORDER BY locale"
+)
+
+type PageTranslationRepository struct {
+	db  *pgxpool.Pool
+	log zerolog.Logger
+}
+
+func NewPageTranslationRepository(db *pgxpool.Pool) *PageTranslationRepository {
+	return &PageTranslationRepository{
+		db:  db,
+		log: logger.Get().With().Str("postgres", "page_translation_repository").Logger(),
+	}
+}
+
+func (r *PageTranslationRepository) Save(ctx context.Context, translation *entities.PageTranslation) error {
+	err := r.db.QueryRow(ctx, queryPageTranslationSave,
+		translation.PageID,
+		translation.Locale,
+		translation.Name,
+		translation.Meta,
+		translation.Title,
+		translation.H1,
+		translation.Content,
+		translation.ContentShort,
+		translation.ContentFormat,
+		translation.ContentHTML,
+	).Scan(&translation.ID, &translation.CreatedAt, &translation.UpdatedAt)
+	if err != nil {
+		r.log.Debug().Err(err).Msg("Save")
+		return wrapPageTranslationError("failed to save page translation", err)
+	}
+
+	return nil
+}
+
+func (r *PageTranslationRepository) Delete(ctx context.Context, pageID int64, locale string) error {
+	tag, err := r.db.Exec(ctx, queryPageTranslationDelete, pageID, locale)
+	if err != nil {
+		r.log.Debug().Err(err).Msg("Delete")
+		return fmt.Errorf("failed to delete page translation: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return appTranslation.ErrTranslationNotFound
+	}
+
+	return nil
+}
+
+func (r *PageTranslationRepository) FindByPage(ctx context.Context, pageID int64, locale string) (*entities.PageTranslation, error) {
+	translation, err := scanPageTranslation(r.db.QueryRow(ctx, queryPageTranslationSelectByPage, pageID, locale))
+	if err != nil {
+		r.log.Debug().Err(err).Msg("FindByPage")
+		return nil, wrapPageTranslationError("failed to find page translation", err)
+	}
+
+	return translation, nil
+}
+
+func (r *PageTranslationRepository) FindByName(ctx context.Context, locale string, name string) (*entities.PageTranslation, error) {
+	translation, err := scanPageTranslation(r.db.QueryRow(ctx, queryPageTranslationSelectByName, locale, name))
+	if err != nil {
+		r.log.Debug().Err(err).Msg("FindByName")
+		return nil, wrapPageTranslationError("failed to find page translation", err)
+	}
+
+	return translation, nil
+}
+
+func (r *PageTranslationRepository) ListByPage(ctx context.Context, pageID int64) (*entities.PageTranslations, error) {
+	rows, err := r.db.Query(ctx, queryPageTranslationSelectAll, pageID)
+	if err != nil {
+		r.log.Debug().Err(err).Msg("ListByPage")
+		return nil, fmt.Errorf("failed to list page translations: %w", err)
+	}
+	defer rows.Close()
+
+	translations := entities.PageTranslations{}
+	for rows.Next() {
+		translation, err := scanPageTranslation(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan page translation row: %w", err)
+		}
+		translations = append(translations, *translation)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error after iterating page translation rows: %w", err)
+	}
+
+	return &translations, nil
+}
+
+func scanPageTranslation(row pgx.Row) (*entities.PageTranslation, error) {
+	var translation entities.PageTranslation
+	err := row.Scan(
+		&translation.ID,
+		&translation.PageID,
+		&translation.Locale,
+		&translation.Name,
+		&translation.Meta,
+		&translation.Title,
+		&translation.H1,
+		&translation.Content,
+		&translation.ContentShort,
+		&translation.ContentFormat,
+		&translation.ContentHTML,
+		&translation.CreatedAt,
+		&translation.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &translation, nil
+}
+
+// wrapPageTranslationError maps driver errors to application errors of the translation package.
+func wrapPageTranslationError(msg string, err error) error {
+	var pgErr *pgconn.PgError
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		return appTranslation.ErrTranslationNotFound
+	case errors.As(err, &pgErr) && pgErr.Code == "23505":
+		return appTranslation.ErrTranslationExists
+	}
+	return fmt.Errorf("%s: %w", msg, err)
+}
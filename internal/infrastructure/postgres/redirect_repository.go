@@ -0,0 +1,226 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aube/auth/internal/application/dto"
+	appRedirect "github.com/aube/auth/internal/application/redirect"
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/aube/auth/internal/utils/logger"
+	"github.com/aube/auth/internal/utils/query"
+	"github.com/rs/zerolog"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	redirectColumns string = "id, source, target, kind, code, auto, created_at, updated_at"
+
+	queryRedirectInsert string = `INSERT INTO redirects (source, target, kind, code, auto)
+		VALUES ($1, $2, $3, $4, $5) RETURNING id`
+	queryRedirectUpdate string = `UPDATE redirects SET source = $2, target = $3, kind = $4, code = $5, auto = $6
+		WHERE id = $1`
+	queryRedirectDelete     string = "DELETE FROM redirects WHERE id = $1"
+	queryRedirectSelectByID string = "SELECT " + redirectColumns + " FROM redirects WHERE id = $1"
+	queryRedirectSelectAll  string = "SELECT " + redirectColumns + " FROM redirects ORDER BY id"
+
+	// Ручное правило с тем же источником не перезаписывается
+	queryRedirectSaveAuto string = `INSERT INTO redirects (source, target, kind, code, auto)
+		VALUES ($1, $2, $3, $4, true)
+		ON CONFLICT (kind, source) DO UPDATE SET target = EXCLUDED.target, code = EXCLUDED.code
+		WHERE redirects.auto = true`
+	queryRedirectDeleteAuto string = `DELETE FROM redirects
+		WHERE auto = true and (source = $1 or ($2 and starts_with(source, $1 || '/')))`
+	queryRedirectRetargetAuto string = `UPDATE redirects SET target = $2 || substr(target, length($1) + 1)
+		WHERE auto = true and kind = 'exact' and (target = $1 or ($3 and starts_with(target, $1 || '/')))`
+)
+
+var redirectListSpec = listSpec{
+	fields:     redirectColumns,
+	from:       "redirects",
+	columns:    query.Columns{"id": "id"},
+	tieBreaker: "id",
+}
+
+type RedirectRepository struct {
+	db  *pgxpool.Pool
+	log zerolog.Logger
+}
+
+func NewRedirectRepository(db *pgxpool.Pool) *RedirectRepository {
+	return &RedirectRepository{
+		db:  db,
+		log: logger.Get().With().Str("postgres", "redirect_repository").Logger(),
+	}
+}
+
+func (r *RedirectRepository) Create(ctx context.Context, redirect *entities.Redirect) error {
+	err := r.db.QueryRow(ctx, queryRedirectInsert,
+		redirect.Source,
+		redirect.Target,
+		redirect.Kind,
+		redirect.Code,
+		redirect.Auto,
+	).Scan(&redirect.ID)
+	if err != nil {
+		r.log.Debug().Err(err).Msg("Create")
+		return wrapRedirectError("failed to create redirect", err)
+	}
+
+	return nil
+}
+
+func (r *RedirectRepository) Update(ctx context.Context, redirect *entities.Redirect) error {
+	tag, err := r.db.Exec(ctx, queryRedirectUpdate,
+		redirect.ID,
+		redirect.Source,
+		redirect.Target,
+		redirect.Kind,
+		redirect.Code,
+		redirect.Auto,
+	)
+	if err != nil {
+		r.log.Debug().Err(err).Msg("Update")
+		return wrapRedirectError("failed to update redirect", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return appRedirect.ErrRedirectNotFound
+	}
+
+	return nil
+}
+
+func (r *RedirectRepository) Delete(ctx context.Context, id int64) error {
+	tag, err := r.db.Exec(ctx, queryRedirectDelete, id)
+	if err != nil {
+		r.log.Debug().Err(err).Msg("Delete")
+		return fmt.Errorf("failed to delete redirect: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return appRedirect.ErrRedirectNotFound
+	}
+
+	return nil
+}
+
+func (r *RedirectRepository) FindByID(ctx context.Context, id int64) (*entities.Redirect, error) {
+	redirect, err := scanRedirect(r.db.QueryRow(ctx, queryRedirectSelectByID, id))
+	if err != nil {
+		r.log.Debug().Err(err).Msg("FindByID")
+		return nil, wrapRedirectError("failed to find redirect", err)
+	}
+
+	return redirect, nil
+}
+
+func (r *RedirectRepository) List(ctx context.Context, offset, limit int, filter *query.Query) (*entities.Redirects, *dto.Pagination, error) {
+	// Новые правила первыми
+	filter = filter.Clone()
+	filter.Sort = []query.Sort{{Field: "id", Desc: true}}
+
+	redirects, pagination, err := listPage(ctx, r.db, redirectListSpec, offset, limit, filter, scanRedirect)
+	if err != nil {
+		r.log.Debug().Err(err).Msg("List")
+		return nil, nil, fmt.Errorf("failed to list redirects: %w", err)
+	}
+
+	result := entities.Redirects(redirects)
+	return &result, pagination, nil
+}
+
+func (r *RedirectRepository) ListAll(ctx context.Context) (*entities.Redirects, error) {
+	redirects, err := r.list(ctx, queryRedirectSelectAll)
+	if err != nil {
+		r.log.Debug().Err(err).Msg("ListAll")
+		return nil, err
+	}
+
+	return redirects, nil
+}
+
+func (r *RedirectRepository) SaveAuto(ctx context.Context, redirect *entities.Redirect) error {
+	_, err := r.db.Exec(ctx, queryRedirectSaveAuto, redirect.Source, redirect.Target, redirect.Kind, redirect.Code)
+	if err != nil {
+		r.log.Debug().Err(err).Msg("SaveAuto")
+		return fmt.Errorf("failed to save redirect: %w", err)
+	}
+
+	return nil
+}
+
+func (r *RedirectRepository) DeleteAuto(ctx context.Context, path string, subtree bool) (int64, error) {
+	tag, err := r.db.Exec(ctx, queryRedirectDeleteAuto, path, subtree)
+	if err != nil {
+		r.log.Debug().Err(err).Msg("DeleteAuto")
+		return 0, fmt.Errorf("failed to delete redirects: %w", err)
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+func (r *RedirectRepository) RetargetAuto(ctx context.Context, oldTarget, newTarget string, subtree bool) (int64, error) {
+	tag, err := r.db.Exec(ctx, queryRedirectRetargetAuto, oldTarget, newTarget, subtree)
+	if err != nil {
+		r.log.Debug().Err(err).Msg("RetargetAuto")
+		return 0, fmt.Errorf("failed to retarget redirects: %w", err)
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+func (r *RedirectRepository) list(ctx context.Context, query string, args ...any) (*entities.Redirects, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list redirects: %w", err)
+	}
+	defer rows.Close()
+
+	redirects := entities.Redirects{}
+	for rows.Next() {
+		redirect, err := scanRedirect(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan redirect row: %w", err)
+		}
+		redirects = append(redirects, *redirect)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error after iterating redirect rows: %w", err)
+	}
+
+	return &redirects, nil
+}
+
+func scanRedirect(row pgx.Row) (*entities.Redirect, error) {
+	var redirect entities.Redirect
+	err := row.Scan(
+		&redirect.ID,
+		&redirect.Source,
+		&redirect.Target,
+		&redirect.Kind,
+		&redirect.Code,
+		&redirect.Auto,
+		&redirect.CreatedAt,
+		&redirect.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &redirect, nil
+}
+
+// wrapRedirectError maps driver errors to application errors of the redirect package.
+func wrapRedirectError(msg string, err error) error {
+	var pgErr *pgconn.PgError
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		return appRedirect.ErrRedirectNotFound
+	case errors.As(err, &pgErr) && pgErr.Code == "23505":
+		return appRedirect.ErrRedirectExists
+	}
+	return fmt.Errorf("%s: %w", msg, err)
+}
@@ -0,0 +1,77 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aube/auth/internal/application/dto"
+	appUpload "github.com/aube/auth/internal/application/upload"
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/aube/auth/internal/utils/logger"
+	"github.com/aube/auth/internal/utils/query"
+	"github.com/rs/zerolog"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	// Хранимый файл — текущее содержимое загрузки или одна из её версий
+	queryScanSetStatus string = `WITH u AS (
+			UPDATE uploads SET scan_status = $2, scan_detail = $3 WHERE uuid = $1::uuid RETURNING 1
+		), v AS (
+			UPDATE upload_versions SET scan_status = $2, scan_detail = $3 WHERE uuid = $1::uuid RETURNING 1
+		)
+		SELECT (SELECT count(*) FROM u) + (SELECT count(*) FROM v)`
+	queryScanListPending string = `SELECT uuid::text FROM (
+			SELECT uuid, coalesce(version_at, created_at) AS stored_at FROM uploads
+				WHERE scan_status = 'pending' and deleted = false
+			UNION ALL
+			SELECT uuid, created_at FROM upload_versions WHERE scan_status = 'pending'
+		) p
+		ORDER BY stored_at LIMIT $1`
+)
+
+type ScanRepository struct {
+	db  *pgxpool.Pool
+	log zerolog.Logger
+}
+
+func NewScanRepository(db *pgxpool.Pool) *ScanRepository {
+	return &ScanRepository{
+		db:  db,
+		log: logger.Get().With().Str("postgres", "scan_repository").Logger(),
+	}
+}
+
+func (r *ScanRepository) SetStatus(ctx context.Context, uuid string, status string, detail string) error {
+	var updated int64
+	if err := r.db.QueryRow(ctx, queryScanSetStatus, uuid, status, detail).Scan(&updated); err != nil {
+		r.log.Debug().Err(err).Msg("SetStatus")
+		return fmt.Errorf("failed to set scan status: %w", err)
+	}
+	if updated == 0 {
+		return appUpload.ErrFileNotFound
+	}
+	return nil
+}
+
+func (r *ScanRepository) ListPending(ctx context.Context, limit int) ([]string, error) {
+	pending, err := collectKeys[string](ctx, r.db, queryScanListPending, limit)
+	if err != nil {
+		r.log.Debug().Err(err).Msg("ListPending")
+		return nil, fmt.Errorf("failed to list pending files: %w", err)
+	}
+	return pending, nil
+}
+
+// ListQuarantined lists uploads of all users; the filter decides which statuses.
+func (r *ScanRepository) ListQuarantined(ctx context.Context, offset, limit int, filter *query.Query) (*entities.Uploads, *dto.Pagination, error) {
+	uploads, pagination, err := listPage(ctx, r.db, uploadListSpec, offset, limit, filter, scanUpload)
+	if err != nil {
+		r.log.Debug().Err(err).Msg("ListQuarantined")
+		return nil, nil, fmt.Errorf("failed to list uploads: %w", err)
+	}
+
+	result := entities.Uploads(uploads)
+	return &result, pagination, nil
+}
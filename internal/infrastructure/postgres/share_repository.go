@@ -0,0 +1,175 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aube/auth/internal/application/dto"
+	appShare "github.com/aube/auth/internal/application/share"
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/aube/auth/internal/domain/valueobjects"
+	"github.com/aube/auth/internal/utils/logger"
+	"github.com/aube/auth/internal/utils/query"
+	"github.com/rs/zerolog"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	shareFields string = `s.id, s.token, s.user_id, s.password, s.expires_at, s.max_downloads, s.downloads, s.disposition, s.created_at,
+		u.uuid, u.name, u.content_type, u.size, u.scan_status`
+	// Ссылки удалённых загрузок не действуют
+	shareFrom string = "upload_shares s JOIN uploads u ON u.id = s.upload_id and u.deleted = false"
+
+	queryShareInsert string = `INSERT INTO upload_shares (token, upload_id, user_id, password, expires_at, max_downloads, disposition, created_at)
+		SELECT $1, id, user_id, $4, $5, $6, $7, $8 FROM uploads WHERE uuid = $2 and user_id = $3 and deleted = false
+		RETURNING id`
+	queryShareSelectByToken string = "SELECT " + shareFields + " FROM " + shareFrom + " WHERE s.token = $1"
+	queryShareDelete        string = "DELETE FROM upload_shares WHERE id = $1 and user_id = $2"
+	queryShareCountDownload string = `UPDATE upload_shares SET downloads = downloads + 1
+		WHERE id = $1 and (max_downloads = 0 or downloads < max_downloads) and (expires_at IS NULL or expires_at > now())`
+)
+
+var shareListSpec = listSpec{
+	fields:     shareFields,
+	from:       shareFrom,
+	where:      "s.user_id = $1",
+	columns:    query.Columns{"id": "s.id"},
+	tieBreaker: "s.id",
+}
+
+type ShareRepository struct {
+	db  *pgxpool.Pool
+	log zerolog.Logger
+}
+
+func NewShareRepository(db *pgxpool.Pool) *ShareRepository {
+	return &ShareRepository{
+		db:  db,
+		log: logger.Get().With().Str("postgres", "share_repository").Logger(),
+	}
+}
+
+func (r *ShareRepository) Create(ctx context.Context, share *entities.UploadShare) error {
+	var password string
+	if share.Password != nil {
+		password = share.Password.String()
+	}
+
+	err := r.db.QueryRow(ctx, queryShareInsert,
+		share.Token,
+		share.Upload.UUID,
+		share.UserID,
+		password,
+		share.ExpiresAt,
+		share.MaxDownloads,
+		share.Disposition,
+		share.CreatedAt,
+	).Scan(&share.ID)
+	if err != nil {
+		r.log.Debug().Err(err).Msg("Create")
+		if errors.Is(err, pgx.ErrNoRows) {
+			return appShare.ErrShareNotFound
+		}
+		return fmt.Errorf("failed to create share link: %w", err)
+	}
+
+	return nil
+}
+
+func (r *ShareRepository) FindByToken(ctx context.Context, token string) (*entities.UploadShare, error) {
+	share, err := scanShare(r.db.QueryRow(ctx, queryShareSelectByToken, token))
+	if err != nil {
+		r.log.Debug().Err(err).Msg("FindByToken")
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, appShare.ErrShareNotFound
+		}
+		return nil, fmt.Errorf("failed to find share link: %w", err)
+	}
+
+	return share, nil
+}
+
+func (r *ShareRepository) ListByUserID(ctx context.Context, userID int64, offset, limit int, filter *query.Query) (*entities.UploadShares, *dto.Pagination, error) {
+	// Новые ссылки первыми
+	filter = filter.Clone()
+	filter.Sort = []query.Sort{{Field: "id", Desc: true}}
+
+	spec := shareListSpec
+	spec.args = []any{userID}
+
+	shares, pagination, err := listPage(ctx, r.db, spec, offset, limit, filter, scanShare)
+	if err != nil {
+		r.log.Debug().Err(err).Msg("ListByUserID")
+		return nil, nil, fmt.Errorf("failed to list share links: %w", err)
+	}
+
+	result := entities.UploadShares(shares)
+	return &result, pagination, nil
+}
+
+func (r *ShareRepository) Delete(ctx context.Context, id int64, userID int64) error {
+	tag, err := r.db.Exec(ctx, queryShareDelete, id, userID)
+	if err != nil {
+		r.log.Debug().Err(err).Msg("Delete")
+		return fmt.Errorf("failed to delete share link: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return appShare.ErrShareNotFound
+	}
+
+	return nil
+}
+
+func (r *ShareRepository) CountDownload(ctx context.Context, id int64) error {
+	tag, err := r.db.Exec(ctx, queryShareCountDownload, id)
+	if err != nil {
+		r.log.Debug().Err(err).Msg("CountDownload")
+		return fmt.Errorf("failed to count download: %w", err)
+	}
+	// Лимит исчерпан или срок истёк между проверкой и скачиванием
+	if tag.RowsAffected() == 0 {
+		return appShare.ErrShareExhausted
+	}
+
+	return nil
+}
+
+func scanShare(row pgx.Row) (*entities.UploadShare, error) {
+	var (
+		share    entities.UploadShare
+		upload   entities.Upload
+		password string
+	)
+	err := row.Scan(
+		&share.ID,
+		&share.Token,
+		&share.UserID,
+		&password,
+		&share.ExpiresAt,
+		&share.MaxDownloads,
+		&share.Downloads,
+		&share.Disposition,
+		&share.CreatedAt,
+		&upload.UUID,
+		&upload.Name,
+		&upload.ContentType,
+		&upload.Size,
+		&upload.ScanStatus,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if password != "" {
+		share.Password, err = valueobjects.NewPassword(password)
+		if err != nil {
+			return nil, fmt.Errorf("invalid password format in DB: %w", err)
+		}
+	}
+	upload.UserID = share.UserID
+	share.Upload = &upload
+	return &share, nil
+}
@@ -0,0 +1,118 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/aube/auth/internal/utils/locale"
+	"github.com/aube/auth/internal/utils/logger"
+	"github.com/rs/zerolog"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	// Публичный путь страницы: путь первого опубликованного узла дерева или "/<name>",
+	// главная страница - "/"; $3 - имя главной страницы
+	publicPathJoin string = `LEFT JOIN LATERAL (
+			SELECT path FROM nodes WHERE page_id = p.id and published = true and deleted = false ORDER BY level, id LIMIT 1
+		) n ON true`
+	publicPathExpr string = `coalesce(n.path, CASE WHEN p.name = $3 THEN '/' ELSE '/' || p.name END)`
+
+	// Опубликованные страницы без noindex
+	sitemapWhere string = `p.deleted = false and p.status = 'published'
+			and coalesce(p.meta->>'robots', '') !~ '(noindex|none)'`
+	// Переводы без noindex
+	sitemapTranslationWhere string = sitemapWhere + `
+			and coalesce(t.meta->>'robots', '') !~ '(noindex|none)'`
+
+	// $1 - языки переводов
+	querySitemapCount string = `SELECT (SELECT count(*) FROM pages p WHERE ` + sitemapWhere + `)
+		+ (SELECT count(*) FROM page_translations t JOIN pages p ON p.id = t.page_id
+			WHERE ` + sitemapTranslationWhere + ` and t.locale = any($1))`
+
+	// Адреса страниц и их переводов ("/en/about"); $4 - язык по умолчанию, $5 - языки переводов.
+	// Для страниц с переводами в alternates перечислены все языковые версии
+	querySitemapEntries string = `WITH urls AS (
+			SELECT p.id, $4::text AS locale, ` + publicPathExpr + ` AS path,
+				coalesce(p.updated_at, p.created_at, now()) AS lastmod
+			FROM pages p ` + publicPathJoin + ` WHERE ` + sitemapWhere + `
+			UNION ALL
+			SELECT p.id, t.locale, '/' || t.locale || CASE
+					WHEN n.path IS NOT NULL THEN CASE WHEN n.path = '/' THEN '' ELSE n.path END
+					WHEN p.name = $3 THEN ''
+					ELSE '/' || t.name END,
+				greatest(t.updated_at, p.updated_at)
+			FROM pages p JOIN page_translations t ON t.page_id = p.id ` + publicPathJoin + `
+			WHERE ` + sitemapTranslationWhere + ` and t.locale = any($5)
+		)
+		SELECT path, lastmod, CASE WHEN count(*) OVER w > 1
+			THEN json_agg(json_build_object('locale', locale, 'path', path)) OVER w END
+		FROM urls
+		WINDOW w AS (PARTITION BY id ORDER BY locale ROWS BETWEEN UNBOUNDED PRECEDING AND UNBOUNDED FOLLOWING)
+		ORDER BY id, locale OFFSET $1 LIMIT $2`
+)
+
+type SitemapRepository struct {
+	db      *pgxpool.Pool
+	locales *locale.Locales
+	log     zerolog.Logger
+}
+
+// NewSitemapRepository creates the repository; translations are listed
+// for the supported locales other than the default one.
+func NewSitemapRepository(db *pgxpool.Pool, locales *locale.Locales) *SitemapRepository {
+	return &SitemapRepository{
+		db:      db,
+		locales: locales,
+		log:     logger.Get().With().Str("postgres", "sitemap_repository").Logger(),
+	}
+}
+
+// translated returns the locales translations are listed for.
+func (r *SitemapRepository) translated() []string {
+	res := []string{}
+	for _, l := range r.locales.Supported() {
+		if l != r.locales.Default() {
+			res = append(res, l)
+		}
+	}
+	return res
+}
+
+func (r *SitemapRepository) CountEntries(ctx context.Context) (int, error) {
+	var total int
+	if err := r.db.QueryRow(ctx, querySitemapCount, r.translated()).Scan(&total); err != nil {
+		r.log.Debug().Err(err).Msg("CountEntries")
+		return 0, fmt.Errorf("failed to count sitemap entries: %w", err)
+	}
+
+	return total, nil
+}
+
+func (r *SitemapRepository) ListEntries(ctx context.Context, offset, limit int) (*entities.SitemapEntries, error) {
+	rows, err := r.db.Query(ctx, querySitemapEntries, offset, limit, entities.HomePageName, r.locales.Default(), r.translated())
+	if err != nil {
+		r.log.Debug().Err(err).Msg("ListEntries1")
+		return nil, fmt.Errorf("failed to list sitemap entries: %w", err)
+	}
+	defer rows.Close()
+
+	entries := entities.SitemapEntries{}
+	for rows.Next() {
+		var entry entities.SitemapEntry
+		if err := rows.Scan(&entry.Path, &entry.LastMod, &entry.Alternates); err != nil {
+			r.log.Debug().Err(err).Msg("ListEntries2")
+			return nil, fmt.Errorf("failed to scan sitemap entry: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+
+	if err = rows.Err(); err != nil {
+		r.log.Debug().Err(err).Msg("ListEntries3")
+		return nil, fmt.Errorf("error after iterating sitemap entries: %w", err)
+	}
+
+	return &entries, nil
+}
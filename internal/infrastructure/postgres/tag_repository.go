@@ -0,0 +1,319 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	appTag "github.com/aube/auth/internal/application/tag"
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/aube/auth/internal/utils/logger"
+	"github.com/aube/auth/internal/utils/query"
+	"github.com/rs/zerolog"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Теги записи одним массивом: для выдачи в списках и фильтров any/all
+const (
+	pageTagsColumn   string = "array(SELECT t.name::text FROM page_tag x JOIN tags t ON t.id = x.tag_id WHERE x.page_id = pages.id ORDER BY t.name)"
+	uploadTagsColumn string = "array(SELECT t.name::text FROM upload_tag x JOIN tags t ON t.id = x.tag_id WHERE x.upload_id = uploads.id ORDER BY t.name)"
+	imageTagsColumn  string = "array(SELECT t.name::text FROM image_tag x JOIN tags t ON t.id = x.tag_id WHERE x.image_id = images.id ORDER BY t.name)"
+)
+
+const (
+	// maxFacets limits values of each facet
+	maxFacets = 100
+
+	queryTagsSelect string = `SELECT t.id, t.user_id, t.name,
+			(SELECT count(*) FROM page_tag x WHERE x.tag_id = t.id)
+			+ (SELECT count(*) FROM upload_tag x WHERE x.tag_id = t.id)
+			+ (SELECT count(*) FROM image_tag x WHERE x.tag_id = t.id)
+		FROM tags t WHERE t.user_id = $1 ORDER BY t.name`
+	queryTagsInsert string = `INSERT INTO tags (user_id, name) SELECT $1, unnest($2::text[])
+		ON CONFLICT (user_id, name) DO NOTHING`
+	queryTagsLockNames string = "SELECT id FROM tags WHERE user_id = $1 and name = ANY($2) FOR UPDATE"
+	queryTagUpsert     string = `INSERT INTO tags (user_id, name) VALUES ($1, $2)
+		ON CONFLICT (user_id, name) DO UPDATE SET name = EXCLUDED.name RETURNING id`
+	queryTagsDelete string = "DELETE FROM tags WHERE id = ANY($1)"
+)
+
+// tagTarget holds queries of one kind of tagged items.
+type tagTarget struct {
+	table   string
+	columns query.Columns
+	// lookup finds the item ID: pages by ID, files by UUID and owner
+	lookup string
+	link   string
+	item   string
+	// nameScope is the column names of the owner's files are unique
+	// within: the folder of uploads, the owner itself for images
+	nameScope string
+}
+
+var tagTargets = map[string]tagTarget{
+	entities.TagKindPage: {
+		table:   "pages",
+		columns: pageColumns,
+		lookup:  "SELECT id FROM pages WHERE id = $1 and deleted = false",
+		link:    "page_tag",
+		item:    "page_id",
+	},
+	entities.TagKindUpload: {
+		table:     "uploads",
+		columns:   uploadListSpec.columns,
+		lookup:    "SELECT id FROM uploads WHERE uuid = $1 and user_id = $2 and deleted = false",
+		link:      "upload_tag",
+		item:      "upload_id",
+		nameScope: "folder_id",
+	},
+	entities.TagKindImage: {
+		table:     "images",
+		columns:   imageListSpec.columns,
+		lookup:    "SELECT id FROM images WHERE uuid = $1 and user_id = $2 and deleted = false",
+		link:      "image_tag",
+		item:      "image_id",
+		nameScope: "user_id",
+	},
+}
+
+type TagRepository struct {
+	db  *pgxpool.Pool
+	log zerolog.Logger
+}
+
+func NewTagRepository(db *pgxpool.Pool) *TagRepository {
+	return &TagRepository{
+		db:  db,
+		log: logger.Get().With().Str("postgres", "tag_repository").Logger(),
+	}
+}
+
+func (r *TagRepository) List(ctx context.Context, owner int64) (*entities.Tags, error) {
+	rows, err := r.db.Query(ctx, queryTagsSelect, owner)
+	if err != nil {
+		r.log.Debug().Err(err).Msg("List")
+		return nil, fmt.Errorf("failed to list tags: %w", err)
+	}
+
+	tags, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (entities.Tag, error) {
+		var tag entities.Tag
+		err := row.Scan(&tag.ID, &tag.UserID, &tag.Name, &tag.Count)
+		return tag, err
+	})
+	if err != nil {
+		r.log.Debug().Err(err).Msg("List")
+		return nil, fmt.Errorf("failed to scan tag row: %w", err)
+	}
+
+	result := entities.Tags(tags)
+	return &result, nil
+}
+
+func (r *TagRepository) ItemTags(ctx context.Context, item *entities.TaggedItem, owner int64) ([]string, error) {
+	target, ok := tagTargets[item.Kind]
+	if !ok {
+		return nil, appTag.ErrInvalidKind
+	}
+
+	itemID, err := r.lookup(ctx, r.db, target, item, owner, "")
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.Query(ctx, fmt.Sprintf(
+		"SELECT t.name FROM %s x JOIN tags t ON t.id = x.tag_id WHERE x.%s = $1 ORDER BY t.name",
+		target.link, target.item), itemID)
+	if err != nil {
+		r.log.Debug().Err(err).Msg("ItemTags")
+		return nil, fmt.Errorf("failed to list item tags: %w", err)
+	}
+
+	tags, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		r.log.Debug().Err(err).Msg("ItemTags")
+		return nil, fmt.Errorf("failed to scan item tags: %w", err)
+	}
+
+	return tags, nil
+}
+
+func (r *TagRepository) SetItemTags(ctx context.Context, item *entities.TaggedItem, owner int64, tags []string) error {
+	target, ok := tagTargets[item.Kind]
+	if !ok {
+		return appTag.ErrInvalidKind
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		r.log.Debug().Err(err).Msg("SetItemTags1")
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	itemID, err := r.lookup(ctx, tx, target, item, owner, " FOR UPDATE")
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(ctx, queryTagsInsert, owner, tags); err != nil {
+		r.log.Debug().Err(err).Msg("SetItemTags2")
+		return fmt.Errorf("failed to create tags: %w", err)
+	}
+	if _, err := tx.Exec(ctx, fmt.Sprintf("DELETE FROM %s WHERE %s = $1", target.link, target.item), itemID); err != nil {
+		r.log.Debug().Err(err).Msg("SetItemTags3")
+		return fmt.Errorf("failed to clear item tags: %w", err)
+	}
+	_, err = tx.Exec(ctx, fmt.Sprintf(
+		"INSERT INTO %s (%s, tag_id) SELECT $1, id FROM tags WHERE user_id = $2 and name = ANY($3)",
+		target.link, target.item), itemID, owner, tags)
+	if err != nil {
+		r.log.Debug().Err(err).Msg("SetItemTags4")
+		return fmt.Errorf("failed to tag item: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		r.log.Debug().Err(err).Msg("SetItemTags5")
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func (r *TagRepository) Merge(ctx context.Context, owner int64, from []string, to string) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		r.log.Debug().Err(err).Msg("Merge1")
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	rows, err := tx.Query(ctx, queryTagsLockNames, owner, from)
+	if err != nil {
+		r.log.Debug().Err(err).Msg("Merge2")
+		return fmt.Errorf("failed to find tags: %w", err)
+	}
+	sources, err := pgx.CollectRows(rows, pgx.RowTo[int64])
+	if err != nil {
+		r.log.Debug().Err(err).Msg("Merge2")
+		return fmt.Errorf("failed to find tags: %w", err)
+	}
+	if len(sources) == 0 {
+		return appTag.ErrTagNotFound
+	}
+
+	var targetID int64
+	if err := tx.QueryRow(ctx, queryTagUpsert, owner, to).Scan(&targetID); err != nil {
+		r.log.Debug().Err(err).Msg("Merge3")
+		return fmt.Errorf("failed to create tag: %w", err)
+	}
+
+	for _, target := range tagTargets {
+		_, err := tx.Exec(ctx, fmt.Sprintf(
+			"INSERT INTO %[1]s (%[2]s, tag_id) SELECT %[2]s, $1 FROM %[1]s WHERE tag_id = ANY($2) ON CONFLICT DO NOTHING",
+			target.link, target.item), targetID, sources)
+		if err != nil {
+			r.log.Debug().Err(err).Msg("Merge4")
+			return fmt.Errorf("failed to move tagged items: %w", err)
+		}
+	}
+
+	// Связи исходных тегов удаляются каскадом
+	if _, err := tx.Exec(ctx, queryTagsDelete, sources); err != nil {
+		r.log.Debug().Err(err).Msg("Merge5")
+		return fmt.Errorf("failed to delete tags: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		r.log.Debug().Err(err).Msg("Merge6")
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func (r *TagRepository) Facets(ctx context.Context, kind string, filter *query.Query) (*entities.Facets, error) {
+	target, ok := tagTargets[kind]
+	if !ok {
+		return nil, appTag.ErrInvalidKind
+	}
+
+	conditions, args, err := filter.Conditions(target.columns, 1)
+	if err != nil {
+		return nil, err
+	}
+	var where []string
+	if conditions != "" {
+		where = append(where, conditions)
+	}
+
+	facets := entities.Facets{}
+	facets.Tags, err = r.facet(ctx, fmt.Sprintf(
+		`SELECT t.name, count(*) FROM (SELECT id FROM %s %s) f
+			JOIN %s x ON x.%s = f.id JOIN tags t ON t.id = x.tag_id
+		GROUP BY t.name ORDER BY count(*) DESC, t.name LIMIT %d`,
+		target.table, whereClause(where), target.link, target.item, maxFacets), args)
+	if err != nil {
+		r.log.Debug().Err(err).Msg("Facets1")
+		return nil, err
+	}
+
+	where = append(where, "coalesce(category, '') <> ''")
+	facets.Categories, err = r.facet(ctx, fmt.Sprintf(
+		"SELECT category, count(*) FROM %s %s GROUP BY category ORDER BY count(*) DESC, category LIMIT %d",
+		target.table, whereClause(where), maxFacets), args)
+	if err != nil {
+		r.log.Debug().Err(err).Msg("Facets2")
+		return nil, err
+	}
+
+	return &facets, nil
+}
+
+func (r *TagRepository) facet(ctx context.Context, sql string, args []any) ([]entities.Facet, error) {
+	rows, err := r.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count facets: %w", err)
+	}
+
+	facets, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (entities.Facet, error) {
+		var facet entities.Facet
+		err := row.Scan(&facet.Value, &facet.Count)
+		return facet, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan facet row: %w", err)
+	}
+
+	return facets, nil
+}
+
+// lookup returns the ID of a live item visible to the owner.
+func (r *TagRepository) lookup(ctx context.Context, db queryer, target tagTarget, item *entities.TaggedItem, owner int64, lock string) (int64, error) {
+	args := []any{item.ID}
+	if item.Kind != entities.TagKindPage {
+		args = []any{item.UUID, owner}
+	}
+
+	var id int64
+	if err := db.QueryRow(ctx, target.lookup+lock, args...).Scan(&id); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, appTag.ErrItemNotFound
+		}
+		r.log.Debug().Err(err).Msg("lookup")
+		return 0, fmt.Errorf("failed to find tagged item: %w", err)
+	}
+
+	return id, nil
+}
+
+// tagsRow appends the tags column to the columns read by a scan function.
+type tagsRow struct {
+	row  pgx.Row
+	tags *[]string
+}
+
+func (t tagsRow) Scan(dest ...any) error {
+	return t.row.Scan(append(dest, t.tags)...)
+}
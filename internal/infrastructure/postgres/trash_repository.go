@@ -0,0 +1,214 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aube/auth/internal/application/dto"
+	appTrash "github.com/aube/auth/internal/application/trash"
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/aube/auth/internal/utils/logger"
+	"github.com/aube/auth/internal/utils/query"
+	"github.com/rs/zerolog"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	trashItemsSelect string = `SELECT 'page' AS type, id, '' AS uuid, name, title, 0::bigint AS user_id, 0::bigint AS size, deleted_at
+			FROM pages WHERE deleted = true
+		UNION ALL SELECT 'upload', id, uuid::text, name, '', user_id, coalesce(size, 0), deleted_at
+			FROM uploads WHERE deleted = true
+		UNION ALL SELECT 'image', id, uuid::text, name, '', user_id, coalesce(size, 0), deleted_at
+			FROM images WHERE deleted = true`
+	// Страницы общие для редакторов, файлы видит только владелец
+	trashItemsWhere string = "($1::varchar = '' or type = $1::varchar) and (type = 'page' or user_id = $2)"
+
+	trashItemsFields string = "type, id, uuid, name, title, user_id, size, deleted_at"
+
+	queryTrashExpired string = "SELECT " + trashItemsFields + " FROM (" + trashItemsSelect + ") t WHERE deleted_at < $1 ORDER BY deleted_at LIMIT $2"
+
+	// Восстановление не допускает двух живых записей с одним именем (у файлов загрузок — в одной папке)
+	queryTrashRestorePage string = `UPDATE pages SET deleted = false, deleted_at = NULL
+		WHERE id = $1 and deleted = true
+			and not exists (SELECT 1 FROM pages p WHERE p.name = pages.name and p.deleted = false)`
+	queryTrashRestoreUpload string = `UPDATE uploads SET deleted = false, deleted_at = NULL
+		WHERE uuid = $1 and user_id = $2 and deleted = true
+			and not exists (SELECT 1 FROM uploads u WHERE u.name = uploads.name and u.user_id = uploads.user_id
+				and coalesce(u.folder_id, 0) = coalesce(uploads.folder_id, 0) and u.deleted = false)`
+	queryTrashRestoreImage string = `UPDATE images SET deleted = false, deleted_at = NULL
+		WHERE uuid = $1 and user_id = $2 and deleted = true
+			and not exists (SELECT 1 FROM images i WHERE i.name = images.name and i.user_id = images.user_id and i.deleted = false)`
+
+	queryTrashExistsPage   string = "SELECT exists(SELECT 1 FROM pages WHERE id = $1 and deleted = true)"
+	queryTrashExistsUpload string = "SELECT exists(SELECT 1 FROM uploads WHERE uuid = $1 and user_id = $2 and deleted = true)"
+	queryTrashExistsImage  string = "SELECT exists(SELECT 1 FROM images WHERE uuid = $1 and user_id = $2 and deleted = true)"
+
+	// Привязки страницы к узлам и изображениям не имеют внешних ключей
+	queryTrashPurgePage string = `WITH p AS (DELETE FROM pages WHERE id = $1 and deleted = true RETURNING id),
+			np AS (DELETE FROM node_page WHERE page_id IN (SELECT id FROM p)),
+			pi AS (DELETE FROM page_image WHERE page_id IN (SELECT id FROM p))
+		SELECT count(*) FROM p`
+	// Версии удаляются каскадом, их файлы отдаются сервису
+	queryTrashPurgeUpload string = `WITH f AS (DELETE FROM uploads WHERE uuid = $1 and ($2::bigint = 0 or user_id = $2) and deleted = true RETURNING id)
+		SELECT (SELECT count(*) FROM f), array(SELECT v.uuid::text FROM upload_versions v WHERE v.upload_id IN (SELECT id FROM f))`
+	queryTrashPurgeImage string = `WITH f AS (DELETE FROM images WHERE uuid = $1 and ($2::bigint = 0 or user_id = $2) and deleted = true RETURNING id)
+		SELECT (SELECT count(*) FROM f), array(SELECT v.uuid::text FROM image_versions v WHERE v.image_id IN (SELECT id FROM f))`
+)
+
+// id уникален только внутри типа
+var trashListSpec = listSpec{
+	fields:     trashItemsFields,
+	from:       "(" + trashItemsSelect + ") t",
+	where:      trashItemsWhere,
+	columns:    query.Columns{"deleted_at": "deleted_at"},
+	tieBreaker: "type || ':' || id",
+}
+
+type TrashRepository struct {
+	db  *pgxpool.Pool
+	log zerolog.Logger
+}
+
+func NewTrashRepository(db *pgxpool.Pool) *TrashRepository {
+	return &TrashRepository{
+		db:  db,
+		log: logger.Get().With().Str("postgres", "trash_repository").Logger(),
+	}
+}
+
+func (r *TrashRepository) List(ctx context.Context, itemType string, userID int64, offset, limit int, filter *query.Query) (*entities.TrashItems, *dto.Pagination, error) {
+	spec := trashListSpec
+	spec.args = []any{itemType, userID}
+
+	// Недавно удалённые первыми
+	filter = filter.Clone()
+	filter.Sort = []query.Sort{{Field: "deleted_at", Desc: true}}
+
+	items, pagination, err := listPage(ctx, r.db, spec, offset, limit, filter, scanTrashItem)
+	if err != nil {
+		r.log.Debug().Err(err).Msg("List")
+		return nil, nil, fmt.Errorf("failed to list trash: %w", err)
+	}
+
+	result := entities.TrashItems(items)
+	return &result, pagination, nil
+}
+
+func (r *TrashRepository) ListExpired(ctx context.Context, before time.Time, limit int) (*entities.TrashItems, error) {
+	rows, err := r.db.Query(ctx, queryTrashExpired, before, limit)
+	if err != nil {
+		r.log.Debug().Err(err).Msg("ListExpired")
+		return nil, fmt.Errorf("failed to list expired trash: %w", err)
+	}
+
+	return scanTrashItems(rows)
+}
+
+func (r *TrashRepository) Restore(ctx context.Context, item *entities.TrashItem, userID int64) error {
+	var (
+		restore, exists string
+		args            []any
+	)
+	switch item.Type {
+	case entities.TrashTypePage:
+		restore, exists, args = queryTrashRestorePage, queryTrashExistsPage, []any{item.ID}
+	case entities.TrashTypeUpload:
+		restore, exists, args = queryTrashRestoreUpload, queryTrashExistsUpload, []any{item.UUID, userID}
+	case entities.TrashTypeImage:
+		restore, exists, args = queryTrashRestoreImage, queryTrashExistsImage, []any{item.UUID, userID}
+	default:
+		return appTrash.ErrInvalidType
+	}
+
+	tag, err := r.db.Exec(ctx, restore, args...)
+	if err != nil {
+		r.log.Debug().Err(err).Msg("Restore1")
+		return fmt.Errorf("failed to restore %s: %w", item.Type, err)
+	}
+	if tag.RowsAffected() > 0 {
+		return nil
+	}
+
+	// Запись не восстановлена: её нет в корзине или имя уже занято
+	var found bool
+	if err := r.db.QueryRow(ctx, exists, args...).Scan(&found); err != nil {
+		r.log.Debug().Err(err).Msg("Restore2")
+		return fmt.Errorf("failed to restore %s: %w", item.Type, err)
+	}
+	if found {
+		return appTrash.ErrNameTaken
+	}
+
+	return appTrash.ErrItemNotFound
+}
+
+func (r *TrashRepository) Purge(ctx context.Context, item *entities.TrashItem, userID int64) ([]string, error) {
+	var (
+		purged   int64
+		versions []string
+	)
+	switch item.Type {
+	case entities.TrashTypePage:
+		if err := r.db.QueryRow(ctx, queryTrashPurgePage, item.ID).Scan(&purged); err != nil {
+			r.log.Debug().Err(err).Msg("Purge")
+			return nil, fmt.Errorf("failed to purge page: %w", err)
+		}
+	case entities.TrashTypeUpload, entities.TrashTypeImage:
+		query := queryTrashPurgeUpload
+		if item.Type == entities.TrashTypeImage {
+			query = queryTrashPurgeImage
+		}
+		if err := r.db.QueryRow(ctx, query, item.UUID, userID).Scan(&purged, &versions); err != nil {
+			r.log.Debug().Err(err).Msg("Purge")
+			return nil, fmt.Errorf("failed to purge %s: %w", item.Type, err)
+		}
+	default:
+		return nil, appTrash.ErrInvalidType
+	}
+
+	if purged == 0 {
+		return nil, appTrash.ErrItemNotFound
+	}
+
+	return versions, nil
+}
+
+func scanTrashItem(row pgx.Row) (*entities.TrashItem, error) {
+	var item entities.TrashItem
+	err := row.Scan(
+		&item.Type,
+		&item.ID,
+		&item.UUID,
+		&item.Name,
+		&item.Title,
+		&item.UserID,
+		&item.Size,
+		&item.DeletedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+func scanTrashItems(rows pgx.Rows) (*entities.TrashItems, error) {
+	defer rows.Close()
+
+	items := entities.TrashItems{}
+	for rows.Next() {
+		item, err := scanTrashItem(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan trash row: %w", err)
+		}
+		items = append(items, *item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error after iterating trash rows: %w", err)
+	}
+
+	return &items, nil
+}
@@ -5,31 +5,67 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"math"
-	"strings"
 	"time"
 
 	"github.com/aube/auth/internal/application/dto"
+	appFolder "github.com/aube/auth/internal/application/folder"
 	appUpload "github.com/aube/auth/internal/application/upload"
 	"github.com/aube/auth/internal/domain/entities"
 	"github.com/aube/auth/internal/utils/logger"
-	"github.com/aube/auth/internal/utils/sql"
+	"github.com/aube/auth/internal/utils/query"
 	"github.com/rs/zerolog"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 const (
-	queryUploadInsert              string = "INSERT INTO uploads (user_id, uuid, size, name, category, content_type, description) VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id"
-	queryUploadSelectByUserID      string = "SELECT id, user_id, uuid, size, name, category, content_type, description, created_at FROM uploads %WHERE% OFFSET $1 LIMIT $2"
-	queryUploadSelectByUserIDTotal string = "SELECT count(*) total FROM uploads %WHERE%"
-	queryUploadGetByUUID           string = "SELECT id, user_id, size, name, category, content_type, description, created_at FROM uploads WHERE uuid = $1 and user_id=$2 and deleted=false"
-	queryUploadGetByName           string = "SELECT id, user_id, uuid, size, category, content_type, description, created_at FROM uploads WHERE name = $1 and user_id=$2 and deleted=false"
-	queryUploadDelete              string = "UPDATE uploads SET deleted=true WHERE uuid = $1 and user_id=$2"
-	queryUploadDeleteForce         string = "DELETE uploads WHERE uuid = $1 and user_id=$2"
+	// Папка должна принадлежать владельцу файла, 0 — корень
+	queryUploadInsert string = `INSERT INTO uploads (user_id, uuid, size, name, category, content_type, description, folder_id, declared_content_type, scan_status)
+		SELECT $1, $2, $3, $4, $5, $6, $7, nullif($8::integer, 0), $9, $10
+		WHERE $8 = 0 or exists (SELECT 1 FROM upload_folders WHERE id = $8 and user_id = $1)
+		RETURNING id`
+	queryUploadGetByUUID   string = "SELECT id, user_id, coalesce(folder_id, 0), size, name, category, content_type, version, description, created_at, scan_status, scan_detail, declared_content_type FROM uploads WHERE uuid = $1 and user_id=$2 and deleted=false"
+	queryUploadGetByName   string = "SELECT id, user_id, uuid, size, category, content_type, version, description, created_at, scan_status, scan_detail, declared_content_type FROM uploads WHERE name = $1 and coalesce(folder_id, 0) = $3 and user_id=$2 and deleted=false"
+	queryUploadNamesPrefix string = "SELECT name FROM uploads WHERE user_id = $1 and coalesce(folder_id, 0) = $2 and starts_with(name, $3) and deleted = false"
+	queryUploadMove        string = `UPDATE uploads SET folder_id = nullif($3::integer, 0), name = $4
+		WHERE uuid = $1 and user_id = $2 and deleted = false
+			and ($3 = 0 or exists (SELECT 1 FROM upload_folders WHERE id = $3 and user_id = $2))`
+	queryUploadExists      string = "SELECT exists(SELECT 1 FROM uploads WHERE uuid = $1 and user_id = $2 and deleted = false)"
+	queryUploadDelete      string = "UPDATE uploads SET deleted=true, deleted_at=now() WHERE uuid = $1 and user_id=$2 and deleted=false"
+	queryUploadDeleteForce string = "DELETE FROM uploads WHERE uuid = $1 and user_id=$2"
 )
 
+// fileColumns lists the columns list filters of uploads and images may use:
+// client fields (appUpload.ListSchema) and the ones set by the application.
+var fileColumns = query.Columns{
+	"id":           "id",
+	"name":         "name",
+	"category":     "category",
+	"content_type": "content_type",
+	"size":         "size",
+	"description":  "description",
+	"created_at":   "created_at",
+	"updated_at":   "updated_at",
+	"deleted":      "deleted",
+	"user_id":      "user_id",
+	"uuid":         "uuid",
+}
+
+// uploadColumns adds the upload-only columns to fileColumns.
+var uploadColumns = fileColumns.
+	With("folder_id", "coalesce(folder_id, 0)").
+	With("scan_status", "scan_status").
+	With("tags", uploadTagsColumn)
+
+var uploadListSpec = listSpec{
+	fields:     "id, user_id, coalesce(folder_id, 0), uuid, size, name, category, content_type, version, description, created_at, scan_status, scan_detail, declared_content_type, " + uploadTagsColumn,
+	from:       "uploads",
+	columns:    uploadColumns,
+	tieBreaker: "id",
+}
+
 // UploadRepository provides PostgreSQL storage for upload metadata.
 // Features:
 //   - Soft deletion support (deleted flag)
@@ -57,9 +93,12 @@ type UploadRepository struct {
 //   - GetByUUID: Retrieves by UUID with owner check
 //     Returns ErrFileNotFound for missing records
 //
-//   - GetByName: Retrieves by filename with owner check
+//   - GetByName: Retrieves by filename in a folder with owner check
 //     Returns ErrFileNotFound for missing records
 //
+//   - Move: Moves to a folder of the owner under a name
+//     Returns ErrNameTaken for a name used in the folder
+//
 //   - Delete: Soft-deletes record (sets deleted flag)
 //
 //   - DeleteForce: Permanent deletion (admin operation)
@@ -92,118 +131,61 @@ func (r *UploadRepository) Create(
 		upload.Category,
 		upload.ContentType,
 		upload.Description,
+		upload.FolderID,
+		upload.DeclaredType,
+		upload.ScanStatus,
 	).Scan(&id)
 
 	if err != nil {
 		r.log.Debug().Err(err).Msg("Create2")
+		var pgErr *pgconn.PgError
+		switch {
+		case errors.Is(err, pgx.ErrNoRows):
+			return appFolder.ErrFolderNotFound
+		case errors.As(err, &pgErr) && pgErr.Code == "23505":
+			return appUpload.ErrNameTaken
+		}
 		return fmt.Errorf("failed to create upload: %w", err)
 	}
 
+	upload.ID = id
 	return nil
 }
 
 // List returns all URL mappings for the current user from the database.
 // Returns an unauthorized error if no user ID is present in context.
-func (r *UploadRepository) ListByUserID(ctx context.Context, userID int64, offset, limit int, params map[string]any) (*entities.Uploads, *dto.Pagination, error) {
-
-	whereClause, whereParams := sql.BuildWhere(params, "AND", 3)
-	allParams := []any{offset, limit}
-	allParams = append(allParams, whereParams...)
-
-	query := strings.Replace(queryUploadSelectByUserID, "%WHERE%", "WHERE "+whereClause, 1)
-
-	rows, err := r.db.Query(ctx, query, allParams...)
-	if err != nil {
-		r.log.Debug().Err(err).Msg("ListByUserID1")
-		return nil, nil, err
-	}
-	defer rows.Close()
-
-	var uploads entities.Uploads
-
-	for rows.Next() {
-		var (
-			id          int64
-			userId      int64
-			uuid        string
-			size        int64
-			name        string
-			category    string
-			contentType string
-			description string
-			createdAt   time.Time
-		)
-
-		err := rows.Scan(
-			&id,
-			&userId,
-			&uuid,
-			&size,
-			&name,
-			&category,
-			&contentType,
-			&description,
-			&createdAt,
-		)
-		if err != nil {
-			r.log.Error().Err(err).Msg("Failed to scan upload row")
-			return nil, nil, fmt.Errorf("failed to scan upload row: %w", err)
-		}
-
-		file := entities.NewFile(uuid, "", size)
-		upload := entities.NewUpload(
-			file,
-			id,
-			userId,
-			name,
-			category,
-			contentType,
-			description,
-			createdAt,
-		)
-		uploads = append(uploads, *upload)
-	}
-
-	if err = rows.Err(); err != nil {
-		r.log.Debug().Err(err).Msg("ListByUserID2")
-		return nil, nil, fmt.Errorf("error after iterating upload rows: %w", err)
-	}
-
-	// Totals
-	whereClause, whereParams = sql.BuildWhere(params, "AND", 1)
-	query = strings.Replace(queryUploadSelectByUserIDTotal, "%WHERE%", "WHERE "+whereClause, 1)
-
-	var total int
-	err = r.db.QueryRow(ctx, query, whereParams...).Scan(&total)
+func (r *UploadRepository) ListByUserID(ctx context.Context, userID int64, offset, limit int, filter *query.Query) (*entities.Uploads, *dto.Pagination, error) {
 
+	// Пользователь видит только свои файлы
+	filter = filter.Clone().Add("user_id", query.OpEq, userID)
+	uploads, pagination, err := listPage(ctx, r.db, uploadListSpec, offset, limit, filter, scanUpload)
 	if err != nil {
-		r.log.Debug().Err(err).Msg("GetTotals")
-		return nil, nil, fmt.Errorf("failed to get totals: %w", err)
+		r.log.Debug().Err(err).Msg("ListByUserID")
+		return nil, nil, fmt.Errorf("failed to list uploads: %w", err)
 	}
 
-	page := float64(offset) / float64(limit)
-	pagination := dto.Pagination{
-		Total: total,
-		Page:  int(math.Round(page)) + 1,
-		Size:  limit,
-	}
-
-	return &uploads, &pagination, nil
+	result := entities.Uploads(uploads)
+	return &result, pagination, nil
 }
 
 func (r *UploadRepository) GetByUUID(ctx context.Context, uuid string, userID int64) (*entities.Upload, error) {
 	var (
 		id          int64
 		user_id     int64
+		folderID    int64
 		name        string
 		category    string
 		size        int64
 		contentType string
+		version     int
 		description string
 		createdAt   time.Time
+		scanStatus  string
+		scanDetail  string
+		declared    string
 	)
 
-	err := r.db.QueryRow(ctx, queryUploadGetByUUID, uuid, userID).Scan(&id, &user_id, &size, &name, &category, &contentType, &description, &createdAt)
+	err := r.db.QueryRow(ctx, queryUploadGetByUUID, uuid, userID).Scan(&id, &user_id, &folderID, &size, &name, &category, &contentType, &version, &description, &createdAt, &scanStatus, &scanDetail, &declared)
 
 	if err != nil {
 		r.log.Debug().Err(err).Msg("GetByUUID")
@@ -215,7 +197,7 @@ func (r *UploadRepository) GetByUUID(ctx context.Context, uuid string, userID in
 
 	file := entities.NewFile(uuid, "", size)
 
-	return entities.NewUpload(
+	upload := entities.NewUpload(
 		file,
 		id,
 		user_id,
@@ -224,10 +206,16 @@ func (r *UploadRepository) GetByUUID(ctx context.Context, uuid string, userID in
 		contentType,
 		description,
 		createdAt,
-	), nil
+	)
+	upload.FolderID = folderID
+	upload.Version = version
+	upload.ScanStatus = scanStatus
+	upload.ScanDetail = scanDetail
+	upload.DeclaredType = declared
+	return upload, nil
 }
 
-func (r *UploadRepository) GetByName(ctx context.Context, name string, userID int64) (*entities.Upload, error) {
+func (r *UploadRepository) GetByName(ctx context.Context, name string, folderID int64, userID int64) (*entities.Upload, error) {
 	var (
 		id          int64
 		user_id     int64
@@ -235,11 +223,15 @@ func (r *UploadRepository) GetByName(ctx context.Context, name string, userID in
 		size        int64
 		category    string
 		contentType string
+		version     int
 		description string
 		createdAt   time.Time
+		scanStatus  string
+		scanDetail  string
+		declared    string
 	)
 
-	err := r.db.QueryRow(ctx, queryUploadGetByName, name, userID).Scan(&id, &user_id, &uuid, &size, &category, &contentType, &description, &createdAt)
+	err := r.db.QueryRow(ctx, queryUploadGetByName, name, userID, folderID).Scan(&id, &user_id, &uuid, &size, &category, &contentType, &version, &description, &createdAt, &scanStatus, &scanDetail, &declared)
 
 	if err != nil {
 		r.log.Debug().Err(err).Msg("GetByName")
@@ -251,7 +243,7 @@ func (r *UploadRepository) GetByName(ctx context.Context, name string, userID in
 
 	file := entities.NewFile(uuid, "", size)
 
-	return entities.NewUpload(
+	upload := entities.NewUpload(
 		file,
 		id,
 		user_id,
@@ -260,33 +252,132 @@ func (r *UploadRepository) GetByName(ctx context.Context, name string, userID in
 		contentType,
 		description,
 		createdAt,
-	), nil
+	)
+	upload.FolderID = folderID
+	upload.Version = version
+	upload.ScanStatus = scanStatus
+	upload.ScanDetail = scanDetail
+	upload.DeclaredType = declared
+	return upload, nil
 }
 
-func (r *UploadRepository) Delete(ctx context.Context, uuid string, userID int64) error {
-	_, err := r.db.Query(ctx, queryUploadDelete, uuid, userID)
+func (r *UploadRepository) NamesWithPrefix(ctx context.Context, userID int64, folderID int64, prefix string) ([]string, error) {
+	names, err := collectKeys[string](ctx, r.db, queryUploadNamesPrefix, userID, folderID, prefix)
+	if err != nil {
+		r.log.Debug().Err(err).Msg("NamesWithPrefix")
+		return nil, fmt.Errorf("failed to list upload names: %w", err)
+	}
+	return names, nil
+}
 
+func (r *UploadRepository) Move(ctx context.Context, uuid string, userID int64, folderID int64, name string) error {
+	tag, err := r.db.Exec(ctx, queryUploadMove, uuid, userID, folderID, name)
 	if err != nil {
-		r.log.Debug().Err(err).Msg("Delete")
-		if errors.Is(err, pgx.ErrNoRows) {
-			return appUpload.ErrFileNotFound
+		r.log.Debug().Err(err).Msg("Move1")
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return appUpload.ErrNameTaken
 		}
+		return fmt.Errorf("failed to move upload: %w", err)
+	}
+	if tag.RowsAffected() > 0 {
+		return nil
+	}
+
+	// Не перемещён: нет файла или нет папки
+	var found bool
+	if err := r.db.QueryRow(ctx, queryUploadExists, uuid, userID).Scan(&found); err != nil {
+		r.log.Debug().Err(err).Msg("Move2")
+		return fmt.Errorf("failed to move upload: %w", err)
+	}
+	if found {
+		return appFolder.ErrFolderNotFound
+	}
+	return appUpload.ErrFileNotFound
+}
+
+func (r *UploadRepository) Delete(ctx context.Context, uuid string, userID int64) error {
+	tag, err := r.db.Exec(ctx, queryUploadDelete, uuid, userID)
+	if err != nil {
+		r.log.Debug().Err(err).Msg("Delete")
 		return fmt.Errorf("failed to delete upload: %w", err)
 	}
+	if tag.RowsAffected() == 0 {
+		return appUpload.ErrFileNotFound
+	}
 
 	return nil
 }
 
 func (r *UploadRepository) DeleteForce(ctx context.Context, uuid string, userID int64) error {
-	_, err := r.db.Query(ctx, queryUploadDeleteForce, uuid, userID)
-
+	tag, err := r.db.Exec(ctx, queryUploadDeleteForce, uuid, userID)
 	if err != nil {
-		r.log.Debug().Err(err).Msg("Delete")
-		if errors.Is(err, pgx.ErrNoRows) {
-			return appUpload.ErrFileNotFound
-		}
+		r.log.Debug().Err(err).Msg("DeleteForce")
 		return fmt.Errorf("failed to delete upload: %w", err)
 	}
+	if tag.RowsAffected() == 0 {
+		return appUpload.ErrFileNotFound
+	}
 
 	return nil
 }
+
+func scanUpload(row pgx.Row) (*entities.Upload, error) {
+	var (
+		id          int64
+		userId      int64
+		folderID    int64
+		uuid        string
+		size        int64
+		name        string
+		category    string
+		contentType string
+		version     int
+		description string
+		createdAt   time.Time
+		scanStatus  string
+		scanDetail  string
+		declared    string
+		tags        []string
+	)
+
+	err := row.Scan(
+		&id,
+		&userId,
+		&folderID,
+		&uuid,
+		&size,
+		&name,
+		&category,
+		&contentType,
+		&version,
+		&description,
+		&createdAt,
+		&scanStatus,
+		&scanDetail,
+		&declared,
+		&tags,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	file := entities.NewFile(uuid, "", size)
+	upload := entities.NewUpload(
+		file,
+		id,
+		userId,
+		name,
+		category,
+		contentType,
+		description,
+		createdAt,
+	)
+	upload.FolderID = folderID
+	upload.Version = version
+	upload.ScanStatus = scanStatus
+	upload.ScanDetail = scanDetail
+	upload.DeclaredType = declared
+	upload.Tags = tags
+	return upload, nil
+}
@@ -0,0 +1,253 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	appUpload "github.com/aube/auth/internal/application/upload"
+	appVersion "github.com/aube/auth/internal/application/version"
+	"github.com/aube/auth/internal/domain/entities"
+	"github.com/aube/auth/internal/utils/logger"
+	"github.com/rs/zerolog"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// %[1]s — таблица файлов, %[2]s — таблица версий, %[3]s — ссылка версии на файл,
+// %[4]s — колонки, которые переходят вместе с содержимым, %[5]s — их сброс у нового содержимого,
+// %[6]s — статус проверки
+const (
+	queryVersionLockFile string = "SELECT version FROM %[1]s WHERE id = $1 and user_id = $2 and deleted = false FOR UPDATE"
+	// После возврата к старой версии текущий номер не самый большой
+	queryVersionNext    string = "SELECT greatest($2::integer, coalesce(max(version), 0)) + 1 FROM %[2]s WHERE %[3]s = $1"
+	queryVersionArchive string = `INSERT INTO %[2]s (%[3]s, version, uuid, size, content_type, created_at%[4]s)
+		SELECT id, version, uuid, size, content_type, coalesce(version_at, created_at)%[4]s FROM %[1]s WHERE id = $1`
+	queryVersionSetCurrent string = `UPDATE %[1]s SET uuid = $2, size = $3, content_type = $4, version = $5, declared_content_type = $6, version_at = now()%[5]s
+		WHERE id = $1`
+	queryVersionPromote string = `WITH v AS (DELETE FROM %[2]s WHERE %[3]s = $1 and version = $2 RETURNING *)
+		UPDATE %[1]s f SET (uuid, size, content_type, version, version_at%[4]s) =
+			(SELECT uuid, size, content_type, version, created_at%[4]s FROM v)
+		WHERE f.id = $1 and exists (SELECT 1 FROM v)`
+	queryVersionList string = `SELECT version, uuid::text, size, content_type, %[6]s, true, coalesce(version_at, created_at)
+			FROM %[1]s WHERE id = $1
+		UNION ALL
+		SELECT version, uuid::text, size, content_type, %[6]s, false, created_at
+			FROM %[2]s WHERE %[3]s = $1
+		ORDER BY 1 DESC`
+	queryVersionPrune string = `DELETE FROM %[2]s WHERE %[3]s = $1
+		and (
+			($2::integer > 0 and id NOT IN (SELECT id FROM %[2]s WHERE %[3]s = $1 ORDER BY version DESC LIMIT $2))
+			or ($3::timestamp IS NOT NULL and created_at < $3::timestamp)
+		)
+		RETURNING uuid::text`
+)
+
+// versionTarget holds tables of one kind of versioned files.
+// content lists the columns describing the content besides the stored
+// file, reset clears them for new content, status is the scan status.
+// For scanned files reset takes the scan status of new content as $7.
+type versionTarget struct {
+	table    string
+	versions string
+	item     string
+	content  string
+	reset    string
+	status   string
+	scanned  bool
+}
+
+var versionTargets = map[string]versionTarget{
+	entities.VersionKindUpload: {
+		table:    "uploads",
+		versions: "upload_versions",
+		item:     "upload_id",
+		content:  ", scan_status, scan_detail, declared_content_type",
+		reset:    ", scan_status = $7, scan_detail = ''",
+		status:   "scan_status",
+		scanned:  true,
+	},
+	entities.VersionKindImage: {
+		table:    "images",
+		versions: "image_versions",
+		item:     "image_id",
+		content:  ", declared_content_type",
+		status:   "''",
+	},
+}
+
+func (t versionTarget) query(q string) string {
+	return fmt.Sprintf(q, t.table, t.versions, t.item, t.content, t.reset, t.status)
+}
+
+type VersionRepository struct {
+	db  *pgxpool.Pool
+	log zerolog.Logger
+}
+
+func NewVersionRepository(db *pgxpool.Pool) *VersionRepository {
+	return &VersionRepository{
+		db:  db,
+		log: logger.Get().With().Str("postgres", "version_repository").Logger(),
+	}
+}
+
+func (r *VersionRepository) AddVersion(ctx context.Context, kind string, fileID int64, userID int64, file *entities.File, contentType string) (int, error) {
+	target, ok := versionTargets[kind]
+	if !ok {
+		return 0, appVersion.ErrInvalidKind
+	}
+
+	var next int
+	err := r.inVersionTx(ctx, func(tx pgx.Tx) error {
+		current, err := r.lockFile(ctx, tx, target, fileID, userID)
+		if err != nil {
+			return err
+		}
+
+		if err := tx.QueryRow(ctx, target.query(queryVersionNext), fileID, current).Scan(&next); err != nil {
+			r.log.Debug().Err(err).Msg("AddVersion1")
+			return fmt.Errorf("failed to number version: %w", err)
+		}
+		if err := r.archive(ctx, tx, target, fileID); err != nil {
+			return err
+		}
+
+		args := []any{fileID, file.Name, file.Size, contentType, next, file.DeclaredType}
+		if target.scanned {
+			args = append(args, file.ScanStatus)
+		}
+		_, err = tx.Exec(ctx, target.query(queryVersionSetCurrent), args...)
+		if err != nil {
+			r.log.Debug().Err(err).Msg("AddVersion2")
+			return fmt.Errorf("failed to set current version: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return next, nil
+}
+
+func (r *VersionRepository) List(ctx context.Context, kind string, fileID int64) (*entities.FileVersions, error) {
+	target, ok := versionTargets[kind]
+	if !ok {
+		return nil, appVersion.ErrInvalidKind
+	}
+
+	rows, err := r.db.Query(ctx, target.query(queryVersionList), fileID)
+	if err != nil {
+		r.log.Debug().Err(err).Msg("List")
+		return nil, fmt.Errorf("failed to list versions: %w", err)
+	}
+
+	versions, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (entities.FileVersion, error) {
+		v := entities.FileVersion{FileID: fileID}
+		err := row.Scan(&v.Version, &v.UUID, &v.Size, &v.ContentType, &v.ScanStatus, &v.Current, &v.CreatedAt)
+		return v, err
+	})
+	if err != nil {
+		r.log.Debug().Err(err).Msg("List")
+		return nil, fmt.Errorf("failed to list versions: %w", err)
+	}
+
+	result := entities.FileVersions(versions)
+	return &result, nil
+}
+
+func (r *VersionRepository) Promote(ctx context.Context, kind string, fileID int64, userID int64, version int) error {
+	target, ok := versionTargets[kind]
+	if !ok {
+		return appVersion.ErrInvalidKind
+	}
+
+	return r.inVersionTx(ctx, func(tx pgx.Tx) error {
+		current, err := r.lockFile(ctx, tx, target, fileID, userID)
+		if err != nil {
+			return err
+		}
+		if current == version {
+			return nil
+		}
+
+		if err := r.archive(ctx, tx, target, fileID); err != nil {
+			return err
+		}
+
+		tag, err := tx.Exec(ctx, target.query(queryVersionPromote), fileID, version)
+		if err != nil {
+			r.log.Debug().Err(err).Msg("Promote")
+			return fmt.Errorf("failed to set current version: %w", err)
+		}
+		if tag.RowsAffected() == 0 {
+			return appVersion.ErrVersionNotFound
+		}
+		return nil
+	})
+}
+
+func (r *VersionRepository) Prune(ctx context.Context, kind string, fileID int64, keepLast int, olderThan time.Time) ([]string, error) {
+	target, ok := versionTargets[kind]
+	if !ok {
+		return nil, appVersion.ErrInvalidKind
+	}
+
+	var before any
+	if !olderThan.IsZero() {
+		before = olderThan
+	}
+
+	pruned, err := collectKeys[string](ctx, r.db, target.query(queryVersionPrune), fileID, keepLast, before)
+	if err != nil {
+		r.log.Debug().Err(err).Msg("Prune")
+		return nil, fmt.Errorf("failed to prune versions: %w", err)
+	}
+
+	return pruned, nil
+}
+
+// lockFile returns the current version number of the owner's file and locks the file.
+func (r *VersionRepository) lockFile(ctx context.Context, tx pgx.Tx, target versionTarget, fileID int64, userID int64) (int, error) {
+	var current int
+	err := tx.QueryRow(ctx, target.query(queryVersionLockFile), fileID, userID).Scan(&current)
+	if err != nil {
+		r.log.Debug().Err(err).Msg("lockFile")
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, appUpload.ErrFileNotFound
+		}
+		return 0, fmt.Errorf("failed to find file: %w", err)
+	}
+	return current, nil
+}
+
+// archive keeps the current content of the file as an older version.
+func (r *VersionRepository) archive(ctx context.Context, tx pgx.Tx, target versionTarget, fileID int64) error {
+	if _, err := tx.Exec(ctx, target.query(queryVersionArchive), fileID); err != nil {
+		r.log.Debug().Err(err).Msg("archive")
+		return fmt.Errorf("failed to keep version: %w", err)
+	}
+	return nil
+}
+
+func (r *VersionRepository) inVersionTx(ctx context.Context, fn func(tx pgx.Tx) error) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		r.log.Debug().Err(err).Msg("inVersionTx1")
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		r.log.Debug().Err(err).Msg("inVersionTx2")
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,236 @@
+// Package views loads the html/template theme used to render public pages.
+//
+// Theme directory layout:
+//
+//	layouts/*.html   page skeletons, executed as the root template
+//	partials/*.html  shared fragments, available as {{template "partials/<name>" .}}
+//	pages/*.html     page templates selected by entities.Page.Template
+//	errors/*.html    error pages (404.html and 500.html are required)
+//
+// A page or error template fills blocks of its layout with {{define}} and
+// selects the layout with a leading {{/* extends "name" */}} comment;
+// without it the "base" layout is used.
+package views
+
+import (
+	"errors"
+	"fmt"
+	"html/template"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/aube/auth/internal/domain/entities"
+)
+
+const (
+	DefaultTemplate = "default"
+	defaultLayout   = "base"
+)
+
+var (
+	ErrTemplateNotFound = errors.New("template not found")
+
+	extendsRegex = regexp.MustCompile(`^\s*\{\{/\*\s*extends\s+"([\w-]+)"\s*\*/\}\}`)
+)
+
+// PageData is passed to page and error templates.
+// Lang: Locale of the content, empty for the default one
+// Alternates: Locale variants of the page for hreflang links
+// XDefault: URL of the variant in the default locale (hreflang="x-default")
+// ContentType/Fields: Type and field values of typed pages
+type PageData struct {
+	Title        string
+	Meta         entities.PageMeta
+	Canonical    string
+	H1           string
+	Content      template.HTML
+	ContentShort string
+	Path         string
+	Status       int
+	Lang         string
+	Alternates   []Alternate
+	XDefault     string
+	ContentType  string
+	Fields       entities.PageFields
+}
+
+// Alternate is an absolute URL of a locale variant of the page.
+type Alternate struct {
+	Lang string
+	URL  string
+}
+
+// Views holds parsed templates.
+// With reload enabled templates are re-read from disk on every render,
+// which is meant for development.
+type Views struct {
+	dir    string
+	reload bool
+
+	mu     sync.RWMutex
+	pages  map[string]*template.Template
+	errors map[string]*template.Template
+}
+
+// New loads and validates the theme in dir.
+// Returns an error when a template fails to parse, references a missing
+// layout or partial, or a required template (pages/default, errors/404,
+// errors/500) is missing.
+func New(dir string, reload bool) (*Views, error) {
+	v := &Views{dir: dir, reload: reload}
+	if err := v.load(); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// RenderPage executes the page template name, falling back to the default one
+// when it does not exist.
+func (v *Views) RenderPage(w io.Writer, name string, data PageData) error {
+	if err := v.reloadIfNeeded(); err != nil {
+		return err
+	}
+
+	v.mu.RLock()
+	tpl, ok := v.pages[name]
+	if !ok {
+		tpl = v.pages[DefaultTemplate]
+	}
+	v.mu.RUnlock()
+
+	return tpl.ExecuteTemplate(w, "layout", data)
+}
+
+// RenderError executes errors/<status>.html, falling back to errors/500.html.
+func (v *Views) RenderError(w io.Writer, status int, data PageData) error {
+	if err := v.reloadIfNeeded(); err != nil {
+		return err
+	}
+
+	v.mu.RLock()
+	tpl, ok := v.errors[fmt.Sprint(status)]
+	if !ok {
+		tpl = v.errors["500"]
+	}
+	v.mu.RUnlock()
+
+	data.Status = status
+	return tpl.ExecuteTemplate(w, "layout", data)
+}
+
+func (v *Views) reloadIfNeeded() error {
+	if !v.reload {
+		return nil
+	}
+	return v.load()
+}
+
+func (v *Views) load() error {
+	layouts, err := readDir(filepath.Join(v.dir, "layouts"))
+	if err != nil {
+		return err
+	}
+	partials, err := readDir(filepath.Join(v.dir, "partials"))
+	if err != nil {
+		return err
+	}
+	pageFiles, err := readDir(filepath.Join(v.dir, "pages"))
+	if err != nil {
+		return err
+	}
+	errorFiles, err := readDir(filepath.Join(v.dir, "errors"))
+	if err != nil {
+		return err
+	}
+
+	pages, err := build(pageFiles, layouts, partials, "pages")
+	if err != nil {
+		return err
+	}
+	errorPages, err := build(errorFiles, layouts, partials, "errors")
+	if err != nil {
+		return err
+	}
+
+	if _, ok := pages[DefaultTemplate]; !ok {
+		return fmt.Errorf("pages/%s.html: %w", DefaultTemplate, ErrTemplateNotFound)
+	}
+	for _, name := range []string{"404", "500"} {
+		if _, ok := errorPages[name]; !ok {
+			return fmt.Errorf("errors/%s.html: %w", name, ErrTemplateNotFound)
+		}
+	}
+
+	v.mu.Lock()
+	v.pages = pages
+	v.errors = errorPages
+	v.mu.Unlock()
+	return nil
+}
+
+// build parses every file with its layout and all partials, then executes it
+// once with empty data so that missing templates are reported at load time.
+func build(files, layouts, partials map[string]string, kind string) (map[string]*template.Template, error) {
+	res := make(map[string]*template.Template, len(files))
+
+	for name, src := range files {
+		layoutName := defaultLayout
+		if m := extendsRegex.FindStringSubmatch(src); m != nil {
+			layoutName = m[1]
+		}
+		layout, ok := layouts[layoutName]
+		if !ok {
+			return nil, fmt.Errorf("%s/%s.html: layout %q: %w", kind, name, layoutName, ErrTemplateNotFound)
+		}
+
+		tpl, err := template.New("layout").Parse(layout)
+		if err != nil {
+			return nil, fmt.Errorf("layouts/%s.html: %w", layoutName, err)
+		}
+		for partialName, partial := range partials {
+			if _, err := tpl.New("partials/" + partialName).Parse(partial); err != nil {
+				return nil, fmt.Errorf("partials/%s.html: %w", partialName, err)
+			}
+		}
+		if _, err := tpl.New(kind + "/" + name).Parse(src); err != nil {
+			return nil, fmt.Errorf("%s/%s.html: %w", kind, name, err)
+		}
+
+		if err := tpl.ExecuteTemplate(io.Discard, "layout", PageData{}); err != nil {
+			return nil, fmt.Errorf("%s/%s.html: %w", kind, name, err)
+		}
+		res[name] = tpl
+	}
+
+	return res, nil
+}
+
+// readDir returns the contents of *.html files in dir keyed by name without extension.
+// A missing directory is treated as empty.
+func readDir(dir string) (map[string]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return map[string]string{}, nil
+		}
+		return nil, err
+	}
+
+	res := make(map[string]string, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".html" {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		res[strings.TrimSuffix(entry.Name(), ".html")] = string(data)
+	}
+
+	return res, nil
+}
@@ -0,0 +1,119 @@
+package views
+
+import (
+	"bytes"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aube/auth/internal/domain/entities"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeTheme creates a theme in a temp dir from name -> content pairs.
+func writeTheme(t *testing.T, files map[string]string) string {
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+	return dir
+}
+
+func baseTheme() map[string]string {
+	return map[string]string{
+		"layouts/base.html":    `<title>{{block "title" .}}{{.Title}}{{end}}</title>{{template "partials/nav" .}}{{block "content" .}}{{end}}`,
+		"layouts/wide.html":    `<div class="wide">{{block "content" .}}{{end}}</div>`,
+		"partials/nav.html":    `<nav>{{.Path}}</nav>`,
+		"pages/default.html":   `{{define "content"}}<h1>{{.H1}}</h1>{{.Content}}{{end}}`,
+		"pages/landing.html":   `{{/* extends "wide" */}}{{define "content"}}landing {{.Title}}{{end}}`,
+		"errors/404.html":      `{{define "content"}}not found{{end}}`,
+		"errors/500.html":      `{{define "content"}}error {{.Status}}{{end}}`,
+		"errors/readme.txt":    `ignored`,
+		"pages/escaping.html":  `{{define "content"}}{{.Meta.Description}}{{end}}`,
+		"partials/unused.html": `{{define "unused"}}{{end}}`,
+	}
+}
+
+func TestViews_RenderPage(t *testing.T) {
+	v, err := New(writeTheme(t, baseTheme()), false)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, v.RenderPage(&buf, "default", PageData{Title: "About", H1: "About us", Content: "<p>Hi</p>", Path: "/about"}))
+	assert.Equal(t, `<title>About</title><nav>/about</nav><h1>About us</h1><p>Hi</p>`, buf.String())
+
+	buf.Reset()
+	require.NoError(t, v.RenderPage(&buf, "landing", PageData{Title: "Promo"}))
+	assert.Equal(t, `<div class="wide">landing Promo</div>`, buf.String())
+
+	buf.Reset()
+	require.NoError(t, v.RenderPage(&buf, "missing", PageData{H1: "Fallback"}))
+	assert.Contains(t, buf.String(), "<h1>Fallback</h1>")
+
+	buf.Reset()
+	require.NoError(t, v.RenderPage(&buf, "escaping", PageData{Meta: entities.PageMeta{Description: "<script>"}}))
+	assert.Contains(t, buf.String(), "&lt;script&gt;")
+}
+
+func TestViews_RenderError(t *testing.T) {
+	v, err := New(writeTheme(t, baseTheme()), false)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, v.RenderError(&buf, http.StatusNotFound, PageData{}))
+	assert.Contains(t, buf.String(), "not found")
+
+	buf.Reset()
+	require.NoError(t, v.RenderError(&buf, http.StatusBadGateway, PageData{}))
+	assert.Contains(t, buf.String(), "error 502")
+}
+
+func TestNew_Validation(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(files map[string]string)
+	}{
+		{"missing default page", func(f map[string]string) { delete(f, "pages/default.html") }},
+		{"missing 404 page", func(f map[string]string) { delete(f, "errors/404.html") }},
+		{"unknown layout", func(f map[string]string) { f["pages/landing.html"] = `{{/* extends "narrow" */}}` }},
+		{"syntax error", func(f map[string]string) { f["pages/default.html"] = `{{define "content"}}{{.H1}` }},
+		{"missing partial", func(f map[string]string) {
+			f["pages/default.html"] = `{{define "content"}}{{template "partials/none" .}}{{end}}`
+		}},
+		{"unknown field", func(f map[string]string) { f["pages/default.html"] = `{{define "content"}}{{.Author}}{{end}}` }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			files := baseTheme()
+			tt.modify(files)
+
+			_, err := New(writeTheme(t, files), false)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestViews_Reload(t *testing.T) {
+	files := baseTheme()
+	dir := writeTheme(t, files)
+
+	v, err := New(dir, true)
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "pages/default.html"), []byte(`{{define "content"}}changed{{end}}`), 0644))
+
+	var buf bytes.Buffer
+	require.NoError(t, v.RenderPage(&buf, "default", PageData{}))
+	assert.Contains(t, buf.String(), "changed")
+}
+
+func TestNew_SampleTheme(t *testing.T) {
+	_, err := New("../../api/rest/site", false)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,80 @@
+package diff
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func apply(lines []Line) (string, string) {
+	var a, b []string
+	for _, l := range lines {
+		if l.Op != OpInsert {
+			a = append(a, l.Text)
+		}
+		if l.Op != OpDelete {
+			b = append(b, l.Text)
+		}
+	}
+	return join(a), join(b)
+}
+
+func join(s []string) string {
+	res := ""
+	for i, l := range s {
+		if i > 0 {
+			res += "\n"
+		}
+		res += l
+	}
+	return res
+}
+
+func TestLines(t *testing.T) {
+	tests := []struct {
+		name    string
+		a, b    string
+		changes int
+	}{
+		{"equal", "a\nb\nc", "a\nb\nc", 0},
+		{"insert", "a\nc", "a\nb\nc", 1},
+		{"delete", "a\nb\nc", "a\nc", 1},
+		{"replace", "a\nb\nc", "a\nx\nc", 2},
+		{"from empty", "", "a\nb", 2},
+		{"to empty", "a\nb", "", 2},
+		{"both empty", "", "", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lines := Lines(tt.a, tt.b)
+			a, b := apply(lines)
+			assert.Equal(t, tt.a, a)
+			assert.Equal(t, tt.b, b)
+
+			changes := 0
+			for _, l := range lines {
+				if l.Op != OpEqual {
+					changes++
+				}
+			}
+			assert.Equal(t, tt.changes, changes)
+		})
+	}
+}
+
+func TestHunks(t *testing.T) {
+	a := "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12"
+	b := "1\nX\n3\n4\n5\n6\n7\n8\n9\n10\nY\n12"
+
+	hunks := Hunks(Lines(a, b), 1)
+
+	assert.Len(t, hunks, 2)
+	assert.Equal(t, 1, hunks[0].OldStart)
+	assert.Equal(t, 3, hunks[0].OldLines)
+	assert.Equal(t, 3, hunks[0].NewLines)
+	assert.Equal(t, 10, hunks[1].OldStart)
+
+	assert.Len(t, Hunks(Lines(a, b), 5), 1)
+	assert.Empty(t, Hunks(Lines(a, a), 3))
+}
@@ -0,0 +1,105 @@
+// Package disposition formats Content-Disposition headers of downloads.
+package disposition
+
+import (
+	"fmt"
+	"path"
+	"strings"
+	"unicode"
+)
+
+const (
+	Attachment = "attachment"
+	Inline     = "inline"
+)
+
+// fallbackName is used when nothing is left of the name.
+const fallbackName = "download"
+
+// Header formats the header per RFC 6266: an ASCII filename for old
+// clients and the UTF-8 filename* for the rest. Unknown dispositions
+// become attachment; the name is sanitized (no paths, quotes or control
+// characters).
+func Header(disposition string, filename string) string {
+	if disposition != Inline {
+		disposition = Attachment
+	}
+
+	name := Sanitize(filename)
+	header := disposition + "; filename=" + quote(asciiName(name))
+	if !isASCII(name) {
+		header += "; filename*=UTF-8''" + encode(name)
+	}
+	return header
+}
+
+// Sanitize returns the base name without control characters, quotes and
+// backslashes.
+func Sanitize(filename string) string {
+	filename = strings.ReplaceAll(filename, "\\", "/")
+	name := strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) || r == '"' || r == unicode.ReplacementChar {
+			return -1
+		}
+		return r
+	}, path.Base(filename))
+
+	name = strings.TrimSpace(name)
+	if name == "" || name == "." || name == "/" || name == ".." {
+		return fallbackName
+	}
+	return name
+}
+
+// asciiName replaces non-ASCII characters, keeping the extension readable.
+func asciiName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if r > unicode.MaxASCII {
+			return '_'
+		}
+		return r
+	}, name)
+}
+
+// encode percent-encodes the name as an RFC 5987 value: everything
+// but attr-char bytes is escaped.
+func encode(name string) string {
+	var b strings.Builder
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		if isAttrChar(c) {
+			b.WriteByte(c)
+		} else {
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+// quote returns the name as an RFC 2616 token if it is one, otherwise
+// as a quoted string; Sanitize has removed quotes already.
+func quote(name string) string {
+	for i := 0; i < len(name); i++ {
+		if !isAttrChar(name[i]) && strings.IndexByte("'*%", name[i]) < 0 {
+			return `"` + name + `"`
+		}
+	}
+	return name
+}
+
+func isAttrChar(c byte) bool {
+	switch {
+	case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9':
+		return true
+	}
+	return strings.IndexByte("!#$&+-.^_`|~", c) >= 0
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] > unicode.MaxASCII {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,30 @@
+package disposition
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHeader(t *testing.T) {
+	tests := []struct {
+		name        string
+		disposition string
+		filename    string
+		want        string
+	}{
+		{"ascii", Attachment, "report.pdf", `attachment; filename=report.pdf`},
+		{"inline", Inline, "photo.png", `inline; filename=photo.png`},
+		{"unknown disposition", "download", "a.txt", `attachment; filename=a.txt`},
+		{"utf-8", Attachment, "отчёт 1.pdf", `attachment; filename="_____ 1.pdf"; filename*=UTF-8''%D0%BE%D1%82%D1%87%D1%91%D1%82%201.pdf`},
+		{"header injection", Attachment, "a\"\r\nSet-Cookie: x=1.txt", `attachment; filename="aSet-Cookie: x=1.txt"`},
+		{"path", Inline, `..\..\etc/passwd`, `inline; filename=passwd`},
+		{"empty", Attachment, "", `attachment; filename=download`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Header(tt.disposition, tt.filename))
+		})
+	}
+}
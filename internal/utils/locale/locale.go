@@ -0,0 +1,211 @@
+// Package locale negotiates content locales.
+//
+// Locales are lowercase BCP 47 tags with "-" separators ("ru", "en", "en-gb").
+// A request is matched against the supported locales by an explicit
+// parameter first and the Accept-Language header second; content is then
+// looked up along a fallback chain ending with the default locale.
+package locale
+
+import (
+	"errors"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var tagRegex = regexp.MustCompile(`^[a-z]{2,3}(-[a-z0-9]{2,8})*$`)
+
+// Normalize lowercases a tag and replaces "_" with "-" ("en_GB" -> "en-gb").
+func Normalize(tag string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(tag), "_", "-"))
+}
+
+// IsValid reports whether tag is a well-formed normalized locale.
+func IsValid(tag string) bool {
+	return tagRegex.MatchString(tag)
+}
+
+// Base returns the language part of a tag ("en-gb" -> "en").
+func Base(tag string) string {
+	base, _, _ := strings.Cut(tag, "-")
+	return base
+}
+
+// Locales holds the locales of a site.
+type Locales struct {
+	def       string
+	supported []string
+	fallbacks map[string]string
+}
+
+// New validates the locale settings of a site.
+// def: Default locale, the language of the pages themselves
+// supported: Locales content can be requested in (def is added when missing)
+// fallbacks: Locale to try next for a locale, e.g. {"uk": "ru", "en-gb": "en"}
+func New(def string, supported []string, fallbacks map[string]string) (*Locales, error) {
+	def = Normalize(def)
+	if !IsValid(def) {
+		return nil, errors.New("invalid default locale: " + def)
+	}
+
+	l := &Locales{def: def, supported: []string{def}, fallbacks: make(map[string]string)}
+	for _, tag := range supported {
+		tag = Normalize(tag)
+		if tag == "" || tag == def {
+			continue
+		}
+		if !IsValid(tag) {
+			return nil, errors.New("invalid locale: " + tag)
+		}
+		l.supported = append(l.supported, tag)
+	}
+
+	for from, to := range fallbacks {
+		from, to = Normalize(from), Normalize(to)
+		if !l.IsSupported(from) || !l.IsSupported(to) {
+			return nil, errors.New("fallback between unsupported locales: " + from + " -> " + to)
+		}
+		l.fallbacks[from] = to
+	}
+	// Цепочки не должны зацикливаться
+	for from := range l.fallbacks {
+		seen := map[string]bool{from: true}
+		for next, ok := l.fallbacks[from]; ok; next, ok = l.fallbacks[next] {
+			if seen[next] {
+				return nil, errors.New("fallback cycle at locale " + from)
+			}
+			seen[next] = true
+		}
+	}
+
+	return l, nil
+}
+
+// ParseFallbacks parses "uk:ru,en-gb:en" into a fallback map.
+func ParseFallbacks(s string) (map[string]string, error) {
+	res := make(map[string]string)
+	for _, pair := range strings.Split(s, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		from, to, ok := strings.Cut(pair, ":")
+		if !ok {
+			return nil, errors.New("invalid locale fallback: " + pair)
+		}
+		res[Normalize(from)] = Normalize(to)
+	}
+	return res, nil
+}
+
+// Default returns the default locale.
+func (l *Locales) Default() string {
+	return l.def
+}
+
+// Supported returns all supported locales, the default one first.
+func (l *Locales) Supported() []string {
+	return append([]string(nil), l.supported...)
+}
+
+// IsSupported reports whether tag is one of the site locales.
+func (l *Locales) IsSupported(tag string) bool {
+	for _, s := range l.supported {
+		if s == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// Negotiate picks the locale for a request.
+// param: Explicit locale (?lang=), used when supported
+// acceptLanguage: Accept-Language header; tags are tried by quality,
+// then by their base language ("en-us" matches "en")
+// Returns the default locale when nothing matches.
+func (l *Locales) Negotiate(param string, acceptLanguage string) string {
+	if tag := Normalize(param); l.IsSupported(tag) {
+		return tag
+	}
+
+	tags := parseAcceptLanguage(acceptLanguage)
+	for _, tag := range tags {
+		if l.IsSupported(tag) {
+			return tag
+		}
+	}
+	for _, tag := range tags {
+		if base := Base(tag); l.IsSupported(base) {
+			return base
+		}
+	}
+
+	return l.def
+}
+
+// Chain returns the locales to look content up in, most preferred first:
+// the locale, its fallbacks, its base language and the default locale.
+func (l *Locales) Chain(tag string) []string {
+	tag = Normalize(tag)
+	if !l.IsSupported(tag) {
+		return []string{l.def}
+	}
+
+	chain := []string{}
+	seen := map[string]bool{}
+	add := func(t string) {
+		if t != "" && !seen[t] && l.IsSupported(t) {
+			seen[t] = true
+			chain = append(chain, t)
+		}
+	}
+
+	for t, ok := tag, true; ok; t, ok = l.fallbacks[t] {
+		add(t)
+	}
+	add(Base(tag))
+	add(l.def)
+
+	return chain
+}
+
+type weightedTag struct {
+	tag     string
+	quality float64
+}
+
+// parseAcceptLanguage returns normalized tags ordered by quality;
+// "*" and tags with q=0 are skipped.
+func parseAcceptLanguage(header string) []string {
+	var weighted []weightedTag
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		tag = Normalize(tag)
+		if tag == "" || tag == "*" {
+			continue
+		}
+
+		quality := 1.0
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			v, err := strconv.ParseFloat(q, 64)
+			if err != nil {
+				continue
+			}
+			quality = v
+		}
+		if quality <= 0 {
+			continue
+		}
+		weighted = append(weighted, weightedTag{tag: tag, quality: quality})
+	}
+
+	sort.SliceStable(weighted, func(i, j int) bool {
+		return weighted[i].quality > weighted[j].quality
+	})
+
+	tags := make([]string, len(weighted))
+	for i, w := range weighted {
+		tags[i] = w.tag
+	}
+	return tags
+}
@@ -0,0 +1,73 @@
+package locale
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testLocales(t *testing.T) *Locales {
+	l, err := New("ru", []string{"ru", "en", "en-GB", "uk"}, map[string]string{"uk": "ru", "en-gb": "en"})
+	require.NoError(t, err)
+	return l
+}
+
+func TestNew_Invalid(t *testing.T) {
+	_, err := New("", nil, nil)
+	assert.Error(t, err)
+
+	_, err = New("ru", []string{"english"}, nil)
+	assert.Error(t, err)
+
+	_, err = New("ru", []string{"en"}, map[string]string{"de": "en"})
+	assert.Error(t, err)
+
+	_, err = New("ru", []string{"en", "uk"}, map[string]string{"en": "uk", "uk": "en"})
+	assert.Error(t, err)
+}
+
+func TestLocales_Negotiate(t *testing.T) {
+	l := testLocales(t)
+
+	tests := []struct {
+		name   string
+		param  string
+		accept string
+		want   string
+	}{
+		{"param wins", "en", "uk", "en"},
+		{"param normalized", "EN_gb", "", "en-gb"},
+		{"unsupported param", "de", "en", "en"},
+		{"quality order", "", "de;q=0.9, uk;q=0.5, en;q=0.8", "en"},
+		{"exact region", "", "en-GB,en;q=0.8", "en-gb"},
+		{"base language", "", "en-US", "en"},
+		{"zero quality", "", "en;q=0", "ru"},
+		{"wildcard", "", "*", "ru"},
+		{"nothing", "", "", "ru"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, l.Negotiate(tt.param, tt.accept))
+		})
+	}
+}
+
+func TestLocales_Chain(t *testing.T) {
+	l := testLocales(t)
+
+	assert.Equal(t, []string{"en-gb", "en", "ru"}, l.Chain("en-gb"))
+	assert.Equal(t, []string{"uk", "ru"}, l.Chain("uk"))
+	assert.Equal(t, []string{"ru"}, l.Chain("ru"))
+	assert.Equal(t, []string{"ru"}, l.Chain("de"))
+}
+
+func TestParseFallbacks(t *testing.T) {
+	fallbacks, err := ParseFallbacks("uk:ru, en-GB:en,")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"uk": "ru", "en-gb": "en"}, fallbacks)
+
+	_, err = ParseFallbacks("uk-ru")
+	assert.Error(t, err)
+}
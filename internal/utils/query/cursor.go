@@ -0,0 +1,170 @@
+package query
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aube/auth/internal/utils/signer"
+	"github.com/google/uuid"
+)
+
+const cursorPurpose = "list-cursor"
+
+// Cursor is a position in a sorted list: Key holds the sort key
+// (sort fields and the tie breaker) of the row the page starts after,
+// or before it when Backward. Sort binds the cursor to the order it was issued for.
+type Cursor struct {
+	Key      []any
+	Backward bool
+	Sort     string
+}
+
+// CursorAt returns a cursor at the row with the given sort key.
+func (q *Query) CursorAt(key []any, backward bool) *Cursor {
+	return &Cursor{Key: key, Backward: backward, Sort: q.sortSpec()}
+}
+
+// sortSpec returns the sort in the sort parameter syntax.
+func (q *Query) sortSpec() string {
+	if q == nil {
+		return ""
+	}
+	parts := make([]string, len(q.Sort))
+	for i, s := range q.Sort {
+		parts[i] = s.Field
+		if s.Desc {
+			parts[i] = "-" + parts[i]
+		}
+	}
+	return strings.Join(parts, ",")
+}
+
+// Cursors issues opaque signed cursor tokens, so clients can neither
+// read nor forge the key values.
+type Cursors struct {
+	signer *signer.Signer
+}
+
+func NewCursors(secret string) *Cursors {
+	return &Cursors{signer: signer.New(secret, cursorPurpose)}
+}
+
+type cursorPayload struct {
+	Key      []string `json:"k"`
+	Backward bool     `json:"b,omitempty"`
+	Sort     string   `json:"s,omitempty"`
+}
+
+// Encode returns the token of a cursor ("" for nil).
+func (c *Cursors) Encode(cursor *Cursor) (string, error) {
+	if cursor == nil {
+		return "", nil
+	}
+
+	payload := cursorPayload{Key: make([]string, len(cursor.Key)), Backward: cursor.Backward, Sort: cursor.Sort}
+	for i, v := range cursor.Key {
+		value, err := encodeKeyValue(v)
+		if err != nil {
+			return "", err
+		}
+		payload.Key[i] = value
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+	return c.signer.Sign(string(data), time.Time{}), nil
+}
+
+// Decode verifies a token and returns its cursor.
+func (c *Cursors) Decode(token string) (*Cursor, error) {
+	data, err := c.signer.Verify(token, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("%w: cursor: %v", ErrInvalidQuery, err)
+	}
+
+	var payload cursorPayload
+	if err := json.Unmarshal([]byte(data), &payload); err != nil {
+		return nil, fmt.Errorf("%w: cursor: %v", ErrInvalidQuery, err)
+	}
+
+	cursor := &Cursor{Key: make([]any, len(payload.Key)), Backward: payload.Backward, Sort: payload.Sort}
+	for i, v := range payload.Key {
+		if cursor.Key[i], err = decodeKeyValue(v); err != nil {
+			return nil, err
+		}
+	}
+	return cursor, nil
+}
+
+// Значения ключа хранятся с префиксом типа, чтобы вернуться в SQL тем же типом
+func encodeKeyValue(v any) (string, error) {
+	switch v := v.(type) {
+	case nil:
+		return "n", nil
+	case string:
+		return "s:" + v, nil
+	case bool:
+		return "b:" + strconv.FormatBool(v), nil
+	case int:
+		return "i:" + strconv.FormatInt(int64(v), 10), nil
+	case int16:
+		return "i:" + strconv.FormatInt(int64(v), 10), nil
+	case int32:
+		return "i:" + strconv.FormatInt(int64(v), 10), nil
+	case int64:
+		return "i:" + strconv.FormatInt(v, 10), nil
+	case float32:
+		return "f:" + strconv.FormatFloat(float64(v), 'g', -1, 32), nil
+	case float64:
+		return "f:" + strconv.FormatFloat(v, 'g', -1, 64), nil
+	case time.Time:
+		return "t:" + v.UTC().Format(time.RFC3339Nano), nil
+	case [16]byte:
+		return "u:" + uuid.UUID(v).String(), nil
+	case uuid.UUID:
+		return "u:" + v.String(), nil
+	default:
+		return "", fmt.Errorf("unsupported cursor value %T", v)
+	}
+}
+
+func decodeKeyValue(value string) (any, error) {
+	if value == "n" {
+		return nil, nil
+	}
+
+	kind, raw, ok := strings.Cut(value, ":")
+	if !ok {
+		return nil, fmt.Errorf("%w: cursor value", ErrInvalidQuery)
+	}
+
+	var (
+		v   any
+		err error
+	)
+	switch kind {
+	case "s":
+		v = raw
+	case "b":
+		v, err = strconv.ParseBool(raw)
+	case "i":
+		v, err = strconv.ParseInt(raw, 10, 64)
+	case "f":
+		v, err = strconv.ParseFloat(raw, 64)
+	case "t":
+		v, err = time.Parse(time.RFC3339Nano, raw)
+	case "u":
+		v, err = uuid.Parse(raw)
+	default:
+		err = fmt.Errorf("unknown type %s", kind)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: cursor value: %v", ErrInvalidQuery, err)
+	}
+	return v, nil
+}
@@ -0,0 +1,117 @@
+package query
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeyset_Forward(t *testing.T) {
+	q, err := Parse(url.Values{"sort": {"-created_at,name"}}, testSchema)
+	require.NoError(t, err)
+	created := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	q.Cursor = q.CursorAt([]any{created, "about", int64(7)}, false)
+
+	where, args, err := q.Keyset(testColumns, "id", 3)
+	require.NoError(t, err)
+	assert.Equal(t, "((created_at < $3) OR (created_at = $4 AND name > $5) OR (created_at = $6 AND name = $7 AND id > $8))", where)
+	assert.Equal(t, []any{created, created, "about", created, "about", int64(7)}, args)
+
+	order, err := q.OrderBy(testColumns, "id")
+	require.NoError(t, err)
+	assert.Equal(t, "ORDER BY created_at DESC, name, id", order)
+}
+
+func TestKeyset_Backward(t *testing.T) {
+	q := New()
+	q.Sort = []Sort{{Field: "type", Nullable: true}}
+	q.Cursor = q.CursorAt([]any{"news", int64(7)}, true)
+
+	where, _, err := q.Keyset(testColumns, "id", 1)
+	require.NoError(t, err)
+	assert.Equal(t, "((content_type < $1) OR (content_type = $2 AND id < $3))", where)
+
+	order, err := q.OrderBy(testColumns, "id")
+	require.NoError(t, err)
+	assert.Equal(t, "ORDER BY content_type DESC, id DESC", order)
+}
+
+func TestKeyset_Nulls(t *testing.T) {
+	q := New()
+	q.Sort = []Sort{{Field: "type", Nullable: true}}
+
+	q.Cursor = q.CursorAt([]any{"news", int64(7)}, false)
+	where, _, err := q.Keyset(testColumns, "id", 1)
+	require.NoError(t, err)
+	assert.Equal(t, "(((content_type > $1 OR content_type IS NULL)) OR (content_type = $2 AND id > $3))", where)
+
+	q.Cursor = q.CursorAt([]any{nil, int64(7)}, false)
+	where, _, err = q.Keyset(testColumns, "id", 1)
+	require.NoError(t, err)
+	assert.Equal(t, "((content_type IS NULL AND id > $1))", where)
+
+	q.Cursor = q.CursorAt([]any{nil, int64(7)}, true)
+	where, _, err = q.Keyset(testColumns, "id", 1)
+	require.NoError(t, err)
+	assert.Equal(t, "((content_type IS NOT NULL) OR (content_type IS NULL AND id < $1))", where)
+}
+
+func TestKeyset_SortMismatch(t *testing.T) {
+	q, err := Parse(url.Values{"sort": {"name"}}, testSchema)
+	require.NoError(t, err)
+	q.Cursor = New().CursorAt([]any{int64(7)}, false)
+
+	_, _, err = q.Keyset(testColumns, "id", 1)
+
+	assert.ErrorIs(t, err, ErrInvalidQuery)
+}
+
+func TestKeyset_TieBreakerSort(t *testing.T) {
+	q := New()
+	q.Sort = []Sort{{Field: "id", Desc: true}}
+	columns := Columns{"id": "id"}
+
+	keys, err := q.KeyColumns(columns, "id")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"id"}, keys)
+
+	order, err := q.OrderBy(columns, "id")
+	require.NoError(t, err)
+	assert.Equal(t, "ORDER BY id DESC", order)
+}
+
+func TestCursors_RoundTrip(t *testing.T) {
+	cursors := NewCursors("secret")
+	id := uuid.New()
+	cursor := &Cursor{
+		Key:      []any{time.Date(2025, 1, 1, 10, 0, 0, 123456000, time.UTC), "a:b", int32(5), 1.5, true, nil, id},
+		Backward: true,
+		Sort:     "-created_at",
+	}
+
+	token, err := cursors.Encode(cursor)
+	require.NoError(t, err)
+	decoded, err := cursors.Decode(token)
+	require.NoError(t, err)
+
+	assert.Equal(t, &Cursor{
+		Key:      []any{time.Date(2025, 1, 1, 10, 0, 0, 123456000, time.UTC), "a:b", int64(5), 1.5, true, nil, id},
+		Backward: true,
+		Sort:     "-created_at",
+	}, decoded)
+}
+
+func TestCursors_Invalid(t *testing.T) {
+	token, err := NewCursors("secret").Encode(&Cursor{Key: []any{int64(1)}})
+	require.NoError(t, err)
+
+	_, err = NewCursors("other").Decode(token)
+	assert.ErrorIs(t, err, ErrInvalidQuery)
+
+	_, err = NewCursors("secret").Decode("garbage")
+	assert.ErrorIs(t, err, ErrInvalidQuery)
+}
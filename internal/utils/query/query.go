@@ -0,0 +1,349 @@
+// Package query parses list filters and sorting from request parameters
+// and turns them into parameterized SQL. Clients may only use fields listed
+// in a Schema; values are parsed by the field type and never reach SQL text.
+//
+// Syntax:
+//
+//	filter[name]=about                 name = 'about'
+//	filter[size][gte]=1024             size >= 1024
+//	filter[category][in]=news,blog     category IN ('news', 'blog')
+//	filter[created_at][between]=2025-01-01,2025-02-01
+//	filter[title][like]=intro*         title ILIKE 'intro%' (no * - substring)
+//	filter[content_type][null]=true    content_type IS NULL
+//	filter[tags][any]=news,blog        tags && '{news,blog}' (any of the tags)
+//	filter[tags]=news,blog             tags @> '{news,blog}' (all of the tags)
+//	sort=-created_at,name              ORDER BY created_at DESC, name
+//
+// A Cursor continues a sorted list after (or before) a known row
+// instead of skipping rows by offset.
+package query
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrInvalidQuery is returned for unknown fields, operators or malformed values.
+var ErrInvalidQuery = errors.New("invalid query")
+
+// MaxValues limits values of in filters.
+const MaxValues = 100
+
+type Op string
+
+const (
+	OpEq      Op = "eq"
+	OpNe      Op = "ne"
+	OpLt      Op = "lt"
+	OpLte     Op = "lte"
+	OpGt      Op = "gt"
+	OpGte     Op = "gte"
+	OpIn      Op = "in"
+	OpLike    Op = "like"
+	OpBetween Op = "between"
+	OpNull    Op = "null"
+	OpAny     Op = "any"
+	OpAll     Op = "all"
+	// OpContains matches JSON documents containing the value.
+	// Not available to clients.
+	OpContains Op = "contains"
+)
+
+type Type int
+
+const (
+	TypeString Type = iota
+	TypeInt
+	TypeFloat
+	TypeBool
+	// TypeTime accepts RFC 3339 timestamps and YYYY-MM-DD dates.
+	TypeTime
+	TypeUUID
+)
+
+// Field describes a field clients may filter by.
+// Nullable fields accept the null operator, Sortable ones may be used in sort.
+// List fields hold a set of strings and accept only any and all (the default).
+type Field struct {
+	Type     Type
+	Sortable bool
+	Nullable bool
+	List     bool
+}
+
+// Schema is the allowlist of client fields of a resource.
+type Schema map[string]Field
+
+// Filter is a condition on a field. Value holds a typed value,
+// []any for in and between, a bool for null (true - IS NULL).
+type Filter struct {
+	Field string
+	Op    Op
+	Value any
+}
+
+// Sort orders by a field. Nullable fields keep NULLs last in ascending order.
+type Sort struct {
+	Field    string
+	Desc     bool
+	Nullable bool
+}
+
+// Query is a set of filters joined by AND and the sort order.
+// Cursor continues the list from a known row, SkipTotal disables counting.
+// A nil *Query matches everything.
+type Query struct {
+	Filters   []Filter
+	Sort      []Sort
+	Cursor    *Cursor
+	SkipTotal bool
+}
+
+func New() *Query {
+	return &Query{}
+}
+
+// Add appends a filter and returns q.
+func (q *Query) Add(field string, op Op, value any) *Query {
+	q.Filters = append(q.Filters, Filter{Field: field, Op: op, Value: value})
+	return q
+}
+
+// Clone returns a copy of q that can be extended without changing q.
+func (q *Query) Clone() *Query {
+	if q == nil {
+		return New()
+	}
+	return &Query{
+		Filters:   append([]Filter(nil), q.Filters...),
+		Sort:      append([]Sort(nil), q.Sort...),
+		Cursor:    q.Cursor,
+		SkipTotal: q.SkipTotal,
+	}
+}
+
+// Value returns the value of the first eq filter on field.
+func (q *Query) Value(field string) (any, bool) {
+	if q == nil {
+		return nil, false
+	}
+	for _, f := range q.Filters {
+		if f.Field == field && f.Op == OpEq {
+			return f.Value, true
+		}
+	}
+	return nil, false
+}
+
+// Extract removes and returns filters whose field starts with prefix.
+func (q *Query) Extract(prefix string) []Filter {
+	if q == nil {
+		return nil
+	}
+	var taken []Filter
+	kept := q.Filters[:0]
+	for _, f := range q.Filters {
+		if strings.HasPrefix(f.Field, prefix) {
+			taken = append(taken, f)
+		} else {
+			kept = append(kept, f)
+		}
+	}
+	q.Filters = kept
+	return taken
+}
+
+// Parse reads filter[...] and sort parameters; other parameters are ignored.
+func Parse(values url.Values, schema Schema) (*Query, error) {
+	// Ключи по порядку: одинаковые запросы дают одинаковый SQL
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	q := New()
+	for _, key := range keys {
+		vals := values[key]
+		if key == "sort" {
+			for _, v := range vals {
+				if err := q.parseSort(v, schema); err != nil {
+					return nil, err
+				}
+			}
+			continue
+		}
+
+		rest, ok := strings.CutPrefix(key, "filter[")
+		if !ok {
+			continue
+		}
+		name, op, err := parseKey(rest)
+		if err != nil {
+			return nil, err
+		}
+		field, ok := schema[name]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown field %s", ErrInvalidQuery, name)
+		}
+		if field.List && op == OpEq {
+			op = OpAll
+		}
+		for _, v := range vals {
+			value, err := parseValue(field, op, v)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %s: %v", ErrInvalidQuery, name, err)
+			}
+			q.Add(name, op, value)
+		}
+	}
+	return q, nil
+}
+
+// parseKey splits "name]" or "name][op]" (after the "filter[" prefix).
+func parseKey(rest string) (string, Op, error) {
+	name, rest, ok := strings.Cut(rest, "]")
+	if !ok || name == "" {
+		return "", "", fmt.Errorf("%w: malformed filter", ErrInvalidQuery)
+	}
+	if rest == "" {
+		return name, OpEq, nil
+	}
+
+	op, ok := strings.CutPrefix(rest, "[")
+	if !ok || !strings.HasSuffix(op, "]") {
+		return "", "", fmt.Errorf("%w: malformed filter %s", ErrInvalidQuery, name)
+	}
+	return name, Op(strings.TrimSuffix(op, "]")), nil
+}
+
+func (q *Query) parseSort(value string, schema Schema) error {
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		name, desc := strings.CutPrefix(part, "-")
+		field, ok := schema[name]
+		if !ok || !field.Sortable {
+			return fmt.Errorf("%w: cannot sort by %s", ErrInvalidQuery, name)
+		}
+		q.Sort = append(q.Sort, Sort{Field: name, Desc: desc, Nullable: field.Nullable})
+	}
+	return nil
+}
+
+func parseValue(field Field, op Op, value string) (any, error) {
+	if field.List {
+		if op != OpAny && op != OpAll {
+			return nil, fmt.Errorf("operator %s is not supported", op)
+		}
+		var values []string
+		for _, part := range strings.Split(value, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				values = append(values, part)
+			}
+		}
+		if len(values) == 0 || len(values) > MaxValues {
+			return nil, fmt.Errorf("needs 1 to %d values", MaxValues)
+		}
+		return values, nil
+	}
+
+	switch op {
+	case OpEq, OpNe:
+		return parseScalar(field.Type, value)
+
+	case OpLt, OpLte, OpGt, OpGte:
+		if field.Type == TypeBool || field.Type == TypeUUID {
+			return nil, fmt.Errorf("operator %s is not supported", op)
+		}
+		return parseScalar(field.Type, value)
+
+	case OpIn:
+		parts := strings.Split(value, ",")
+		if len(parts) > MaxValues {
+			return nil, fmt.Errorf("more than %d values", MaxValues)
+		}
+		return parseList(field.Type, parts)
+
+	case OpBetween:
+		if field.Type == TypeBool || field.Type == TypeUUID {
+			return nil, fmt.Errorf("operator %s is not supported", op)
+		}
+		parts := strings.Split(value, ",")
+		if len(parts) != 2 {
+			return nil, errors.New("between needs two values")
+		}
+		return parseList(field.Type, parts)
+
+	case OpLike:
+		if field.Type != TypeString {
+			return nil, fmt.Errorf("operator %s is not supported", op)
+		}
+		return likePattern(value), nil
+
+	case OpNull:
+		if !field.Nullable {
+			return nil, fmt.Errorf("operator %s is not supported", op)
+		}
+		if value == "" {
+			return true, nil
+		}
+		return strconv.ParseBool(value)
+	}
+
+	return nil, fmt.Errorf("unknown operator %q", op)
+}
+
+func parseList(t Type, parts []string) ([]any, error) {
+	values := make([]any, len(parts))
+	for i, part := range parts {
+		v, err := parseScalar(t, strings.TrimSpace(part))
+		if err != nil {
+			return nil, err
+		}
+		values[i] = v
+	}
+	return values, nil
+}
+
+func parseScalar(t Type, value string) (any, error) {
+	switch t {
+	case TypeInt:
+		return strconv.ParseInt(value, 10, 64)
+	case TypeFloat:
+		return strconv.ParseFloat(value, 64)
+	case TypeBool:
+		return strconv.ParseBool(value)
+	case TypeTime:
+		if d, err := time.Parse(time.DateOnly, value); err == nil {
+			return d, nil
+		}
+		return time.Parse(time.RFC3339, value)
+	case TypeUUID:
+		id, err := uuid.Parse(value)
+		if err != nil {
+			return nil, err
+		}
+		return id.String(), nil
+	}
+	return value, nil
+}
+
+// likePattern escapes LIKE wildcards and turns * into %.
+// A value without * matches as a substring.
+func likePattern(value string) string {
+	escaped := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(value)
+	if !strings.Contains(escaped, "*") {
+		return "%" + escaped + "%"
+	}
+	return strings.ReplaceAll(escaped, "*", "%")
+}
@@ -0,0 +1,154 @@
+package query
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testSchema = Schema{
+	"name":       {Type: TypeString, Sortable: true},
+	"size":       {Type: TypeInt, Sortable: true},
+	"created_at": {Type: TypeTime, Sortable: true},
+	"type":       {Type: TypeString, Nullable: true},
+	"public":     {Type: TypeBool},
+}
+
+var testColumns = Columns{
+	"name":       "name",
+	"size":       "size",
+	"created_at": "created_at",
+	"type":       "content_type",
+	"public":     "public",
+	"deleted":    "deleted",
+}
+
+func TestParse_ToSQL(t *testing.T) {
+	values := url.Values{
+		"filter[name][like]":          {"intro*"},
+		"filter[size][gte]":           {"1024"},
+		"filter[type][null]":          {"true"},
+		"filter[created_at][between]": {"2025-01-01,2025-02-01T10:00:00Z"},
+		"filter[public]":              {"true"},
+		"sort":                        {"-created_at,name"},
+		"page":                        {"2"},
+	}
+
+	q, err := Parse(values, testSchema)
+	require.NoError(t, err)
+	q.Add("deleted", OpEq, false)
+
+	where, args, err := q.Where(testColumns, 3)
+	require.NoError(t, err)
+	assert.Equal(t, "WHERE created_at BETWEEN $3 AND $4 AND name ILIKE $5 AND public = $6 AND size >= $7 AND content_type IS NULL AND deleted = $8", where)
+	assert.Equal(t, []any{
+		time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2025, 2, 1, 10, 0, 0, 0, time.UTC),
+		"intro%",
+		true,
+		int64(1024),
+		false,
+	}, args)
+
+	order, err := q.OrderBy(testColumns, "id")
+	require.NoError(t, err)
+	assert.Equal(t, "ORDER BY created_at DESC, name, id", order)
+}
+
+func TestParse_In(t *testing.T) {
+	q, err := Parse(url.Values{"filter[size][in]": {"1,2,3"}}, testSchema)
+	require.NoError(t, err)
+
+	where, args, err := q.Conditions(testColumns, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "size IN ($1, $2, $3)", where)
+	assert.Equal(t, []any{int64(1), int64(2), int64(3)}, args)
+}
+
+func TestParse_LikeEscapes(t *testing.T) {
+	q, err := Parse(url.Values{"filter[name][like]": {`50%_off\`}}, testSchema)
+	require.NoError(t, err)
+	assert.Equal(t, `%50\%\_off\\%`, q.Filters[0].Value)
+}
+
+func TestParse_Invalid(t *testing.T) {
+	tests := []url.Values{
+		{"filter[password]": {"x"}},
+		{"filter[name; drop table pages]": {"x"}},
+		{"filter[name][regex]": {"x"}},
+		{"filter[name": {"x"}},
+		{"filter[size]": {"big"}},
+		{"filter[size][like]": {"1"}},
+		{"filter[public][gt]": {"true"}},
+		{"filter[name][null]": {"true"}},
+		{"filter[size][between]": {"1"}},
+		{"filter[created_at]": {"yesterday"}},
+		{"sort": {"password"}},
+		{"sort": {"type"}},
+	}
+
+	for _, values := range tests {
+		_, err := Parse(values, testSchema)
+		assert.ErrorIs(t, err, ErrInvalidQuery, values)
+	}
+}
+
+func TestQuery_UnknownColumn(t *testing.T) {
+	q := New().Add("password", OpEq, "x")
+
+	_, _, err := q.Where(testColumns, 1)
+
+	assert.ErrorIs(t, err, ErrInvalidQuery)
+}
+
+func TestQuery_Empty(t *testing.T) {
+	var q *Query
+
+	where, args, err := q.Where(testColumns, 1)
+	require.NoError(t, err)
+	assert.Empty(t, where)
+	assert.Empty(t, args)
+
+	order, err := q.OrderBy(testColumns, "id")
+	require.NoError(t, err)
+	assert.Equal(t, "ORDER BY id", order)
+}
+
+func TestQuery_Extract(t *testing.T) {
+	q := New().
+		Add("content_type", OpEq, "news").
+		Add("fields.rating", OpEq, "5")
+
+	taken := q.Extract("fields.")
+
+	assert.Equal(t, []Filter{{Field: "fields.rating", Op: OpEq, Value: "5"}}, taken)
+	assert.Len(t, q.Filters, 1)
+	value, ok := q.Value("content_type")
+	assert.True(t, ok)
+	assert.Equal(t, "news", value)
+}
+
+func TestParse_List(t *testing.T) {
+	schema := Schema{"tags": {Type: TypeString, List: true}}
+	columns := Columns{"tags": "tags"}
+
+	q, err := Parse(url.Values{"filter[tags]": {"news, blog"}, "filter[tags][any]": {"a,b"}}, schema)
+	require.NoError(t, err)
+
+	where, args, err := q.Conditions(columns, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "tags @> $1 AND tags && $2", where)
+	assert.Equal(t, []any{[]string{"news", "blog"}, []string{"a", "b"}}, args)
+
+	for _, values := range []url.Values{
+		{"filter[tags][in]": {"a"}},
+		{"filter[tags][any]": {","}},
+		{"filter[name][any]": {"a"}},
+	} {
+		_, err := Parse(values, Schema{"tags": {List: true}, "name": {}})
+		assert.ErrorIs(t, err, ErrInvalidQuery, values)
+	}
+}
@@ -0,0 +1,225 @@
+package query
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Columns maps field names to SQL column expressions.
+// Filters and sorts on fields missing from the map are rejected.
+type Columns map[string]string
+
+// With returns a copy of c with one more field.
+func (c Columns) With(field, column string) Columns {
+	columns := make(Columns, len(c)+1)
+	for f, col := range c {
+		columns[f] = col
+	}
+	columns[field] = column
+	return columns
+}
+
+// Conditions returns filters as SQL joined by AND ("" without filters)
+// with arguments numbered from start.
+func (q *Query) Conditions(columns Columns, start int) (string, []any, error) {
+	if q == nil || len(q.Filters) == 0 {
+		return "", nil, nil
+	}
+
+	conditions := make([]string, 0, len(q.Filters))
+	var args []any
+	next := func(value any) string {
+		args = append(args, value)
+		return "$" + strconv.Itoa(start+len(args)-1)
+	}
+
+	for _, f := range q.Filters {
+		column, ok := columns[f.Field]
+		if !ok {
+			return "", nil, fmt.Errorf("%w: unknown field %s", ErrInvalidQuery, f.Field)
+		}
+
+		switch f.Op {
+		case OpEq, OpNe, OpLt, OpLte, OpGt, OpGte, OpLike, OpContains, OpAny, OpAll:
+			conditions = append(conditions, column+" "+sqlOperators[f.Op]+" "+next(f.Value))
+
+		case OpIn:
+			values, ok := f.Value.([]any)
+			if !ok || len(values) == 0 {
+				return "", nil, fmt.Errorf("%w: %s: in needs values", ErrInvalidQuery, f.Field)
+			}
+			placeholders := make([]string, len(values))
+			for i, v := range values {
+				placeholders[i] = next(v)
+			}
+			conditions = append(conditions, column+" IN ("+strings.Join(placeholders, ", ")+")")
+
+		case OpBetween:
+			values, ok := f.Value.([]any)
+			if !ok || len(values) != 2 {
+				return "", nil, fmt.Errorf("%w: %s: between needs two values", ErrInvalidQuery, f.Field)
+			}
+			conditions = append(conditions, column+" BETWEEN "+next(values[0])+" AND "+next(values[1]))
+
+		case OpNull:
+			if isNull, _ := f.Value.(bool); isNull {
+				conditions = append(conditions, column+" IS NULL")
+			} else {
+				conditions = append(conditions, column+" IS NOT NULL")
+			}
+
+		default:
+			return "", nil, fmt.Errorf("%w: unknown operator %s", ErrInvalidQuery, f.Op)
+		}
+	}
+
+	return strings.Join(conditions, " AND "), args, nil
+}
+
+// Where is Conditions prefixed with WHERE.
+func (q *Query) Where(columns Columns, start int) (string, []any, error) {
+	conditions, args, err := q.Conditions(columns, start)
+	if err != nil || conditions == "" {
+		return "", args, err
+	}
+	return "WHERE " + conditions, args, nil
+}
+
+// OrderBy returns the ORDER BY clause ending with tieBreaker
+// (a unique column keeping pages stable). A backward cursor reverses the order.
+func (q *Query) OrderBy(columns Columns, tieBreaker string) (string, error) {
+	parts, err := q.keyParts(columns, tieBreaker)
+	if err != nil {
+		return "", err
+	}
+
+	backward := q != nil && q.Cursor != nil && q.Cursor.Backward
+	order := make([]string, len(parts))
+	for i, p := range parts {
+		order[i] = p.column
+		if p.desc != backward {
+			order[i] += " DESC"
+		}
+	}
+	return "ORDER BY " + strings.Join(order, ", "), nil
+}
+
+// KeyColumns returns the columns of the sort key matching Cursor.Key.
+func (q *Query) KeyColumns(columns Columns, tieBreaker string) ([]string, error) {
+	parts, err := q.keyParts(columns, tieBreaker)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, len(parts))
+	for i, p := range parts {
+		keys[i] = p.column
+	}
+	return keys, nil
+}
+
+// Keyset returns the condition selecting rows past the cursor in the order
+// of OrderBy ("" without a cursor) with arguments numbered from start.
+// Rows read by a backward cursor come in reverse and must be flipped by the caller.
+func (q *Query) Keyset(columns Columns, tieBreaker string, start int) (string, []any, error) {
+	if q == nil || q.Cursor == nil {
+		return "", nil, nil
+	}
+
+	parts, err := q.keyParts(columns, tieBreaker)
+	if err != nil {
+		return "", nil, err
+	}
+	if q.Cursor.Sort != q.sortSpec() {
+		return "", nil, fmt.Errorf("%w: cursor does not match sort", ErrInvalidQuery)
+	}
+	if len(q.Cursor.Key) != len(parts) {
+		return "", nil, fmt.Errorf("%w: malformed cursor", ErrInvalidQuery)
+	}
+
+	var args []any
+	next := func(value any) string {
+		args = append(args, value)
+		return "$" + strconv.Itoa(start+len(args)-1)
+	}
+
+	// (a > $1) OR (a = $1 AND id > $2): строка после курсора по первому различающемуся столбцу
+	var alternatives []string
+	for i, p := range parts {
+		greater := p.desc == q.Cursor.Backward
+		if q.Cursor.Key[i] == nil && greater {
+			continue // после NULL по возрастанию ничего нет
+		}
+		terms := make([]string, 0, i+1)
+		for j, prev := range parts[:i] {
+			terms = append(terms, prev.equal(q.Cursor.Key[j], next))
+		}
+		terms = append(terms, p.past(q.Cursor.Key[i], greater, next))
+		alternatives = append(alternatives, "("+strings.Join(terms, " AND ")+")")
+	}
+	if len(alternatives) == 0 {
+		return "FALSE", args, nil
+	}
+
+	return "(" + strings.Join(alternatives, " OR ") + ")", args, nil
+}
+
+// keyPart is a column of the sort key. NULLs sort as the largest values,
+// as PostgreSQL does by default.
+type keyPart struct {
+	column   string
+	desc     bool
+	nullable bool
+}
+
+func (q *Query) keyParts(columns Columns, tieBreaker string) ([]keyPart, error) {
+	var parts []keyPart
+	if q != nil {
+		for _, s := range q.Sort {
+			column, ok := columns[s.Field]
+			if !ok {
+				return nil, fmt.Errorf("%w: cannot sort by %s", ErrInvalidQuery, s.Field)
+			}
+			parts = append(parts, keyPart{column: column, desc: s.Desc, nullable: s.Nullable})
+		}
+	}
+	if len(parts) == 0 || parts[len(parts)-1].column != tieBreaker {
+		parts = append(parts, keyPart{column: tieBreaker})
+	}
+	return parts, nil
+}
+
+func (p keyPart) equal(value any, next func(any) string) string {
+	if value == nil {
+		return p.column + " IS NULL"
+	}
+	return p.column + " = " + next(value)
+}
+
+// past returns the condition for values greater (or less) than value.
+func (p keyPart) past(value any, greater bool, next func(any) string) string {
+	switch {
+	case value == nil:
+		return p.column + " IS NOT NULL"
+	case greater && p.nullable:
+		return "(" + p.column + " > " + next(value) + " OR " + p.column + " IS NULL)"
+	case greater:
+		return p.column + " > " + next(value)
+	default:
+		return p.column + " < " + next(value)
+	}
+}
+
+var sqlOperators = map[Op]string{
+	OpEq:       "=",
+	OpNe:       "<>",
+	OpLt:       "<",
+	OpLte:      "<=",
+	OpGt:       ">",
+	OpGte:      ">=",
+	OpLike:     "ILIKE",
+	OpContains: "@>",
+	OpAny:      "&&",
+	OpAll:      "@>",
+}
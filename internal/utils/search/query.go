@@ -0,0 +1,102 @@
+// Package search converts user search input into PostgreSQL tsquery syntax.
+package search
+
+import (
+	"strings"
+	"unicode"
+)
+
+// ToTsQuery builds a to_tsquery() expression from a search string.
+//
+// Supported syntax:
+//
+//   - word1 word2: both words (AND)
+//   - "quick brown fox": phrase, words must follow each other
+//   - pre*: prefix match
+//   - -word: exclude word
+//   - word1 OR word2: either word
+//
+// Everything except letters and digits is treated as a separator, so the
+// result is always a valid tsquery. An empty string is returned when the
+// input contains no words.
+func ToTsQuery(input string) string {
+	var (
+		terms  []string
+		op     = " & "
+		negate bool
+	)
+
+	add := func(term string) {
+		if term == "" {
+			negate = false
+			return
+		}
+		if negate {
+			term = "!" + term
+			negate = false
+		}
+		if len(terms) > 0 {
+			terms = append(terms, op)
+		}
+		terms = append(terms, term)
+		op = " & "
+	}
+
+	runes := []rune(input)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '"':
+			end := i + 1
+			for end < len(runes) && runes[end] != '"' {
+				end++
+			}
+			add(phraseTerm(words(string(runes[i+1 : end]))))
+			i = end + 1
+		default:
+			start := i
+			for i < len(runes) && !unicode.IsSpace(runes[i]) && runes[i] != '"' {
+				i++
+			}
+			token := string(runes[start:i])
+			if token == "OR" && len(terms) > 0 {
+				op = " | "
+				continue
+			}
+			if strings.HasPrefix(token, "-") {
+				negate = true
+				token = token[1:]
+				if token == "" {
+					continue
+				}
+			}
+			prefix := strings.HasSuffix(token, "*")
+			parts := words(token)
+			if prefix && len(parts) > 0 {
+				parts[len(parts)-1] += ":*"
+			}
+			add(phraseTerm(parts))
+		}
+	}
+
+	return strings.Join(terms, "")
+}
+
+// words splits s into runs of letters and digits, lowercased.
+func words(s string) []string {
+	return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+func phraseTerm(parts []string) string {
+	switch len(parts) {
+	case 0:
+		return ""
+	case 1:
+		return parts[0]
+	}
+	return "(" + strings.Join(parts, " <-> ") + ")"
+}
@@ -0,0 +1,33 @@
+package search
+
+import "testing"
+
+func TestToTsQuery(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"empty", "  ", ""},
+		{"single word", "Hello", "hello"},
+		{"and", "quick fox", "quick & fox"},
+		{"phrase", `"quick brown fox"`, "(quick <-> brown <-> fox)"},
+		{"prefix", "prog*", "prog:*"},
+		{"negation", "fox -dog", "fox & !dog"},
+		{"negated phrase", `fox -"lazy dog"`, "fox & !(lazy <-> dog)"},
+		{"or", "cat OR dog", "cat | dog"},
+		{"leading or is a word", "OR cat", "or & cat"},
+		{"unicode", "Привет мир", "привет & мир"},
+		{"special characters", `a'b & c|d:*`, "(a <-> b) & (c <-> d:*)"},
+		{"unterminated phrase", `"hello world`, "(hello <-> world)"},
+		{"only punctuation", `!!! ---`, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ToTsQuery(tt.input); got != tt.want {
+				t.Errorf("ToTsQuery(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package signer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSigner_RoundTrip(t *testing.T) {
+	s := New("secret", "test")
+	now := time.Now()
+
+	token := s.Sign("page:5", now.Add(time.Hour))
+	payload, err := s.Verify(token, now)
+
+	require.NoError(t, err)
+	assert.Equal(t, "page:5", payload)
+}
+
+func TestSigner_Expired(t *testing.T) {
+	s := New("secret", "test")
+	now := time.Now()
+
+	token := s.Sign("page:5", now.Add(-time.Minute))
+	_, err := s.Verify(token, now)
+
+	assert.ErrorIs(t, err, ErrExpiredToken)
+}
+
+func TestSigner_NoExpiry(t *testing.T) {
+	s := New("secret", "test")
+
+	token := s.Sign("x", time.Time{})
+	_, err := s.Verify(token, time.Now().Add(1000*time.Hour))
+
+	assert.NoError(t, err)
+}
+
+func TestSigner_Tampered(t *testing.T) {
+	s := New("secret", "test")
+	token := s.Sign("page:5", time.Now().Add(time.Hour))
+
+	_, err := s.Verify(token+"x", time.Now())
+	assert.ErrorIs(t, err, ErrInvalidToken)
+
+	_, err = New("secret", "other").Verify(token, time.Now())
+	assert.ErrorIs(t, err, ErrInvalidToken)
+
+	_, err = s.Verify("garbage", time.Now())
+	assert.ErrorIs(t, err, ErrInvalidToken)
+}